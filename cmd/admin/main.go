@@ -20,7 +20,7 @@ func main() {
 		log.Fatalf("❌ Erreur lecture fichier : %v", err)
 	}
 
-	fmt.Println("🔍 Analyse forensique en cours (Algorithme DCT)...")
+	fmt.Println("🔍 Analyse forensique en cours (Algorithme DCT + Reed-Solomon)...")
 
 	// L'appel utilise bien le fichier forensic_service.go !
 	report, err := service.ExtractForensicData(fileData)
@@ -35,9 +35,13 @@ func displayReport(r *service.WatermarkReport) {
 	fmt.Println("\n==================================================")
 	fmt.Println("🚨 RÉSULTAT DE L'INVESTIGATION")
 	fmt.Println("==================================================")
-	fmt.Printf("📸 Auteur Original : %s\n", r.OriginalAuthorID)
-	fmt.Printf("📝 ID du Post      : %s\n", r.PostID)
-	fmt.Printf("🕵️  COUPABLE (User) : %s\n", r.CulpritID)
+	fmt.Printf("📸 Auteur Original : %d\n", r.OriginalAuthorID)
+	fmt.Printf("📝 ID du Post      : %d\n", r.PostID)
+	fmt.Printf("🕵️  COUPABLE (User) : %d\n", r.CulpritID)
 	fmt.Printf("⏰ Date du vol     : %s\n", r.LeakTimestamp.Format("02/01/2006 à 15:04:05"))
+	fmt.Printf("📊 Confiance       : %.4f %% (%d octets corrigés, %.1f %% d'erreurs brutes)\n",
+		r.Confidence*100, r.Stats.CorrectedSymbols, r.Stats.RawBitErrorRate*100)
+	fmt.Printf("📐 Géométrie       : échelle %.3f, %.1f tuiles, synchro %.2f\n",
+		r.Stats.Scale, r.Stats.Tiles, r.Stats.SyncScore)
 	fmt.Println("==================================================")
 }
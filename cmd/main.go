@@ -11,8 +11,8 @@ import (
 
 	"github.com/QuentinRegnier/nubo-backend/docs"
 	"github.com/QuentinRegnier/nubo-backend/internal/api"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/blobstore"
 	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/cuckoo"
-	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/minio"
 	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/mongo"
 	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/postgres"
 	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/redis"
@@ -20,8 +20,8 @@ import (
 	mongogo "github.com/QuentinRegnier/nubo-backend/internal/repository/mongo"
 	redisgo "github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
 	"github.com/QuentinRegnier/nubo-backend/internal/service"
-	"github.com/QuentinRegnier/nubo-backend/internal/service/algorithm_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/sanction_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/variables"
 	"github.com/QuentinRegnier/nubo-backend/internal/worker"
 	"github.com/gin-gonic/gin"
@@ -84,8 +84,8 @@ func main() {
 	// Initialiser le Hub et lancer sa boucle
 	//websocket.InitHub()
 
-	// Initiatiser MinIO
-	minio.InitMinio()
+	// Initialiser le stockage objet (MinIO, ou dossier local selon BLOB_STORE_DRIVER)
+	blobstore.InitBlobStore()
 
 	// Iniitaliser la structure MongoDB
 	mongogo.InitCacheDatabase()
@@ -114,17 +114,15 @@ func main() {
 		if err := cache_service.SeedUserCache(); err != nil {
 			log.Printf("⚠️ Avertissement lors du seeding du USER cache: %v", err)
 		}
+
+		// ✅ Seeding des sanctions en vigueur (restrictions, strikes, échéances de ban)
+		if err := sanction_service.SeedSanctions(context.Background()); err != nil {
+			log.Printf("⚠️ Avertissement lors du seeding des sanctions: %v", err)
+		}
 	} else {
 		log.Printf("✅ Cache Redis déjà peuplé (%d éléments). Seeding ignoré, démarrage éclair !", count)
 	}
 
-	// ✅ INITIALISATION DU DISTRIBUTEUR GLOBAL
-	// Assure-toi que NewFeedDistributor et NewProtoFeedBuilder correspondent bien à tes constructeurs
-	// Si ton constructeur prend d'autres paramètres (comme rng ou rdb), injecte-les ici.
-	algorithm_service.GlobalDistributor = algorithm_service.NewFeedDistributor(
-		algorithm_service.NewProtoFeedBuilder(),
-	)
-
 	// Lance le moteur V12
 	worker.StartBackgroundWorkers(context.Background())
 
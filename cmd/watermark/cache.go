@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"sync"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/blobstore"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// ============================================================================
+// CACHE DES VARIANTES TATOUÉES (stockage objet)
+// ============================================================================
+// Une variante est propre à un lien signé (média, auteur, post, lecteur, horodatage) : elle est rendue
+// une fois puis relue depuis MinIO (requêtes Range, reprises, rafraîchissements du client).
+// Le nom de l'objet est un HMAC du lien : connaître le tuple d'un autre lecteur ne permet pas de
+// récupérer sa variante (et donc de faire accuser quelqu'un d'autre d'une fuite).
+
+const cacheRuleID = "nubo-watermark-cache"
+
+var cacheBucket string
+
+// cacheName renvoie l'identifiant d'une variante (aussi utilisé comme ETag).
+func cacheName(signedPayload string) string {
+	h := hmac.New(sha256.New, []byte(secretKey))
+	h.Write([]byte("cache:" + signedPayload))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cacheObjectPath range la variante sous le préfixe purgé, avec l'extension du média d'origine.
+func cacheObjectPath(name, key string) string {
+	return variables.WatermarkCachePrefix + name + path.Ext(key)
+}
+
+// loadCached lit une variante. (nil, nil) : absente du cache.
+func loadCached(ctx context.Context, name, key string) ([]byte, error) {
+	object, err := store.Get(ctx, cacheBucket, cacheObjectPath(name, key))
+	if errors.Is(err, blobstore.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func(object io.ReadCloser) {
+		if err := object.Close(); err != nil {
+			log.Printf("⚠️ Erreur fermeture stream S3 (cache): %v", err)
+		}
+	}(object)
+
+	return io.ReadAll(object)
+}
+
+// storeCached écrit une variante (best effort : un échec ne fait que coûter un rendu de plus).
+func storeCached(ctx context.Context, name, key string, data []byte) {
+	err := store.Put(ctx, cacheBucket, cacheObjectPath(name, key), bytes.NewReader(data), int64(len(data)),
+		blobstore.PutOptions{ContentType: contentType(key)})
+	if err != nil {
+		metrics.observeCacheError()
+		log.Printf("⚠️ Erreur écriture cache tatouage: %v", err)
+	}
+}
+
+// ensureCacheLifecycle installe la règle de purge du préfixe de cache, sans toucher aux autres règles
+// du bucket. Les variantes ne servent plus une fois leur lien expiré. Le pilote local n'a pas de cycle
+// de vie : le cache y grossit jusqu'à ce que le dossier soit vidé à la main.
+func ensureCacheLifecycle(ctx context.Context) error {
+	expirer, ok := store.(blobstore.Expirer)
+	if !ok {
+		return fmt.Errorf("purge du cache: %w", blobstore.ErrUnsupported)
+	}
+	return expirer.ExpirePrefix(ctx, cacheBucket, cacheRuleID, variables.WatermarkCachePrefix, variables.WatermarkCacheExpirationDays)
+}
+
+// --- RENDUS EN COURS ---
+// Les requêtes Range d'un même lecteur arrivent souvent en parallèle : un seul rendu par variante.
+
+type flight struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+var (
+	inflightMu sync.Mutex
+	inflight   = make(map[string]*flight)
+)
+
+// renderOnce exécute render une seule fois par variante, les requêtes concurrentes attendent son résultat.
+func renderOnce(name string, render func() ([]byte, error)) ([]byte, error) {
+	inflightMu.Lock()
+	if f, ok := inflight[name]; ok {
+		inflightMu.Unlock()
+		<-f.done
+		return f.data, f.err
+	}
+	f := &flight{done: make(chan struct{})}
+	inflight[name] = f
+	inflightMu.Unlock()
+
+	f.data, f.err = render()
+
+	inflightMu.Lock()
+	delete(inflight, name)
+	inflightMu.Unlock()
+	close(f.done)
+	return f.data, f.err
+}
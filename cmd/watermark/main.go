@@ -3,41 +3,32 @@ package main
 import (
 	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
+	"errors"
 	"fmt"
-	"image"
-	"image/color"
-	"image/draw"
+	"image/jpeg"
 	"io"
 	"log"
-	"math"
 	"net/http"
 	"os"
-
+	"path"
+	"strings"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/blobstore"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg/mediaurl"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg/watermark"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
 	"github.com/gen2brain/avif"
-	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
 )
 
-var minioClient *minio.Client
+var store blobstore.BlobStore
 var bucketName string
 var secretKey string
 
-const (
-	Delta  = 15.0 // Force du tatouage (plus élevé = plus robuste mais plus visible)
-	Margin = 10.0 // Marge de sécurité pour le différentiel DCT
-)
-
 func main() {
 	log.Println("🚀 Démarrage du micro-service de tatouage (Watermark)...")
 
 	// 1. Chargement des variables d'environnement
-	endpoint := os.Getenv("MINIO_ENDPOINT")
-	accessKey := os.Getenv("MINIO_ACCESS_KEY")
-	minioSecret := os.Getenv("MINIO_SECRET_KEY")
-	useSSL := os.Getenv("USE_SSL") == "true"
 	bucketName = os.Getenv("MINIO_BUCKET_NAME")
 	secretKey = os.Getenv("WATERMARK_SECRET_KEY")
 
@@ -45,20 +36,30 @@ func main() {
 		log.Fatal("❌ ERREUR: WATERMARK_SECRET_KEY n'est pas défini")
 	}
 
-	// 2. Initialisation du client S3 (MinIO/Scaleway) isolé
+	// 2. Stockage objet isolé (MinIO/Scaleway, ou dossier local partagé avec l'API selon BLOB_STORE_DRIVER)
 	var err error
-	minioClient, err = minio.New(endpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(accessKey, minioSecret, ""),
-		Secure: useSSL,
-	})
+	store, err = blobstore.New(blobstore.ConfigFromEnv())
 	if err != nil {
-		log.Fatalf("❌ ERREUR MinIO: %v", err)
+		log.Fatalf("❌ ERREUR stockage objet: %v", err)
 	}
 
-	// 3. Définition de la route unique
-	http.HandleFunc("/process", processHandler)
+	// 3. Cache des variantes tatouées (même bucket par défaut, préfixe purgé par cycle de vie)
+	cacheBucket = os.Getenv("WATERMARK_CACHE_BUCKET_NAME")
+	if cacheBucket == "" {
+		cacheBucket = bucketName
+	}
+	if err := ensureCacheLifecycle(context.Background()); err != nil {
+		log.Printf("⚠️ Règle de purge du cache non installée (les variantes expirées resteront en place) : %v", err)
+	}
 
-	// 4. Lancement du serveur
+	// 4. Révocations des liens (Redis partagé avec l'API)
+	initRevocations()
+
+	// 5. Définition des routes
+	http.HandleFunc("/process", instrument(processHandler))
+	http.HandleFunc("/metrics", metricsHandler)
+
+	// 6. Lancement du serveur
 	port := "8080" // Port interne du conteneur
 	log.Printf("✅ Service Watermark prêt sur le port %s", port)
 	if err := http.ListenAndServe(":"+port, nil); err != nil {
@@ -67,206 +68,164 @@ func main() {
 }
 
 func processHandler(w http.ResponseWriter, r *http.Request) {
-	// --- A. EXTRACTION DES PARAMÈTRES ---
-	q := r.URL.Query()
-	key := q.Get("key")
-	author := q.Get("author")
-	post := q.Get("post_service")
-	reader := q.Get("reader")
-	ts := q.Get("ts")
-	clientSig := q.Get("sig")
-
-	if key == "" || author == "" || post == "" || reader == "" || ts == "" || clientSig == "" {
+	// --- A. VÉRIFICATION DE LA SIGNATURE (SÉCURITÉ) ---
+	// La chaîne signée est reconstruite par le même code que l'API (mediaurl)
+	claims, err := mediaurl.Verify(r.URL.Query(), secretKey)
+	switch {
+	case errors.Is(err, mediaurl.ErrSignature):
+		log.Printf("⚠️ Signature invalide pour l'image: %s", r.URL.Query().Get("key"))
+		http.Error(w, "Accès refusé", http.StatusForbidden)
+		return
+	case errors.Is(err, mediaurl.ErrMissing):
 		http.Error(w, "Paramètres manquants", http.StatusBadRequest)
 		return
+	case err != nil:
+		http.Error(w, "Paramètres invalides", http.StatusBadRequest)
+		return
 	}
+	key, payload := claims.Key, claims.Payload()
+	record := watermark.Payload{AuthorID: claims.AuthorID, PostID: claims.PostID, ReaderID: claims.ReaderID, Timestamp: claims.IssuedAt}
 
-	// --- B. VÉRIFICATION DE LA SIGNATURE (SÉCURITÉ) ---
-	// On reconstruit exactement la même chaîne que dans GenerateWatermarkedURL
-	payload := fmt.Sprintf("key=%s&author=%s&post_service=%s&reader=%s&ts=%s", key, author, post, reader, ts)
-	h := hmac.New(sha256.New, []byte(secretKey))
-	h.Write([]byte(payload))
-	expectedSig := hex.EncodeToString(h.Sum(nil))
-
-	if clientSig != expectedSig {
-		log.Printf("⚠️ Signature invalide pour l'image: %s", key)
+	// --- B. EXPIRATION DU LIEN ---
+	// Un lien fuité (historique, capture réseau) ne doit pas servir indéfiniment : l'échéance signée
+	// est elle-même plafonnée par la durée de vie maximale d'un lien
+	now := time.Now()
+	if record.Timestamp.After(now.Add(variables.WatermarkURLClockSkewSeconds * time.Second)) {
 		http.Error(w, "Accès refusé", http.StatusForbidden)
 		return
 	}
-
-	// --- C. TÉLÉCHARGEMENT DE L'IMAGE VIERGE EN RAM ---
-	ctx := context.Background()
-	object, err := minioClient.GetObject(ctx, bucketName, key, minio.GetObjectOptions{})
-	if err != nil {
-		http.Error(w, "Image introuvable", http.StatusNotFound)
+	expiresAt := claims.ExpiresAt
+	if maxExpiry := record.Timestamp.Add(variables.WatermarkURLMaxAgeSeconds * time.Second); expiresAt.After(maxExpiry) {
+		expiresAt = maxExpiry
+	}
+	if now.After(expiresAt) {
+		http.Error(w, "Lien expiré", http.StatusGone)
 		return
 	}
-	defer func(object *minio.Object) {
-		err := object.Close()
-		if err != nil {
-			log.Printf("⚠️ Erreur fermeture stream S3: %v", err)
-		}
-	}(object)
 
-	// On lit tout le fichier binaire (AVIF) dans la RAM
-	imgBytes, err := io.ReadAll(object)
-	if err != nil {
-		log.Printf("❌ Erreur lecture stream S3: %v", err)
-		http.Error(w, "Erreur interne", http.StatusInternalServerError)
+	// --- C. RÉVOCATION ---
+	// Droit d'accès perdu depuis l'émission (post passé en privé ou supprimé, blocage, compte masqué)
+	if isRevoked(r.Context(), claims) {
+		http.Error(w, "Lien révoqué", http.StatusGone)
 		return
 	}
 
-	// Décodage AVIF uniquement
-	src, err := avif.Decode(bytes.NewReader(imgBytes))
-	if err != nil {
-		http.Error(w, "Erreur décodage", http.StatusInternalServerError)
+	// --- D. VALIDATION CONDITIONNELLE ---
+	// La variante est entièrement déterminée par le lien signé : son ETag l'est aussi, sans lecture MinIO
+	name := cacheName(payload)
+	etag := `"` + name[:32] + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d, immutable", int(time.Until(expiresAt).Seconds())))
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
 		return
 	}
 
-	// Injection du tatouage
-	watermarkData := fmt.Sprintf("A:%s|P:%s|R:%s|T:%s", author, post, reader, ts)
-	watermarkedImg, err := applyDCTWatermark(src, watermarkData)
+	// --- E. VARIANTE EN CACHE OU RENDU ---
+	ctx := r.Context()
+	data, err := loadCached(ctx, name, key)
 	if err != nil {
-		http.Error(w, "Erreur traitement", http.StatusInternalServerError)
-		return
+		metrics.observeCacheError()
+		log.Printf("⚠️ Erreur lecture cache tatouage: %v", err)
 	}
+	metrics.observeCache(data != nil)
 
-	// Encodage AVIF final
-	var buf bytes.Buffer
-	if err := avif.Encode(&buf, watermarkedImg, avif.Options{Quality: 65, Speed: 5}); err != nil {
-		http.Error(w, "Erreur encodage", http.StatusInternalServerError)
-		return
+	if data == nil {
+		data, err = renderOnce(name, func() ([]byte, error) {
+			start := time.Now()
+			out, err := render(context.Background(), key, record)
+			if err != nil {
+				return nil, err
+			}
+			metrics.observeRender(time.Since(start))
+			storeCached(context.Background(), name, key, out)
+			return out, nil
+		})
+		if errors.Is(err, errSourceNotFound) {
+			http.Error(w, "Image introuvable", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			log.Printf("❌ Erreur rendu tatouage (%s): %v", key, err)
+			http.Error(w, "Erreur interne", http.StatusInternalServerError)
+			return
+		}
 	}
 
-	w.Header().Set("Content-Type", "image/avif")
-	w.Header().Set("Content-Length", fmt.Sprintf("%d", buf.Len()))
-	if _, err := io.Copy(w, &buf); err != nil {
-		log.Printf("⚠️ Erreur lors de l'envoi de l'image au client (connexion coupée ?) : %v", err)
-	}
+	// --- F. ENVOI (Range, If-Range, If-Modified-Since gérés par net/http) ---
+	w.Header().Set("Content-Type", contentType(key))
+	http.ServeContent(w, r, "", record.Timestamp, bytes.NewReader(data))
 }
 
-// applyDCTWatermark implémente l'algorithme Koch-Zhao (Pixels -> DCT -> IDCT)
-func applyDCTWatermark(src image.Image, payload string) (image.Image, error) {
-	bounds := src.Bounds()
-	// On crée une copie modifiable de l'image
-	dst := image.NewRGBA(bounds)
-	draw.Draw(dst, bounds, src, bounds.Min, draw.Src)
+// errSourceNotFound signale un média vierge absent du stockage.
+var errSourceNotFound = errors.New("image source introuvable")
 
-	bits := stringToBits(payload)
-	width, height := bounds.Dx(), bounds.Dy()
-	bitIdx := 0
-
-	// Parcours par blocs de 8x8
-	for y := 0; y < height-8 && bitIdx < len(bits); y += 8 {
-		for x := 0; x < width-8 && bitIdx < len(bits); x += 8 {
-			injectBitInBlock(dst, x, y, bits[bitIdx])
-			bitIdx++
-		}
+// render télécharge l'image vierge en RAM, la tatoue et la réencode dans son format (AVIF ou JPEG).
+func render(ctx context.Context, key string, record watermark.Payload) ([]byte, error) {
+	object, err := store.Get(ctx, bucketName, key)
+	if errors.Is(err, blobstore.ErrNotFound) {
+		return nil, errSourceNotFound
 	}
-
-	return dst, nil
-}
-
-func injectBitInBlock(img *image.RGBA, startX, startY, bit int) {
-	var f [8][8]float64
-	// 1. Extraction de la luminance
-	// 1. Extraction de la luminance
-	for y := 0; y < 8; y++ {
-		for x := 0; x < 8; x++ {
-			c := img.At(startX+x, startY+y)
-			r, g, b, _ := c.RGBA()
-			// Y = 0.299R + 0.587G + 0.114B
-			f[x][y] = 0.299*float64(r>>8) + 0.587*float64(g>>8) + 0.114*float64(b>>8)
-		}
+	if err != nil {
+		return nil, fmt.Errorf("lecture stream S3: %w", err)
 	}
+	defer func(object io.ReadCloser) {
+		err := object.Close()
+		if err != nil {
+			log.Printf("⚠️ Erreur fermeture stream S3: %v", err)
+		}
+	}(object)
 
-	// 2. Passage dans le domaine fréquentiel (DCT-II)
-	F := dct2D(f)
-
-	// 3. Modification des coefficients (Koch-Zhao sur les fréquences moyennes 4,5 et 5,4)
-	u1, v1 := 4, 5
-	u2, v2 := 5, 4
+	// On lit tout le fichier binaire (AVIF) dans la RAM
+	imgBytes, err := io.ReadAll(object)
+	if err != nil {
+		return nil, fmt.Errorf("lecture stream S3: %w", err)
+	}
 
-	if bit == 1 {
-		if F[u1][v1] <= F[u2][v2]+Margin {
-			F[u1][v1] = F[u2][v2] + Delta
+	// Les déclinaisons de repli (JPEG) sont servies dans leur format, tout le reste en AVIF
+	var buf bytes.Buffer
+	if isJPEG(key) {
+		src, err := jpeg.Decode(bytes.NewReader(imgBytes))
+		if err != nil {
+			return nil, fmt.Errorf("décodage: %w", err)
 		}
-	} else {
-		if F[u2][v2] <= F[u1][v1]+Margin {
-			F[u2][v2] = F[u1][v1] + Delta
+		// Injection du tatouage (codec partagé avec l'outil d'investigation)
+		if err := jpeg.Encode(&buf, watermark.Embed(src, record), &jpeg.Options{Quality: 82}); err != nil {
+			return nil, fmt.Errorf("encodage: %w", err)
 		}
+		return buf.Bytes(), nil
 	}
 
-	// 4. Retour dans le domaine spatial (IDCT)
-	fNew := idct2D(F)
-
-	// 5. Mise à jour des pixels dans l'image
-	for y := 0; y < 8; y++ {
-		for x := 0; x < 8; x++ {
-			oldColor := img.At(startX+x, startY+y)
-			_, _, _, a := oldColor.RGBA()
-
-			val := uint8(math.Max(0, math.Min(255, fNew[x][y])))
-			img.Set(startX+x, startY+y, color.RGBA{R: val, G: val, B: val, A: uint8(a >> 8)})
-		}
+	src, err := avif.Decode(bytes.NewReader(imgBytes))
+	if err != nil {
+		return nil, fmt.Errorf("décodage: %w", err)
 	}
-}
-
-func dct2D(f [8][8]float64) [8][8]float64 {
-	var F [8][8]float64
-	for u := 0; u < 8; u++ {
-		for v := 0; v < 8; v++ {
-			sum := 0.0
-			for x := 0; x < 8; x++ {
-				for y := 0; y < 8; y++ {
-					sum += f[x][y] * math.Cos((float64(2*x+1)*float64(u)*math.Pi)/16.0) * math.Cos((float64(2*y+1)*float64(v)*math.Pi)/16.0)
-				}
-			}
-			alphaU := 1.0
-			if u == 0 {
-				alphaU = 1.0 / math.Sqrt(2)
-			}
-			alphaV := 1.0
-			if v == 0 {
-				alphaV = 1.0 / math.Sqrt(2)
-			}
-			F[u][v] = 0.25 * alphaU * alphaV * sum
-		}
+	if err := avif.Encode(&buf, watermark.Embed(src, record), avif.Options{Quality: 65, Speed: 5}); err != nil {
+		return nil, fmt.Errorf("encodage: %w", err)
 	}
-	return F
+	return buf.Bytes(), nil
 }
 
-func idct2D(F [8][8]float64) [8][8]float64 {
-	var f [8][8]float64
-	for x := 0; x < 8; x++ {
-		for y := 0; y < 8; y++ {
-			sum := 0.0
-			for u := 0; u < 8; u++ {
-				for v := 0; v < 8; v++ {
-					alphaU := 1.0
-					if u == 0 {
-						alphaU = 1.0 / math.Sqrt(2)
-					}
-					alphaV := 1.0
-					if v == 0 {
-						alphaV = 1.0 / math.Sqrt(2)
-					}
+// isJPEG indique une déclinaison de repli (servie en JPEG).
+func isJPEG(key string) bool {
+	ext := strings.ToLower(path.Ext(key))
+	return ext == ".jpg" || ext == ".jpeg"
+}
 
-					sum += alphaU * alphaV * F[u][v] * math.Cos((float64(2*x+1)*float64(u)*math.Pi)/16.0) * math.Cos((float64(2*y+1)*float64(v)*math.Pi)/16.0)
-				}
-			}
-			f[x][y] = 0.25 * sum
-		}
+func contentType(key string) string {
+	if isJPEG(key) {
+		return "image/jpeg"
 	}
-	return f
+	return "image/avif"
 }
 
-func stringToBits(s string) []int {
-	var bits []int
-	for _, char := range []byte(s) {
-		for i := 7; i >= 0; i-- {
-			bits = append(bits, int((char>>i)&1))
+// etagMatches applique la comparaison faible de If-None-Match (liste d'ETags ou "*").
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
 		}
 	}
-	return bits
+	return false
 }
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ============================================================================
+// MÉTRIQUES (format texte Prometheus, exposé sur /metrics)
+// ============================================================================
+// Le micro-service reste sans dépendance : les quelques séries utiles sont tenues à la main.
+// Le taux de succès du cache se calcule côté Prometheus à partir des deux compteurs, mais une jauge
+// cumulée est aussi exposée pour les tableaux de bord simples.
+
+// renderBuckets couvre décodage + tatouage + encodage AVIF (de la vignette à la photo 12 Mpx).
+var renderBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type serviceMetrics struct {
+	mu sync.Mutex
+
+	responses    map[int]uint64 // Code HTTP -> nombre de réponses
+	cacheHits    uint64
+	cacheMisses  uint64
+	cacheErrors  uint64 // Lectures/écritures MinIO échouées (la requête est servie quand même)
+	revoked      uint64 // Liens refusés par une révocation
+	revokeErrors uint64 // Lectures Redis des révocations échouées (la requête est servie quand même)
+	renderCounts []uint64
+	renderSum    float64
+	renderTotal  uint64
+}
+
+var metrics = &serviceMetrics{
+	responses:    make(map[int]uint64),
+	renderCounts: make([]uint64, len(renderBuckets)),
+}
+
+func (m *serviceMetrics) observeResponse(status int) {
+	m.mu.Lock()
+	m.responses[status]++
+	m.mu.Unlock()
+}
+
+func (m *serviceMetrics) observeCache(hit bool) {
+	m.mu.Lock()
+	if hit {
+		m.cacheHits++
+	} else {
+		m.cacheMisses++
+	}
+	m.mu.Unlock()
+}
+
+func (m *serviceMetrics) observeCacheError() {
+	m.mu.Lock()
+	m.cacheErrors++
+	m.mu.Unlock()
+}
+
+func (m *serviceMetrics) observeRevoked() {
+	m.mu.Lock()
+	m.revoked++
+	m.mu.Unlock()
+}
+
+func (m *serviceMetrics) observeRevocationError() {
+	m.mu.Lock()
+	m.revokeErrors++
+	m.mu.Unlock()
+}
+
+func (m *serviceMetrics) observeRender(d time.Duration) {
+	seconds := d.Seconds()
+	m.mu.Lock()
+	for i, bound := range renderBuckets {
+		if seconds <= bound {
+			m.renderCounts[i]++
+		}
+	}
+	m.renderSum += seconds
+	m.renderTotal++
+	m.mu.Unlock()
+}
+
+// instrument compte les réponses de h par code HTTP.
+func instrument(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		h(rec, r)
+		metrics.observeResponse(rec.status)
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// metricsHandler expose les séries au format texte Prometheus (version 0.0.4).
+func metricsHandler(w http.ResponseWriter, _ *http.Request) {
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	var b strings.Builder
+
+	b.WriteString("# HELP watermark_responses_total Réponses du service par code HTTP.\n")
+	b.WriteString("# TYPE watermark_responses_total counter\n")
+	codes := make([]int, 0, len(metrics.responses))
+	for code := range metrics.responses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		fmt.Fprintf(&b, "watermark_responses_total{code=\"%d\"} %d\n", code, metrics.responses[code])
+	}
+
+	b.WriteString("# HELP watermark_cache_requests_total Recherches de variantes tatouées dans le cache MinIO.\n")
+	b.WriteString("# TYPE watermark_cache_requests_total counter\n")
+	fmt.Fprintf(&b, "watermark_cache_requests_total{result=\"hit\"} %d\n", metrics.cacheHits)
+	fmt.Fprintf(&b, "watermark_cache_requests_total{result=\"miss\"} %d\n", metrics.cacheMisses)
+
+	b.WriteString("# HELP watermark_cache_hit_ratio Part des variantes servies depuis le cache depuis le démarrage.\n")
+	b.WriteString("# TYPE watermark_cache_hit_ratio gauge\n")
+	ratio := 0.0
+	if lookups := metrics.cacheHits + metrics.cacheMisses; lookups > 0 {
+		ratio = float64(metrics.cacheHits) / float64(lookups)
+	}
+	fmt.Fprintf(&b, "watermark_cache_hit_ratio %g\n", ratio)
+
+	b.WriteString("# HELP watermark_cache_errors_total Échanges MinIO du cache en échec.\n")
+	b.WriteString("# TYPE watermark_cache_errors_total counter\n")
+	fmt.Fprintf(&b, "watermark_cache_errors_total %d\n", metrics.cacheErrors)
+
+	b.WriteString("# HELP watermark_revoked_total Liens refusés car révoqués avant leur échéance.\n")
+	b.WriteString("# TYPE watermark_revoked_total counter\n")
+	fmt.Fprintf(&b, "watermark_revoked_total %d\n", metrics.revoked)
+
+	b.WriteString("# HELP watermark_revocation_errors_total Lectures Redis des révocations en échec.\n")
+	b.WriteString("# TYPE watermark_revocation_errors_total counter\n")
+	fmt.Fprintf(&b, "watermark_revocation_errors_total %d\n", metrics.revokeErrors)
+
+	b.WriteString("# HELP watermark_render_duration_seconds Durée d'un rendu (décodage, tatouage, encodage).\n")
+	b.WriteString("# TYPE watermark_render_duration_seconds histogram\n")
+	for i, bound := range renderBuckets {
+		fmt.Fprintf(&b, "watermark_render_duration_seconds_bucket{le=\"%g\"} %d\n", bound, metrics.renderCounts[i])
+	}
+	fmt.Fprintf(&b, "watermark_render_duration_seconds_bucket{le=\"+Inf\"} %d\n", metrics.renderTotal)
+	fmt.Fprintf(&b, "watermark_render_duration_seconds_sum %g\n", metrics.renderSum)
+	fmt.Fprintf(&b, "watermark_render_duration_seconds_count %d\n", metrics.renderTotal)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write([]byte(b.String()))
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg/mediaurl"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+	"github.com/go-redis/redis/v8"
+)
+
+// ============================================================================
+// RÉVOCATION DES LIENS (Redis)
+// ============================================================================
+// L'API horodate chaque perte de droit sous un périmètre (post, auteur, lecteur, couple auteur/lecteur).
+// Un lien émis au plus tard à cet instant est refusé, même s'il n'a pas expiré.
+// Fail-Open : sans Redis, seule l'échéance courte des liens borne l'exposition (les images restent servies).
+
+// revocationTimeout borne la lecture : une latence Redis ne doit pas bloquer le service des images.
+const revocationTimeout = 200 * time.Millisecond
+
+var revocations *redis.Client
+
+// initRevocations ouvre la connexion Redis (désactivée si REDIS_ADDR est absent).
+func initRevocations() {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		log.Println("⚠️ REDIS_ADDR non défini : révocation des liens désactivée")
+		return
+	}
+	revocations = redis.NewClient(&redis.Options{Addr: addr})
+}
+
+// isRevoked indique si une révocation couvre le lien.
+func isRevoked(ctx context.Context, claims mediaurl.Claims) bool {
+	if revocations == nil {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(ctx, revocationTimeout)
+	defer cancel()
+
+	scopes := claims.Scopes()
+	keys := make([]string, len(scopes))
+	for i, scope := range scopes {
+		keys[i] = variables.MediaRevocationPrefix + ":" + scope
+	}
+	values, err := revocations.MGet(ctx, keys...).Result()
+	if err != nil {
+		metrics.observeRevocationError()
+		log.Printf("⚠️ Lecture des révocations impossible : %v", err)
+		return false
+	}
+	for _, v := range values {
+		raw, ok := v.(string)
+		if !ok {
+			continue
+		}
+		if revokedAt, err := strconv.ParseInt(raw, 10, 64); err == nil && claims.RevokedBy(revokedAt) {
+			metrics.observeRevoked()
+			return true
+		}
+	}
+	return false
+}
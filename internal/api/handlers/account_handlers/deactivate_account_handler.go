@@ -0,0 +1,90 @@
+package account_handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/account_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/auth_service"
+	"github.com/gin-gonic/gin"
+)
+
+// DeactivateAccountHandler godoc
+// @Summary      Désactiver son compte
+// @Description  Masque le compte partout (profil, posts, commentaires, recherche, feeds) sans supprimer aucune donnée.
+// @Description  Toutes les sessions sont révoquées. Un login dans les 30 jours réactive le compte.
+// @Description  Cette route nécessite une authentification par JWT et une signature HMAC valide.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Format JSON invalide ou champs manquants` : Le corps de la requête est mal formé.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden :**
+// @Description  * `Invalid password` : Le mot de passe de confirmation ne correspond pas.
+// @Description
+// @Description  🔴 **409 Conflict :**
+// @Description  * `This account is not active` : Le compte est déjà désactivé.
+// @Description  * `A deletion is already scheduled for this account` : Une suppression est déjà planifiée.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Erreur de communication BDD ou rupture de la file Write-Behind.
+// @Tags         account
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        data          body   account_models.DeactivateAccountInput true "Hash du mot de passe courant"
+// @Success      200  {object}  account_models.AccountLifecycleOutput "Compte désactivé"
+// @Failure      400  {object}  domain.ErrorResponse "Données invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Mot de passe incorrect"
+// @Failure      409  {object}  domain.ErrorResponse "Compte déjà inactif"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /account/deactivate [post]
+func DeactivateAccountHandler(c *gin.Context) {
+	// 1. Authentification
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Parsing
+	var input account_models.DeactivateAccountInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Format JSON invalide ou champs manquants"})
+		return
+	}
+
+	// 3. Appel au service
+	output, err := auth_service.DeactivateAccount(c.Request.Context(), userID, input, c.ClientIP())
+	if err != nil {
+		respondAccountError(c, "DeactivateAccount", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// respondAccountError traduit les erreurs du cycle de vie du compte en réponses HTTP.
+func respondAccountError(c *gin.Context, origin string, err error) {
+	switch {
+	case errors.Is(err, nubo_error.ErrInvalidCredentials):
+		c.JSON(http.StatusForbidden, nubo_error.ErrorResponse{Error: "Invalid password"})
+	case errors.Is(err, nubo_error.ErrAccountNotActive), errors.Is(err, nubo_error.ErrDeletionPending):
+		c.JSON(http.StatusConflict, nubo_error.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, nubo_error.ErrNotFound):
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+	default:
+		fmt.Printf("❌ ERREUR SÉCURITÉ CRITIQUE (%s): %v\n", origin, err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+	}
+}
@@ -0,0 +1,73 @@
+package account_handlers
+
+import (
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/account_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/auth_service"
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteAccountHandler godoc
+// @Summary      Supprimer définitivement son compte
+// @Description  Masque immédiatement le compte et planifie sa suppression définitive dans 14 jours :
+// @Description  Postgres, MongoDB, caches Redis, buckets LSH, timelines, index de recherche et fichiers MinIO.
+// @Description  Un login avant l'échéance annule la demande. Un reçu de suppression (SHA-256) est enregistré à la fin de la cascade.
+// @Description  Cette route nécessite une authentification par JWT et une signature HMAC valide.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Format JSON invalide ou champs manquants` : Le corps de la requête est mal formé.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden :**
+// @Description  * `Invalid password` : Le mot de passe de confirmation ne correspond pas.
+// @Description
+// @Description  🔴 **409 Conflict :**
+// @Description  * `A deletion is already scheduled for this account` : Une suppression est déjà planifiée.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Erreur de communication BDD ou rupture de la file Write-Behind.
+// @Tags         account
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        data          body   account_models.DeleteAccountInput true "Hash du mot de passe courant"
+// @Success      202  {object}  account_models.AccountLifecycleOutput "Suppression planifiée"
+// @Failure      400  {object}  domain.ErrorResponse "Données invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Mot de passe incorrect"
+// @Failure      409  {object}  domain.ErrorResponse "Suppression déjà planifiée"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /account [delete]
+func DeleteAccountHandler(c *gin.Context) {
+	// 1. Authentification
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Parsing
+	var input account_models.DeleteAccountInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Format JSON invalide ou champs manquants"})
+		return
+	}
+
+	// 3. Appel au service
+	output, err := auth_service.ScheduleAccountDeletion(c.Request.Context(), userID, input, c.ClientIP())
+	if err != nil {
+		respondAccountError(c, "ScheduleAccountDeletion", err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, output)
+}
@@ -0,0 +1,62 @@
+package account_handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/export_service"
+	"github.com/gin-gonic/gin"
+)
+
+// GetExportHandler godoc
+// @Summary      Suivre l'export de ses données
+// @Description  Renvoie l'état de la dernière demande d'export (0 = en attente, 1 = en cours, 2 = prête, -1 = échec).
+// @Description  Quand l'archive est prête, la réponse contient un lien de téléchargement signé valable 15 minutes ;
+// @Description  l'archive elle-même est conservée 7 jours. Chaque appel génère un nouveau lien.
+// @Description  Cette route nécessite une authentification par JWT et une signature HMAC valide.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Aucun export disponible` : Aucune demande, ou archive expirée.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Redis ou MinIO indisponible.
+// @Tags         account
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Success      200  {object}  account_models.ExportStatusOutput "État de l'export"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      404  {object}  domain.ErrorResponse "Aucun export disponible"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /account/export [get]
+func GetExportHandler(c *gin.Context) {
+	// 1. Authentification
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Appel au service
+	status, err := export_service.GetExportStatus(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, nubo_error.ErrNotFound) {
+			c.JSON(http.StatusNotFound, nubo_error.ErrorResponse{Error: "Aucun export disponible"})
+			return
+		}
+		fmt.Printf("❌ ERREUR (GetExportStatus): %v\n", err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
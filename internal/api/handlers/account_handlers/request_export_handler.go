@@ -0,0 +1,58 @@
+package account_handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/export_service"
+	"github.com/gin-gonic/gin"
+)
+
+// RequestExportHandler godoc
+// @Summary      Demander l'export de ses données
+// @Description  Met en file la construction d'une archive ZIP (RGPD) : profil, réglages, sessions, posts, commentaires,
+// @Description  likes, relations, messages et médias originaux. Le suivi et le lien de téléchargement sont exposés par GET /account/export.
+// @Description  Une demande déjà en cours, ou une archive prête depuis moins de 24h, est renvoyée sans nouvelle construction (200).
+// @Description  Cette route nécessite une authentification par JWT et une signature HMAC valide.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Redis indisponible.
+// @Tags         account
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Success      202  {object}  map[string]interface{} "job_id + state (nouvelle demande)"
+// @Success      200  {object}  map[string]interface{} "job_id + state (demande existante)"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /account/export [post]
+func RequestExportHandler(c *gin.Context) {
+	// 1. Authentification
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Appel au service
+	job, created, err := export_service.RequestExport(c.Request.Context(), userID)
+	if err != nil {
+		fmt.Printf("❌ ERREUR (RequestExport): %v\n", err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusAccepted
+	}
+	c.JSON(status, gin.H{"job_id": job.ID, "state": job.State, "requested_at": job.RequestedAt})
+}
@@ -0,0 +1,78 @@
+package admin_handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/audit_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/gin-gonic/gin"
+)
+
+// GetAuditLogHandler godoc
+// @Summary      Consulter le journal d'audit
+// @Description  Renvoie les entrées du journal d'audit (connexions, révocations de sessions, mots de passe, cycle de vie
+// @Description  des comptes, signalements, sanctions...) de la plus récente à la plus ancienne, par pages de 50 (max 200).
+// @Description  Tous les filtres sont optionnels et cumulables. `action` accepte un préfixe terminé par un point (ex: `password.`).
+// @Description  Pour la page suivante, renvoyer `next_cursor` dans `cursor`. Chaque consultation est elle-même auditée.
+// @Description  Cette route nécessite une authentification par JWT, une signature HMAC valide et la permission `audit:view` (administrateur).
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Paramètres invalides` : Un identifiant n'est pas numérique ou une date n'est pas au format RFC3339.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `Permission insuffisante` : Le grade de l'appelant n'accorde pas `audit:view`, ou le compte est banni/désactivé.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : PostgreSQL indisponible.
+// @Tags         admin
+// @Produce      json
+// @Param        Authorization header string true  "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true  "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true  "Timestamp Unix de la requête"
+// @Param        actor_id      query  int    false "Auteur de l'action (0 = système)"
+// @Param        action        query  string false "Action exacte ou préfixe terminé par un point"
+// @Param        target_type   query  string false "Type de cible (user, session, post, comment, report, export)"
+// @Param        target_id     query  int    false "Identifiant de la cible"
+// @Param        from          query  string false "Borne basse incluse (RFC3339)"
+// @Param        to            query  string false "Borne haute exclue (RFC3339)"
+// @Param        cursor        query  int    false "next_cursor de la page précédente"
+// @Param        limit         query  int    false "Taille de page (défaut 50, max 200)"
+// @Success      200  {object}  audit_models.AuditQueryOutput "Page du journal d'audit"
+// @Failure      400  {object}  domain.ErrorResponse "Paramètres invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Permission insuffisante"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /admin/audit [get]
+func GetAuditLogHandler(c *gin.Context) {
+	// 1. Authentification
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Filtres (la permission audit:view est vérifiée en amont par RequirePermission)
+	var input audit_models.AuditQueryInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Paramètres invalides"})
+		return
+	}
+
+	// 3. Appel au service
+	out, err := audit.Query(c.Request.Context(), audit.User(userID, c.ClientIP()), input)
+	if err != nil {
+		fmt.Printf("❌ ERREUR (GetAuditLog): %v\n", err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
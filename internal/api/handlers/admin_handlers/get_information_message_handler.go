@@ -0,0 +1,73 @@
+package admin_handlers
+
+import (
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/admin_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/admin_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/gin-gonic/gin"
+)
+
+// GetInformationMessageHandler godoc
+// @Summary      Fiche d'un message privé
+// @Description  Renvoie un message privé tel qu'il est stocké en L3 et, s'il est présent, en L1, ainsi que les dossiers de modération
+// @Description  qui le visent. La messagerie n'ayant pas encore de modèle typé, les objets sont restitués colonne par colonne.
+// @Description  Chaque consultation est auditée (`admin.information`).
+// @Description  Cette route nécessite une authentification par JWT, une signature HMAC valide et la permission `private_info:view` (administrateur).
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Paramètres invalides` : `message_id` absent ou non numérique.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `Permission insuffisante` : Le grade de l'appelant n'accorde pas `private_info:view`, ou le compte est banni/désactivé.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Message introuvable` : Aucun message ne porte cet identifiant en L3.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : PostgreSQL indisponible.
+// @Tags         admin
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        message_id    query  int    true "Identifiant du message"
+// @Success      200  {object}  admin_models.MessageInformationOutput "Fiche d'un message privé"
+// @Failure      400  {object}  domain.ErrorResponse "Paramètres invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Permission insuffisante"
+// @Failure      404  {object}  domain.ErrorResponse "Message introuvable"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /admin/information-message [get]
+func GetInformationMessageHandler(c *gin.Context) {
+	// 1. Authentification
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Paramètres (la permission private_info:view est vérifiée en amont par RequirePermission)
+	var input admin_models.InformationMessageInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Paramètres invalides"})
+		return
+	}
+
+	// 3. Appel au service
+	out, err := admin_service.LoadMessageInformation(c.Request.Context(), audit.User(userID, c.ClientIP()), input.MessageID)
+	if err != nil {
+		respondInformationError(c, "LoadMessageInformation", "Message introuvable", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
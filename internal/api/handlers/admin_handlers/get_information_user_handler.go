@@ -0,0 +1,87 @@
+package admin_handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/admin_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/admin_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/gin-gonic/gin"
+)
+
+// GetInformationUserHandler godoc
+// @Summary      Fiche consolidée d'un utilisateur
+// @Description  Renvoie la fiche d'un utilisateur lue directement en L3 et confrontée à L1 : compte (sans empreinte de mot de passe),
+// @Description  sessions (sans secrets) et historique IP dédupliqué, historique complet des sanctions, signalements envoyés et reçus
+// @Description  (avec leur issue), statistiques du graphe social (abonnés confrontés au Speed Cache), 20 derniers posts et commentaires
+// @Description  (supprimés et masqués compris). `discrepancies` liste les champs divergents entre L1 et L3.
+// @Description  Chaque consultation est auditée (`admin.information`).
+// @Description  Cette route nécessite une authentification par JWT, une signature HMAC valide et la permission `private_info:view` (administrateur).
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Paramètres invalides` : `user_id` absent ou non numérique.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `Permission insuffisante` : Le grade de l'appelant n'accorde pas `private_info:view`, ou le compte est banni/désactivé.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Utilisateur introuvable` : Aucun utilisateur ne porte cet identifiant en L3.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : PostgreSQL indisponible.
+// @Tags         admin
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        user_id       query  int    true "Identifiant de l'utilisateur"
+// @Success      200  {object}  admin_models.UserInformationOutput "Fiche consolidée d'un utilisateur"
+// @Failure      400  {object}  domain.ErrorResponse "Paramètres invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Permission insuffisante"
+// @Failure      404  {object}  domain.ErrorResponse "Utilisateur introuvable"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /admin/information-user [get]
+func GetInformationUserHandler(c *gin.Context) {
+	// 1. Authentification
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Paramètres (la permission private_info:view est vérifiée en amont par RequirePermission)
+	var input admin_models.InformationUserInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Paramètres invalides"})
+		return
+	}
+
+	// 3. Appel au service
+	out, err := admin_service.LoadUserInformation(c.Request.Context(), audit.User(userID, c.ClientIP()), input.UserID)
+	if err != nil {
+		respondInformationError(c, "LoadUserInformation", "Utilisateur introuvable", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
+// respondInformationError traduit les erreurs des fiches d'administration en réponses HTTP.
+func respondInformationError(c *gin.Context, origin string, notFound string, err error) {
+	if errors.Is(err, nubo_error.ErrNotFound) {
+		c.JSON(http.StatusNotFound, nubo_error.ErrorResponse{Error: notFound})
+		return
+	}
+	fmt.Printf("❌ ERREUR (%s): %v\n", origin, err)
+	c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+}
@@ -0,0 +1,53 @@
+package admin_handlers
+
+import (
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/admin_service"
+	"github.com/gin-gonic/gin"
+)
+
+// GetMediaGCHandler godoc
+// @Summary      Dernier rapport du ramasse-miettes des médias
+// @Description  Renvoie le rapport du dernier passage, planifié ou manuel : objets examinés, orphelins, octets mis à la corbeille,
+// @Description  octets libérés (corbeille échue et uploads abandonnés), médias marqués supprimés, erreurs et mode simulation.
+// @Description  Cette route nécessite une authentification par JWT, une signature HMAC valide et la permission `storage:manage` (administrateur).
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `Permission insuffisante` : Le grade de l'appelant n'accorde pas `storage:manage`.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Aucun passage enregistré` : Le ramasse-miettes n'a encore jamais tourné.
+// @Tags         admin
+// @Produce      json
+// @Param        Authorization header string true  "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true  "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true  "Timestamp Unix de la requête"
+// @Success      200  {object}  media_models.MediaGCReport "Rapport du dernier passage"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Permission insuffisante"
+// @Failure      404  {object}  domain.ErrorResponse "Aucun passage enregistré"
+// @Router       /admin/media-gc [get]
+func GetMediaGCHandler(c *gin.Context) {
+	// 1. Authentification
+	if _, err := pkg.GetUserIDFromContext(c); err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Appel au service
+	report, err := admin_service.LastMediaGC(c.Request.Context())
+	if err != nil {
+		respondInformationError(c, "LastMediaGC", "Aucun passage enregistré", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
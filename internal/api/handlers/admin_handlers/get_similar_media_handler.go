@@ -0,0 +1,86 @@
+package admin_handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/admin_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/admin_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/gin-gonic/gin"
+)
+
+// GetSimilarMediaHandler godoc
+// @Summary      Médias similaires à un média
+// @Description  Renvoie les médias dont l'empreinte perceptuelle (pHash, confirmée par dHash) est proche de celle du média demandé,
+// @Description  du plus proche au plus lointain, ainsi que les empreintes bloquées qu'il approche (dossier et catégorie d'origine).
+// @Description  Sert à retrouver les copies d'une image signalée, y compris recompressées ou redimensionnées.
+// @Description  `max_distance` (distance de Hamming pHash, 10 par défaut) ne peut dépasser 11, limite garantie par l'index.
+// @Description  `limit` : 50 par défaut, 200 au maximum. Chaque recherche est auditée (`admin.media_similar`).
+// @Description  Cette route nécessite une authentification par JWT, une signature HMAC valide et la permission `reports:view` (modérateur).
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Paramètres invalides` : `media_id` absent ou non numérique, `max_distance` hors de [0, 11], `limit` hors de [1, 200].
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `Permission insuffisante` : Le grade de l'appelant n'accorde pas `reports:view`, ou le compte est banni/désactivé.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Média introuvable` : Aucun média ne porte cet identifiant.
+// @Description
+// @Description  🟠 **409 Conflict :**
+// @Description  * `Média sans empreinte` : Média encore en traitement, ou antérieur au calcul des empreintes.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Redis indisponible.
+// @Tags         admin
+// @Produce      json
+// @Param        Authorization header string true  "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true  "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true  "Timestamp Unix de la requête"
+// @Param        media_id      query  int    true  "Identifiant du média"
+// @Param        max_distance  query  int    false "Distance pHash maximale (0 à 11)"
+// @Param        limit         query  int    false "Nombre maximal de médias renvoyés (1 à 200)"
+// @Success      200  {object}  media_models.SimilarMediaOutput "Médias similaires et empreintes bloquées proches"
+// @Failure      400  {object}  domain.ErrorResponse "Paramètres invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Permission insuffisante"
+// @Failure      404  {object}  domain.ErrorResponse "Média introuvable"
+// @Failure      409  {object}  domain.ErrorResponse "Média sans empreinte"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /admin/media-similar [get]
+func GetSimilarMediaHandler(c *gin.Context) {
+	// 1. Authentification
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Paramètres (la permission reports:view est vérifiée en amont par RequirePermission)
+	var input admin_models.SimilarMediaInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Paramètres invalides"})
+		return
+	}
+
+	// 3. Appel au service
+	out, err := admin_service.FindSimilarMedia(c.Request.Context(), audit.User(userID, c.ClientIP()), input)
+	if err != nil {
+		if errors.Is(err, nubo_error.ErrMediaNotHashed) {
+			c.JSON(http.StatusConflict, nubo_error.ErrorResponse{Error: "Média sans empreinte"})
+			return
+		}
+		respondInformationError(c, "FindSimilarMedia", "Média introuvable", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
@@ -0,0 +1,83 @@
+package admin_handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/admin_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/admin_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/gin-gonic/gin"
+)
+
+// RunMediaGCHandler godoc
+// @Summary      Lancer le ramasse-miettes des médias
+// @Description  Confronte le stockage MinIO (images et déclinaisons, rendus vidéo, zone de transit) à content.media et aux références
+// @Description  (médias des posts, photos de profil). Un objet est orphelin si aucun média vivant ne le porte : média inconnu, supprimé,
+// @Description  ou non référencé depuis plus de 24 h. Les médias en quarantaine et les posts supprimés visés par un dossier ouvert
+// @Description  sont conservés. Les orphelins sont déplacés sous `trash/`, purgée après 7 jours ; les uploads vidéo abandonnés sont annulés.
+// @Description  Un passage examine au plus 20 000 objets et en met au plus 2 000 à la corbeille (`truncated` signale la limite atteinte).
+// @Description  `dry_run=true` produit le rapport sans rien déplacer. Le même passage tourne toutes les 6 h ; chaque passage est audité (`admin.media_gc`).
+// @Description  Cette route nécessite une authentification par JWT, une signature HMAC valide et la permission `storage:manage` (administrateur).
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Paramètres invalides` : `dry_run` n'est pas un booléen.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `Permission insuffisante` : Le grade de l'appelant n'accorde pas `storage:manage`.
+// @Description
+// @Description  🟠 **409 Conflict :**
+// @Description  * `Ramasse-miettes déjà en cours` : Un passage (planifié ou manuel) est en cours sur une instance.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Redis indisponible.
+// @Tags         admin
+// @Produce      json
+// @Param        Authorization header string true  "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true  "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true  "Timestamp Unix de la requête"
+// @Param        dry_run       query  bool   false "Simulation : rapport sans suppression"
+// @Success      200  {object}  media_models.MediaGCReport "Rapport du passage"
+// @Failure      400  {object}  domain.ErrorResponse "Paramètres invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Permission insuffisante"
+// @Failure      409  {object}  domain.ErrorResponse "Ramasse-miettes déjà en cours"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /admin/media-gc [post]
+func RunMediaGCHandler(c *gin.Context) {
+	// 1. Authentification
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Paramètres (la permission storage:manage est vérifiée en amont par RequirePermission)
+	var input admin_models.MediaGCInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Paramètres invalides"})
+		return
+	}
+
+	// 3. Appel au service
+	report, err := admin_service.RunMediaGC(c.Request.Context(), audit.User(userID, c.ClientIP()), input)
+	if err != nil {
+		if errors.Is(err, nubo_error.ErrMediaGCRunning) {
+			c.JSON(http.StatusConflict, nubo_error.ErrorResponse{Error: "Ramasse-miettes déjà en cours"})
+			return
+		}
+		fmt.Printf("❌ ERREUR (RunMediaGC): %v\n", err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
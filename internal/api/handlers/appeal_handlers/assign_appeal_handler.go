@@ -0,0 +1,90 @@
+package appeal_handlers
+
+import (
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/appeal_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/appeal_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/sanction_service"
+	"github.com/gin-gonic/gin"
+)
+
+// AssignAppealHandler godoc
+// @Summary      Prendre en charge ou assigner un appel
+// @Description  Sans `assignee_id`, l'appelant prend l'appel pour lui-même ; sinon il l'assigne au modérateur indiqué.
+// @Description  L'auteur de la sanction contestée ne peut jamais examiner l'appel. Reprendre un appel détenu par un autre modérateur
+// @Description  exige la permission `reports:override` (administrateur).
+// @Description  Cette route nécessite une authentification par JWT, une signature HMAC valide et la permission `appeals:resolve`.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Format JSON invalide ou champs manquants` : `appeal_id` absent.
+// @Description  * `The assignee is not allowed to handle this case` : L'assigné n'a pas la permission `appeals:resolve`.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `Permission insuffisante` : Le grade de l'appelant n'accorde pas `appeals:resolve`.
+// @Description  * `You cannot review an appeal against your own sanction` : L'assigné est l'auteur de la sanction.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Appel introuvable` : Aucun appel ne porte cet identifiant.
+// @Description
+// @Description  🔴 **409 Conflict :**
+// @Description  * `This appeal is assigned to another moderator` : L'appel est détenu par un autre modérateur.
+// @Description  * `This appeal cannot go through this transition in its current state` : Appel tranché, ou modifié entre-temps.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : PostgreSQL indisponible.
+// @Tags         moderation
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        data          body   appeal_models.AssignAppealInput true "Appel et assigné"
+// @Success      200  {object}  appeal_models.AppealPayload "Appel mis à jour"
+// @Failure      400  {object}  domain.ErrorResponse "Données invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Permission insuffisante ou conflit d'intérêts"
+// @Failure      404  {object}  domain.ErrorResponse "Appel introuvable"
+// @Failure      409  {object}  domain.ErrorResponse "Transition impossible"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /admin/appeal [patch]
+func AssignAppealHandler(c *gin.Context) {
+	// 1. Modérateur (identité et grade placés par RequirePermission)
+	moderator, err := moderatorFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Parsing
+	var input appeal_models.AssignAppealInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Format JSON invalide ou champs manquants"})
+		return
+	}
+
+	// 3. Appel au service
+	a, err := appeal_service.AssignAppeal(c.Request.Context(), moderator, input)
+	if err != nil {
+		respondAppealError(c, "AssignAppeal", "Appel introuvable", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, a)
+}
+
+// moderatorFromContext reconstruit l'auteur d'une action de modération depuis le contexte gin.
+func moderatorFromContext(c *gin.Context) (sanction_service.Moderator, error) {
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		return sanction_service.Moderator{}, err
+	}
+	return sanction_service.Moderator{UserID: userID, Grade: c.GetInt("grade"), IP: c.ClientIP()}, nil
+}
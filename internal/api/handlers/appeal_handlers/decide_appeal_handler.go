@@ -0,0 +1,80 @@
+package appeal_handlers
+
+import (
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/appeal_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/appeal_service"
+	"github.com/gin-gonic/gin"
+)
+
+// DecideAppealHandler godoc
+// @Summary      Trancher un appel
+// @Description  Clôt un appel pris en charge par l'appelant : `outcome` 1 = sanction maintenue, 2 = sanction annulée.
+// @Description  Une annulation lève immédiatement la sanction : un compte banni est rétabli et ses contenus redeviennent visibles,
+// @Description  une restriction ou un avertissement est retiré. Le contenu masqué ou retiré au titre du dossier de la sanction
+// @Description  retrouve sa visibilité d'origine. La note est communiquée à l'utilisateur.
+// @Description  Un administrateur (`reports:override`) peut trancher un appel qu'il ne détient pas.
+// @Description  Cette route nécessite une authentification par JWT, une signature HMAC valide et la permission `appeals:resolve`.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Format JSON invalide ou champs manquants` : `appeal_id` ou `note` absent, ou `outcome` hors de 1 et 2.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `Permission insuffisante` : Le grade de l'appelant n'accorde pas `appeals:resolve`.
+// @Description  * `You cannot review an appeal against your own sanction` : L'appelant est l'auteur de la sanction.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Appel introuvable` : Aucun appel ne porte cet identifiant.
+// @Description
+// @Description  🔴 **409 Conflict :**
+// @Description  * `This appeal is assigned to another moderator` : L'appel est détenu par un autre modérateur.
+// @Description  * `This appeal cannot go through this transition in its current state` : Appel non pris en charge, déjà tranché, ou modifié entre-temps.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : PostgreSQL ou Redis (Write-Behind) indisponible.
+// @Tags         moderation
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        data          body   appeal_models.DecideAppealInput true "Appel, issue et note"
+// @Success      200  {object}  appeal_models.AppealPayload "Appel tranché"
+// @Failure      400  {object}  domain.ErrorResponse "Données invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Permission insuffisante ou conflit d'intérêts"
+// @Failure      404  {object}  domain.ErrorResponse "Appel introuvable"
+// @Failure      409  {object}  domain.ErrorResponse "Transition impossible"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /admin/appeal [delete]
+func DecideAppealHandler(c *gin.Context) {
+	// 1. Modérateur
+	moderator, err := moderatorFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Parsing
+	var input appeal_models.DecideAppealInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Format JSON invalide ou champs manquants"})
+		return
+	}
+
+	// 3. Appel au service
+	a, err := appeal_service.DecideAppeal(c.Request.Context(), moderator, input)
+	if err != nil {
+		respondAppealError(c, "DecideAppeal", "Appel introuvable", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, a)
+}
@@ -0,0 +1,60 @@
+package appeal_handlers
+
+import (
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/appeal_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/appeal_service"
+	"github.com/gin-gonic/gin"
+)
+
+// GetAppealHandler godoc
+// @Summary      Détail d'un appel
+// @Description  Renvoie un appel et la sanction contestée (motif, auteur, échéance, dossier de modération d'origine).
+// @Description  Cette route nécessite une authentification par JWT, une signature HMAC valide et la permission `appeals:view`.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Paramètres invalides` : `appeal_id` absent ou non numérique.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `Permission insuffisante` : Le grade de l'appelant n'accorde pas `appeals:view`.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Appel introuvable` : Aucun appel ne porte cet identifiant.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : PostgreSQL indisponible.
+// @Tags         moderation
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        appeal_id     query  int    true "Identifiant de l'appel"
+// @Success      200  {object}  appeal_models.AppealDetailOutput "Appel et sanction contestée"
+// @Failure      400  {object}  domain.ErrorResponse "Paramètres invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Permission insuffisante"
+// @Failure      404  {object}  domain.ErrorResponse "Appel introuvable"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /admin/appeal [get]
+func GetAppealHandler(c *gin.Context) {
+	var input appeal_models.GetAppealInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Paramètres invalides"})
+		return
+	}
+
+	out, err := appeal_service.GetAppeal(c.Request.Context(), input.AppealID)
+	if err != nil {
+		respondAppealError(c, "GetAppeal", "Appel introuvable", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
@@ -0,0 +1,80 @@
+package appeal_handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/appeal_service"
+	"github.com/gin-gonic/gin"
+)
+
+// GetMyAppealsHandler godoc
+// @Summary      Suivre ses appels
+// @Description  Liste les appels de l'appelant, du plus récent au plus ancien, avec leur état (0 = en attente, 1 = en cours d'examen,
+// @Description  -1 = tranché) et leur issue (1 = sanction maintenue, 2 = sanction annulée) accompagnée de la note du modérateur.
+// @Description  Cette route nécessite une authentification par JWT et une signature HMAC valide.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : PostgreSQL indisponible.
+// @Tags         account
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Success      200  {object}  appeal_models.MyAppealsOutput "Appels de l'utilisateur"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /account/appeals [get]
+func GetMyAppealsHandler(c *gin.Context) {
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+	listMyAppeals(c, userID)
+}
+
+// GetBannedAppealsHandler godoc
+// @Summary      Suivre ses appels (compte banni)
+// @Description  Variante de `GET /account/appeals` pour un compte banni, identifié par le jeton d'appel remis au login.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Jeton d'appel invalide ou expiré` : En-tête `X-Appeal-Token` absent, inconnu ou expiré.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Redis ou PostgreSQL indisponible.
+// @Tags         auth
+// @Produce      json
+// @Param        X-Appeal-Token header string true "Jeton d'appel remis au login"
+// @Success      200  {object}  appeal_models.MyAppealsOutput "Appels de l'utilisateur"
+// @Failure      401  {object}  domain.ErrorResponse "Jeton d'appel invalide"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /appeal [get]
+func GetBannedAppealsHandler(c *gin.Context) {
+	userID, ok := appellantFromToken(c)
+	if !ok {
+		return
+	}
+	listMyAppeals(c, userID)
+}
+
+// listMyAppeals est le corps commun aux deux routes de suivi.
+func listMyAppeals(c *gin.Context, userID int64) {
+	out, err := appeal_service.ListMyAppeals(c.Request.Context(), userID)
+	if err != nil {
+		fmt.Printf("❌ ERREUR (ListMyAppeals): %v\n", err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
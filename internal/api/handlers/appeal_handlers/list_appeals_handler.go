@@ -0,0 +1,66 @@
+package appeal_handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/appeal_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/appeal_service"
+	"github.com/gin-gonic/gin"
+)
+
+// ListAppealsHandler godoc
+// @Summary      File des appels
+// @Description  Liste les appels contre des sanctions, échéance de traitement la plus proche en tête.
+// @Description  Sans filtre `state`, seuls les appels non tranchés sont listés (0 = en attente, 1 = en cours d'examen).
+// @Description  `overdue=true` ne garde que les appels dont l'échéance est dépassée (`sla_breached_at` renseigné par le suivi des délais).
+// @Description  Cette route nécessite une authentification par JWT, une signature HMAC valide et la permission `appeals:view`.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Paramètres invalides` : Un filtre n'est pas numérique ou booléen.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `Permission insuffisante` : Le grade de l'appelant n'accorde pas `appeals:view`.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : PostgreSQL indisponible.
+// @Tags         moderation
+// @Produce      json
+// @Param        Authorization header string true  "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true  "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true  "Timestamp Unix de la requête"
+// @Param        state         query  int    false "État (0, 1, -1)"
+// @Param        overdue       query  bool   false "Uniquement les appels hors délai"
+// @Param        assignee_id   query  int    false "Modérateur assigné"
+// @Param        limit         query  int    false "Taille de page (défaut 50, max 200)"
+// @Param        offset        query  int    false "Décalage"
+// @Success      200  {object}  appeal_models.ListAppealsOutput "Page de la file"
+// @Failure      400  {object}  domain.ErrorResponse "Paramètres invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Permission insuffisante"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /admin/appeals [get]
+func ListAppealsHandler(c *gin.Context) {
+	// 1. Filtres (l'identité et la permission sont vérifiées en amont par RequirePermission)
+	var input appeal_models.ListAppealsInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Paramètres invalides"})
+		return
+	}
+
+	// 2. Appel au service
+	out, err := appeal_service.ListAppeals(c.Request.Context(), input)
+	if err != nil {
+		fmt.Printf("❌ ERREUR (ListAppeals): %v\n", err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
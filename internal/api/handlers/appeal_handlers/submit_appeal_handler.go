@@ -0,0 +1,159 @@
+package appeal_handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/appeal_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/appeal_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/auth_service"
+	"github.com/gin-gonic/gin"
+)
+
+// SubmitAppealHandler godoc
+// @Summary      Contester une sanction
+// @Description  Soumet un appel contre une sanction en vigueur sur le compte de l'appelant (restriction, avertissement ou retrait de contenu).
+// @Description  Un seul appel par sanction, avant sa date limite de contestation (`appealable_until`). L'appel rejoint la file
+// @Description  des modérateurs avec une échéance de traitement (24h pour un ban, 48h pour une restriction ou un retrait de contenu, 72h pour un avertissement).
+// @Description  Cette route nécessite une authentification par JWT et une signature HMAC valide.
+// @Description  Un compte banni n'a plus de session : il utilise `POST /appeal` avec le jeton d'appel remis au login.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Format JSON invalide ou champs manquants` : `sanction_id` ou `message` absent, ou message trop long (2000 caractères max).
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `The appeal period for this sanction has ended` : La date limite de contestation est dépassée.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Sanction introuvable` : Aucune sanction de l'appelant ne porte cet identifiant.
+// @Description
+// @Description  🔴 **409 Conflict :**
+// @Description  * `This sanction has already been appealed` : Un appel existe déjà pour cette sanction.
+// @Description  * `This sanction is no longer active` : La sanction est levée ou expirée.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : PostgreSQL indisponible.
+// @Tags         account
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        data          body   appeal_models.SubmitAppealInput true "Sanction contestée et message"
+// @Success      201  {object}  appeal_models.AppealPayload "Appel enregistré"
+// @Failure      400  {object}  domain.ErrorResponse "Données invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Délai de contestation dépassé"
+// @Failure      404  {object}  domain.ErrorResponse "Sanction introuvable"
+// @Failure      409  {object}  domain.ErrorResponse "Appel déjà soumis ou sanction inactive"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /account/appeal [post]
+func SubmitAppealHandler(c *gin.Context) {
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+	submitAppeal(c, userID)
+}
+
+// SubmitBannedAppealHandler godoc
+// @Summary      Contester un ban
+// @Description  Variante de `POST /account/appeal` pour un compte banni, qui n'a plus de session.
+// @Description  L'appelant s'identifie par le jeton d'appel (`appeal_token`) renvoyé par `POST /login` avec l'erreur `Account banned`,
+// @Description  valable 30 minutes. Mêmes règles : un seul appel par sanction, avant la date limite de contestation.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Format JSON invalide ou champs manquants` : `sanction_id` ou `message` absent, ou message trop long (2000 caractères max).
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Jeton d'appel invalide ou expiré` : En-tête `X-Appeal-Token` absent, inconnu ou expiré (se reconnecter pour en obtenir un nouveau).
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `The appeal period for this sanction has ended` : La date limite de contestation est dépassée.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Sanction introuvable` : Aucune sanction de l'appelant ne porte cet identifiant.
+// @Description
+// @Description  🔴 **409 Conflict :**
+// @Description  * `This sanction has already been appealed` : Un appel existe déjà pour cette sanction.
+// @Description  * `This sanction is no longer active` : Le ban est levé ou expiré.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Redis ou PostgreSQL indisponible.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        X-Appeal-Token header string true "Jeton d'appel remis au login"
+// @Param        data           body   appeal_models.SubmitAppealInput true "Sanction contestée et message"
+// @Success      201  {object}  appeal_models.AppealPayload "Appel enregistré"
+// @Failure      400  {object}  domain.ErrorResponse "Données invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Jeton d'appel invalide"
+// @Failure      403  {object}  domain.ErrorResponse "Délai de contestation dépassé"
+// @Failure      404  {object}  domain.ErrorResponse "Sanction introuvable"
+// @Failure      409  {object}  domain.ErrorResponse "Appel déjà soumis ou sanction inactive"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /appeal [post]
+func SubmitBannedAppealHandler(c *gin.Context) {
+	userID, ok := appellantFromToken(c)
+	if !ok {
+		return
+	}
+	submitAppeal(c, userID)
+}
+
+// submitAppeal est le corps commun aux deux routes de soumission, une fois l'appelant identifié.
+func submitAppeal(c *gin.Context, userID int64) {
+	var input appeal_models.SubmitAppealInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Format JSON invalide ou champs manquants"})
+		return
+	}
+
+	a, err := appeal_service.SubmitAppeal(c.Request.Context(), userID, input, c.ClientIP())
+	if err != nil {
+		respondAppealError(c, "SubmitAppeal", "Sanction introuvable", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, a)
+}
+
+// appellantFromToken identifie un compte banni par son jeton d'appel (en-tête X-Appeal-Token).
+// Répond 401 et renvoie false si le jeton est absent, inconnu ou expiré.
+func appellantFromToken(c *gin.Context) (int64, bool) {
+	userID, err := auth_service.ResolveAppealToken(c.Request.Context(), c.GetHeader("X-Appeal-Token"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Jeton d'appel invalide ou expiré"})
+		return 0, false
+	}
+	return userID, true
+}
+
+// respondAppealError traduit les erreurs du workflow d'appel en réponses HTTP.
+func respondAppealError(c *gin.Context, origin string, notFound string, err error) {
+	switch {
+	case errors.Is(err, nubo_error.ErrNotFound):
+		c.JSON(http.StatusNotFound, nubo_error.ErrorResponse{Error: notFound})
+	case errors.Is(err, nubo_error.ErrInvalidAssignee):
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, nubo_error.ErrAppealWindowClosed), errors.Is(err, nubo_error.ErrAppealConflict), errors.Is(err, nubo_error.ErrSanctionTarget):
+		c.JSON(http.StatusForbidden, nubo_error.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, nubo_error.ErrAppealExists), errors.Is(err, nubo_error.ErrSanctionInactive),
+		errors.Is(err, nubo_error.ErrAppealUnavailable), errors.Is(err, nubo_error.ErrAppealNotAssignee):
+		c.JSON(http.StatusConflict, nubo_error.ErrorResponse{Error: err.Error()})
+	default:
+		fmt.Printf("❌ ERREUR (%s): %v\n", origin, err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+	}
+}
@@ -0,0 +1,86 @@
+package auth_handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/auth_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/auth_service"
+	"github.com/gin-gonic/gin"
+)
+
+// ChangePasswordHandler godoc
+// @Summary      Changer le mot de passe
+// @Description  Remplace le mot de passe de l'utilisateur connecté après vérification de l'ancien.
+// @Description  La session de l'appareil courant est conservée ; toutes les autres sessions sont révoquées.
+// @Description  Cette route nécessite une authentification par JWT et une signature HMAC valide.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Format JSON invalide ou champs manquants` : Le corps de la requête est mal formé.
+// @Description  * `The new password must differ from the current one` : Le nouveau mot de passe est identique à l'ancien.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden :**
+// @Description  * `Invalid current password` : L'ancien mot de passe ne correspond pas.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Erreur de communication BDD ou rupture de la file Write-Behind.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        data          body   auth_models.ChangePasswordInput true "Ancien et nouveau hash du mot de passe"
+// @Success      200  {object}  map[string]interface{} "message + revoked_sessions"
+// @Failure      400  {object}  domain.ErrorResponse "Données invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Ancien mot de passe incorrect"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /password [patch]
+func ChangePasswordHandler(c *gin.Context) {
+	// 1. Authentification
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+	deviceToken, err := pkg.GetDeviceTokenFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Parsing
+	var input auth_models.ChangePasswordInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Format JSON invalide ou champs manquants"})
+		return
+	}
+
+	// 3. Appel au service
+	revoked, err := auth_service.ChangePassword(c.Request.Context(), userID, deviceToken, input, c.ClientIP())
+	if err != nil {
+		switch {
+		case errors.Is(err, nubo_error.ErrInvalidCredentials):
+			c.JSON(http.StatusForbidden, nubo_error.ErrorResponse{Error: "Invalid current password"})
+		case errors.Is(err, nubo_error.ErrSamePassword):
+			c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: nubo_error.ErrSamePassword.Error()})
+		case errors.Is(err, nubo_error.ErrNotFound):
+			c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		default:
+			fmt.Printf("❌ ERREUR SÉCURITÉ CRITIQUE (ChangePassword): %v\n", err)
+			c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password updated", "revoked_sessions": revoked})
+}
@@ -0,0 +1,66 @@
+package auth_handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/auth_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/auth_service"
+	"github.com/gin-gonic/gin"
+)
+
+// ForgotPasswordHandler godoc
+// @Summary      Demander la récupération d'un compte
+// @Description  Émet un jeton de récupération à usage unique (valable 15 minutes) envoyé via le canal de vérification (email / SMS).
+// @Description  La réponse est identique que le compte existe ou non (protection contre l'énumération des comptes).
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `The 'data' field containing the JSON is required` : Le champ texte 'data' est manquant.
+// @Description  * `Invalid JSON format in 'data': ...` : Format JSON corrompu ou mal écrit.
+// @Description  * `Validation failed: ...` : L'email est absent ou mal formé.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Échec du stockage du jeton ou canal de vérification indisponible.
+// @Tags         auth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        data formData string true "Données JSON (auth_models.ForgotPasswordInput)"
+// @Success      200  {object}  map[string]string "message: If an account matches, a recovery code has been sent"
+// @Failure      400  {object}  domain.ErrorResponse "Données d'entrée invalides"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /password/forgot [post]
+func ForgotPasswordHandler(c *gin.Context) {
+	var input auth_models.ForgotPasswordInput
+
+	// --- 1. RÉCUPÉRATION DU PAYLOAD ---
+	jsonData := c.PostForm("data")
+	if jsonData == "" {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "The 'data' field containing the JSON is required"})
+		return
+	}
+
+	if err := json.Unmarshal([]byte(jsonData), &input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Invalid JSON format in 'data': " + err.Error()})
+		return
+	}
+
+	// --- 2. 🛡️ BOUCLIER STATIQUE ---
+	if err := pkg.ValidateStruct(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	// --- 3. APPEL AU SERVICE MÉTIER ---
+	if err := auth_service.ForgotPassword(c.Request.Context(), input, c.ClientIP()); err != nil {
+		fmt.Printf("❌ ERREUR (ForgotPassword): %v\n", err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "If an account matches, a recovery code has been sent"})
+}
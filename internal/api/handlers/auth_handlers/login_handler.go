@@ -16,6 +16,7 @@ import (
 // Login godoc
 // @Summary      Connecter un utilisateur
 // @Description  Authentifie un utilisateur via email/password, synchronise les caches chauds (Session, Speed, Timeline) et renvoie le profil complet.
+// @Description  Un compte désactivé depuis moins de 30 jours, ou dont la suppression est planifiée mais pas encore exécutée, est réactivé par ce login.
 // @Description
 // @Description  **Règles de validation & Erreurs :**
 // @Description
@@ -28,8 +29,8 @@ import (
 // @Description  * `Invalid email or password` : Identifiants incorrects ou utilisateur introuvable en base de données.
 // @Description
 // @Description  ⛔ **403 Forbidden (Statut du compte) :**
-// @Description  * `Account deactivated` : Le compte a été volontairement désactivé par l'utilisateur.
-// @Description  * `Account banned` : Le compte a été banni pour non-respect des règles.
+// @Description  * `Account deactivated` : Le compte est désactivé et ne peut plus être réactivé par login (délai de grâce dépassé ou désactivation par la modération).
+// @Description  * `Account banned` : Le compte a été banni pour non-respect des règles. Le corps contient la notice du ban (motif, échéance, contestation) et un `appeal_token` valable 30 minutes pour `/appeal`.
 // @Description
 // @Description  ⚫ **500 Internal Server Error (Serveur) :**
 // @Description  * `Internal server error` : Erreur de communication BDD ou génération de jetons défaillante.
@@ -76,6 +77,11 @@ func LoginHandler(c *gin.Context) {
 			c.JSON(http.StatusForbidden, nubo_error.ErrorResponse{Error: "Account deactivated"})
 			return
 		}
+		var sanctionErr *nubo_error.SanctionError
+		if errors.As(err, &sanctionErr) && errors.Is(err, nubo_error.ErrBanned) {
+			c.JSON(http.StatusForbidden, nubo_error.SanctionResponse{Error: "Account banned", Sanction: sanctionErr.Notice, AppealToken: sanctionErr.AppealToken})
+			return
+		}
 		if errors.Is(err, nubo_error.ErrBanned) {
 			c.JSON(http.StatusForbidden, nubo_error.ErrorResponse{Error: "Account banned"})
 			return
@@ -112,6 +118,8 @@ func LoginHandler(c *gin.Context) {
 		UpdatedAt:         user.UpdatedAt,
 		ProfilePictureID:  user.ProfilePictureID,
 		ProfilePictureURL: profilePicURL, // Injection de la clé signée directement exploitable par le front
+		CoverPictureID:    user.CoverPictureID,
+		CoverPictureURL:   auth_service.ProfileImageURL(c.Request.Context(), user.ID, user.CoverPictureID),
 		MasterToken:       sessions.MasterToken,
 		JWT:               jwtToken,
 		ExpiresAt:         sessions.ExpiresAt,
@@ -0,0 +1,75 @@
+package auth_handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/auth_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/auth_service"
+	"github.com/gin-gonic/gin"
+)
+
+// ResetPasswordHandler godoc
+// @Summary      Réinitialiser le mot de passe
+// @Description  Consomme le jeton de récupération (usage unique), applique le nouveau hash de mot de passe,
+// @Description  ré-initialise le Ratchet et révoque toutes les sessions actives de l'utilisateur sur tous les appareils.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `The 'data' field containing the JSON is required` : Le champ texte 'data' est manquant.
+// @Description  * `Invalid JSON format in 'data': ...` : Format JSON corrompu ou mal écrit.
+// @Description  * `Validation failed: ...` : Jeton mal formé (64 caractères hexadécimaux) ou mot de passe trop court.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Invalid or expired token` : Jeton inconnu, déjà utilisé ou expiré.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Erreur de communication BDD ou rupture de la file Write-Behind.
+// @Tags         auth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        data formData string true "Données JSON (auth_models.ResetPasswordInput)"
+// @Success      200  {object}  map[string]string "message: Password updated, please log in again"
+// @Failure      400  {object}  domain.ErrorResponse "Données d'entrée invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Jeton invalide ou expiré"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /password/reset [post]
+func ResetPasswordHandler(c *gin.Context) {
+	var input auth_models.ResetPasswordInput
+
+	// --- 1. RÉCUPÉRATION DU PAYLOAD ---
+	jsonData := c.PostForm("data")
+	if jsonData == "" {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "The 'data' field containing the JSON is required"})
+		return
+	}
+
+	if err := json.Unmarshal([]byte(jsonData), &input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Invalid JSON format in 'data': " + err.Error()})
+		return
+	}
+
+	// --- 2. 🛡️ BOUCLIER STATIQUE ---
+	if err := pkg.ValidateStruct(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	// --- 3. APPEL AU SERVICE MÉTIER ---
+	if err := auth_service.ResetPassword(c.Request.Context(), input, c.ClientIP()); err != nil {
+		if errors.Is(err, nubo_error.ErrInvalidToken) || errors.Is(err, nubo_error.ErrNotFound) {
+			c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Invalid or expired token"})
+			return
+		}
+		fmt.Printf("❌ ERREUR SÉCURITÉ CRITIQUE (ResetPassword): %v\n", err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password updated, please log in again"})
+}
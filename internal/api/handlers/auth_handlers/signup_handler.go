@@ -2,6 +2,7 @@ package auth_handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -24,6 +25,8 @@ import (
 // @Description  * `Invalid date format. Expected format: ddmmaaaa` : La date de naissance n'est pas bonne.
 // @Description  * `Gender must be 0, 1, 2, or null` : Tu as envoyé un entier invalide pour le sexe.
 // @Description  * `Impossible to read image file` : Le fichier image est corrompu ou illisible.
+// @Description  * `Image resolution is too large` : L'avatar dépasse 4 mégapixels.
+// @Description  * `Invalid crop rectangle` : L'avatar, recadré en carré centré, fait moins de 128 px de côté.
 // @Description  * `You must be at least 13 years old` : Restrictions d'âge.
 // @Description  * `Invalid birthdate` : Date absurde (ex: plus de 120 ans).
 // @Description
@@ -33,7 +36,7 @@ import (
 // @Description  * `This phone number is already taken` : Le téléphone est déjà en base.
 // @Description
 // @Description  ⚫ **500 Internal Server Error (Problèmes serveur) :**
-// @Description  * `Internal nubo_error (image upload)` : MinIO est down ou mal configuré.
+// @Description  * `database nubo_error` : MinIO est down ou mal configuré (dépôt de l'avatar en zone de transit).
 // @Description  * `Internal nubo_error (token generation)` : Problème avec la signature JWT.
 // @Description  * `database nubo_error` : Postgres ou Mongo ne répondent pas.
 // @Tags         users
@@ -82,6 +85,19 @@ func SignUpHandler(c *gin.Context) {
 		case nubo_error.ErrInvalidDate, nubo_error.ErrAgeUnder13, nubo_error.ErrAgeOver120, nubo_error.ErrInvalidGender:
 			c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: err.Error()})
 		default:
+			// Avatar refusé au dépôt (l'erreur enveloppe la cause du décodeur, qui n'est pas exposée)
+			if errors.Is(err, nubo_error.ErrInvalidMedia) {
+				c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: nubo_error.ErrInvalidMedia.Error()})
+				return
+			}
+			if errors.Is(err, nubo_error.ErrMediaTooLarge) {
+				c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: nubo_error.ErrMediaTooLarge.Error()})
+				return
+			}
+			if errors.Is(err, nubo_error.ErrInvalidCrop) {
+				c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: nubo_error.ErrInvalidCrop.Error()})
+				return
+			}
 			fmt.Printf("❌ ERREUR CRITIQUE SERVEUR (CreateUser): %v\n", err)
 			c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "database nubo_error"})
 		}
@@ -1,11 +1,13 @@
 package comment_handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/comment_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
 	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/comment_service"
 )
@@ -27,6 +29,9 @@ import (
 // @Description
 // @Description  🟠 **401 Unauthorized (Authentification) :**
 // @Description  * `Utilisateur non identifié` : Le userID n'a pas pu être extrait du token JWT ou la signature HMAC est invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Sanction) :**
+// @Description  * `Action restricted` : Les commentaires sont restreints sur ce compte. Le corps contient la notice de la sanction (motif, échéance, contestation).
 // @Tags         comments
 // @Accept       json
 // @Produce      json
@@ -37,6 +42,7 @@ import (
 // @Success      200  {object}  map[string]string "message: Commentaire en cours de publication"
 // @Failure      400  {object}  domain.ErrorResponse "Format JSON invalide, post_id manquant ou contenu vide"
 // @Failure      401  {object}  domain.ErrorResponse "Session expirée ou utilisateur non identifié"
+// @Failure      403  {object}  nubo_error.SanctionResponse "Commentaires restreints"
 // @Router       /comment [post]
 func CreateCommentHandler(c *gin.Context) {
 	// 1. Sécurité : Extraction de l'ID via JWT
@@ -62,8 +68,14 @@ func CreateCommentHandler(c *gin.Context) {
 		return
 	}
 
-	// 4 & 5. Envoi au Service Asynchrone
-	_ = comment_service.CreateComment(c.Request.Context(), input)
+	// 4 & 5. Envoi au Service Asynchrone (seule une sanction est remontée au client)
+	if err := comment_service.CreateComment(c.Request.Context(), input); err != nil {
+		var sanctionErr *nubo_error.SanctionError
+		if errors.As(err, &sanctionErr) {
+			c.JSON(http.StatusForbidden, nubo_error.SanctionResponse{Error: "Action restricted", Sanction: sanctionErr.Notice})
+			return
+		}
+	}
 
 	// 6. Confirmation immédiate (Latence ~1ms)
 	c.JSON(http.StatusOK, gin.H{"message": "Commentaire en cours de publication"})
@@ -0,0 +1,63 @@
+package media_handlers
+
+import (
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/media_service"
+	"github.com/gin-gonic/gin"
+)
+
+// AbortUploadHandler godoc
+// @Summary      Abandonner un upload vidéo
+// @Description  Abandonne un upload inachevé et libère les morceaux déjà stockés.
+// @Description  Cette route nécessite une authentification par JWT et une signature HMAC valide.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `ID d'upload invalide` : L'ID dans l'URL n'est pas un entier positif.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Upload introuvable` : Session inconnue, expirée, terminée ou appartenant à un autre utilisateur.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : MinIO ou Redis indisponible.
+// @Tags         media
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        id            path   int    true "ID de l'upload"
+// @Success      200  {object}  map[string]string "message: Upload abandonné"
+// @Failure      400  {object}  domain.ErrorResponse "ID invalide"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      404  {object}  domain.ErrorResponse "Upload introuvable"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /media/uploads/{id} [delete]
+func AbortUploadHandler(c *gin.Context) {
+	// 1. Authentification
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Extraction de l'ID d'upload
+	sessionID, ok := uploadIDParam(c)
+	if !ok {
+		return
+	}
+
+	// 3. Appel au service
+	if err := media_service.AbortUpload(c.Request.Context(), userID, sessionID); err != nil {
+		respondUploadError(c, "AbortUpload", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Upload abandonné"})
+}
@@ -0,0 +1,75 @@
+package media_handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/media_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/media_service"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateUploadHandler godoc
+// @Summary      Ouvrir un upload vidéo
+// @Description  Ouvre un upload par morceaux d'une vidéo courte (mp4, mov ou webm, 200 Mo et 60 secondes au plus).
+// @Description  La réponse indique la taille des morceaux et leur nombre : chaque morceau, sauf le dernier, fait exactement `chunk_bytes`.
+// @Description  Une fois tous les morceaux reçus (PUT /media/uploads/{id}/chunks/{index}), POST /media/uploads/{id}/complete
+// @Description  renvoie le `media_id` à passer dans `media_ids` à la création du post. La session expire après 24h.
+// @Description  Cette route nécessite une authentification par JWT et une signature HMAC valide.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Validation failed: ...` : Type de contenu ou taille manquants.
+// @Description  * `Unsupported video format` : Le type de contenu n'est pas un conteneur vidéo accepté.
+// @Description  * `Video exceeds the maximum size` : La taille annoncée dépasse 200 Mo.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : MinIO ou Redis indisponible.
+// @Tags         media
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        data          body   media_models.CreateUploadInput true "Type de contenu et taille de la vidéo"
+// @Success      201  {object}  media_models.UploadSessionOutput "Session d'upload ouverte"
+// @Failure      400  {object}  domain.ErrorResponse "Format ou taille refusés"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /media/uploads [post]
+func CreateUploadHandler(c *gin.Context) {
+	// 1. Authentification
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Parsing & validation
+	var input media_models.CreateUploadInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Validation failed: " + err.Error()})
+		return
+	}
+
+	// 3. Appel au service
+	session, err := media_service.CreateUploadSession(c.Request.Context(), userID, input)
+	if err != nil {
+		if errors.Is(err, nubo_error.ErrUnsupportedMedia) || errors.Is(err, nubo_error.ErrVideoTooLarge) {
+			c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: err.Error()})
+			return
+		}
+		fmt.Printf("❌ ERREUR (CreateUploadSession): %v\n", err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, session)
+}
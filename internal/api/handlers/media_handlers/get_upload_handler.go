@@ -0,0 +1,91 @@
+package media_handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/media_service"
+	"github.com/gin-gonic/gin"
+)
+
+// GetUploadHandler godoc
+// @Summary      Suivre un upload vidéo
+// @Description  Renvoie l'état d'un upload par morceaux : après une coupure réseau, le client ne renvoie que les
+// @Description  index absents de `received_chunks`.
+// @Description  Cette route nécessite une authentification par JWT et une signature HMAC valide.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `ID d'upload invalide` : L'ID dans l'URL n'est pas un entier positif.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Upload introuvable` : Session inconnue, expirée, terminée ou appartenant à un autre utilisateur.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Redis indisponible.
+// @Tags         media
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        id            path   int    true "ID de l'upload"
+// @Success      200  {object}  media_models.UploadSessionOutput "État de l'upload"
+// @Failure      400  {object}  domain.ErrorResponse "ID invalide"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      404  {object}  domain.ErrorResponse "Upload introuvable"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /media/uploads/{id} [get]
+func GetUploadHandler(c *gin.Context) {
+	// 1. Authentification
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Extraction de l'ID d'upload
+	sessionID, ok := uploadIDParam(c)
+	if !ok {
+		return
+	}
+
+	// 3. Appel au service
+	session, err := media_service.GetUploadSession(c.Request.Context(), userID, sessionID)
+	if err != nil {
+		respondUploadError(c, "GetUploadSession", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// uploadIDParam lit l'ID d'upload du chemin ; répond 400 s'il est invalide.
+func uploadIDParam(c *gin.Context) (int64, bool) {
+	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || sessionID <= 0 {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "ID d'upload invalide"})
+		return 0, false
+	}
+	return sessionID, true
+}
+
+// respondUploadError traduit les erreurs communes aux routes d'upload.
+func respondUploadError(c *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, nubo_error.ErrNotFound):
+		c.JSON(http.StatusNotFound, nubo_error.ErrorResponse{Error: "Upload introuvable"})
+	case errors.Is(err, nubo_error.ErrInvalidChunk), errors.Is(err, nubo_error.ErrUploadIncomplete):
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: err.Error()})
+	default:
+		fmt.Printf("❌ ERREUR (%s): %v\n", op, err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+	}
+}
@@ -0,0 +1,76 @@
+package media_handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/media_service"
+	"github.com/gin-gonic/gin"
+)
+
+// PutUploadChunkHandler godoc
+// @Summary      Envoyer un morceau de vidéo
+// @Description  Stocke le morceau `index` (à partir de 0) d'un upload ouvert. Le corps est le contenu brut du morceau
+// @Description  (`application/octet-stream`) ; sa taille doit valoir exactement `chunk_bytes`, sauf pour le dernier
+// @Description  morceau qui porte le reste. Les morceaux peuvent être envoyés dans n'importe quel ordre, et un morceau
+// @Description  renvoyé remplace le précédent.
+// @Description  Cette route nécessite une authentification par JWT et une signature HMAC valide (calculée sur le morceau).
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `ID d'upload invalide` : L'ID dans l'URL n'est pas un entier positif.
+// @Description  * `Invalid chunk index or size` : Index hors limites ou taille de morceau inattendue.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Upload introuvable` : Session inconnue, expirée, terminée ou appartenant à un autre utilisateur.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : MinIO ou Redis indisponible (le morceau peut être renvoyé).
+// @Tags         media
+// @Accept       octet-stream
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        id            path   int    true "ID de l'upload"
+// @Param        index         path   int    true "Index du morceau (à partir de 0)"
+// @Success      200  {object}  media_models.UploadSessionOutput "État de l'upload après réception"
+// @Failure      400  {object}  domain.ErrorResponse "Morceau invalide"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      404  {object}  domain.ErrorResponse "Upload introuvable"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /media/uploads/{id}/chunks/{index} [put]
+func PutUploadChunkHandler(c *gin.Context) {
+	// 1. Authentification
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Extraction de l'ID d'upload et de l'index
+	sessionID, ok := uploadIDParam(c)
+	if !ok {
+		return
+	}
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: nubo_error.ErrInvalidChunk.Error()})
+		return
+	}
+
+	// 3. Appel au service (la taille annoncée est vérifiée contre celle attendue pour cet index)
+	session, err := media_service.PutUploadChunk(c.Request.Context(), userID, sessionID, index, c.Request.Body, c.Request.ContentLength)
+	if err != nil {
+		respondUploadError(c, "PutUploadChunk", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
@@ -5,7 +5,7 @@ import (
 
 	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
 	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
-	"github.com/QuentinRegnier/nubo-backend/internal/service/feed_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/worker"
 	"github.com/gin-gonic/gin"
 )
 
@@ -49,7 +49,7 @@ func RegisterBatchViewsHandler(c *gin.Context) {
 	for _, postID := range input.PostIDs {
 		// Nettoyage anti-doublon direct : s'assurer qu'un ID n'est pas à 0
 		if postID > 0 {
-			feed_service.RegisterView(userID, postID)
+			worker.RegisterView(userID, postID)
 		}
 	}
 
@@ -2,6 +2,7 @@ package post_handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -24,14 +25,24 @@ import (
 // @Description  * `Field 'data' is required` : Le champ texte 'data' contenant le JSON est manquant.
 // @Description  * `Invalid JSON: ...` : Le format JSON dans le champ 'data' est incorrect.
 // @Description  * `Validation failed: ...` : (Géré par pkg.ValidateStruct) Le contenu, les hashtags ou la visibilité ne respectent pas les limites.
-// @Description  * `Maximum 4 images allowed` : Vous avez tenté d'envoyer plus de 4 fichiers média.
+// @Description  * `Maximum 4 images allowed` : Plus de 4 médias au total (fichiers joints + `media_ids`).
 // @Description  * `Empty post_service` : Impossible de publier un post_service sans texte et sans média.
+// @Description  * `Impossible to read image file` : Un fichier n'est pas une image lisible (refusé avant toute écriture).
+// @Description  * `Image resolution is too large` : Une image dépasse 4 mégapixels.
+// @Description  * `Unknown or already used media` : Un `media_id` (vidéo terminée via /media/uploads) est inconnu, appartient à un autre utilisateur ou est déjà rattaché à un post.
+// @Description
+// @Description  Les images sont encodées (et les vidéos transcodées) en arrière-plan : le post reste visible de son seul auteur (visibility = -3)
+// @Description  jusqu'à ce que toutes soient prêtes, puis prend la visibilité demandée. L'auteur reçoit l'événement
+// @Description  WebSocket `post.published`, ou `post.media_failed` si une image n'a pas pu être traitée (visibility = -4).
 // @Description
 // @Description  🟠 **401 Unauthorized (Authentification) :**
 // @Description  * `Utilisateur non identifié` : Le userID n'a pas pu être extrait du token JWT ou contexte manquant.
 // @Description
+// @Description  ⛔ **403 Forbidden (Sanction) :**
+// @Description  * `Action restricted` : La publication est restreinte sur ce compte. Le corps contient la notice de la sanction (motif, échéance, contestation).
+// @Description
 // @Description  ⚫ **500 Internal Server Error (Serveur) :**
-// @Description  * `Failed to create post_service: ...` : Erreur lors de l'upload MinIO ou de l'insertion dans la file d'attente Redis (Queue).
+// @Description  * `Failed to create post_service: ...` : Erreur lors du dépôt MinIO en zone de transit ou de l'insertion dans la file d'attente Redis (Queue).
 // @Tags         posts
 // @Accept       multipart/form-data
 // @Produce      json
@@ -43,6 +54,7 @@ import (
 // @Success      201  {object}  domain.CreatePostResponse
 // @Failure      400  {object}  domain.ErrorResponse "Données invalides ou trop de fichiers"
 // @Failure      401  {object}  domain.ErrorResponse "Session expirée ou utilisateur non identifié"
+// @Failure      403  {object}  nubo_error.SanctionResponse "Publication restreinte"
 // @Failure      500  {object}  domain.ErrorResponse "Erreur interne de persistance"
 // @Router       /posts [post_service]
 func CreatePostHandler(c *gin.Context) {
@@ -79,17 +91,18 @@ func CreatePostHandler(c *gin.Context) {
 	// Supprimer les doublons (évite de stocker 10x le même ID utilisateur ou hashtag)
 	input.Identifiers = pkg.SliceUniqueInt64(input.Identifiers)
 	input.Hashtags = pkg.SliceUniqueStr(input.Hashtags)
+	input.MediaIDs = pkg.SliceUniqueInt64(input.MediaIDs)
 
-	// 5. Récupération des images (1 à 4 autorisées)
+	// 5. Récupération des images (1 à 4 médias autorisés, vidéos déposées comprises)
 	form, _ := c.MultipartForm()
 	files := form.File["media"]
-	if len(files) > 4 {
+	if len(files)+len(input.MediaIDs) > 4 {
 		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Maximum 4 images allowed"})
 		return
 	}
 
 	// Prévention stricte des "Posts Fantômes"
-	if input.Content == "" && len(files) == 0 {
+	if input.Content == "" && len(files) == 0 && len(input.MediaIDs) == 0 {
 		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Empty post_service: un texte ou un média est requis"})
 		return
 	}
@@ -97,6 +110,23 @@ func CreatePostHandler(c *gin.Context) {
 	// 6. Appel au service métier pour la création (et Fan-Out asynchrone)
 	postID, err := post_service.CreatePost(userID, input, files)
 	if err != nil {
+		var sanctionErr *nubo_error.SanctionError
+		if errors.As(err, &sanctionErr) {
+			c.JSON(http.StatusForbidden, nubo_error.SanctionResponse{Error: "Action restricted", Sanction: sanctionErr.Notice})
+			return
+		}
+		if errors.Is(err, nubo_error.ErrInvalidMedia) {
+			c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: nubo_error.ErrInvalidMedia.Error()})
+			return
+		}
+		if errors.Is(err, nubo_error.ErrMediaTooLarge) {
+			c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: nubo_error.ErrMediaTooLarge.Error()})
+			return
+		}
+		if errors.Is(err, nubo_error.ErrMediaUnavailable) {
+			c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: nubo_error.ErrMediaUnavailable.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Failed to create post_service: " + err.Error()})
 		return
 	}
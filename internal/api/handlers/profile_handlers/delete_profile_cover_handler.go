@@ -0,0 +1,35 @@
+package profile_handlers
+
+import (
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteProfileCoverHandler godoc
+// @Summary      Retirer la couverture du profil
+// @Description  Retire l'image de couverture du compte ; le média retiré n'est plus servi (ses fichiers sont collectés par le ramasse-miettes).
+// @Description  Cette route nécessite une authentification par JWT et une signature HMAC valide.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `No image to remove` : Le compte n'a pas de couverture.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Write-Behind indisponible.
+// @Tags         profile
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Success      200  {object}  map[string]string "message: Profile cover removed"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      404  {object}  domain.ErrorResponse "Aucune couverture"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /profile/cover [delete]
+func DeleteProfileCoverHandler(c *gin.Context) {
+	removeProfileImage(c, models.MediaLayoutCover, "DeleteProfileCover", "Profile cover removed")
+}
@@ -0,0 +1,59 @@
+package profile_handlers
+
+import (
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/auth_service"
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteProfilePictureHandler godoc
+// @Summary      Retirer la photo de profil
+// @Description  Retire la photo de profil du compte : l'avatar disparaît partout sans attendre l'expiration du cache,
+// @Description  et le média retiré n'est plus servi (ses fichiers sont collectés par le ramasse-miettes).
+// @Description  Cette route nécessite une authentification par JWT et une signature HMAC valide.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `No image to remove` : Le compte n'a pas de photo de profil.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Write-Behind indisponible.
+// @Tags         profile
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Success      200  {object}  map[string]string "message: Profile picture removed"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      404  {object}  domain.ErrorResponse "Aucune photo de profil"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /profile/picture [delete]
+func DeleteProfilePictureHandler(c *gin.Context) {
+	removeProfileImage(c, models.MediaLayoutAvatar, "DeleteProfilePicture", "Profile picture removed")
+}
+
+// removeProfileImage porte les routes de retrait d'une photo de profil ou d'une couverture.
+func removeProfileImage(c *gin.Context, layout string, origin string, message string) {
+	// 1. Authentification
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Appel au service
+	if err := auth_service.RemoveProfileImage(c.Request.Context(), userID, c.ClientIP(), layout); err != nil {
+		respondProfileError(c, origin, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": message})
+}
@@ -0,0 +1,91 @@
+package profile_handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/profile_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/profile_service"
+	"github.com/gin-gonic/gin"
+)
+
+// SyncProfileHandler godoc
+// @Summary      Synchroniser le profil d'intérêt
+// @Description  Enregistre le vecteur d'intérêt u ∈ R^224 calculé par le client (moyenne mobile exponentielle des signaux
+// @Description  implicites et explicites). Ce vecteur alimente le feed personnalisé : similarité cosinus, LSH et MMR.
+// @Description  Le vecteur doit être normalisé L2 (‖u‖₂ = 1 à 10⁻³ près) et suivre la version courante du schéma vectoriel.
+// @Description  La confiance renvoyée croît avec les interactions cumulées et décroît avec l'âge du profil (demi-vie de 21 jours) ;
+// @Description  au-delà de 0.70, la recherche de voisins LSH est activée.
+// @Description  Le feed personnalisé en cache est invalidé (`drifted`) au premier profil ou quand le vecteur s'écarte de plus de 0.15 (distance L2).
+// @Description  Cette route nécessite une authentification par JWT et une signature HMAC valide, qui garantit l'intégrité du vecteur.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Format JSON invalide ou champs manquants` : `version`, `vector` ou `computed_at` absent, ou `interactions` négatif.
+// @Description  * `Invalid interest vector` : Le vecteur n'a pas 224 composantes, contient une valeur non finie ou n'est pas normalisé.
+// @Description  * `Profile computed_at is in the future` : `computed_at` dépasse l'horloge serveur de plus de 5 minutes.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  🟠 **409 Conflict :**
+// @Description  * `Unsupported interest vector version` : Le client calcule une autre version du vecteur (mise à jour requise).
+// @Description  * `A more recent profile is already stored` : `computed_at` n'est pas postérieur au profil déjà synchronisé.
+// @Description
+// @Description  🟠 **429 Too Many Requests :**
+// @Description  * `Profile synced too recently` : Une synchronisation a déjà eu lieu dans la dernière minute.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Redis, PostgreSQL ou file Write-Behind indisponible.
+// @Tags         profile
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        data          body   profile_models.SyncProfileInput true "Vecteur d'intérêt et métadonnées du calcul"
+// @Success      200  {object}  profile_models.SyncProfileOutput "Profil enregistré"
+// @Failure      400  {object}  domain.ErrorResponse "Vecteur ou horodatage invalide"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      409  {object}  domain.ErrorResponse "Version non supportée ou profil obsolète"
+// @Failure      429  {object}  domain.ErrorResponse "Synchronisation trop fréquente"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /v1/profile/sync [post]
+func SyncProfileHandler(c *gin.Context) {
+	// 1. Authentification
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Décodage (le corps a déjà été authentifié par HMACMiddleware)
+	var input profile_models.SyncProfileInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Format JSON invalide ou champs manquants"})
+		return
+	}
+
+	// 3. Appel au service
+	out, err := profile_service.SyncInterestProfile(c.Request.Context(), userID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, nubo_error.ErrInvalidProfileVector), errors.Is(err, nubo_error.ErrInvalidProfileClock):
+			c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, nubo_error.ErrProfileVersionMismatch), errors.Is(err, nubo_error.ErrProfileStale):
+			c.JSON(http.StatusConflict, nubo_error.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, nubo_error.ErrProfileSyncTooFrequent):
+			c.JSON(http.StatusTooManyRequests, nubo_error.ErrorResponse{Error: err.Error()})
+		default:
+			fmt.Printf("❌ ERREUR (SyncProfile): %v\n", err)
+			c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
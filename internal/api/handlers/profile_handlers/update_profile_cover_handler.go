@@ -0,0 +1,49 @@
+package profile_handlers
+
+import (
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateProfileCoverHandler godoc
+// @Summary      Remplacer la couverture du profil
+// @Description  Dépose une nouvelle image de couverture, substituée à l'ancienne (retirée, puis collectée par le ramasse-miettes) une fois traitée.
+// @Description  Le client peut proposer un rectangle (`crop_x`, `crop_y`, `crop_width`, `crop_height`, en pixels de l'image redressée) :
+// @Description  le serveur en retient le plus grand bandeau 3:1 centré, ou à défaut le plus grand bandeau 3:1 centré de l'image.
+// @Description  Les déclinaisons (640, 1080 et 1500 px de large, AVIF + JPEG) sont encodées en arrière-plan : l'auteur reçoit
+// @Description  l'événement WebSocket `media.ready` (ou `media.failed`). En cas d'échec ou de quarantaine, l'ancienne couverture reste en place.
+// @Description  Cette route nécessite une authentification par JWT et une signature HMAC valide.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Field 'image' is required` : Aucun fichier `image` dans le formulaire.
+// @Description  * `Paramètres invalides` : Un champ de recadrage n'est pas un entier positif.
+// @Description  * `Impossible to read image file` : Le fichier n'est pas une image lisible.
+// @Description  * `Image resolution is too large` : L'image dépasse 4 mégapixels.
+// @Description  * `Invalid crop rectangle` : Rectangle partiel, débordant de l'image, ou dont le bandeau retenu fait moins de 128 px de haut.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Dépôt MinIO, file de traitement ou Write-Behind indisponible.
+// @Tags         profile
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        Authorization header   string true  "Bearer <votre_jwt>"
+// @Param        X-Signature   header   string true  "Signature HMAC de la requête"
+// @Param        X-Timestamp   header   string true  "Timestamp Unix de la requête"
+// @Param        image         formData file   true  "Nouvelle image de couverture"
+// @Param        crop_x        formData int    false "Abscisse du rectangle proposé"
+// @Param        crop_y        formData int    false "Ordonnée du rectangle proposé"
+// @Param        crop_width    formData int    false "Largeur du rectangle proposé"
+// @Param        crop_height   formData int    false "Hauteur du rectangle proposé"
+// @Success      202  {object}  auth_models.ProfileImageOutput "Couverture déposée, encodage en cours"
+// @Failure      400  {object}  domain.ErrorResponse "Image absente, illisible ou recadrage invalide"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /profile/cover [put]
+func UpdateProfileCoverHandler(c *gin.Context) {
+	replaceProfileImage(c, models.MediaLayoutCover, "UpdateProfileCover")
+}
@@ -0,0 +1,106 @@
+package profile_handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/auth_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/auth_service"
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateProfilePictureHandler godoc
+// @Summary      Remplacer la photo de profil
+// @Description  Dépose une nouvelle photo de profil, substituée à l'ancienne (retirée, puis collectée par le ramasse-miettes) une fois traitée.
+// @Description  Le client peut proposer un rectangle (`crop_x`, `crop_y`, `crop_width`, `crop_height`, en pixels de l'image redressée) :
+// @Description  le serveur en retient le plus grand carré centré, ou à défaut le plus grand carré centré de l'image. Les déclinaisons
+// @Description  (64, 160, 320 et 640 px, AVIF + JPEG) sont carrées : le cercle inscrit est exactement le disque affiché sous un masque circulaire.
+// @Description  L'encodage se fait en arrière-plan : l'auteur reçoit l'événement WebSocket `media.ready` (ou `media.failed`). En cas d'échec ou de quarantaine, l'ancienne photo reste en place.
+// @Description  À la substitution, l'avatar est mis à jour dans le SPEED cache : il change partout (fil, recherche, commentaires) sans attendre l'expiration du cache.
+// @Description  Cette route nécessite une authentification par JWT et une signature HMAC valide.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Field 'image' is required` : Aucun fichier `image` dans le formulaire.
+// @Description  * `Paramètres invalides` : Un champ de recadrage n'est pas un entier positif.
+// @Description  * `Impossible to read image file` : Le fichier n'est pas une image lisible.
+// @Description  * `Image resolution is too large` : L'image dépasse 4 mégapixels.
+// @Description  * `Invalid crop rectangle` : Rectangle partiel, débordant de l'image, ou dont le carré retenu fait moins de 128 px de côté.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Dépôt MinIO, file de traitement ou Write-Behind indisponible.
+// @Tags         profile
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        Authorization header   string true  "Bearer <votre_jwt>"
+// @Param        X-Signature   header   string true  "Signature HMAC de la requête"
+// @Param        X-Timestamp   header   string true  "Timestamp Unix de la requête"
+// @Param        image         formData file   true  "Nouvelle photo de profil"
+// @Param        crop_x        formData int    false "Abscisse du rectangle proposé"
+// @Param        crop_y        formData int    false "Ordonnée du rectangle proposé"
+// @Param        crop_width    formData int    false "Largeur du rectangle proposé"
+// @Param        crop_height   formData int    false "Hauteur du rectangle proposé"
+// @Success      202  {object}  auth_models.ProfileImageOutput "Photo déposée, encodage en cours"
+// @Failure      400  {object}  domain.ErrorResponse "Image absente, illisible ou recadrage invalide"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /profile/picture [put]
+func UpdateProfilePictureHandler(c *gin.Context) {
+	replaceProfileImage(c, models.MediaLayoutAvatar, "UpdateProfilePicture")
+}
+
+// replaceProfileImage porte les routes de dépôt d'une photo de profil ou d'une couverture.
+func replaceProfileImage(c *gin.Context, layout string, origin string) {
+	// 1. Authentification
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Parsing (fichier + recadrage proposé)
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Field 'image' is required"})
+		return
+	}
+	var input auth_models.ProfileImageInput
+	if err := c.ShouldBind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Paramètres invalides"})
+		return
+	}
+
+	// 3. Appel au service
+	output, err := auth_service.ReplaceProfileImage(c.Request.Context(), userID, c.ClientIP(), layout, fileHeader, input)
+	if err != nil {
+		respondProfileError(c, origin, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, output)
+}
+
+// respondProfileError traduit les erreurs des images de profil en réponses HTTP.
+func respondProfileError(c *gin.Context, origin string, err error) {
+	switch {
+	case errors.Is(err, nubo_error.ErrInvalidMedia):
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: nubo_error.ErrInvalidMedia.Error()})
+	case errors.Is(err, nubo_error.ErrMediaTooLarge), errors.Is(err, nubo_error.ErrInvalidCrop):
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, nubo_error.ErrNoProfileImage):
+		c.JSON(http.StatusNotFound, nubo_error.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, nubo_error.ErrNotFound):
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+	default:
+		fmt.Printf("❌ ERREUR (%s): %v\n", origin, err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+	}
+}
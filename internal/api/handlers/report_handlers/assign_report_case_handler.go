@@ -0,0 +1,105 @@
+package report_handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/report_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/report_service"
+	"github.com/gin-gonic/gin"
+)
+
+// AssignReportCaseHandler godoc
+// @Summary      Prendre en charge ou assigner un dossier
+// @Description  Sans `assignee_id`, l'appelant prend le dossier pour lui-même ; sinon il l'assigne au modérateur indiqué.
+// @Description  Un modérateur peut prendre un dossier en attente ou céder un dossier qu'il détient. Les dossiers escaladés
+// @Description  ou détenus par un autre modérateur exigent la permission `reports:override` (administrateur).
+// @Description  Cette route nécessite une authentification par JWT, une signature HMAC valide et la permission `reports:resolve`.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Format JSON invalide ou champs manquants` : `case_id` absent.
+// @Description  * `The assignee is not allowed to handle this case` : L'assigné n'a pas les permissions requises.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `Permission insuffisante` : Le grade de l'appelant n'accorde pas `reports:resolve`.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Dossier introuvable` : Aucun dossier ne porte cet identifiant.
+// @Description
+// @Description  🔴 **409 Conflict :**
+// @Description  * `This case is assigned to another moderator` : Le dossier est détenu par un autre modérateur.
+// @Description  * `This case cannot go through this transition in its current state` : Dossier clos, escaladé, ou modifié entre-temps.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : PostgreSQL indisponible.
+// @Tags         moderation
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        data          body   report_models.AssignReportCaseInput true "Dossier et assigné"
+// @Success      200  {object}  report_models.ReportCasePayload "Dossier mis à jour"
+// @Failure      400  {object}  domain.ErrorResponse "Données invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Permission insuffisante"
+// @Failure      404  {object}  domain.ErrorResponse "Dossier introuvable"
+// @Failure      409  {object}  domain.ErrorResponse "Transition impossible"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /admin/report [patch]
+func AssignReportCaseHandler(c *gin.Context) {
+	// 1. Modérateur (identité et grade placés par RequirePermission)
+	moderator, err := moderatorFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Parsing
+	var input report_models.AssignReportCaseInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Format JSON invalide ou champs manquants"})
+		return
+	}
+
+	// 3. Appel au service
+	rc, err := report_service.AssignReportCase(c.Request.Context(), moderator, input)
+	if err != nil {
+		respondReportCaseError(c, "AssignReportCase", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rc)
+}
+
+// moderatorFromContext reconstruit l'auteur d'une action de modération depuis le contexte gin.
+func moderatorFromContext(c *gin.Context) (report_service.Moderator, error) {
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		return report_service.Moderator{}, err
+	}
+	return report_service.Moderator{UserID: userID, Grade: c.GetInt("grade"), IP: c.ClientIP()}, nil
+}
+
+// respondReportCaseError traduit les erreurs de la file de modération en réponses HTTP.
+func respondReportCaseError(c *gin.Context, origin string, err error) {
+	switch {
+	case errors.Is(err, nubo_error.ErrNotFound):
+		c.JSON(http.StatusNotFound, nubo_error.ErrorResponse{Error: "Dossier introuvable"})
+	case errors.Is(err, nubo_error.ErrInvalidAssignee):
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, nubo_error.ErrNotAssignee), errors.Is(err, nubo_error.ErrCaseUnavailable):
+		c.JSON(http.StatusConflict, nubo_error.ErrorResponse{Error: err.Error()})
+	default:
+		fmt.Printf("❌ ERREUR (%s): %v\n", origin, err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+	}
+}
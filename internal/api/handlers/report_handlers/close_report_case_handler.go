@@ -0,0 +1,79 @@
+package report_handlers
+
+import (
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/report_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/report_service"
+	"github.com/gin-gonic/gin"
+)
+
+// CloseReportCaseHandler godoc
+// @Summary      Clore un dossier
+// @Description  Clôt un dossier avec un code de résolution : 1 = aucune infraction, 2 = contenu retiré, 3 = averti,
+// @Description  4 = restreint, 5 = banni, 6 = doublon. Tous les signalements du dossier passent à l'état clos.
+// @Description  Un modérateur ne clôt que les dossiers qu'il détient ; `reports:override` permet de clore tout dossier ouvert.
+// @Description  Un contenu masqué automatiquement est rétabli (1, 6) ; sinon son retrait est confirmé : l'auteur reçoit une sanction
+// @Description  de retrait (kind 4) contestable en appel, et le contenu reste masqué jusqu'à la fin du délai d'appel avant sa suppression.
+// @Description  Cette route nécessite une authentification par JWT, une signature HMAC valide et la permission `reports:resolve`.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Format JSON invalide ou champs manquants` : `case_id` absent, code de résolution inconnu, note trop longue.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `Permission insuffisante` : Le grade de l'appelant n'accorde pas `reports:resolve`.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Dossier introuvable` : Aucun dossier ne porte cet identifiant.
+// @Description
+// @Description  🔴 **409 Conflict :**
+// @Description  * `This case is assigned to another moderator` : L'appelant ne détient pas le dossier.
+// @Description  * `This case cannot go through this transition in its current state` : Dossier déjà clos ou non pris en charge.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : PostgreSQL indisponible.
+// @Tags         moderation
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        data          body   report_models.CloseReportCaseInput true "Dossier, code et note de résolution"
+// @Success      200  {object}  report_models.ReportCasePayload "Dossier clos"
+// @Failure      400  {object}  domain.ErrorResponse "Données invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Permission insuffisante"
+// @Failure      404  {object}  domain.ErrorResponse "Dossier introuvable"
+// @Failure      409  {object}  domain.ErrorResponse "Transition impossible"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /admin/report [delete]
+func CloseReportCaseHandler(c *gin.Context) {
+	// 1. Modérateur
+	moderator, err := moderatorFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Parsing
+	var input report_models.CloseReportCaseInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Format JSON invalide ou champs manquants"})
+		return
+	}
+
+	// 3. Appel au service
+	rc, err := report_service.CloseReportCase(c.Request.Context(), moderator, input)
+	if err != nil {
+		respondReportCaseError(c, "CloseReportCase", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rc)
+}
@@ -59,7 +59,7 @@ func CreateReportHandler(c *gin.Context) {
 	input.UserID = userID
 
 	// 3. Appel au service asynchrone
-	if err := report_service.SubmitReport(c.Request.Context(), input); err != nil {
+	if err := report_service.SubmitReport(c.Request.Context(), input, c.ClientIP()); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"nubo_error": "Impossible de traiter le signalement pour le moment."})
 		return
 	}
@@ -0,0 +1,76 @@
+package report_handlers
+
+import (
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/report_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/report_service"
+	"github.com/gin-gonic/gin"
+)
+
+// EscalateReportCaseHandler godoc
+// @Summary      Escalader un dossier
+// @Description  Transmet un dossier que l'appelant détient aux administrateurs, avec une note obligatoire.
+// @Description  Le dossier est désassigné et n'est plus modifiable qu'avec la permission `reports:override`.
+// @Description  Cette route nécessite une authentification par JWT, une signature HMAC valide et la permission `reports:resolve`.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Format JSON invalide ou champs manquants` : `case_id` ou `note` absent, note de plus de 1000 caractères.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `Permission insuffisante` : Le grade de l'appelant n'accorde pas `reports:resolve`.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Dossier introuvable` : Aucun dossier ne porte cet identifiant.
+// @Description
+// @Description  🔴 **409 Conflict :**
+// @Description  * `This case is assigned to another moderator` : L'appelant ne détient pas le dossier.
+// @Description  * `This case cannot go through this transition in its current state` : Le dossier n'est pas en cours.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : PostgreSQL indisponible.
+// @Tags         moderation
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        data          body   report_models.EscalateReportCaseInput true "Dossier et motif de l'escalade"
+// @Success      200  {object}  report_models.ReportCasePayload "Dossier escaladé"
+// @Failure      400  {object}  domain.ErrorResponse "Données invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Permission insuffisante"
+// @Failure      404  {object}  domain.ErrorResponse "Dossier introuvable"
+// @Failure      409  {object}  domain.ErrorResponse "Transition impossible"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /admin/report/escalate [post]
+func EscalateReportCaseHandler(c *gin.Context) {
+	// 1. Modérateur
+	moderator, err := moderatorFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Parsing
+	var input report_models.EscalateReportCaseInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Format JSON invalide ou champs manquants"})
+		return
+	}
+
+	// 3. Appel au service
+	rc, err := report_service.EscalateReportCase(c.Request.Context(), moderator, input)
+	if err != nil {
+		respondReportCaseError(c, "EscalateReportCase", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rc)
+}
@@ -0,0 +1,69 @@
+package report_handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/report_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/report_service"
+	"github.com/gin-gonic/gin"
+)
+
+// GetReportCaseHandler godoc
+// @Summary      Consulter un dossier de modération
+// @Description  Renvoie un dossier et tous les signalements qui y ont été agrégés (auteur, catégorie, justification).
+// @Description  Cette route nécessite une authentification par JWT, une signature HMAC valide et la permission `reports:view`.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Paramètres invalides` : `case_id` absent ou non numérique.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `Permission insuffisante` : Le grade de l'appelant n'accorde pas `reports:view`.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Dossier introuvable` : Aucun dossier ne porte cet identifiant.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : PostgreSQL indisponible.
+// @Tags         moderation
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        case_id       query  int    true "Identifiant du dossier"
+// @Success      200  {object}  report_models.ReportCaseDetailOutput "Dossier et signalements"
+// @Failure      400  {object}  domain.ErrorResponse "Paramètres invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Permission insuffisante"
+// @Failure      404  {object}  domain.ErrorResponse "Dossier introuvable"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /admin/report [get]
+func GetReportCaseHandler(c *gin.Context) {
+	// 1. Parsing
+	var input report_models.GetReportCaseInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Paramètres invalides"})
+		return
+	}
+
+	// 2. Appel au service
+	out, err := report_service.GetReportCase(c.Request.Context(), input.CaseID)
+	if err != nil {
+		if errors.Is(err, nubo_error.ErrNotFound) {
+			c.JSON(http.StatusNotFound, nubo_error.ErrorResponse{Error: "Dossier introuvable"})
+			return
+		}
+		fmt.Printf("❌ ERREUR (GetReportCase): %v\n", err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
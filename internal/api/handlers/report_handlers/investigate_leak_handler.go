@@ -0,0 +1,107 @@
+package report_handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/report_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+	"github.com/gin-gonic/gin"
+)
+
+// InvestigateLeakHandler godoc
+// @Summary      Analyser une image fuitée
+// @Description  Relit le tatouage DCT d'une image retrouvée hors de Nubo (AVIF, HEIC, WebP, JPEG, PNG, TIFF) : auteur, post,
+// @Description  compte ayant reçu l'image et heure d'émission du lien. Les identifiants sont résolus en cascade (post supprimé ou
+// @Description  masqué compris). `confidence` estime la probabilité d'une lecture exacte des identifiants à partir de l'accord sur
+// @Description  les bits imposés par le format (`stats`). Si la confiance atteint 0.8 et qu'aucune incohérence n'est relevée
+// @Description  (`findings` vide), un dossier est ouvert (ou complété) contre le responsable et l'image y est versée telle quelle
+// @Description  (MinIO, lien signé 15 minutes). Chaque analyse est auditée (`admin.forensic`).
+// @Description  Cette route nécessite une authentification par JWT, une signature HMAC valide et la permission `forensic:run` (administrateur).
+// @Description
+// @Description  **Codes `findings` :** `low_confidence`, `author_not_found`, `post_not_found`, `culprit_not_found`,
+// @Description  `author_mismatch` (le post n'appartient pas à l'auteur tatoué), `self_leak` (l'auteur diffuse sa propre image),
+// @Description  `leak_before_post`, `leak_in_future` (horodatage incohérent).
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Field 'image' is required` : Aucun fichier `image` dans le formulaire.
+// @Description  * `Image trop volumineuse` : Le fichier dépasse 20 Mo.
+// @Description  * `No readable watermark was found in this image` : Format non décodable ou aucun tatouage exploitable.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `Permission insuffisante` : Le grade de l'appelant n'accorde pas `forensic:run`.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : MinIO ou PostgreSQL indisponible.
+// @Tags         moderation
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        Authorization header   string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header   string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header   string true "Timestamp Unix de la requête"
+// @Param        image         formData file   true "Image fuitée"
+// @Success      200  {object}  report_models.ForensicInvestigationOutput "Lecture non concluante : aucun dossier ouvert"
+// @Success      201  {object}  report_models.ForensicInvestigationOutput "Dossier ouvert ou complété contre le responsable"
+// @Failure      400  {object}  domain.ErrorResponse "Image absente, trop volumineuse ou illisible"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Permission insuffisante"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /admin/forensic [post]
+func InvestigateLeakHandler(c *gin.Context) {
+	// 1. Modérateur (identité et grade placés par RequirePermission)
+	moderator, err := moderatorFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Lecture de l'image en RAM (jamais écrite sur le disque du serveur)
+	header, err := c.FormFile("image")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Field 'image' is required"})
+		return
+	}
+	if header.Size > variables.ForensicMaxUploadBytes {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Image trop volumineuse"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Field 'image' is required"})
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	image, err := io.ReadAll(io.LimitReader(file, variables.ForensicMaxUploadBytes))
+	if err != nil {
+		fmt.Printf("❌ ERREUR (InvestigateLeak): lecture de l'image: %v\n", err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+		return
+	}
+
+	// 3. Appel au service
+	out, err := report_service.InvestigateLeak(c.Request.Context(), moderator, image)
+	if err != nil {
+		if errors.Is(err, nubo_error.ErrWatermarkUnreadable) {
+			c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: nubo_error.ErrWatermarkUnreadable.Error()})
+			return
+		}
+		fmt.Printf("❌ ERREUR (InvestigateLeak): %v\n", err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+		return
+	}
+
+	if out.Case != nil {
+		c.JSON(http.StatusCreated, out)
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
@@ -0,0 +1,68 @@
+package report_handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/report_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/report_service"
+	"github.com/gin-gonic/gin"
+)
+
+// ListReportCasesHandler godoc
+// @Summary      File de modération
+// @Description  Liste les dossiers de modération (signalements agrégés par cible), du plus urgent au moins urgent :
+// @Description  priorité de la catégorie (mineur, contenu non consenti en tête), puis nombre de signalements, puis ancienneté.
+// @Description  Sans filtre `state`, seuls les dossiers ouverts sont listés (0 = en attente, 1 = en cours, 2 = escaladé).
+// @Description  Cette route nécessite une authentification par JWT, une signature HMAC valide et la permission `reports:view`.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Paramètres invalides` : Un filtre n'est pas numérique.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `Permission insuffisante` : Le grade de l'appelant n'accorde pas `reports:view`.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : PostgreSQL indisponible.
+// @Tags         moderation
+// @Produce      json
+// @Param        Authorization header string true  "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true  "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true  "Timestamp Unix de la requête"
+// @Param        state         query  int    false "État (0, 1, 2, -1)"
+// @Param        category      query  int    false "Catégorie de signalement"
+// @Param        target_type   query  int    false "Type de cible (0, 1, 2, 3, 5)"
+// @Param        target_id     query  int    false "Identifiant d'une cible du dossier"
+// @Param        assignee_id   query  int    false "Modérateur assigné"
+// @Param        limit         query  int    false "Taille de page (défaut 50, max 200)"
+// @Param        offset        query  int    false "Décalage"
+// @Success      200  {object}  report_models.ListReportCasesOutput "Page de la file"
+// @Failure      400  {object}  domain.ErrorResponse "Paramètres invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Permission insuffisante"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /admin/reports [get]
+func ListReportCasesHandler(c *gin.Context) {
+	// 1. Filtres (l'identité et la permission sont vérifiées en amont par RequirePermission)
+	var input report_models.ListReportCasesInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Paramètres invalides"})
+		return
+	}
+
+	// 2. Appel au service
+	out, err := report_service.ListReportCases(c.Request.Context(), input)
+	if err != nil {
+		fmt.Printf("❌ ERREUR (ListReportCases): %v\n", err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
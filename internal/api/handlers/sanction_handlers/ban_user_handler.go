@@ -0,0 +1,99 @@
+package sanction_handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/sanction_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/sanction_service"
+	"github.com/gin-gonic/gin"
+)
+
+// BanUserHandler godoc
+// @Summary      Bannir un utilisateur
+// @Description  Bannit un utilisateur définitivement (`duration_days` = 0) ou temporairement (1 à 365 jours, levée automatique à l'échéance).
+// @Description  Toutes ses sessions sont révoquées immédiatement et son compte est masqué. Le motif et l'échéance lui sont présentés au login.
+// @Description  Cette route nécessite une authentification par JWT, une signature HMAC valide et la permission `users:sanction`.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Format JSON invalide ou champs manquants` : `user_id` ou `reason` absent, durée hors bornes.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `Permission insuffisante` : Le grade de l'appelant n'accorde pas `users:sanction`.
+// @Description  * `You cannot sanction a user of equal or higher grade` : L'utilisateur visé a un grade supérieur ou égal.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Utilisateur introuvable` : Aucun utilisateur ne porte cet identifiant.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Redis (Write-Behind) ou les bases de persistance sont indisponibles.
+// @Tags         moderation
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        data          body   sanction_models.BanUserInput true "Utilisateur, motif et durée"
+// @Success      201  {object}  sanction_models.SanctionOutput "Ban émis"
+// @Failure      400  {object}  domain.ErrorResponse "Données invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Permission insuffisante ou grade de la cible"
+// @Failure      404  {object}  domain.ErrorResponse "Utilisateur introuvable"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /admin/ban [post]
+func BanUserHandler(c *gin.Context) {
+	// 1. Modérateur (identité et grade placés par RequirePermission)
+	moderator, err := moderatorFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Parsing
+	var input sanction_models.BanUserInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Format JSON invalide ou champs manquants"})
+		return
+	}
+
+	// 3. Appel au service
+	out, err := sanction_service.BanUser(c.Request.Context(), moderator, input)
+	if err != nil {
+		respondSanctionError(c, "BanUser", "Utilisateur introuvable", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, out)
+}
+
+// moderatorFromContext reconstruit l'auteur d'une sanction depuis le contexte gin.
+func moderatorFromContext(c *gin.Context) (sanction_service.Moderator, error) {
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		return sanction_service.Moderator{}, err
+	}
+	return sanction_service.Moderator{UserID: userID, Grade: c.GetInt("grade"), IP: c.ClientIP()}, nil
+}
+
+// respondSanctionError traduit les erreurs du moteur de sanctions en réponses HTTP.
+func respondSanctionError(c *gin.Context, origin string, notFound string, err error) {
+	switch {
+	case errors.Is(err, nubo_error.ErrNotFound):
+		c.JSON(http.StatusNotFound, nubo_error.ErrorResponse{Error: notFound})
+	case errors.Is(err, nubo_error.ErrSanctionTarget):
+		c.JSON(http.StatusForbidden, nubo_error.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, nubo_error.ErrSanctionInactive):
+		c.JSON(http.StatusConflict, nubo_error.ErrorResponse{Error: err.Error()})
+	default:
+		fmt.Printf("❌ ERREUR (%s): %v\n", origin, err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+	}
+}
@@ -0,0 +1,50 @@
+package sanction_handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/sanction_service"
+	"github.com/gin-gonic/gin"
+)
+
+// GetMySanctionsHandler godoc
+// @Summary      Consulter ses sanctions
+// @Description  Liste les sanctions en vigueur sur le compte de l'appelant : avertissements des 90 derniers jours et restrictions en cours,
+// @Description  avec leur motif, leur échéance et la date limite de contestation (`appealable_until`).
+// @Description  Cette route nécessite une authentification par JWT et une signature HMAC valide.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : PostgreSQL indisponible.
+// @Tags         account
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Success      200  {object}  sanction_models.MySanctionsOutput "Sanctions en vigueur"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /account/sanctions [get]
+func GetMySanctionsHandler(c *gin.Context) {
+	userID, err := pkg.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	out, err := sanction_service.ListMySanctions(c.Request.Context(), userID)
+	if err != nil {
+		fmt.Printf("❌ ERREUR (ListMySanctions): %v\n", err)
+		c.JSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
@@ -0,0 +1,75 @@
+package sanction_handlers
+
+import (
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/sanction_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/sanction_service"
+	"github.com/gin-gonic/gin"
+)
+
+// LiftSanctionHandler godoc
+// @Summary      Lever une sanction
+// @Description  Lève un ban, une restriction ou un avertissement avant son terme. La levée d'un ban rétablit le compte
+// @Description  (sauf s'il est par ailleurs désactivé) ; celle d'un avertissement le retire de l'échelle des strikes.
+// @Description  Cette route nécessite une authentification par JWT, une signature HMAC valide et la permission `users:sanction`.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Format JSON invalide ou champs manquants` : `sanction_id` ou `reason` absent.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `Permission insuffisante` : Le grade de l'appelant n'accorde pas `users:sanction`.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Sanction introuvable` : Aucune sanction ne porte cet identifiant.
+// @Description
+// @Description  🔴 **409 Conflict :**
+// @Description  * `This sanction is no longer active` : La sanction est déjà levée ou expirée.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Redis (Write-Behind) ou les bases de persistance sont indisponibles.
+// @Tags         moderation
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        data          body   sanction_models.LiftSanctionInput true "Sanction et motif de la levée"
+// @Success      200  {object}  sanction_models.SanctionPayload "Sanction levée"
+// @Failure      400  {object}  domain.ErrorResponse "Données invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Permission insuffisante"
+// @Failure      404  {object}  domain.ErrorResponse "Sanction introuvable"
+// @Failure      409  {object}  domain.ErrorResponse "Sanction inactive"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /admin/sanction [delete]
+func LiftSanctionHandler(c *gin.Context) {
+	// 1. Modérateur
+	moderator, err := moderatorFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Parsing
+	var input sanction_models.LiftSanctionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Format JSON invalide ou champs manquants"})
+		return
+	}
+
+	// 3. Appel au service
+	s, err := sanction_service.LiftSanction(c.Request.Context(), moderator, input)
+	if err != nil {
+		respondSanctionError(c, "LiftSanction", "Sanction introuvable", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, s)
+}
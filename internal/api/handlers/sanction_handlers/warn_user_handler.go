@@ -0,0 +1,72 @@
+package sanction_handlers
+
+import (
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/sanction_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/sanction_service"
+	"github.com/gin-gonic/gin"
+)
+
+// WarnUserHandler godoc
+// @Summary      Avertir un utilisateur
+// @Description  Émet un avertissement (strike). Les avertissements actifs sur 90 jours forment une échelle automatique :
+// @Description  3 → restriction totale de 7 jours, 5 → ban de 30 jours, 7 → ban définitif. La sanction déclenchée est renvoyée dans `escalation`.
+// @Description  Cette route nécessite une authentification par JWT, une signature HMAC valide et la permission `users:sanction`.
+// @Description
+// @Description  **Règles de validation & Erreurs :**
+// @Description
+// @Description  🔴 **400 Bad Request (Erreurs client) :**
+// @Description  * `Format JSON invalide ou champs manquants` : `user_id` ou `reason` absent.
+// @Description
+// @Description  🟠 **401 Unauthorized (Authentification) :**
+// @Description  * `Utilisateur non identifié` : Le contexte JWT est absent ou invalide.
+// @Description
+// @Description  ⛔ **403 Forbidden (Autorisation) :**
+// @Description  * `Permission insuffisante` : Le grade de l'appelant n'accorde pas `users:sanction`.
+// @Description  * `You cannot sanction a user of equal or higher grade` : L'utilisateur visé a un grade supérieur ou égal.
+// @Description
+// @Description  ⚫ **404 Not Found :**
+// @Description  * `Utilisateur introuvable` : Aucun utilisateur ne porte cet identifiant.
+// @Description
+// @Description  ⚫ **500 Internal Server Error (Serveur) :**
+// @Description  * `Internal server error` : Redis (Write-Behind) ou les bases de persistance sont indisponibles.
+// @Tags         moderation
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer <votre_jwt>"
+// @Param        X-Signature   header string true "Signature HMAC de la requête"
+// @Param        X-Timestamp   header string true "Timestamp Unix de la requête"
+// @Param        data          body   sanction_models.WarnUserInput true "Utilisateur et motif"
+// @Success      201  {object}  sanction_models.SanctionOutput "Avertissement émis (et escalade éventuelle)"
+// @Failure      400  {object}  domain.ErrorResponse "Données invalides"
+// @Failure      401  {object}  domain.ErrorResponse "Utilisateur non identifié"
+// @Failure      403  {object}  domain.ErrorResponse "Permission insuffisante ou grade de la cible"
+// @Failure      404  {object}  domain.ErrorResponse "Utilisateur introuvable"
+// @Failure      500  {object}  domain.ErrorResponse "Erreur interne du serveur"
+// @Router       /admin/warning [post]
+func WarnUserHandler(c *gin.Context) {
+	// 1. Modérateur
+	moderator, err := moderatorFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+		return
+	}
+
+	// 2. Parsing
+	var input sanction_models.WarnUserInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Format JSON invalide ou champs manquants"})
+		return
+	}
+
+	// 3. Appel au service
+	out, err := sanction_service.WarnUser(c.Request.Context(), moderator, input)
+	if err != nil {
+		respondSanctionError(c, "WarnUser", "Utilisateur introuvable", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, out)
+}
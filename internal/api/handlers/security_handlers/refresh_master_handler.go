@@ -20,6 +20,7 @@ import (
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/mongo"
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/variables"
 	"github.com/gin-gonic/gin"
@@ -194,6 +195,8 @@ func RefreshMaster(c *gin.Context) {
 		log.Printf("Error enqueuing to DB: %v", err)
 	}
 
+	audit.Record(c, audit.User(input.UserID, c.ClientIP()), audit.ActionMasterRefresh, audit.On(audit.TargetSession, sessionRaw.ID), nil)
+
 	// 9. PRÉPARATION DE LA RÉPONSE SIGNÉE
 	respData := security_models.RefreshMasterResponse{
 		MasterToken: newMasterToken,
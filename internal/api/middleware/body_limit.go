@@ -13,6 +13,9 @@ const (
 
 	// Limite pour les envois de médias / Multipart (15 Mégaoctets)
 	MaxMultipartSize = 15 << 20
+
+	// Limite pour un morceau d'upload vidéo / octet-stream (8 Mégaoctets, morceaux de 5 Mio)
+	MaxChunkSize = 8 << 20
 )
 
 // MaxBodySize est la guillotine qui protège la RAM et le CPU
@@ -25,6 +28,8 @@ func MaxBodySize() gin.HandlerFunc {
 		// Définir la limite selon le type de requête
 		if strings.HasPrefix(contentType, "multipart/form-data") {
 			limit = MaxMultipartSize
+		} else if strings.HasPrefix(contentType, "application/octet-stream") {
+			limit = MaxChunkSize
 		} else {
 			limit = MaxJSONSize
 		}
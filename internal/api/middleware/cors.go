@@ -20,10 +20,10 @@ func CORSMiddleware() gin.HandlerFunc {
 		// Il faut lister TOUS tes headers de sécurité ici, sinon le navigateur les bloquera.
 		c.Writer.Header().Set("Access-Control-Allow-Headers",
 			"Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, "+
-				"X-Signature, X-Timestamp, X-Secret, X-Nubo-Timestamp")
+				"X-Signature, X-Timestamp, X-Secret, X-Nubo-Timestamp, X-Request-Id, Idempotency-Key")
 
 		// 4. Quels headers le client a le droit de LIRE dans la réponse ?
-		c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length, X-Signature, X-Timestamp")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length, X-Signature, X-Timestamp, X-Request-Id, Idempotent-Replayed")
 
 		// 5. Gestion des Cookies / Credentials
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
@@ -5,6 +5,7 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -18,6 +19,7 @@ import (
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/mongo"
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/variables"
 	"github.com/gin-gonic/gin"
@@ -118,8 +120,9 @@ func HMACMiddleware() gin.HandlerFunc {
 
 		if !sessionFound {
 			// B. Essai Mongo L2 (Stockage Documentaire)
-			session, errMongo := mongo.MongoLoadSession(userID, deviceToken, "", "")
-			if errMongo == nil && session.ID != 0 {
+			sessionL2, errMongo := mongo.MongoLoadSession(userID, deviceToken, "", "")
+			if errMongo == nil && sessionL2.ID != 0 {
+				session = sessionL2
 				fmt.Println("✅ Session trouvée dans Mongo L2, réhydratation du cache L1...")
 				sessionFound = true
 				// Repopulation : Le SET écrase/crée la session dans le cache pour les requêtes suivantes
@@ -129,8 +132,9 @@ func HMACMiddleware() gin.HandlerFunc {
 
 		if !sessionFound {
 			// C. Essai Postgres L3 (Le filet de sécurité absolu)
-			session, errPg := postgres.FuncLoadSession(-1, userID, deviceToken, "")
-			if errPg == nil && session.ID != 0 {
+			sessionL3, errPg := postgres.FuncLoadSession(-1, userID, deviceToken, "")
+			if errPg == nil && sessionL3.ID != 0 {
+				session = sessionL3
 				fmt.Println("✅ Session trouvée dans Postgres L3, réhydratation massive...")
 				sessionFound = true
 
@@ -194,6 +198,39 @@ func HMACMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// 6. X-Request-Id (optionnel) : identifiant client stable entre les retries, sert de clé d'idempotence
+		// sur les routes mutatrices (voir IdempotencyMiddleware). Exposé via le contexte et renvoyé dans la réponse.
+		requestID := c.GetHeader("X-Request-Id")
+		if requestID != "" {
+			if err := security.ValidateRequestID(requestID); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "X-Request-Id invalide"})
+				return
+			}
+			c.Set("requestID", requestID)
+			c.Header("X-Request-Id", requestID)
+		}
+
+		// 7. Anti-Rejeu (Nonce) : une signature valide ne peut être consommée qu'une seule fois par session
+		// pendant la fenêtre de tolérance (SETNX sur l'empreinte de la signature).
+		// Exception : le retry à l'identique d'une requête dont la réponse est mémorisée (même clé d'idempotence,
+		// même corps) passe, IdempotencyMiddleware la rejoue sans réexécuter le handler.
+		if err := security.ClaimSignatureNonce(c, session.ID, clientSig, tsInt); err != nil {
+			if !errors.Is(err, security.ErrReplayDetected) {
+				// Fail-Closed : sans store de nonces, l'anti-rejeu ne peut pas être garanti
+				c.AbortWithStatusJSON(http.StatusServiceUnavailable, nubo_error.ErrorResponse{Error: "Service temporairement indisponible"})
+				return
+			}
+			if !hasStoredResponse(c, userID, bodyBytes) {
+				audit.Record(c, audit.User(userID, c.ClientIP()), audit.ActionReplayDetected, audit.On(audit.TargetSession, session.ID), map[string]any{
+					"method": c.Request.Method,
+					"path":   c.FullPath(),
+				})
+				c.AbortWithStatusJSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Requête rejouée"})
+				return
+			}
+		}
+		c.Set("sessionID", session.ID)
+
 		// =====================================================================
 		// PARTIE 2 : INTERCEPTION ET SIGNATURE DE LA RÉPONSE (SORTANTE)
 		// =====================================================================
@@ -0,0 +1,217 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg/security"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+	"github.com/gin-gonic/gin"
+)
+
+// -------------------------------------------------------------------------
+// ENREGISTREMENT D'IDEMPOTENCE
+// -------------------------------------------------------------------------
+
+const (
+	idempotencyStatePending = 0
+	idempotencyStateDone    = 1
+)
+
+// idempotencyRecord est la réponse mémorisée pour un couple (utilisateur, clé).
+type idempotencyRecord struct {
+	State       int    `msgpack:"s"`
+	Fingerprint string `msgpack:"f"` // METHOD|PATH|sha256(body) : détecte la réutilisation d'une clé pour une autre requête
+	Status      int    `msgpack:"c"`
+	ContentType string `msgpack:"t"`
+	Body        []byte `msgpack:"b"`
+}
+
+// idempotencyWriter duplique la réponse du handler pour pouvoir la mémoriser
+// tout en la laissant poursuivre vers le writer suivant (signature HMAC).
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w idempotencyWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w idempotencyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// -------------------------------------------------------------------------
+// MIDDLEWARE
+// -------------------------------------------------------------------------
+
+// IdempotencyMiddleware rend les routes POST/PATCH/DELETE rejouables sans effet de bord.
+// La clé provient du header "Idempotency-Key" ou, à défaut, du X-Request-Id validé par HMACMiddleware.
+//
+//   - Première requête : verrou "en cours" (SETNX), exécution, puis mémorisation status + body pendant 24h.
+//   - Retry après succès : la réponse mémorisée est renvoyée telle quelle (header Idempotent-Replayed: true).
+//   - Doublon concurrent : 409 tant que la première requête n'a pas répondu.
+//   - Réponse non rejouable (5xx, 4xx dépendant de l'état ou de la saisie) : la clé est libérée pour
+//     permettre un nouvel essai, éventuellement corrigé (voir isStorableStatus).
+//
+// Un retry à l'identique réutilise la signature de la première requête : HMACMiddleware le laisse passer
+// malgré son nonce consommé lorsqu'une réponse est mémorisée (voir hasStoredResponse).
+//
+// Doit être placé APRÈS JWTMiddleware et HMACMiddleware (userID et requestID dans le contexte).
+func IdempotencyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		method := c.Request.Method
+		if !security.IsMutatingMethod(method) {
+			c.Next()
+			return
+		}
+
+		key := idempotencyKey(c)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if err := security.ValidateRequestID(key); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, nubo_error.ErrorResponse{Error: "Idempotency-Key invalide"})
+			return
+		}
+
+		userID, err := pkg.GetUserIDFromContext(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+			return
+		}
+
+		// Empreinte de la requête (le body a déjà été relu et restauré par HMACMiddleware)
+		var bodyBytes []byte
+		if c.Request.Body != nil {
+			bodyBytes, _ = io.ReadAll(c.Request.Body)
+			c.Request.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+		}
+		fingerprint := requestFingerprint(method, c.Request.URL.Path, bodyBytes)
+
+		recordID := fmt.Sprintf("%d:%s", userID, key)
+		ctx := c.Request.Context()
+
+		// 1. Prise du verrou "en cours"
+		lock := idempotencyRecord{State: idempotencyStatePending, Fingerprint: fingerprint}
+		acquired, err := redis.IdempotencyKeys.SetObjectNX(ctx, recordID, lock, time.Duration(variables.IdempotencyLockSeconds)*time.Second)
+		if err != nil {
+			// Fail-Open : l'idempotence est une protection de confort, on ne bloque pas l'API si Redis plante
+			c.Next()
+			return
+		}
+
+		if !acquired {
+			var existing idempotencyRecord
+			if errGet := redis.IdempotencyKeys.GetObject(ctx, recordID, &existing); errGet != nil {
+				// Le verrou vient d'expirer entre SETNX et GET : le client peut réessayer
+				c.AbortWithStatusJSON(http.StatusConflict, nubo_error.ErrorResponse{Error: "Requête identique en cours de traitement"})
+				return
+			}
+			if existing.Fingerprint != fingerprint {
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, nubo_error.ErrorResponse{Error: "Idempotency-Key déjà utilisée pour une autre requête"})
+				return
+			}
+			if existing.State == idempotencyStatePending {
+				c.AbortWithStatusJSON(http.StatusConflict, nubo_error.ErrorResponse{Error: "Requête identique en cours de traitement"})
+				return
+			}
+
+			// Rejeu de la réponse mémorisée
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(existing.Status, existing.ContentType, existing.Body)
+			c.Abort()
+			return
+		}
+
+		// 2. Exécution du handler avec capture de la réponse
+		w := &idempotencyWriter{body: bytes.NewBufferString(""), ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+
+		// 3. Mémorisation (ou libération de la clé si la réponse n'est pas rejouable)
+		status := w.Status()
+		if !isStorableStatus(status) || w.body.Len() > variables.IdempotencyMaxStoredBytes {
+			_ = redis.IdempotencyKeys.DeleteObject(ctx, recordID)
+			return
+		}
+
+		done := idempotencyRecord{
+			State:       idempotencyStateDone,
+			Fingerprint: fingerprint,
+			Status:      status,
+			ContentType: w.Header().Get("Content-Type"),
+			Body:        w.body.Bytes(),
+		}
+		if err := redis.IdempotencyKeys.SetObject(ctx, recordID, done); err != nil {
+			fmt.Printf("⚠️ Idempotency: échec de mémorisation de la réponse %s : %v\n", recordID, err)
+			_ = redis.IdempotencyKeys.DeleteObject(ctx, recordID)
+		}
+	}
+}
+
+// -------------------------------------------------------------------------
+// HELPERS
+// -------------------------------------------------------------------------
+
+// idempotencyKey renvoie la clé d'idempotence de la requête : header "Idempotency-Key", à défaut X-Request-Id.
+func idempotencyKey(c *gin.Context) string {
+	if key := c.GetHeader("Idempotency-Key"); key != "" {
+		return key
+	}
+	return c.GetString("requestID")
+}
+
+// requestFingerprint construit l'empreinte METHOD|PATH|sha256(body) d'une requête.
+func requestFingerprint(method string, path string, body []byte) string {
+	sum := sha256.Sum256(body)
+	return method + "|" + path + "|" + hex.EncodeToString(sum[:])
+}
+
+// isStorableStatus indique si une réponse peut être rejouée pendant 24h : succès, et erreurs 4xx qu'un
+// retry à l'identique reproduirait forcément. Les erreurs de saisie (400, 422) sont exclues pour qu'un
+// client puisse corriger sa requête sous la même clé, comme celles liées à l'état (401, 403, 409, 429).
+func isStorableStatus(status int) bool {
+	switch status {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusGone,
+		http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType:
+		return true
+	}
+	return status >= http.StatusOK && status < http.StatusMultipleChoices
+}
+
+// replayableRecord indique si un enregistrement contient la réponse mémorisée de cette requête exacte.
+func replayableRecord(rec idempotencyRecord, fingerprint string) bool {
+	return rec.State == idempotencyStateDone && rec.Fingerprint == fingerprint
+}
+
+// hasStoredResponse indique si la requête est le retry exact (même clé, même empreinte) d'une requête dont
+// la réponse est mémorisée. Utilisé par HMACMiddleware avant de rejeter un nonce déjà consommé :
+// IdempotencyMiddleware renverra cette réponse sans réexécuter le handler.
+func hasStoredResponse(c *gin.Context, userID int64, body []byte) bool {
+	if !security.IsMutatingMethod(c.Request.Method) {
+		return false
+	}
+	key := idempotencyKey(c)
+	if key == "" || security.ValidateRequestID(key) != nil {
+		return false
+	}
+
+	var rec idempotencyRecord
+	if err := redis.IdempotencyKeys.GetObject(c.Request.Context(), fmt.Sprintf("%d:%s", userID, key), &rec); err != nil {
+		return false
+	}
+	return replayableRecord(rec, requestFingerprint(c.Request.Method, c.Request.URL.Path, body))
+}
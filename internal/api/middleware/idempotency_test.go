@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+)
+
+// Un retry mobile rejoue la requête à l'identique (même signature) : il n'est laissé passer par HMACMiddleware
+// que si l'enregistrement mémorisé porte exactement son empreinte.
+func TestReplayableRecord(t *testing.T) {
+	body := []byte(`{"content":"bonjour"}`)
+	fingerprint := requestFingerprint(http.MethodPost, "/posts", body)
+
+	cases := []struct {
+		name   string
+		record idempotencyRecord
+		want   bool
+	}{
+		{"retry à l'identique", idempotencyRecord{State: idempotencyStateDone, Fingerprint: fingerprint, Status: http.StatusCreated}, true},
+		{"première requête en cours", idempotencyRecord{State: idempotencyStatePending, Fingerprint: fingerprint}, false},
+		{"corps modifié", idempotencyRecord{State: idempotencyStateDone, Fingerprint: requestFingerprint(http.MethodPost, "/posts", []byte(`{}`))}, false},
+		{"autre route", idempotencyRecord{State: idempotencyStateDone, Fingerprint: requestFingerprint(http.MethodPost, "/comments", body)}, false},
+		{"clé inconnue", idempotencyRecord{}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := replayableRecord(tc.record, fingerprint); got != tc.want {
+				t.Fatalf("replayableRecord = %v, attendu %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsStorableStatus(t *testing.T) {
+	cases := map[int]bool{
+		http.StatusOK:                    true,
+		http.StatusCreated:               true,
+		http.StatusNoContent:             true,
+		http.StatusNotFound:              true,
+		http.StatusRequestEntityTooLarge: true,
+		http.StatusBadRequest:            false, // saisie corrigeable sous la même clé
+		http.StatusUnprocessableEntity:   false,
+		http.StatusUnauthorized:          false,
+		http.StatusForbidden:             false,
+		http.StatusConflict:              false,
+		http.StatusTooManyRequests:       false,
+		http.StatusInternalServerError:   false,
+		http.StatusServiceUnavailable:    false,
+	}
+	for status, want := range cases {
+		if got := isStorableStatus(status); got != want {
+			t.Errorf("isStorableStatus(%d) = %v, attendu %v", status, got, want)
+		}
+	}
+}
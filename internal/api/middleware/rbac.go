@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/rbac"
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission n'autorise la route qu'aux utilisateurs dont le grade accorde TOUTES les permissions listées.
+// À placer après JWTMiddleware (userID dans le contexte). Le grade effectif est exposé via c.Get("grade").
+// Chaque refus est inscrit au journal d'audit.
+func RequirePermission(perms ...rbac.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := pkg.GetUserIDFromContext(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+			return
+		}
+
+		subject, err := rbac.LoadSubject(c.Request.Context(), userID)
+		if err != nil {
+			if errors.Is(err, nubo_error.ErrNotFound) || errors.Is(err, nubo_error.ErrBanned) || errors.Is(err, nubo_error.ErrDesactivated) {
+				c.AbortWithStatusJSON(http.StatusForbidden, nubo_error.ErrorResponse{Error: "Permission insuffisante"})
+				return
+			}
+			fmt.Printf("❌ ERREUR (RequirePermission): %v\n", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, nubo_error.ErrorResponse{Error: "Internal server error"})
+			return
+		}
+
+		for _, perm := range perms {
+			if !rbac.Can(subject.Grade, perm) {
+				audit.Record(c, audit.User(userID, c.ClientIP()), audit.ActionAccessDenied, audit.Target{}, map[string]any{
+					"permission": string(perm),
+					"grade":      subject.Grade,
+					"method":     c.Request.Method,
+					"path":       c.FullPath(),
+				})
+				c.AbortWithStatusJSON(http.StatusForbidden, nubo_error.ErrorResponse{Error: "Permission insuffisante"})
+				return
+			}
+		}
+
+		c.Set("grade", subject.Grade)
+		c.Next()
+	}
+}
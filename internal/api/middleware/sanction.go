@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/sanction_service"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireNotRestricted bloque la route si l'utilisateur est sous restriction pour ce périmètre
+// (variables.RestrictionScope*). Pour les fonctionnalités sans service dédié (messagerie) ;
+// posts et commentaires sont contrôlés directement dans leur service.
+func RequireNotRestricted(scope int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := pkg.GetUserIDFromContext(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, nubo_error.ErrorResponse{Error: "Utilisateur non identifié"})
+			return
+		}
+
+		var sanctionErr *nubo_error.SanctionError
+		if errors.As(sanction_service.CheckRestriction(c.Request.Context(), userID, scope), &sanctionErr) {
+			c.AbortWithStatusJSON(http.StatusForbidden, nubo_error.SanctionResponse{Error: "Action restricted", Sanction: sanctionErr.Notice})
+			return
+		}
+		c.Next()
+	}
+}
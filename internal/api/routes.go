@@ -5,18 +5,27 @@ import (
 	"os"
 	"time"
 
+	"github.com/QuentinRegnier/nubo-backend/internal/api/handlers/account_handlers"
+	"github.com/QuentinRegnier/nubo-backend/internal/api/handlers/admin_handlers"
+	"github.com/QuentinRegnier/nubo-backend/internal/api/handlers/appeal_handlers"
 	"github.com/QuentinRegnier/nubo-backend/internal/api/handlers/auth_handlers"
 	"github.com/QuentinRegnier/nubo-backend/internal/api/handlers/comment_handlers"
 	"github.com/QuentinRegnier/nubo-backend/internal/api/handlers/feed_handlers"
 	"github.com/QuentinRegnier/nubo-backend/internal/api/handlers/like_handlers"
+	"github.com/QuentinRegnier/nubo-backend/internal/api/handlers/media_handlers"
 	"github.com/QuentinRegnier/nubo-backend/internal/api/handlers/post_handlers"
+	"github.com/QuentinRegnier/nubo-backend/internal/api/handlers/profile_handlers"
 	"github.com/QuentinRegnier/nubo-backend/internal/api/handlers/report_handlers"
+	"github.com/QuentinRegnier/nubo-backend/internal/api/handlers/sanction_handlers"
 	"github.com/QuentinRegnier/nubo-backend/internal/api/handlers/security_handlers"
 	"github.com/golang-jwt/jwt/v5"
 
 	"github.com/QuentinRegnier/nubo-backend/internal/api/handlers"
 	"github.com/QuentinRegnier/nubo-backend/internal/api/middleware"
 	"github.com/QuentinRegnier/nubo-backend/internal/api/websocket"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/blobstore"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/rbac"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
 	"github.com/gin-gonic/gin"
 )
 
@@ -45,11 +54,24 @@ func SetupRoutes(r *gin.Engine) {
 	r.POST("/signup", auth_handlers.SignUpHandler)
 	r.POST("/login", auth_handlers.LoginHandler)
 
+	// Récupération de compte (jeton à usage unique via le canal de vérification)
+	r.POST("/password/forgot", auth_handlers.ForgotPasswordHandler)
+	r.POST("/password/reset", auth_handlers.ResetPasswordHandler)
+
+	// Appel d'un compte banni (jeton d'appel remis au login, en-tête X-Appeal-Token)
+	r.POST("/appeal", appeal_handlers.SubmitBannedAppealHandler)
+	r.GET("/appeal", appeal_handlers.GetBannedAppealsHandler)
+
 	// Renouvellement de Tokens (Ratchet / Master)
 	// Ces routes gèrent leur propre sécurité (HMAC spécial, checks BDD...)
 	r.POST("/renew-jwt", security_handlers.RenewJWT)
 	r.POST("/refresh-master", security_handlers.RefreshMaster)
 
+	// Liens signés du stockage local (pilote "local" uniquement : MinIO sert lui-même ses liens)
+	if local, ok := blobstore.Store.(*blobstore.LocalStore); ok {
+		r.GET("/blobs/*path", gin.WrapH(http.StripPrefix("/blobs", local)))
+	}
+
 	// WebSocket
 	r.GET("/token", func(c *gin.Context) {
 		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
@@ -80,8 +102,9 @@ func SetupRoutes(r *gin.Engine) {
 
 	// On crée un groupe "plat" qui applique les deux middlewares d'un coup
 	secured := r.Group("/")
-	secured.Use(middleware.JWTMiddleware())  // 1. Qui est-ce ? (Populate context with UserID & DeviceToken)
-	secured.Use(middleware.HMACMiddleware()) // 2. Est-ce authentique ? (Check Signature with Redis Secret)
+	secured.Use(middleware.JWTMiddleware())         // 1. Qui est-ce ? (Populate context with UserID & DeviceToken)
+	secured.Use(middleware.HMACMiddleware())        // 2. Est-ce authentique ? (Check Signature with Redis Secret)
+	secured.Use(middleware.IdempotencyMiddleware()) // 3. Déjà traitée ? (Idempotency-Key / X-Request-Id → réponse mémorisée)
 
 	// --- Posts ---
 	secured.GET("/feed", feed_handlers.GetFeedHandler)
@@ -94,6 +117,13 @@ func SetupRoutes(r *gin.Engine) {
 	secured.GET("/post/user", post_handlers.GetUserPostsHandler)
 	secured.GET("/post/user/force", post_handlers.GetUserPostsHandler)
 
+	// --- Médias (upload vidéo par morceaux) ---
+	secured.POST("/media/uploads", media_handlers.CreateUploadHandler)
+	secured.GET("/media/uploads/:id", media_handlers.GetUploadHandler)
+	secured.PUT("/media/uploads/:id/chunks/:index", media_handlers.PutUploadChunkHandler)
+	secured.POST("/media/uploads/:id/complete", media_handlers.CompleteUploadHandler)
+	secured.DELETE("/media/uploads/:id", media_handlers.AbortUploadHandler)
+
 	// --- Profils / Utilisateurs ---
 	secured.GET("/search/users/quick", handlers.UserSearchHandler) // ℹ️❌ à vérifier
 
@@ -119,35 +149,35 @@ func SetupRoutes(r *gin.Engine) {
 	// --- Reglage ---
 	secured.PATCH("/profile", UpdateProfileHangler)            // ℹ️❌
 	secured.PATCH("/confidentials", UpdateConfidentialHandler) // ℹ️❌
-	secured.POST("/logout", LogoutHandler)                     // ℹ️❌
-	secured.GET("/sessions", LoadSessionsHandler)              // ℹ️❌
-	secured.DELETE("/sessions", DeleteSessionsHandler)         // ℹ️❌
-	secured.PATCH("/language", UpdateLanguageHandler)          // ℹ️❌
-
-	// --- Administration / Modération ---
-	secured.POST("/ban", BanHandler)                                            // ℹ️❌
-	secured.POST("/restriction", RestrictionHandler)                            // ℹ️❌
-	secured.POST("/warning", WarningHandler)                                    // ℹ️❌
-	secured.GET("/reports", LoadReportHandler)                                  // ℹ️❌
-	secured.DELETE("/report", CloseReportHandler)                               // ℹ️❌
-	secured.PATCH("/report", UpdateManagerReportHandler)                        // ℹ️❌
-	secured.GET("/information-user", LoadAdminInformationUserHandler)           // ℹ️❌
-	secured.GET("/information-group", LoadAdminInformationGroupHandler)         // ℹ️❌
-	secured.GET("/information-community", LoadAdminInformationCommunityHandler) // ℹ️❌
-	secured.GET("/information-post", LoadAdminInformationPostHandler)           // ℹ️❌
-	secured.GET("/information-comment", LoadAdminInformationCommentHandler)     // ℹ️❌
-	secured.GET("/information-message", LoadAdminInformationMessageHandler)     // ℹ️❌
+	secured.PATCH("/password", auth_handlers.ChangePasswordHandler)
+	secured.POST("/logout", LogoutHandler)             // ℹ️❌
+	secured.GET("/sessions", LoadSessionsHandler)      // ℹ️❌
+	secured.DELETE("/sessions", DeleteSessionsHandler) // ℹ️❌
+	secured.PATCH("/language", UpdateLanguageHandler)  // ℹ️❌
+
+	// --- Photo de profil & couverture (recadrage côté serveur) ---
+	secured.PUT("/profile/picture", profile_handlers.UpdateProfilePictureHandler)
+	secured.DELETE("/profile/picture", profile_handlers.DeleteProfilePictureHandler)
+	secured.PUT("/profile/cover", profile_handlers.UpdateProfileCoverHandler)
+	secured.DELETE("/profile/cover", profile_handlers.DeleteProfileCoverHandler)
+	secured.POST("/v1/profile/sync", profile_handlers.SyncProfileHandler) // Vecteur d'intérêt calculé côté client (README §1.2)
+
+	// --- Cycle de vie du compte ---
+	secured.POST("/account/deactivate", account_handlers.DeactivateAccountHandler)
+	secured.DELETE("/account", account_handlers.DeleteAccountHandler)
+	secured.POST("/account/export", account_handlers.RequestExportHandler)
+	secured.GET("/account/export", account_handlers.GetExportHandler)
+	secured.GET("/account/sanctions", sanction_handlers.GetMySanctionsHandler)
+	secured.POST("/account/appeal", appeal_handlers.SubmitAppealHandler)
+	secured.GET("/account/appeals", appeal_handlers.GetMyAppealsHandler)
 
 	// --- Messagerie / Groupes ---
 	secured.GET("/inbox", handlers.InboxHandler)                       // <--- SPEED Cache: Démarrage Inbox
-	secured.POST("/conversation", ConversationHandler)                 // ℹ️❌
 	secured.DELETE("/conversation", DeleteConversationHandler)         // ℹ️❌
 	secured.PATCH("/conversation", ModifyConversationHandler)          // ℹ️❌
 	secured.GET("/conversations", LoadConversationHandler)             // ℹ️❌
-	secured.POST("/message", MessageHandler)                           // ℹ️❌
 	secured.GET("/messages", LoadNewMessagesHandler)                   // ℹ️❌
 	secured.DELETE("/messages", DeleteMessagesHandler)                 // ℹ️❌
-	secured.PATCH("/message", UpdateMessageHandler)                    // ℹ️❌
 	secured.POST("/user-group", AddUserGroupHandler)                   // ℹ️❌
 	secured.DELETE("/user-group", DeleteUserGroupHandler)              // ℹ️❌
 	secured.POST("/promote-group", SetAdminGroupHandler)               // ℹ️❌
@@ -160,6 +190,12 @@ func SetupRoutes(r *gin.Engine) {
 	secured.POST("/join", JoinGroupHandler)                            // ℹ️❌
 	secured.POST("/quit", QuitGroupHandler)                            // ℹ️❌
 
+	// Écriture de messages : refusée pendant une restriction "message"
+	messaging := secured.Group("/", middleware.RequireNotRestricted(variables.RestrictionScopeMessage))
+	messaging.POST("/conversation", ConversationHandler) // ℹ️❌
+	messaging.POST("/message", MessageHandler)           // ℹ️❌
+	messaging.PATCH("/message", UpdateMessageHandler)    // ℹ️❌
+
 	// --- Recherche ---
 	secured.POST("/search/user", SearchUserHandler)           // ℹ️❌
 	secured.POST("/search/post", SearchPostHandler)           // ℹ️❌
@@ -170,6 +206,54 @@ func SetupRoutes(r *gin.Engine) {
 
 	// --- Report ---
 	secured.POST("/report", report_handlers.CreateReportHandler)
+
+	// =========================================================================
+	// 3. ROUTES D'ADMINISTRATION / MODÉRATION (JWT + HMAC + RBAC)
+	// Même chaîne que "secured", puis contrôle des permissions accordées par le grade
+	// (voir rbac.Can). Chaque route déclare explicitement ce qu'elle exige.
+	// =========================================================================
+	admin := secured.Group("/admin")
+
+	// --- Sanctions ---
+	admin.POST("/ban", middleware.RequirePermission(rbac.PermUsersSanction), sanction_handlers.BanUserHandler)
+	admin.POST("/restriction", middleware.RequirePermission(rbac.PermUsersSanction), sanction_handlers.RestrictUserHandler)
+	admin.POST("/warning", middleware.RequirePermission(rbac.PermUsersSanction), sanction_handlers.WarnUserHandler)
+	admin.DELETE("/sanction", middleware.RequirePermission(rbac.PermUsersSanction), sanction_handlers.LiftSanctionHandler)
+
+	// --- Signalements (file de modération) ---
+	admin.GET("/reports", middleware.RequirePermission(rbac.PermReportsView), report_handlers.ListReportCasesHandler)
+	admin.GET("/report", middleware.RequirePermission(rbac.PermReportsView), report_handlers.GetReportCaseHandler)
+	admin.PATCH("/report", middleware.RequirePermission(rbac.PermReportsResolve), report_handlers.AssignReportCaseHandler)
+	admin.POST("/report/escalate", middleware.RequirePermission(rbac.PermReportsResolve), report_handlers.EscalateReportCaseHandler)
+	admin.DELETE("/report", middleware.RequirePermission(rbac.PermReportsResolve), report_handlers.CloseReportCaseHandler)
+
+	// --- Investigation forensique (image fuitée -> dossier contre le responsable) ---
+	admin.POST("/forensic", middleware.RequirePermission(rbac.PermForensicRun), report_handlers.InvestigateLeakHandler)
+
+	// --- Empreintes perceptuelles (copies proches d'un média) ---
+	admin.GET("/media-similar", middleware.RequirePermission(rbac.PermReportsView), admin_handlers.GetSimilarMediaHandler)
+
+	// --- Ramasse-miettes du stockage (objets médias orphelins) ---
+	admin.POST("/media-gc", middleware.RequirePermission(rbac.PermStorageManage), admin_handlers.RunMediaGCHandler)
+	admin.GET("/media-gc", middleware.RequirePermission(rbac.PermStorageManage), admin_handlers.GetMediaGCHandler)
+
+	// --- Appels (file dédiée, échéances de traitement) ---
+	admin.GET("/appeals", middleware.RequirePermission(rbac.PermAppealsView), appeal_handlers.ListAppealsHandler)
+	admin.GET("/appeal", middleware.RequirePermission(rbac.PermAppealsView), appeal_handlers.GetAppealHandler)
+	admin.PATCH("/appeal", middleware.RequirePermission(rbac.PermAppealsResolve), appeal_handlers.AssignAppealHandler)
+	admin.DELETE("/appeal", middleware.RequirePermission(rbac.PermAppealsResolve), appeal_handlers.DecideAppealHandler)
+
+	// --- Informations privées ---
+	privateInfo := admin.Group("/", middleware.RequirePermission(rbac.PermPrivateInfoView))
+	privateInfo.GET("/information-user", admin_handlers.GetInformationUserHandler)
+	privateInfo.GET("/information-group", LoadAdminInformationGroupHandler)         // ℹ️❌
+	privateInfo.GET("/information-community", LoadAdminInformationCommunityHandler) // ℹ️❌
+	privateInfo.GET("/information-post", admin_handlers.GetInformationPostHandler)
+	privateInfo.GET("/information-comment", admin_handlers.GetInformationCommentHandler)
+	privateInfo.GET("/information-message", admin_handlers.GetInformationMessageHandler)
+
+	// --- Journal d'audit ---
+	admin.GET("/audit", middleware.RequirePermission(rbac.PermAuditView), admin_handlers.GetAuditLogHandler)
 }
 
 func FollowHandler(c *gin.Context) {
@@ -252,41 +336,6 @@ func UpdateLanguageHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "language updated"})
 }
 
-func BanHandler(c *gin.Context) {
-	// TODO: gérer les bans
-	c.JSON(http.StatusOK, gin.H{"message": "user banned"})
-}
-
-func RestrictionHandler(c *gin.Context) {
-	// TODO: gérer les restrictions
-	c.JSON(http.StatusOK, gin.H{"message": "user restricted"})
-}
-
-func WarningHandler(c *gin.Context) {
-	// TODO: gérer les avertissements
-	c.JSON(http.StatusOK, gin.H{"message": "user warned"})
-}
-
-func LoadReportHandler(c *gin.Context) {
-	// TODO: charger les rapports depuis la base
-	c.JSON(http.StatusOK, gin.H{"reports": []string{"reports 1", "reports 2"}})
-}
-
-func CloseReportHandler(c *gin.Context) {
-	// TODO: fermer un rapport
-	c.JSON(http.StatusOK, gin.H{"message": "report closed"})
-}
-
-func UpdateManagerReportHandler(c *gin.Context) {
-	// TODO: gérer la mise à jour du manager d'un rapport
-	c.JSON(http.StatusOK, gin.H{"message": "update manager report"})
-}
-
-func LoadAdminInformationUserHandler(c *gin.Context) {
-	// TODO: charger les informations d'un utilisateur
-	c.JSON(http.StatusOK, gin.H{"message": "information user"})
-}
-
 func LoadAdminInformationGroupHandler(c *gin.Context) {
 	// TODO: charger les informations d'un groupe
 	c.JSON(http.StatusOK, gin.H{"message": "information group"})
@@ -297,21 +346,6 @@ func LoadAdminInformationCommunityHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "information community"})
 }
 
-func LoadAdminInformationPostHandler(c *gin.Context) {
-	// TODO: charger les informations d'un post_service
-	c.JSON(http.StatusOK, gin.H{"message": "information post_service"})
-}
-
-func LoadAdminInformationCommentHandler(c *gin.Context) {
-	// TODO: charger les informations d'un commentaire
-	c.JSON(http.StatusOK, gin.H{"message": "information comment"})
-}
-
-func LoadAdminInformationMessageHandler(c *gin.Context) {
-	// TODO: charger les informations d'un message
-	c.JSON(http.StatusOK, gin.H{"message": "information message"})
-}
-
 func ConversationHandler(c *gin.Context) {
 	// TODO: gérer la création d'une nouvelle conversation
 	c.JSON(http.StatusOK, gin.H{"message": "new conversation created"})
@@ -4,6 +4,7 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 )
@@ -26,7 +27,8 @@ func GetHub() *Hub {
 }
 
 func WSHandler(c *gin.Context) {
-	userID := c.GetString("userID")
+	// Le middleware JWT dépose le "sub" brut : on le normalise pour router les événements personnels
+	userID, _ := pkg.GetUserIDFromContext(c)
 	log.Println("Utilisateur connecté (userID):", userID)
 
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
@@ -36,8 +38,9 @@ func WSHandler(c *gin.Context) {
 	}
 
 	client := &Client{
-		conn: conn,
-		send: make(chan []byte, 256),
+		conn:   conn,
+		send:   make(chan []byte, 256),
+		userID: userID,
 	}
 
 	hub.register <- client
@@ -3,11 +3,13 @@ package websocket
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"encoding/json"
 	"log"
 	"sync"
 
 	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/redis"
 	redisgo "github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/event_service"
 	"github.com/gorilla/websocket"
 )
 
@@ -23,8 +25,9 @@ func generateMessageID() string {
 // ---------------- Clients ----------------
 
 type Client struct {
-	conn *websocket.Conn
-	send chan []byte
+	conn   *websocket.Conn
+	send   chan []byte
+	userID int64 // Destinataire des événements personnels (0 : connexion anonyme)
 }
 
 // ---------------- Hub ----------------
@@ -36,21 +39,24 @@ type Hub struct {
 	broadcast  chan []byte
 	mu         sync.Mutex
 
-	channel string
+	channel       string
+	eventsChannel string
 }
 
 // NewHub crée un nouveau Hub et lance l'écoute du flux Redis
 func NewHub() *Hub {
 	h := &Hub{
-		clients:    make(map[*Client]bool),
-		register:   make(chan *Client),
-		unregister: make(chan *Client),
-		broadcast:  make(chan []byte),
-		channel:    "nubo-websocket",
+		clients:       make(map[*Client]bool),
+		register:      make(chan *Client),
+		unregister:    make(chan *Client),
+		broadcast:     make(chan []byte),
+		channel:       "nubo-websocket",
+		eventsChannel: event_service.EventsChannelName,
 	}
 
 	// Utilise la fonction SubscribeFlux pour recevoir les messages
 	go h.listenFlux()
+	go h.listenEvents()
 	return h
 }
 
@@ -73,6 +79,35 @@ func (h *Hub) listenFlux() {
 	}
 }
 
+// listenEvents relaie les événements personnels (event_service) aux seules connexions de leur destinataire
+func (h *Hub) listenEvents() {
+	ch, cancel := redisgo.SubscribeFlux(redis.Rdb, h.eventsChannel)
+	defer cancel()
+
+	for msg := range ch {
+		var envelope struct {
+			UserID int64 `json:"user_id"`
+		}
+		if err := json.Unmarshal(msg, &envelope); err != nil || envelope.UserID == 0 {
+			continue
+		}
+
+		h.mu.Lock()
+		for client := range h.clients {
+			if client.userID != envelope.UserID {
+				continue
+			}
+			select {
+			case client.send <- msg:
+			default:
+				close(client.send)
+				delete(h.clients, client)
+			}
+		}
+		h.mu.Unlock()
+	}
+}
+
 // Run démarre la boucle principale du hub pour gérer l'inscription/désinscription et la diffusion
 func (h *Hub) Run() {
 	for {
@@ -0,0 +1,28 @@
+package account_models
+
+import (
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+)
+
+// AccountLifecyclePayload est l'état courant du cycle de vie d'un compte (L1) et l'événement
+// append-only persisté en L3 à chaque transition (auth.account_lifecycle).
+type AccountLifecyclePayload struct {
+	ID           int64            `json:"id" msgpack:"id"` // ID Snowflake de l'événement
+	UserID       int64            `json:"user_id" msgpack:"user_id"`
+	State        int              `json:"state" msgpack:"state"`
+	RequestedAt  time.Time        `json:"requested_at" msgpack:"requested_at"`
+	ScheduledFor time.Time        `json:"scheduled_for" msgpack:"scheduled_for"` // Échéance de la cascade (PendingDeletion)
+	CompletedAt  time.Time        `json:"completed_at" msgpack:"completed_at"`   // Fin de la cascade (Deleted)
+	Report       map[string]int64 `json:"report,omitempty" msgpack:"report,omitempty"`
+	Digest       string           `json:"digest,omitempty" msgpack:"digest,omitempty"` // SHA-256 du reçu de suppression
+	CreatedAt    time.Time        `json:"created_at" msgpack:"created_at"`
+}
+
+// UserFootprint recense ce qu'un compte a laissé sur la plateforme : c'est la cible de la cascade de suppression.
+type UserFootprint struct {
+	PostIDs     []int64
+	CommentRefs map[int64]int64 // commentID -> postID (retrait du ZSET de commentaires du post parent)
+	Media       []models.MediaRequest
+}
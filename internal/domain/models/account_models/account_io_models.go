@@ -0,0 +1,20 @@
+package account_models
+
+import "time"
+
+// DeactivateAccountInput confirme la désactivation par le hash du mot de passe courant.
+type DeactivateAccountInput struct {
+	PasswordHash string `json:"password_hash" binding:"required"`
+}
+
+// DeleteAccountInput confirme la demande de suppression définitive par le hash du mot de passe courant.
+type DeleteAccountInput struct {
+	PasswordHash string `json:"password_hash" binding:"required"`
+}
+
+// AccountLifecycleOutput est la réponse renvoyée après une transition d'état.
+type AccountLifecycleOutput struct {
+	State            int        `json:"state" example:"2"`
+	ScheduledFor     *time.Time `json:"scheduled_for,omitempty"`
+	ReactivableUntil *time.Time `json:"reactivable_until,omitempty"`
+}
@@ -0,0 +1,32 @@
+package account_models
+
+import "time"
+
+// ExportJobPayload décrit la dernière demande d'export de données d'un utilisateur (L1).
+type ExportJobPayload struct {
+	ID          int64            `json:"id" msgpack:"id"`
+	UserID      int64            `json:"user_id" msgpack:"user_id"`
+	State       int              `json:"state" msgpack:"state"`
+	ObjectPath  string           `json:"-" msgpack:"object_path"` // Chemin MinIO privé de l'archive
+	SizeBytes   int64            `json:"size_bytes" msgpack:"size_bytes"`
+	Counts      map[string]int64 `json:"counts,omitempty" msgpack:"counts,omitempty"`
+	Error       string           `json:"-" msgpack:"error"`
+	Attempts    int              `json:"-" msgpack:"attempts"`
+	RequestedAt time.Time        `json:"requested_at" msgpack:"requested_at"`
+	StartedAt   time.Time        `json:"-" msgpack:"started_at"` // Début de la construction en cours (bail)
+	CompletedAt time.Time        `json:"completed_at" msgpack:"completed_at"`
+	ExpiresAt   time.Time        `json:"expires_at" msgpack:"expires_at"`
+}
+
+// ExportStatusOutput est la réponse de GET /account/export.
+type ExportStatusOutput struct {
+	JobID        int64            `json:"job_id" example:"1893456789012345678"`
+	State        int              `json:"state" example:"2"`
+	RequestedAt  time.Time        `json:"requested_at"`
+	CompletedAt  *time.Time       `json:"completed_at,omitempty"`
+	ExpiresAt    *time.Time       `json:"expires_at,omitempty"` // Fin de conservation de l'archive
+	SizeBytes    int64            `json:"size_bytes,omitempty"`
+	Counts       map[string]int64 `json:"counts,omitempty"`
+	DownloadURL  string           `json:"download_url,omitempty"` // Lien MinIO signé (15 minutes)
+	URLExpiresAt *time.Time       `json:"url_expires_at,omitempty"`
+}
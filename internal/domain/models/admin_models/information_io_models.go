@@ -0,0 +1,155 @@
+package admin_models
+
+import (
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/auth_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/comment_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/post_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/report_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/revision_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/sanction_models"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// ENTRÉES
+// ─────────────────────────────────────────────────────────────────────────────
+
+// InformationUserInput identifie l'utilisateur dont on consulte la fiche.
+type InformationUserInput struct {
+	UserID int64 `form:"user_id" binding:"required"`
+}
+
+// InformationPostInput identifie le post dont on consulte la fiche.
+type InformationPostInput struct {
+	PostID int64 `form:"post_id" binding:"required"`
+}
+
+// InformationCommentInput identifie le commentaire dont on consulte la fiche.
+type InformationCommentInput struct {
+	CommentID int64 `form:"comment_id" binding:"required"`
+}
+
+// InformationMessageInput identifie le message dont on consulte la fiche.
+type InformationMessageInput struct {
+	MessageID int64 `form:"message_id" binding:"required"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// BRIQUES COMMUNES
+// ─────────────────────────────────────────────────────────────────────────────
+
+// CounterView confronte un compteur entre ses sources. Un L1 en avance de quelques secondes sur L3
+// est normal (Write-Behind) ; un écart durable ou un recomptage divergent signale une dérive.
+type CounterView struct {
+	L1    *int64 `json:"l1"`             // Valeur en L1 (nil : objet absent du cache)
+	L3    int64  `json:"l3"`             // Compteur dénormalisé en L3
+	Rows  *int64 `json:"rows,omitempty"` // Recomptage des lignes sources en L3 (nil : non recomptable)
+	Drift bool   `json:"drift"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// FICHE UTILISATEUR
+// ─────────────────────────────────────────────────────────────────────────────
+
+// SessionInformation est une session vue par la modération (sans aucun secret ni jeton).
+type SessionInformation struct {
+	ID         int64          `json:"id"`
+	DeviceInfo map[string]any `json:"device_info"`
+	IPHistory  []string       `json:"ip_history"`
+	CreatedAt  time.Time      `json:"created_at"`
+	ExpiresAt  time.Time      `json:"expires_at"`
+	Expired    bool           `json:"expired"`
+	Cached     bool           `json:"cached"` // Session indexée en L1 (utilisable sans passer par L3)
+}
+
+// ReportStats résume l'historique de signalement d'un utilisateur, comme auteur et comme cible.
+type ReportStats struct {
+	Filed            int64 `json:"filed"`             // Signalements envoyés
+	FiledConfirmed   int64 `json:"filed_confirmed"`   // ... dont le dossier a été clos avec sanction du contenu
+	FiledRejected    int64 `json:"filed_rejected"`    // ... dont le dossier a été clos sans infraction
+	CasesAgainst     int64 `json:"cases_against"`     // Dossiers visant le compte ou l'un de ses contenus
+	ReportsAgainst   int64 `json:"reports_against"`   // Signalements cumulés de ces dossiers
+	OpenAgainst      int64 `json:"open_against"`      // ... dont dossiers encore ouverts
+	ConfirmedAgainst int64 `json:"confirmed_against"` // ... dont dossiers clos avec sanction
+}
+
+// RelationStats résume le graphe social d'un utilisateur (L3, abonnés confrontés au Speed Cache).
+type RelationStats struct {
+	Following int64       `json:"following"`
+	Followers CounterView `json:"followers"`
+	Friends   int64       `json:"friends"`
+	Blocking  int64       `json:"blocking"`
+	BlockedBy int64       `json:"blocked_by"`
+}
+
+// ContentSummary est un contenu récent de l'utilisateur (version L1 si présente, sinon L3).
+type ContentSummary struct {
+	ID         int64     `json:"id"`
+	PostID     int64     `json:"post_id,omitempty"` // Commentaires : post parent
+	Content    string    `json:"content"`
+	Visibility int       `json:"visibility"`
+	LikeCount  int       `json:"like_count"`
+	CreatedAt  time.Time `json:"created_at"`
+	Cached     bool      `json:"cached"`
+}
+
+// UserInformationOutput est la fiche consolidée d'un utilisateur.
+type UserInformationOutput struct {
+	User           auth_models.UserPayload           `json:"user"`          // L3 (empreinte du mot de passe retirée)
+	Cached         *models.UserLiteRequest           `json:"cached"`        // Fiche publique en Speed Cache (nil : absente)
+	Hidden         bool                              `json:"hidden"`        // Compte masqué en L1 (désactivé, banni, en suppression)
+	Discrepancies  []string                          `json:"discrepancies"` // Champs divergents entre L1 et L3
+	Sessions       []SessionInformation              `json:"sessions"`
+	IPAddresses    []string                          `json:"ip_addresses"` // Union dédupliquée de l'historique des sessions
+	Sanctions      []sanction_models.SanctionPayload `json:"sanctions"`    // Historique complet, levées comprises
+	Reports        ReportStats                       `json:"reports"`
+	Relations      RelationStats                     `json:"relations"`
+	RecentPosts    []ContentSummary                  `json:"recent_posts"`
+	RecentComments []ContentSummary                  `json:"recent_comments"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// FICHES CONTENU
+// ─────────────────────────────────────────────────────────────────────────────
+
+// MediaInformation est un média rattaché à un contenu, avec un lien consultable par le modérateur.
+type MediaInformation struct {
+	Media  models.MediaRequest `json:"media"`
+	Cached bool                `json:"cached"`
+	URL    string              `json:"url,omitempty"` // URL tatouée au nom du modérateur (vide si le média est supprimé)
+}
+
+// PostInformationOutput est la fiche consolidée d'un post.
+type PostInformationOutput struct {
+	Post          post_models.PostPayload           `json:"post"`   // L3
+	Cached        *post_models.PostPayload          `json:"cached"` // L1 (nil : absent du cache)
+	Discrepancies []string                          `json:"discrepancies"`
+	Likes         CounterView                       `json:"likes"`
+	Views         CounterView                       `json:"views"`
+	Comments      CounterView                       `json:"comments"`
+	Media         []MediaInformation                `json:"media"`
+	Revisions     []revision_models.RevisionPayload `json:"revisions"` // Du plus récent au plus ancien
+	Cases         []report_models.ReportCasePayload `json:"cases"`
+	Takedowns     []report_models.TakedownPayload   `json:"takedowns"`
+}
+
+// CommentInformationOutput est la fiche consolidée d'un commentaire.
+type CommentInformationOutput struct {
+	Comment       comment_models.CommentPayload     `json:"comment"`
+	Cached        *comment_models.CommentPayload    `json:"cached"`
+	Discrepancies []string                          `json:"discrepancies"`
+	Likes         CounterView                       `json:"likes"`
+	Revisions     []revision_models.RevisionPayload `json:"revisions"`
+	Cases         []report_models.ReportCasePayload `json:"cases"`
+	Takedowns     []report_models.TakedownPayload   `json:"takedowns"`
+}
+
+// MessageInformationOutput est la fiche d'un message. La messagerie n'ayant pas encore de modèle typé,
+// la ligne L3 et l'objet L1 sont restitués tels qu'ils sont stockés.
+type MessageInformationOutput struct {
+	Message map[string]any                    `json:"message"`
+	Cached  map[string]any                    `json:"cached"`
+	Cases   []report_models.ReportCasePayload `json:"cases"`
+}
@@ -0,0 +1,7 @@
+package admin_models
+
+// MediaGCInput déclenche un passage du ramasse-miettes des médias.
+// DryRun : le rapport décrit ce qui serait mis à la corbeille ou supprimé, sans rien toucher.
+type MediaGCInput struct {
+	DryRun bool `form:"dry_run"`
+}
@@ -0,0 +1,9 @@
+package admin_models
+
+// SimilarMediaInput identifie le média dont on recherche les copies proches.
+// MaxDistance (pHash) est plafonnée par la garantie de l'index (phash.MaxIndexedDistance).
+type SimilarMediaInput struct {
+	MediaID     int64 `form:"media_id" binding:"required"`
+	MaxDistance *int  `form:"max_distance" binding:"omitempty,min=0,max=11"`
+	Limit       int   `form:"limit" binding:"omitempty,min=1,max=200"`
+}
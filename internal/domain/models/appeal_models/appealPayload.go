@@ -0,0 +1,36 @@
+package appeal_models
+
+import "time"
+
+// AppealPayload est la contestation d'une sanction par l'utilisateur concerné (moderation.appeals).
+// Un seul appel par sanction. DueAt matérialise le délai de traitement (SLA) ; SLABreachedAt
+// est posé par le cron la première fois que ce délai est dépassé.
+type AppealPayload struct {
+	ID            int64      `json:"id"`
+	SanctionID    int64      `json:"sanction_id"`
+	UserID        int64      `json:"user_id"`
+	SanctionKind  int        `json:"sanction_kind"`
+	Message       string     `json:"message"`
+	State         int        `json:"state"` // 0 = en attente, 1 = en cours, -1 = tranché
+	AssigneeID    int64      `json:"assignee_id,omitempty"`
+	Outcome       int        `json:"outcome,omitempty"` // 1 = maintenue, 2 = annulée
+	DecisionNote  string     `json:"decision_note,omitempty"`
+	DecidedBy     int64      `json:"decided_by,omitempty"`
+	DecidedAt     *time.Time `json:"decided_at,omitempty"`
+	DueAt         time.Time  `json:"due_at"`
+	SLABreachedAt *time.Time `json:"sla_breached_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// AppealTransition décrit un changement d'état appliqué en compare-and-set sur moderation.appeals.
+type AppealTransition struct {
+	AppealID       int64
+	AllowedStates  []int
+	ExpectAssignee int64
+	NewState       int
+	AssigneeID     int64
+	Outcome        int
+	DecisionNote   string
+	DecidedBy      int64
+}
@@ -0,0 +1,52 @@
+package appeal_models
+
+import "github.com/QuentinRegnier/nubo-backend/internal/domain/models/sanction_models"
+
+// SubmitAppealInput conteste une sanction reçue.
+type SubmitAppealInput struct {
+	SanctionID int64  `json:"sanction_id" binding:"required"`
+	Message    string `json:"message" binding:"required,max=2000"`
+}
+
+// MyAppealsOutput liste les appels d'un utilisateur (vue utilisateur).
+type MyAppealsOutput struct {
+	Appeals []AppealPayload `json:"appeals"`
+}
+
+// ListAppealsInput regroupe les filtres de la file des appels (tous optionnels).
+type ListAppealsInput struct {
+	State      *int  `form:"state"`   // Défaut : appels non tranchés
+	Overdue    bool  `form:"overdue"` // Uniquement les appels hors délai
+	AssigneeID int64 `form:"assignee_id"`
+	Limit      int   `form:"limit"`
+	Offset     int   `form:"offset"`
+}
+
+// ListAppealsOutput est une page de la file, échéance la plus proche en tête.
+type ListAppealsOutput struct {
+	Appeals []AppealPayload `json:"appeals"`
+}
+
+// GetAppealInput identifie un appel.
+type GetAppealInput struct {
+	AppealID int64 `form:"appeal_id" binding:"required"`
+}
+
+// AppealDetailOutput est un appel accompagné de la sanction contestée.
+type AppealDetailOutput struct {
+	Appeal   AppealPayload                   `json:"appeal"`
+	Sanction sanction_models.SanctionPayload `json:"sanction"`
+}
+
+// AssignAppealInput prend en charge un appel (AssigneeID absent : pour soi-même).
+type AssignAppealInput struct {
+	AppealID   int64 `json:"appeal_id" binding:"required"`
+	AssigneeID int64 `json:"assignee_id"`
+}
+
+// DecideAppealInput tranche un appel : 1 = sanction maintenue, 2 = sanction annulée.
+type DecideAppealInput struct {
+	AppealID int64  `json:"appeal_id" binding:"required"`
+	Outcome  int    `json:"outcome" binding:"required,oneof=1 2"`
+	Note     string `json:"note" binding:"required,max=1000"`
+}
@@ -0,0 +1,16 @@
+package audit_models
+
+import "time"
+
+// AuditEntryPayload est une entrée du journal d'audit (moderation.audit_log, append-only).
+// ActorID == 0 désigne le système (cron, worker) ; TargetID == 0 signifie "pas de cible".
+type AuditEntryPayload struct {
+	ID         int64          `json:"id" msgpack:"id"` // ID Snowflake de l'entrée
+	ActorID    int64          `json:"actor_id" msgpack:"actor_id"`
+	ActorIP    string         `json:"actor_ip" msgpack:"actor_ip"`
+	Action     string         `json:"action" msgpack:"action"`
+	TargetType string         `json:"target_type" msgpack:"target_type"`
+	TargetID   int64          `json:"target_id" msgpack:"target_id"`
+	Metadata   map[string]any `json:"metadata,omitempty" msgpack:"metadata,omitempty"`
+	CreatedAt  time.Time      `json:"created_at" msgpack:"created_at"`
+}
@@ -0,0 +1,21 @@
+package audit_models
+
+import "time"
+
+// AuditQueryInput regroupe les filtres de consultation du journal d'audit (tous optionnels).
+type AuditQueryInput struct {
+	ActorID    int64     `form:"actor_id"`
+	Action     string    `form:"action"` // Préfixe accepté : "password." renvoie toutes les actions mot de passe
+	TargetType string    `form:"target_type"`
+	TargetID   int64     `form:"target_id"`
+	From       time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Cursor     int64     `form:"cursor"` // ID de la dernière entrée reçue (pagination descendante)
+	Limit      int       `form:"limit"`
+}
+
+// AuditQueryOutput est une page du journal d'audit, de la plus récente à la plus ancienne.
+type AuditQueryOutput struct {
+	Entries    []AuditEntryPayload `json:"entries"`
+	NextCursor int64               `json:"next_cursor,omitempty"` // 0 : plus de résultat
+}
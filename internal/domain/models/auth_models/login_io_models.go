@@ -33,6 +33,8 @@ type LoginResponse struct {
 	UpdatedAt         time.Time `json:"updated_at"`
 	ProfilePictureID  int64     `json:"profile_picture_id"`
 	ProfilePictureURL string    `json:"profile_picture_url" example:"https://storage.nubo.com/signed-avatar-path..."`
+	CoverPictureID    int64     `json:"cover_picture_id"`
+	CoverPictureURL   string    `json:"cover_picture_url" example:"https://storage.nubo.com/signed-cover-path..."`
 	MasterToken       string    `json:"master_token"`
 	JWT               string    `json:"jwt" example:"eyJhbGciOiJIUzI1Ni..."`
 	ExpiresAt         time.Time `json:"expires_at"`
@@ -0,0 +1,15 @@
+package auth_models
+
+type ForgotPasswordInput struct {
+	Email string `json:"email" binding:"required,email,max=100" example:"john@nubo.com"`
+}
+
+type ResetPasswordInput struct {
+	Token           string `json:"token" binding:"required,len=64,hexadecimal" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	NewPasswordHash string `json:"new_password_hash" binding:"required,min=8" example:"newSecretPass123"`
+}
+
+type ChangePasswordInput struct {
+	OldPasswordHash string `json:"old_password_hash" binding:"required" example:"secretPass123"`
+	NewPasswordHash string `json:"new_password_hash" binding:"required,min=8" example:"newSecretPass123"`
+}
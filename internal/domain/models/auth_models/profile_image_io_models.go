@@ -0,0 +1,21 @@
+package auth_models
+
+import "github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+
+// ProfileImageInput porte le recadrage proposé avec une photo de profil ou une couverture, en pixels de
+// l'image redressée (orientation EXIF appliquée). Les quatre champs sont fournis ensemble, ou omis :
+// le serveur retient alors le plus grand rectangle centré au ratio du gabarit.
+type ProfileImageInput struct {
+	CropX      *int `form:"crop_x" binding:"omitempty,min=0"`
+	CropY      *int `form:"crop_y" binding:"omitempty,min=0"`
+	CropWidth  *int `form:"crop_width" binding:"omitempty,min=1"`
+	CropHeight *int `form:"crop_height" binding:"omitempty,min=1"`
+}
+
+// ProfileImageOutput décrit l'image déposée : encodée en arrière-plan (événement "media.ready").
+type ProfileImageOutput struct {
+	MediaID int64            `json:"media_id" example:"1234567890"`
+	Layout  string           `json:"layout" example:"avatar"` // "avatar" ou "cover"
+	Crop    models.MediaCrop `json:"crop"`                    // Recadrage retenu (ramené au ratio du gabarit)
+	Status  int              `json:"status" example:"1"`      // variables.MediaStatus* (1 = en attente de traitement)
+}
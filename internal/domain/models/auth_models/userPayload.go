@@ -16,6 +16,7 @@ type UserPayload struct { // CreateUser
 	Sex              int       `bson:"sex" json:"sex"`
 	Bio              string    `bson:"bio" json:"bio"`
 	ProfilePictureID int64     `bson:"profile_picture_id" json:"profile_picture_id"`
+	CoverPictureID   int64     `bson:"cover_picture_id" json:"cover_picture_id"`
 	Grade            int       `bson:"grade" json:"grade"`
 	Location         string    `bson:"location" json:"location"`
 	School           string    `bson:"school" json:"school"`
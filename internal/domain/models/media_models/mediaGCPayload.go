@@ -0,0 +1,32 @@
+package media_models
+
+import "time"
+
+// MediaGCOwner est le média auquel appartient un objet MinIO examiné par le ramasse-miettes.
+type MediaGCOwner struct {
+	ID          int64
+	StoragePath string
+	VideoDir    string // "video/<base>/" (vide pour une image)
+	Status      int
+	Visibility  bool
+	CreatedAt   time.Time
+	Referenced  bool // Porté par un post non supprimé (ou visé par un dossier ouvert) ou par un avatar
+}
+
+// MediaGCReport résume un passage du ramasse-miettes. En simulation (DryRun), rien n'est déplacé ni
+// supprimé : les compteurs décrivent ce qui l'aurait été.
+type MediaGCReport struct {
+	DryRun         bool      `json:"dry_run" msgpack:"dry_run"`
+	StartedAt      time.Time `json:"started_at" msgpack:"started_at"`
+	FinishedAt     time.Time `json:"finished_at" msgpack:"finished_at"`
+	Scanned        int       `json:"scanned" msgpack:"scanned"`                 // Objets listés (hors corbeille)
+	Orphans        int       `json:"orphans" msgpack:"orphans"`                 // Objets sans média vivant, délai de grâce écoulé
+	Trashed        int       `json:"trashed" msgpack:"trashed"`                 // Objets déplacés dans la corbeille
+	TrashedBytes   int64     `json:"trashed_bytes" msgpack:"trashed_bytes"`     // Volume déplacé (récupéré à la purge)
+	Purged         int       `json:"purged" msgpack:"purged"`                   // Objets de la corbeille supprimés définitivement
+	ReclaimedBytes int64     `json:"reclaimed_bytes" msgpack:"reclaimed_bytes"` // Volume libéré (purge + uploads abandonnés)
+	AbortedUploads int       `json:"aborted_uploads" msgpack:"aborted_uploads"` // Uploads multipart abandonnés annulés
+	OrphanMedia    int       `json:"orphan_media" msgpack:"orphan_media"`       // Médias sans référence marqués supprimés
+	Errors         int       `json:"errors" msgpack:"errors"`
+	Truncated      bool      `json:"truncated" msgpack:"truncated"` // Plafond par passage atteint : la suite au prochain
+}
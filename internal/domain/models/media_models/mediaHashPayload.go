@@ -0,0 +1,38 @@
+package media_models
+
+import "time"
+
+// BlockedHashEntry est une empreinte bloquée (HASH Redis "blocked", clé = ID de l'image d'origine).
+type BlockedHashEntry struct {
+	Hash      string    `json:"hash"` // "<phash>:<dhash>" en hexadécimal
+	CaseID    int64     `json:"case_id"`
+	Category  int       `json:"category"`
+	BlockedAt time.Time `json:"blocked_at"`
+}
+
+// BlockedHashMatch est une correspondance avec une empreinte bloquée.
+type BlockedHashMatch struct {
+	SourceID      int64 `json:"source_id"` // Média (ou preuve d'investigation) à l'origine du blocage
+	CaseID        int64 `json:"case_id"`
+	Category      int   `json:"category"`
+	Distance      int   `json:"distance"` // Distance de Hamming des pHash
+	DHashDistance int   `json:"dhash_distance"`
+}
+
+// SimilarMedia est un média proche dans l'index des empreintes.
+type SimilarMedia struct {
+	MediaID       int64  `json:"media_id"`
+	OwnerID       int64  `json:"owner_id"`
+	Type          string `json:"type"`
+	Status        int    `json:"status"` // variables.MediaStatus* (-2 : quarantaine)
+	Distance      int    `json:"distance"`
+	DHashDistance int    `json:"dhash_distance"`
+}
+
+// SimilarMediaOutput est la réponse de la recherche admin des médias similaires.
+type SimilarMediaOutput struct {
+	MediaID int64              `json:"media_id"`
+	Hash    string             `json:"hash"`
+	Matches []SimilarMedia     `json:"matches"` // Du plus proche au plus lointain
+	Blocked []BlockedHashMatch `json:"blocked"` // Empreintes bloquées proches
+}
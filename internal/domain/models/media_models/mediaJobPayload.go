@@ -0,0 +1,30 @@
+package media_models
+
+import "time"
+
+// MediaJobPayload décrit le traitement d'un média déposé en zone de transit (L1, clé = ID du média).
+type MediaJobPayload struct {
+	MediaID     int64     `json:"media_id" msgpack:"media_id"`
+	OwnerID     int64     `json:"owner_id" msgpack:"owner_id"`
+	PostID      int64     `json:"post_id" msgpack:"post_id"` // 0 : média hors post (avatar)
+	StagingPath string    `json:"-" msgpack:"staging_path"`  // Original dans le préfixe MinIO de transit
+	State       int       `json:"state" msgpack:"state"`     // variables.MediaStatus*
+	Attempts    int       `json:"attempts" msgpack:"attempts"`
+	Error       string    `json:"-" msgpack:"error"`
+	EnqueuedAt  time.Time `json:"enqueued_at" msgpack:"enqueued_at"`
+	CompletedAt time.Time `json:"completed_at" msgpack:"completed_at"`
+}
+
+// MediaEvent est la charge utile des événements "media.ready" / "media.failed" poussés à l'auteur.
+type MediaEvent struct {
+	MediaID int64 `json:"media_id"`
+	PostID  int64 `json:"post_id,omitempty"`
+}
+
+// PostMediaEvent est la charge utile des événements "post.published" / "post.media_failed".
+type PostMediaEvent struct {
+	PostID      int64 `json:"post_id"`
+	FailedMedia int   `json:"failed_media"`
+	ReadyMedia  int   `json:"ready_media"`
+	Visibility  int   `json:"visibility"`
+}
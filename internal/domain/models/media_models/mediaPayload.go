@@ -1,13 +1,41 @@
 package media_models
 
-import "time"
+import (
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+)
 
 // MediaPayload représente l'entité d'un fichier média (image, vidéo) dans Nubo.
 type MediaPayload struct {
-	ID          int64     `json:"id" bson:"id"`
-	OwnerID     int64     `json:"owner_id" bson:"owner_id"`
-	StoragePath string    `json:"storage_path" bson:"storage_path"`
-	Visibility  bool      `json:"visibility" bson:"visibility"`
-	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" bson:"updated_at"`
+	ID          int64                   `json:"id" bson:"id"`
+	OwnerID     int64                   `json:"owner_id" bson:"owner_id"`
+	Type        string                  `json:"type" bson:"type"`
+	StoragePath string                  `json:"storage_path" bson:"storage_path"`
+	Renditions  []models.MediaRendition `json:"renditions" bson:"renditions"`
+	Video       models.MediaVideo       `json:"video" bson:"video"`
+	Metadata    models.MediaMetadata    `json:"metadata" bson:"metadata"`
+	Hash        models.MediaHash        `json:"hash" bson:"hash"`
+	Visibility  bool                    `json:"visibility" bson:"visibility"`
+	Status      int                     `json:"status" bson:"status"`
+	CreatedAt   time.Time               `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at" bson:"updated_at"`
+}
+
+// MediaSourceSet regroupe les URLs signées d'un média, par format puis par largeur croissante :
+// le client choisit la déclinaison adaptée à son affichage (sémantique srcset).
+type MediaSourceSet struct {
+	MediaID    int64                    `json:"media_id"`
+	Type       string                   `json:"type"`                  // "image" ou "video" (les sources sont alors l'image d'affiche)
+	DurationMs int64                    `json:"duration_ms,omitempty"` // Durée d'une vidéo
+	Default    string                   `json:"default"`               // URL de la déclinaison AVIF la plus large
+	Sources    map[string][]MediaSource `json:"sources"`               // "avif", "jpeg"
+	SrcSet     map[string]string        `json:"srcset"`                // Prêt à l'emploi : "<url> 160w, <url> 480w, ..."
+}
+
+// MediaSource est une déclinaison signée. Width vaut 0 pour un média antérieur aux déclinaisons.
+type MediaSource struct {
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	URL    string `json:"url"`
 }
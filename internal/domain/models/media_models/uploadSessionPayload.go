@@ -0,0 +1,41 @@
+package media_models
+
+import "time"
+
+// UploadSessionPayload décrit un upload vidéo par morceaux en cours (L1, clé = ID de session).
+// Les morceaux reçus sont tenus à part (HASH index -> ETag) : ils peuvent arriver en parallèle.
+type UploadSessionPayload struct {
+	ID          int64     `json:"id" msgpack:"id"`
+	OwnerID     int64     `json:"owner_id" msgpack:"owner_id"`
+	MediaID     int64     `json:"media_id" msgpack:"media_id"`
+	UploadID    string    `json:"-" msgpack:"upload_id"`   // Identifiant de l'upload multipart MinIO
+	ObjectPath  string    `json:"-" msgpack:"object_path"` // Original en zone de transit
+	ContentType string    `json:"content_type" msgpack:"content_type"`
+	SizeBytes   int64     `json:"size_bytes" msgpack:"size_bytes"`
+	ChunkBytes  int64     `json:"chunk_bytes" msgpack:"chunk_bytes"`
+	ChunkCount  int       `json:"chunk_count" msgpack:"chunk_count"`
+	CreatedAt   time.Time `json:"created_at" msgpack:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at" msgpack:"expires_at"`
+}
+
+// CreateUploadInput est le corps de POST /media/uploads.
+type CreateUploadInput struct {
+	ContentType string `json:"content_type" binding:"required" example:"video/mp4"`
+	SizeBytes   int64  `json:"size_bytes" binding:"required,min=1" example:"18350080"`
+}
+
+// UploadSessionOutput est l'état d'un upload : le client reprend en n'envoyant que les morceaux manquants.
+type UploadSessionOutput struct {
+	UploadID       int64     `json:"upload_id" example:"1893456789012345678"`
+	MediaID        int64     `json:"media_id" example:"1893456789012345679"`
+	ChunkBytes     int64     `json:"chunk_bytes" example:"5242880"`
+	ChunkCount     int       `json:"chunk_count" example:"4"`
+	ReceivedChunks []int     `json:"received_chunks"` // Index (à partir de 0) déjà stockés
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// CompleteUploadOutput est la réponse de POST /media/uploads/{id}/complete.
+// Le média est ensuite référencé par "media_ids" à la création du post, qui déclenche le transcodage.
+type CompleteUploadOutput struct {
+	MediaID int64 `json:"media_id" example:"1893456789012345679"`
+}
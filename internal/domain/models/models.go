@@ -32,12 +32,84 @@ type SessionsRequest struct { // CreateSession
 }
 
 type MediaRequest struct {
-	ID          int64     `bson:"id" json:"id"`
-	OwnerID     int64     `bson:"owner_id" json:"owner_id"`
-	StoragePath string    `bson:"storage_path" json:"storage_path"`
-	Visibility  bool      `bson:"visibility" json:"visibility"`
-	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
-	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
+	ID          int64            `bson:"id" json:"id"`
+	OwnerID     int64            `bson:"owner_id" json:"owner_id"`
+	Type        string           `bson:"type" json:"type"`                 // MediaTypeImage ou MediaTypeVideo ("" : image antérieure)
+	StoragePath string           `bson:"storage_path" json:"storage_path"` // Déclinaison AVIF la plus large (affiche pour une vidéo)
+	Renditions  []MediaRendition `bson:"renditions" json:"renditions"`     // Vide pour les médias antérieurs
+	Video       MediaVideo       `bson:"video" json:"video"`               // Rendu HLS (vide pour une image)
+	Metadata    MediaMetadata    `bson:"metadata" json:"metadata"`         // Champs EXIF retenus (liste blanche)
+	Hash        MediaHash        `bson:"hash" json:"hash"`                 // Empreintes perceptuelles (zéro : non calculées)
+	Visibility  bool             `bson:"visibility" json:"visibility"`
+	Status      int              `bson:"status" json:"status"` // variables.MediaStatus* (0 = prêt)
+	CreatedAt   time.Time        `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time        `bson:"updated_at" json:"updated_at"`
+}
+
+// IsVideo indique si le média est une vidéo (les médias antérieurs sans type sont des images).
+func (m MediaRequest) IsVideo() bool {
+	return m.Type == MediaTypeVideo
+}
+
+// Types de médias
+const (
+	MediaTypeImage = "image"
+	MediaTypeVideo = "video"
+)
+
+// Gabarits des déclinaisons d'une image ("" : image de post, déclinaisons à la largeur d'origine)
+const (
+	MediaLayoutAvatar = "avatar" // Photo de profil : carrés, affichables sous un masque circulaire
+	MediaLayoutCover  = "cover"  // Couverture de profil : bandeau au ratio fixe
+)
+
+// MediaVideo décrit le rendu HLS d'une vidéo stocké dans MinIO.
+type MediaVideo struct {
+	DurationMs   int64    `bson:"duration_ms" json:"duration_ms"`
+	Width        int      `bson:"width" json:"width"`
+	Height       int      `bson:"height" json:"height"`
+	PlaylistPath string   `bson:"playlist_path" json:"playlist_path"` // "video/<base>/index.m3u8"
+	SegmentPaths []string `bson:"segment_paths" json:"segment_paths"` // Dans l'ordre de lecture
+}
+
+// MediaMetadata regroupe les seules métadonnées conservées d'un original. Aucun autre champ EXIF
+// (GPS, modèle ou numéro de série de l'appareil...) n'est lu ni persisté.
+type MediaMetadata struct {
+	Orientation int        `bson:"orientation" json:"orientation"` // Orientation EXIF à appliquer au rendu (1 = droite)
+	CapturedAt  time.Time  `bson:"captured_at" json:"captured_at"` // Prise de vue (zéro si inconnue)
+	Width       int        `bson:"width" json:"width"`             // Dimensions de l'original une fois redressé
+	Height      int        `bson:"height" json:"height"`
+	Layout      string     `bson:"layout,omitempty" json:"layout,omitempty"` // MediaLayout* ("" : image de post)
+	Crop        *MediaCrop `bson:"crop,omitempty" json:"crop,omitempty"`     // Recadrage appliqué au rendu (gabarits de profil)
+}
+
+// MediaCrop est un rectangle de l'original redressé, en pixels (origine en haut à gauche).
+type MediaCrop struct {
+	X      int `bson:"x" json:"x"`
+	Y      int `bson:"y" json:"y"`
+	Width  int `bson:"width" json:"width"`
+	Height int `bson:"height" json:"height"`
+}
+
+// MediaHash porte les empreintes perceptuelles 64 bits d'un média (bits stockés tels quels en int64).
+type MediaHash struct {
+	PHash int64 `bson:"phash" json:"phash"`
+	DHash int64 `bson:"dhash" json:"dhash"`
+}
+
+// Formats des déclinaisons
+const (
+	MediaFormatAVIF = "avif"
+	MediaFormatJPEG = "jpeg"
+)
+
+// MediaRendition est une déclinaison d'un média (largeur x format) stockée dans MinIO.
+type MediaRendition struct {
+	Width       int    `bson:"width" json:"width"`
+	Height      int    `bson:"height" json:"height"`
+	Format      string `bson:"format" json:"format"` // "avif" ou "jpeg" (repli des anciens clients)
+	StoragePath string `bson:"storage_path" json:"storage_path"`
+	SizeBytes   int64  `bson:"size_bytes" json:"size_bytes"`
 }
 
 // ********************************************************
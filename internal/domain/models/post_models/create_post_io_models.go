@@ -7,6 +7,8 @@ type CreatePostInput struct {
 	Identifiers []int64  `json:"identifiers" binding:"max=10"`
 	Location    string   `json:"location" binding:"max=100"`
 	Visibility  int      `json:"visibility" binding:"oneof=0 1 2"`
+	// Médias déposés à part (vidéos terminées via /media/uploads), comptés avec les fichiers joints
+	MediaIDs []int64 `json:"media_ids" binding:"max=4"`
 }
 type CreatePostResponse struct {
 	PostID int64 `json:"post_id"`
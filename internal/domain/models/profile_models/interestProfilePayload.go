@@ -0,0 +1,32 @@
+package profile_models
+
+import "time"
+
+// InterestProfilePayload est le profil d'intérêt u d'un utilisateur (L1 : profile:interest, L3 : auth.interest_profiles).
+// ID est l'identifiant de l'utilisateur : un seul profil par compte.
+type InterestProfilePayload struct {
+	ID           int64     `json:"id" msgpack:"id"`
+	Version      int       `json:"version" msgpack:"version"`
+	Vector       []float32 `json:"vector" msgpack:"vector"`             // û ∈ R^224 normalisé L2
+	Interactions int64     `json:"interactions" msgpack:"interactions"` // Signaux cumulés ayant nourri l'EMA (base de la confiance)
+	Syncs        int64     `json:"syncs" msgpack:"syncs"`               // 0 : profil absent (cache négatif)
+	ComputedAt   time.Time `json:"computed_at" msgpack:"computed_at"`   // Horodatage client du calcul
+	SyncedAt     time.Time `json:"synced_at" msgpack:"synced_at"`
+	CreatedAt    time.Time `json:"created_at" msgpack:"created_at"`
+}
+
+// SyncProfileInput est le corps de POST /v1/profile/sync.
+type SyncProfileInput struct {
+	Version      int       `json:"version" binding:"required" example:"1"`
+	Vector       []float32 `json:"vector" binding:"required"`                 // 224 composantes, ‖u‖₂ = 1
+	Interactions int       `json:"interactions" binding:"min=0" example:"42"` // Signaux intégrés depuis la dernière synchronisation
+	ComputedAt   time.Time `json:"computed_at" binding:"required" example:"2026-10-18T09:30:00Z"`
+}
+
+// SyncProfileOutput est la réponse de POST /v1/profile/sync.
+type SyncProfileOutput struct {
+	Version    int       `json:"version" example:"1"`
+	Confidence float64   `json:"confidence" example:"0.63"`
+	Drifted    bool      `json:"drifted" example:"true"` // Feed personnalisé invalidé (dérive > δ ou premier profil)
+	SyncedAt   time.Time `json:"synced_at"`
+}
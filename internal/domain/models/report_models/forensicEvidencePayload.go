@@ -0,0 +1,35 @@
+package report_models
+
+import "time"
+
+// ForensicEvidencePayload est une image fuitée versée au dossier ouvert contre le responsable désigné
+// par son tatouage (moderation.forensic_evidence). L'image est conservée telle quelle dans MinIO.
+type ForensicEvidencePayload struct {
+	ID             int64         `json:"id"`
+	CaseID         int64         `json:"case_id"`
+	InvestigatorID int64         `json:"investigator_id"`
+	AuthorID       int64         `json:"author_id"`
+	PostID         int64         `json:"post_id"`
+	CulpritID      int64         `json:"culprit_id"`
+	LeakedAt       time.Time     `json:"leaked_at"` // Horodatage tatoué : émission du lien au responsable
+	ObjectPath     string        `json:"object_path"`
+	ContentType    string        `json:"content_type"`
+	SizeBytes      int64         `json:"size_bytes"`
+	SHA256         string        `json:"sha256"`
+	Confidence     float64       `json:"confidence"`
+	Stats          ForensicStats `json:"stats"`
+	CreatedAt      time.Time     `json:"created_at"`
+	URL            string        `json:"url,omitempty"` // Lien MinIO signé (jamais persisté)
+}
+
+// ForensicStats reprend les statistiques de lecture du tatouage : géométrie retrouvée (échelle, tuiles)
+// et qualité du signal (erreurs brutes, erreurs corrigées par Reed-Solomon).
+type ForensicStats struct {
+	Scale            float64 `json:"scale"` // Image fuitée / image servie
+	Tiles            float64 `json:"tiles"`
+	SyncScore        float64 `json:"sync_score"`
+	RawBitErrorRate  float64 `json:"raw_bit_error_rate"`
+	FoldedBitErrors  int     `json:"folded_bit_errors"`
+	CorrectedSymbols int     `json:"corrected_symbols"` // Capacité : 8 octets
+	Attempts         int     `json:"attempts"`
+}
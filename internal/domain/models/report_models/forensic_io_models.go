@@ -0,0 +1,30 @@
+package report_models
+
+import (
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/post_models"
+)
+
+// ForensicWatermark est le contenu du tatouage relu et corrigé, avant toute résolution.
+type ForensicWatermark struct {
+	AuthorID  int64     `json:"author_id"`
+	PostID    int64     `json:"post_id"`
+	CulpritID int64     `json:"culprit_id"`
+	LeakedAt  time.Time `json:"leaked_at"`
+}
+
+// ForensicInvestigationOutput est le résultat de l'analyse d'une image fuitée. Le dossier n'est ouvert
+// contre le responsable que si la confiance est suffisante et qu'aucune incohérence n'a été relevée.
+type ForensicInvestigationOutput struct {
+	Watermark  ForensicWatermark        `json:"watermark"`
+	Stats      ForensicStats            `json:"stats"`
+	Confidence float64                  `json:"confidence"` // Probabilité que l'enregistrement corrigé soit exact
+	Author     *models.UserLiteRequest  `json:"author"`     // nil : introuvable
+	Culprit    *models.UserLiteRequest  `json:"culprit"`
+	Post       *post_models.PostPayload `json:"post"`     // Quelle que soit sa visibilité (sans vecteur)
+	Findings   []string                 `json:"findings"` // Incohérences relevées (vide : dossier ouvert)
+	Case       *ReportCasePayload       `json:"case,omitempty"`
+	Evidence   *ForensicEvidencePayload `json:"evidence,omitempty"`
+}
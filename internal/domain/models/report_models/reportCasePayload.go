@@ -0,0 +1,37 @@
+package report_models
+
+import "time"
+
+// ReportCasePayload est un dossier de modération : tous les signalements ouverts visant la même cible
+// (target_type + première cible signalée) y sont agrégés (moderation.report_cases).
+type ReportCasePayload struct {
+	ID             int64      `json:"id"`
+	TargetType     int        `json:"target_type"`
+	TargetID       int64      `json:"target_id"`
+	TargetIDs      []int64    `json:"target_ids"` // Union des cibles de tous les signalements du dossier
+	Category       int        `json:"category"`   // Catégorie la plus prioritaire reçue
+	Priority       int        `json:"priority"`
+	State          int        `json:"state"`
+	AssigneeID     int64      `json:"assignee_id,omitempty"`
+	ReportCount    int        `json:"report_count"`
+	EscalationNote string     `json:"escalation_note,omitempty"`
+	Resolution     int        `json:"resolution,omitempty"`
+	ResolutionNote string     `json:"resolution_note,omitempty"`
+	ResolvedBy     int64      `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// ReportCaseTransition décrit une transition d'état conditionnelle (compare-and-set) d'un dossier.
+type ReportCaseTransition struct {
+	CaseID         int64
+	AllowedStates  []int // La transition n'a lieu que si l'état courant en fait partie
+	ExpectAssignee int64 // 0 : peu importe l'assigné actuel
+	NewState       int
+	AssigneeID     int64 // 0 : dossier désassigné
+	EscalationNote string
+	Resolution     int
+	ResolutionNote string
+	ResolvedBy     int64
+}
@@ -0,0 +1,50 @@
+package report_models
+
+// ListReportCasesInput regroupe les filtres de la file de modération (tous optionnels).
+type ListReportCasesInput struct {
+	State      *int  `form:"state"` // Défaut : dossiers ouverts (en attente, en cours, escaladés)
+	Category   int   `form:"category"`
+	TargetType *int  `form:"target_type"`
+	TargetID   int64 `form:"target_id"`
+	AssigneeID int64 `form:"assignee_id"`
+	Limit      int   `form:"limit"`
+	Offset     int   `form:"offset"`
+}
+
+// ListReportCasesOutput est une page de la file, triée par priorité puis ancienneté.
+type ListReportCasesOutput struct {
+	Cases []ReportCasePayload `json:"cases"`
+}
+
+// GetReportCaseInput identifie un dossier.
+type GetReportCaseInput struct {
+	CaseID int64 `form:"case_id" binding:"required"`
+}
+
+// ReportCaseDetailOutput est un dossier accompagné de ses signalements (et de son retrait automatique
+// ou de ses preuves d'investigation éventuels).
+type ReportCaseDetailOutput struct {
+	Case     ReportCasePayload         `json:"case"`
+	Reports  []ReportPayload           `json:"reports"`
+	Takedown *TakedownPayload          `json:"takedown,omitempty"`
+	Evidence []ForensicEvidencePayload `json:"evidence,omitempty"`
+}
+
+// AssignReportCaseInput prend en charge un dossier (AssigneeID absent : pour soi-même).
+type AssignReportCaseInput struct {
+	CaseID     int64 `json:"case_id" binding:"required"`
+	AssigneeID int64 `json:"assignee_id"`
+}
+
+// EscalateReportCaseInput transmet un dossier à un administrateur.
+type EscalateReportCaseInput struct {
+	CaseID int64  `json:"case_id" binding:"required"`
+	Note   string `json:"note" binding:"required,max=1000"`
+}
+
+// CloseReportCaseInput clôt un dossier avec un code de résolution.
+type CloseReportCaseInput struct {
+	CaseID     int64  `json:"case_id" binding:"required"`
+	Resolution int    `json:"resolution" binding:"required,oneof=1 2 3 4 5 6"`
+	Note       string `json:"note" binding:"max=1000"`
+}
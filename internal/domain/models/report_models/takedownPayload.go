@@ -0,0 +1,24 @@
+package report_models
+
+import "time"
+
+// TakedownPayload est le retrait automatique d'un contenu en attente de décision (moderation.takedowns).
+type TakedownPayload struct {
+	ID                 int64      `json:"id"`
+	CaseID             int64      `json:"case_id"`
+	TargetType         int        `json:"target_type"`
+	TargetID           int64      `json:"target_id"`
+	PreviousVisibility int        `json:"previous_visibility"` // Restituée si le dossier est clos sans infraction
+	Score              float64    `json:"score"`
+	HiddenAt           time.Time  `json:"hidden_at"`
+	ReleasedAt         *time.Time `json:"released_at,omitempty"`
+	Restored           *bool      `json:"restored,omitempty"` // nil : dossier ouvert
+}
+
+// TakedownCandidate est un dossier ouvert, sans retrait, dont le score pondéré dépasse le seuil de marquage.
+type TakedownCandidate struct {
+	CaseID     int64
+	TargetType int
+	TargetID   int64
+	Score      float64
+}
@@ -0,0 +1,40 @@
+package revision_models
+
+import (
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/comment_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/post_models"
+)
+
+// RevisionPayload archive l'état d'un post ou d'un commentaire avant une modification (content.revisions).
+type RevisionPayload struct {
+	ID         int64          `json:"id"`
+	TargetType int            `json:"target_type"` // variables.ReportTargetPost / ReportTargetComment
+	TargetID   int64          `json:"target_id"`
+	EditorID   int64          `json:"editor_id"`
+	Snapshot   map[string]any `json:"snapshot"`
+	EditedAt   time.Time      `json:"edited_at"`
+}
+
+// PostSnapshot extrait les champs modifiables d'un post (le vecteur et les compteurs ne sont pas versionnés).
+func PostSnapshot(p post_models.PostPayload) map[string]any {
+	return map[string]any{
+		"content":     p.Content,
+		"hashtags":    p.Hashtags,
+		"identifiers": p.Identifiers,
+		"media_ids":   p.MediaIDs,
+		"location":    p.Location,
+		"visibility":  p.Visibility,
+		"updated_at":  p.UpdatedAt,
+	}
+}
+
+// CommentSnapshot extrait les champs modifiables d'un commentaire.
+func CommentSnapshot(c comment_models.CommentPayload) map[string]any {
+	return map[string]any{
+		"content":    c.Content,
+		"visibility": c.Visibility,
+		"updated_at": c.UpdatedAt,
+	}
+}
@@ -0,0 +1,43 @@
+package sanction_models
+
+import (
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+)
+
+// SanctionPayload est une sanction émise contre un utilisateur (moderation.sanctions, L1 pour les restrictions actives).
+// ExpiresAt nul : sans échéance (ban définitif, avertissement). LiftedAt non nul : levée avant terme.
+type SanctionPayload struct {
+	ID              int64     `json:"id" msgpack:"id"`
+	UserID          int64     `json:"user_id" msgpack:"user_id"`
+	Kind            int       `json:"kind" msgpack:"kind"`
+	Scopes          int       `json:"scopes" msgpack:"scopes"` // Bitmask des fonctionnalités restreintes (Kind == restriction)
+	Reason          string    `json:"reason" msgpack:"reason"`
+	CaseID          int64     `json:"case_id" msgpack:"case_id"` // Dossier de modération à l'origine de la sanction (0 : aucun)
+	IssuedBy        int64     `json:"issued_by" msgpack:"issued_by"`
+	Automatic       bool      `json:"automatic" msgpack:"automatic"` // Émise par l'échelle des strikes
+	IssuedAt        time.Time `json:"issued_at" msgpack:"issued_at"`
+	ExpiresAt       time.Time `json:"expires_at" msgpack:"expires_at"`
+	AppealableUntil time.Time `json:"appealable_until" msgpack:"appealable_until"`
+	LiftedAt        time.Time `json:"lifted_at" msgpack:"lifted_at"`
+	LiftedBy        int64     `json:"lifted_by" msgpack:"lifted_by"`
+	LiftReason      string    `json:"lift_reason" msgpack:"lift_reason"`
+}
+
+// Notice renvoie la face visible de la sanction pour l'utilisateur concerné.
+func (s SanctionPayload) Notice() nubo_error.SanctionNotice {
+	n := nubo_error.SanctionNotice{
+		SanctionID:      s.ID,
+		Kind:            s.Kind,
+		Scopes:          s.Scopes,
+		Reason:          s.Reason,
+		IssuedAt:        s.IssuedAt,
+		AppealableUntil: s.AppealableUntil,
+	}
+	if !s.ExpiresAt.IsZero() {
+		expiresAt := s.ExpiresAt
+		n.ExpiresAt = &expiresAt
+	}
+	return n
+}
@@ -0,0 +1,46 @@
+package sanction_models
+
+import "github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+
+// BanUserInput bannit un utilisateur (DurationDays == 0 : définitif).
+type BanUserInput struct {
+	UserID       int64  `json:"user_id" binding:"required"`
+	Reason       string `json:"reason" binding:"required,max=1000"`
+	DurationDays int    `json:"duration_days" binding:"min=0,max=365"`
+	CaseID       int64  `json:"case_id"`
+}
+
+// RestrictUserInput restreint une ou plusieurs fonctionnalités pour une durée donnée.
+type RestrictUserInput struct {
+	UserID       int64    `json:"user_id" binding:"required"`
+	Scopes       []string `json:"scopes" binding:"required,min=1,dive,oneof=post comment message"`
+	Reason       string   `json:"reason" binding:"required,max=1000"`
+	DurationDays int      `json:"duration_days" binding:"required,min=1,max=365"`
+	CaseID       int64    `json:"case_id"`
+}
+
+// WarnUserInput émet un avertissement (strike).
+type WarnUserInput struct {
+	UserID int64  `json:"user_id" binding:"required"`
+	Reason string `json:"reason" binding:"required,max=1000"`
+	CaseID int64  `json:"case_id"`
+}
+
+// LiftSanctionInput lève une sanction avant son terme.
+type LiftSanctionInput struct {
+	SanctionID int64  `json:"sanction_id" binding:"required"`
+	Reason     string `json:"reason" binding:"required,max=1000"`
+}
+
+// SanctionOutput renvoie la sanction émise et, le cas échéant, celle déclenchée par l'échelle des strikes.
+type SanctionOutput struct {
+	Sanction   SanctionPayload  `json:"sanction"`
+	Escalation *SanctionPayload `json:"escalation,omitempty"`
+	Strikes    int64            `json:"strikes"` // Avertissements actifs sur la fenêtre glissante
+}
+
+// MySanctionsOutput liste les sanctions actives d'un utilisateur (vue utilisateur).
+type MySanctionsOutput struct {
+	Sanctions []nubo_error.SanctionNotice `json:"sanctions"`
+	Strikes   int64                       `json:"strikes"`
+}
@@ -11,4 +11,9 @@ var (
 	ErrAgeUnder13    = errors.New("You must be at least 13 years old")
 	ErrAgeOver120    = errors.New("Invalid birthdate")
 	ErrInvalidGender = errors.New("Gender must be 0, 1, 2, or null")
+	ErrInvalidToken  = errors.New("Invalid or expired token")
+	ErrSamePassword  = errors.New("The new password must differ from the current one")
+
+	ErrAccountNotActive = errors.New("This account is not active")
+	ErrDeletionPending  = errors.New("A deletion is already scheduled for this account")
 )
@@ -0,0 +1,32 @@
+package nubo_error
+
+import "errors"
+
+// Erreurs de dépôt d'un média (refusé pendant la requête, avant toute mise en file)
+var (
+	ErrInvalidMedia     = errors.New("Impossible to read image file")
+	ErrMediaTooLarge    = errors.New("Image resolution is too large")
+	ErrUnsupportedMedia = errors.New("Unsupported video format")
+	ErrVideoTooLarge    = errors.New("Video exceeds the maximum size")
+	ErrVideoTooLong     = errors.New("Video exceeds the maximum duration")
+	ErrInvalidChunk     = errors.New("Invalid chunk index or size")
+	ErrUploadIncomplete = errors.New("Upload is missing chunks")
+	ErrMediaUnavailable = errors.New("Unknown or already used media")
+	ErrInvalidCrop      = errors.New("Invalid crop rectangle")
+)
+
+// ErrNoProfileImage signale le retrait d'une photo de profil ou d'une couverture absente.
+var ErrNoProfileImage = errors.New("No image to remove")
+
+// ErrMediaGCRunning signale un passage du ramasse-miettes déjà en cours (sur cette instance ou une autre).
+var ErrMediaGCRunning = errors.New("Media garbage collection already running")
+
+// ErrMediaNotHashed signale un média sans empreinte perceptuelle (antérieur au calcul, ou encore en traitement).
+var ErrMediaNotHashed = errors.New("Media has no perceptual hash")
+
+// Refus de la politique d'accès aux médias (évaluée sur le post qui les porte)
+var (
+	ErrPostHidden        = errors.New("Post not found or deleted") // Mode furtif : blocage, compte masqué, contenu retiré
+	ErrPostFollowersOnly = errors.New("Post is reserved to the author's followers")
+	ErrPostFriendsOnly   = errors.New("Post is reserved to the author's friends")
+)
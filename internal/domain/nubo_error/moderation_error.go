@@ -0,0 +1,55 @@
+package nubo_error
+
+import (
+	"errors"
+	"time"
+)
+
+// Erreurs de la file de modération et des sanctions, routables par le handler HTTP
+var (
+	ErrCaseUnavailable = errors.New("This case cannot go through this transition in its current state")
+	ErrNotAssignee     = errors.New("This case is assigned to another moderator")
+	ErrInvalidAssignee = errors.New("The assignee is not allowed to handle this case")
+
+	ErrRestricted       = errors.New("This action is restricted on your account")
+	ErrSanctionTarget   = errors.New("You cannot sanction a user of equal or higher grade")
+	ErrSanctionInactive = errors.New("This sanction is no longer active")
+
+	ErrAppealExists       = errors.New("This sanction has already been appealed")
+	ErrAppealWindowClosed = errors.New("The appeal period for this sanction has ended")
+	ErrAppealUnavailable  = errors.New("This appeal cannot go through this transition in its current state")
+	ErrAppealNotAssignee  = errors.New("This appeal is assigned to another moderator")
+	ErrAppealConflict     = errors.New("You cannot review an appeal against your own sanction")
+
+	ErrWatermarkUnreadable = errors.New("No readable watermark was found in this image")
+)
+
+// SanctionNotice est la face visible d'une sanction pour l'utilisateur concerné (motif, échéance, contestation).
+type SanctionNotice struct {
+	SanctionID      int64      `json:"sanction_id"`
+	Kind            int        `json:"kind"`   // 1 = avertissement, 2 = restriction, 3 = ban, 4 = retrait de contenu
+	Scopes          int        `json:"scopes"` // Bitmask : 1 = post, 2 = commentaire, 4 = message
+	Reason          string     `json:"reason"`
+	IssuedAt        time.Time  `json:"issued_at"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"` // Absent : définitif
+	AppealableUntil time.Time  `json:"appealable_until"`
+}
+
+// SanctionError accompagne ErrBanned / ErrRestricted de la notice à présenter à l'utilisateur.
+// errors.Is(err, ErrBanned) reste vrai grâce à Unwrap. AppealToken n'est renseigné que pour un ban :
+// c'est le seul moyen pour un compte banni (sans session) de contester la sanction.
+type SanctionError struct {
+	Cause       error
+	Notice      SanctionNotice
+	AppealToken string
+}
+
+func (e *SanctionError) Error() string { return e.Cause.Error() }
+func (e *SanctionError) Unwrap() error { return e.Cause }
+
+// SanctionResponse est le corps 403 renvoyé quand une sanction bloque l'action.
+type SanctionResponse struct {
+	Error       string         `json:"nubo_error" example:"Account banned"`
+	Sanction    SanctionNotice `json:"sanction"`
+	AppealToken string         `json:"appeal_token,omitempty"` // À présenter dans X-Appeal-Token sur /appeal
+}
@@ -0,0 +1,12 @@
+package nubo_error
+
+import "errors"
+
+// Refus d'une synchronisation du profil d'intérêt (POST /v1/profile/sync)
+var (
+	ErrInvalidProfileVector   = errors.New("Invalid interest vector")                 // Dimension, valeur non finie ou norme ≠ 1
+	ErrInvalidProfileClock    = errors.New("Profile computed_at is in the future")    // Au-delà de la dérive d'horloge tolérée
+	ErrProfileVersionMismatch = errors.New("Unsupported interest vector version")     // Client à migrer vers VectorVersion
+	ErrProfileStale           = errors.New("A more recent profile is already stored") // computed_at antérieur au profil stocké
+	ErrProfileSyncTooFrequent = errors.New("Profile synced too recently")
+)
@@ -6,8 +6,14 @@ import "reflect"
 var MediaSchema = map[string]reflect.Kind{
 	"id":           reflect.Int64,
 	"owner_id":     reflect.Int64,
+	"type":         reflect.String,
 	"storage_path": reflect.String,
+	"renditions":   reflect.Slice,  // JSONB
+	"video":        reflect.Struct, // JSONB
+	"metadata":     reflect.Struct, // JSONB
+	"hash":         reflect.Struct, // JSONB
 	"visibility":   reflect.Bool,
+	"status":       reflect.Int,
 	"created_at":   reflect.Struct,
 	"updated_at":   reflect.Struct,
 }
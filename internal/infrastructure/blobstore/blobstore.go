@@ -0,0 +1,255 @@
+// Package blobstore isole le stockage objet derrière une interface unique. Deux pilotes la réalisent :
+// MinIO/S3 (production) et le système de fichiers local (poste de développement, tests), choisis par
+// BLOB_STORE_DRIVER. Les opérations propres à un pilote (multipart, copie côté serveur, expiration d'un
+// préfixe) sont des interfaces optionnelles, découvertes par assertion de type.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"time"
+)
+
+var (
+	ErrNotFound    = errors.New("blobstore: objet introuvable")
+	ErrInvalidKey  = errors.New("blobstore: clé invalide")
+	ErrUnsupported = errors.New("blobstore: opération non supportée par le pilote")
+)
+
+// Pilotes disponibles (BLOB_STORE_DRIVER)
+const (
+	DriverMinio = "minio"
+	DriverLocal = "local"
+)
+
+// BlobStore est le contrat commun des pilotes. Les clés suivent la convention S3 : chemins séparés par
+// des "/", sans "/" initial.
+type BlobStore interface {
+	// Put écrit un objet de size octets (remplace l'objet existant).
+	Put(ctx context.Context, bucket, key string, r io.Reader, size int64, opts PutOptions) error
+	// Get ouvre un objet en lecture. ErrNotFound si l'objet n'existe pas.
+	Get(ctx context.Context, bucket, key string) (io.ReadCloser, error)
+	// Stat décrit un objet sans le lire. ErrNotFound si l'objet n'existe pas.
+	Stat(ctx context.Context, bucket, key string) (ObjectInfo, error)
+	// Delete supprime un objet. Supprimer un objet absent n'est pas une erreur.
+	Delete(ctx context.Context, bucket, key string) error
+	// List énumère les objets dont la clé commence par prefix, dans l'ordre lexical. En mode non récursif,
+	// les sous-dossiers sont renvoyés comme préfixes communs (clé terminée par "/"). Une erreur est
+	// transmise dans ObjectInfo.Err et termine le listing ; annuler ctx l'interrompt.
+	List(ctx context.Context, bucket, prefix string, recursive bool) <-chan ObjectInfo
+	// Presign renvoie un lien de lecture temporaire. params transmet les surcharges d'en-têtes de la
+	// réponse (response-content-disposition, response-content-type).
+	Presign(ctx context.Context, bucket, key string, ttl time.Duration, params url.Values) (*url.URL, error)
+}
+
+// PutOptions complète l'écriture d'un objet.
+type PutOptions struct {
+	ContentType string
+	Metadata    map[string]string // Métadonnées utilisateur (ignorées par le pilote local)
+}
+
+// ObjectInfo décrit un objet (Stat, List).
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+	ContentType  string
+	ETag         string
+	Err          error
+}
+
+// --- INTERFACES OPTIONNELLES ---
+
+// Copier copie un objet côté stockage, sans le faire transiter par l'application.
+type Copier interface {
+	Copy(ctx context.Context, bucket, srcKey, dstKey string) error
+}
+
+// MultipartStore assemble un objet à partir de parties envoyées séparément (upload vidéo reprenable).
+type MultipartStore interface {
+	BlobStore
+	// CreateMultipart ouvre un upload et renvoie son identifiant.
+	CreateMultipart(ctx context.Context, bucket, key, contentType string) (string, error)
+	// PutPart stocke la partie number (à partir de 1) et renvoie son ETag. Renvoyer une partie la remplace.
+	PutPart(ctx context.Context, bucket, key, uploadID string, number int, r io.Reader, size int64) (string, error)
+	// CompleteMultipart assemble les parties dans l'ordre fourni.
+	CompleteMultipart(ctx context.Context, bucket, key, uploadID string, parts []Part) error
+	// AbortMultipart abandonne un upload et libère ses parties.
+	AbortMultipart(ctx context.Context, bucket, key, uploadID string) error
+	// ListMultipart énumère les uploads inachevés dont la clé commence par prefix.
+	ListMultipart(ctx context.Context, bucket, prefix string) <-chan UploadInfo
+}
+
+// Part identifie une partie reçue lors de l'assemblage.
+type Part struct {
+	Number int
+	ETag   string
+}
+
+// UploadInfo décrit un upload multipart inachevé.
+type UploadInfo struct {
+	Key       string
+	UploadID  string
+	Initiated time.Time
+	Size      int64 // Octets déjà reçus
+	Err       error
+}
+
+// Expirer purge automatiquement les objets d'un préfixe au-delà d'un âge (règle de cycle de vie).
+type Expirer interface {
+	ExpirePrefix(ctx context.Context, bucket, ruleID, prefix string, days int) error
+}
+
+// Copy copie un objet, côté stockage si le pilote le permet, sinon par relecture.
+func Copy(ctx context.Context, s BlobStore, bucket, srcKey, dstKey string) error {
+	if c, ok := s.(Copier); ok {
+		return c.Copy(ctx, bucket, srcKey, dstKey)
+	}
+	info, err := s.Stat(ctx, bucket, srcKey)
+	if err != nil {
+		return err
+	}
+	r, err := s.Get(ctx, bucket, srcKey)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+	return s.Put(ctx, bucket, dstKey, r, info.Size, PutOptions{ContentType: info.ContentType})
+}
+
+// ============================================================================
+// CONFIGURATION
+// ============================================================================
+
+// Config choisit et paramètre le pilote.
+type Config struct {
+	Driver string // DriverMinio (défaut) ou DriverLocal
+
+	// MinIO/S3
+	Endpoint  string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+
+	// Système de fichiers local
+	LocalRoot   string // Racine des buckets (un dossier par bucket)
+	LocalURL    string // URL publique de LocalStore.ServeHTTP, base des liens signés
+	LocalSecret string // Clé HMAC des liens signés (aléatoire par processus si vide)
+}
+
+// ConfigFromEnv lit la configuration des variables d'environnement. La clé d'accès S3 dédiée
+// (MINIO_ACCESS_KEY) prime sur le compte racine de MinIO.
+func ConfigFromEnv() Config {
+	return Config{
+		Driver:      envOr("BLOB_STORE_DRIVER", DriverMinio),
+		Endpoint:    os.Getenv("MINIO_ENDPOINT"),
+		AccessKey:   envOr("MINIO_ACCESS_KEY", os.Getenv("MINIO_ROOT_USER")),
+		SecretKey:   envOr("MINIO_SECRET_KEY", os.Getenv("MINIO_ROOT_PASSWORD")),
+		UseSSL:      os.Getenv("USE_SSL") == "true",
+		LocalRoot:   envOr("BLOB_STORE_LOCAL_ROOT", "./tmp/blobstore"),
+		LocalURL:    envOr("BLOB_STORE_LOCAL_URL", "http://localhost:8080/blobs"),
+		LocalSecret: os.Getenv("BLOB_STORE_LOCAL_SECRET"),
+	}
+}
+
+// New ouvre le pilote demandé.
+func New(cfg Config) (BlobStore, error) {
+	var store BlobStore
+	var err error
+	switch cfg.Driver {
+	case DriverMinio, "":
+		store, err = NewMinio(cfg)
+	case DriverLocal:
+		store, err = NewLocal(cfg)
+	default:
+		return nil, fmt.Errorf("blobstore: pilote inconnu %q", cfg.Driver)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// Store est le stockage partagé par l'API, ouvert par InitBlobStore.
+var Store BlobStore
+
+// InitBlobStore ouvre le pilote configuré et vérifie la présence du bucket principal.
+func InitBlobStore() {
+	cfg := ConfigFromEnv()
+
+	var err error
+	Store, err = New(cfg)
+	if err != nil {
+		log.Fatal("❌ Erreur critique : Impossible d'initialiser le stockage objet :", err)
+	}
+
+	bucketName := os.Getenv("MINIO_BUCKET_NAME")
+	if bucketName == "" {
+		bucketName = "nubo-bucket" // Fallback
+	}
+
+	// Test de connexion : le container 'createbuckets' peut prendre quelques secondes à démarrer,
+	// un bucket absent n'est donc pas fatal
+	if checker, ok := Store.(interface {
+		BucketExists(ctx context.Context, bucket string) (bool, error)
+	}); ok {
+		exists, err := checker.BucketExists(context.Background(), bucketName)
+		if err != nil {
+			log.Printf("Attention : Stockage objet (%s) joignable, mais impossible de vérifier le bucket '%s'. Erreur : %v", cfg.Driver, bucketName, err)
+			return
+		}
+		if !exists {
+			log.Printf("Attention : Le bucket '%s' n'existe pas encore.", bucketName)
+			return
+		}
+	}
+	log.Printf("Stockage objet prêt (pilote %s).", cfg.Driver)
+}
+
+func envOr(name, fallback string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// --- FICHIERS LOCAUX ---
+
+// DownloadFile recopie un objet dans un fichier local (outils qui lisent par accès aléatoire, ffmpeg).
+func DownloadFile(ctx context.Context, s BlobStore, bucket, key, dest string) error {
+	r, err := s.Get(ctx, bucket, key)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// UploadFile dépose un fichier local sous key.
+func UploadFile(ctx context.Context, s BlobStore, bucket, key, src string, opts PutOptions) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return s.Put(ctx, bucket, key, f, info.Size(), opts)
+}
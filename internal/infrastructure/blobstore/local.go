@@ -0,0 +1,543 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// PILOTE SYSTÈME DE FICHIERS LOCAL
+// ============================================================================
+// Chaque bucket est un dossier de la racine, chaque clé un fichier sous ce dossier. Les écritures passent
+// par un fichier temporaire renommé en place : un lecteur ne voit jamais d'objet à moitié écrit. Les
+// dossiers internes (uploads multipart, fichiers temporaires) commencent par un point, ce qu'aucun nom
+// de bucket ne peut faire.
+// Les liens signés pointent vers LocalURL : LocalStore est aussi un http.Handler qui les sert, monté
+// par l'API sous /blobs lorsque ce pilote est actif.
+
+const (
+	localTmpDir     = ".tmp"
+	localUploadsDir = ".uploads"
+	localUploadInfo = "upload.json"
+)
+
+// LocalStore réalise BlobStore, Copier et MultipartStore sur le système de fichiers.
+type LocalStore struct {
+	root    string
+	baseURL *url.URL
+	secret  []byte
+}
+
+// NewLocal prépare la racine du stockage local.
+func NewLocal(cfg Config) (*LocalStore, error) {
+	root, err := filepath.Abs(cfg.LocalRoot)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la résolution de la racine locale: %w", err)
+	}
+	for _, dir := range []string{root, filepath.Join(root, localTmpDir), filepath.Join(root, localUploadsDir)} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("erreur lors de la création de %s: %w", dir, err)
+		}
+	}
+
+	baseURL, err := url.Parse(strings.TrimSuffix(cfg.LocalURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la lecture de BLOB_STORE_LOCAL_URL: %w", err)
+	}
+
+	secret := []byte(cfg.LocalSecret)
+	if len(secret) == 0 {
+		// Les liens émis ne survivent pas au redémarrage : acceptable sur un poste de développement
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("erreur lors de la génération de la clé des liens: %w", err)
+		}
+		log.Println("⚠️ BLOB_STORE_LOCAL_SECRET absent : clé des liens signés générée pour ce processus")
+	}
+	return &LocalStore{root: root, baseURL: baseURL, secret: secret}, nil
+}
+
+func (s *LocalStore) Put(ctx context.Context, bucket, key string, r io.Reader, size int64, _ PutOptions) error {
+	target, err := s.objectPath(bucket, key)
+	if err != nil {
+		return err
+	}
+	return s.writeFile(ctx, target, r, size)
+}
+
+func (s *LocalStore) Get(_ context.Context, bucket, key string) (io.ReadCloser, error) {
+	target, err := s.objectPath(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(target)
+	if err != nil {
+		return nil, mapFSError(err)
+	}
+	if info, err := f.Stat(); err != nil || info.IsDir() {
+		_ = f.Close()
+		return nil, ErrNotFound
+	}
+	return f, nil
+}
+
+func (s *LocalStore) Stat(_ context.Context, bucket, key string) (ObjectInfo, error) {
+	target, err := s.objectPath(bucket, key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	info, err := os.Stat(target)
+	if err != nil {
+		return ObjectInfo{}, mapFSError(err)
+	}
+	if info.IsDir() {
+		return ObjectInfo{}, ErrNotFound
+	}
+	return localObjectInfo(key, info), nil
+}
+
+// Delete supprime l'objet puis les dossiers devenus vides (S3 n'a pas de dossiers).
+func (s *LocalStore) Delete(_ context.Context, bucket, key string) error {
+	target, err := s.objectPath(bucket, key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	s.pruneDirs(filepath.Dir(target), filepath.Join(s.root, bucket))
+	return nil
+}
+
+func (s *LocalStore) List(ctx context.Context, bucket, prefix string, recursive bool) <-chan ObjectInfo {
+	out := make(chan ObjectInfo)
+	go func() {
+		defer close(out)
+		emit := func(info ObjectInfo) bool {
+			select {
+			case out <- info:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		bucketDir, err := s.bucketPath(bucket)
+		if err != nil {
+			emit(ObjectInfo{Err: err})
+			return
+		}
+		// Le préfixe n'est pas forcément un dossier : on part du dossier qui le contient
+		base := prefix[:strings.LastIndex(prefix, "/")+1]
+		dir := filepath.Join(bucketDir, filepath.FromSlash(base))
+
+		if !recursive {
+			entries, err := os.ReadDir(dir)
+			if err != nil && !os.IsNotExist(err) {
+				emit(ObjectInfo{Err: err})
+				return
+			}
+			for _, entry := range entries {
+				key := base + entry.Name()
+				if entry.IsDir() {
+					key += "/"
+				}
+				if !strings.HasPrefix(key, prefix) {
+					continue
+				}
+				if entry.IsDir() {
+					if !emit(ObjectInfo{Key: key}) {
+						return
+					}
+					continue
+				}
+				info, err := entry.Info()
+				if err != nil {
+					continue // Supprimé entre-temps
+				}
+				if !emit(localObjectInfo(key, info)) {
+					return
+				}
+			}
+			return
+		}
+
+		err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			rel, err := filepath.Rel(bucketDir, p)
+			if err != nil {
+				return err
+			}
+			key := filepath.ToSlash(rel)
+			if !strings.HasPrefix(key, prefix) {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			if !emit(localObjectInfo(key, info)) {
+				return ctx.Err()
+			}
+			return nil
+		})
+		if err != nil && ctx.Err() == nil {
+			emit(ObjectInfo{Err: err})
+		}
+	}()
+	return out
+}
+
+// Presign signe un lien vers ServeHTTP : <LocalURL>/<bucket>/<clé>?X-Expires=…&X-Signature=…
+func (s *LocalStore) Presign(_ context.Context, bucket, key string, ttl time.Duration, params url.Values) (*url.URL, error) {
+	if _, err := s.objectPath(bucket, key); err != nil {
+		return nil, err
+	}
+	q := url.Values{}
+	for name, values := range params {
+		q[name] = values
+	}
+	q.Set("X-Expires", strconv.FormatInt(time.Now().Add(ttl).Unix(), 10))
+	q.Set("X-Signature", s.sign(bucket, key, q))
+
+	u := *s.baseURL
+	u.Path = path.Join(u.Path, bucket, key)
+	u.RawQuery = q.Encode()
+	return &u, nil
+}
+
+func (s *LocalStore) Copy(ctx context.Context, bucket, srcKey, dstKey string) error {
+	src, err := s.Get(ctx, bucket, srcKey)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+	return s.Put(ctx, bucket, dstKey, src, -1, PutOptions{})
+}
+
+// ServeHTTP sert un lien émis par Presign. Le chemin attendu est /<bucket>/<clé> (préfixe de montage
+// retiré par l'appelant) ; Range et les requêtes conditionnelles sont gérés par net/http.
+func (s *LocalStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Méthode non autorisée", http.StatusMethodNotAllowed)
+		return
+	}
+	bucket, key, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	q := r.URL.Query()
+	signature := q.Get("X-Signature")
+	q.Del("X-Signature")
+	if !hmac.Equal([]byte(signature), []byte(s.sign(bucket, key, q))) {
+		http.Error(w, "Accès refusé", http.StatusForbidden)
+		return
+	}
+	expires, err := strconv.ParseInt(q.Get("X-Expires"), 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		http.Error(w, "Lien expiré", http.StatusForbidden)
+		return
+	}
+
+	target, err := s.objectPath(bucket, key)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	f, err := os.Open(target)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+
+	if v := q.Get("response-content-type"); v != "" {
+		w.Header().Set("Content-Type", v)
+	}
+	if v := q.Get("response-content-disposition"); v != "" {
+		w.Header().Set("Content-Disposition", v)
+	}
+	http.ServeContent(w, r, path.Base(key), info.ModTime(), f)
+}
+
+// --- MULTIPART ---
+// Un upload est un dossier de .uploads : sa description (upload.json) et une partie par fichier.
+
+type localUpload struct {
+	Bucket      string    `json:"bucket"`
+	Key         string    `json:"key"`
+	ContentType string    `json:"content_type"`
+	Initiated   time.Time `json:"initiated"`
+}
+
+func (s *LocalStore) CreateMultipart(_ context.Context, bucket, key, contentType string) (string, error) {
+	if _, err := s.objectPath(bucket, key); err != nil {
+		return "", err
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	uploadID := hex.EncodeToString(id)
+
+	dir := filepath.Join(s.root, localUploadsDir, uploadID)
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(localUpload{Bucket: bucket, Key: key, ContentType: contentType, Initiated: time.Now().UTC()})
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, localUploadInfo), data, 0o644); err != nil {
+		return "", err
+	}
+	return uploadID, nil
+}
+
+func (s *LocalStore) PutPart(ctx context.Context, bucket, key, uploadID string, number int, r io.Reader, size int64) (string, error) {
+	dir, err := s.uploadDir(bucket, key, uploadID)
+	if err != nil {
+		return "", err
+	}
+	if number < 1 {
+		return "", fmt.Errorf("blobstore: numéro de partie invalide %d", number)
+	}
+	h := md5.New()
+	if err := s.writeFile(ctx, filepath.Join(dir, partName(number)), io.TeeReader(r, h), size); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (s *LocalStore) CompleteMultipart(ctx context.Context, bucket, key, uploadID string, parts []Part) error {
+	dir, err := s.uploadDir(bucket, key, uploadID)
+	if err != nil {
+		return err
+	}
+
+	files := make([]*os.File, 0, len(parts))
+	defer func() {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}()
+	readers := make([]io.Reader, 0, len(parts))
+	for _, p := range parts {
+		f, err := os.Open(filepath.Join(dir, partName(p.Number)))
+		if err != nil {
+			return fmt.Errorf("blobstore: partie %d absente: %w", p.Number, err)
+		}
+		files = append(files, f)
+		readers = append(readers, f)
+	}
+
+	target, _ := s.objectPath(bucket, key)
+	if err := s.writeFile(ctx, target, io.MultiReader(readers...), -1); err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+func (s *LocalStore) AbortMultipart(_ context.Context, bucket, key, uploadID string) error {
+	dir, err := s.uploadDir(bucket, key, uploadID)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+func (s *LocalStore) ListMultipart(ctx context.Context, bucket, prefix string) <-chan UploadInfo {
+	out := make(chan UploadInfo)
+	go func() {
+		defer close(out)
+		entries, err := os.ReadDir(filepath.Join(s.root, localUploadsDir))
+		if err != nil {
+			select {
+			case out <- UploadInfo{Err: err}:
+			case <-ctx.Done():
+			}
+			return
+		}
+		for _, entry := range entries {
+			upload, err := s.readUpload(entry.Name())
+			if err != nil || upload.Bucket != bucket || !strings.HasPrefix(upload.Key, prefix) {
+				continue
+			}
+			info := UploadInfo{Key: upload.Key, UploadID: entry.Name(), Initiated: upload.Initiated, Size: dirSize(filepath.Join(s.root, localUploadsDir, entry.Name()))}
+			select {
+			case out <- info:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// --- HELPERS ---
+
+// bucketPath valide un nom de bucket : un seul segment, qui ne commence pas par un point.
+func (s *LocalStore) bucketPath(bucket string) (string, error) {
+	if bucket == "" || strings.ContainsAny(bucket, `/\`) || strings.HasPrefix(bucket, ".") {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.root, bucket), nil
+}
+
+// objectPath résout une clé sous son bucket. Une clé qui remonterait hors du bucket est refusée.
+func (s *LocalStore) objectPath(bucket, key string) (string, error) {
+	bucketDir, err := s.bucketPath(bucket)
+	if err != nil {
+		return "", err
+	}
+	if key == "" || strings.HasSuffix(key, "/") || strings.HasPrefix(key, "/") || path.Clean(key) != key || key == ".." || strings.HasPrefix(key, "../") {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(bucketDir, filepath.FromSlash(key)), nil
+}
+
+// uploadDir vérifie qu'un upload existe et vise bien la clé annoncée.
+func (s *LocalStore) uploadDir(bucket, key, uploadID string) (string, error) {
+	if _, err := hex.DecodeString(uploadID); err != nil || uploadID == "" {
+		return "", ErrNotFound
+	}
+	upload, err := s.readUpload(uploadID)
+	if err != nil || upload.Bucket != bucket || upload.Key != key {
+		return "", ErrNotFound
+	}
+	return filepath.Join(s.root, localUploadsDir, uploadID), nil
+}
+
+func (s *LocalStore) readUpload(uploadID string) (localUpload, error) {
+	var upload localUpload
+	data, err := os.ReadFile(filepath.Join(s.root, localUploadsDir, uploadID, localUploadInfo))
+	if err != nil {
+		return upload, err
+	}
+	err = json.Unmarshal(data, &upload)
+	return upload, err
+}
+
+// writeFile écrit dans un fichier temporaire puis le renomme en place. size < 0 : taille inconnue.
+func (s *LocalStore) writeFile(ctx context.Context, target string, r io.Reader, size int64) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Join(s.root, localTmpDir), "put-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	written, err := io.Copy(tmp, contextReader{ctx: ctx, r: r})
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if size >= 0 && written != size {
+		return fmt.Errorf("blobstore: %d octets reçus, %d annoncés", written, size)
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+// pruneDirs remonte de dir jusqu'à stop en supprimant les dossiers vides.
+func (s *LocalStore) pruneDirs(dir, stop string) {
+	for dir != stop && strings.HasPrefix(dir, stop) {
+		if err := os.Remove(dir); err != nil {
+			return // Dossier non vide (ou déjà supprimé)
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// sign calcule la signature d'un lien : bucket, clé et paramètres (hors signature), triés par Encode.
+func (s *LocalStore) sign(bucket, key string, q url.Values) string {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(bucket + "/" + key + "?" + q.Encode()))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func localObjectInfo(key string, info fs.FileInfo) ObjectInfo {
+	return ObjectInfo{
+		Key:          key,
+		Size:         info.Size(),
+		LastModified: info.ModTime(),
+		ContentType:  mime.TypeByExtension(path.Ext(key)),
+	}
+}
+
+func partName(number int) string {
+	return fmt.Sprintf("part-%05d", number)
+}
+
+func dirSize(dir string) int64 {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0
+	}
+	var total int64
+	for _, entry := range entries {
+		if entry.Name() == localUploadInfo {
+			continue
+		}
+		if info, err := entry.Info(); err == nil {
+			total += info.Size()
+		}
+	}
+	return total
+}
+
+func mapFSError(err error) error {
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%w: %v", ErrNotFound, err)
+	}
+	return err
+}
+
+// contextReader interrompt une copie longue quand le contexte est annulé.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
@@ -0,0 +1,236 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestStore(t *testing.T) *LocalStore {
+	t.Helper()
+	s, err := NewLocal(Config{LocalRoot: t.TempDir(), LocalURL: "http://blobs.test/blobs", LocalSecret: "secret"})
+	if err != nil {
+		t.Fatalf("NewLocal: %v", err)
+	}
+	return s
+}
+
+func put(t *testing.T, s BlobStore, key, data string) {
+	t.Helper()
+	if err := s.Put(context.Background(), "bucket", key, strings.NewReader(data), int64(len(data)), PutOptions{}); err != nil {
+		t.Fatalf("Put %s: %v", key, err)
+	}
+}
+
+func listKeys(t *testing.T, s BlobStore, prefix string, recursive bool) []string {
+	t.Helper()
+	var keys []string
+	for obj := range s.List(context.Background(), "bucket", prefix, recursive) {
+		if obj.Err != nil {
+			t.Fatalf("List %q: %v", prefix, obj.Err)
+		}
+		keys = append(keys, obj.Key)
+	}
+	return keys
+}
+
+func TestLocalObjectLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	put(t, s, "a.avif", "image")
+
+	r, err := s.Get(ctx, "bucket", "a.avif")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	data, _ := io.ReadAll(r)
+	_ = r.Close()
+	if string(data) != "image" {
+		t.Fatalf("Get = %q", data)
+	}
+
+	info, err := s.Stat(ctx, "bucket", "a.avif")
+	if err != nil || info.Size != 5 || info.ContentType != "image/avif" {
+		t.Fatalf("Stat = %+v, %v", info, err)
+	}
+
+	if err := s.Delete(ctx, "bucket", "a.avif"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := s.Delete(ctx, "bucket", "a.avif"); err != nil {
+		t.Fatalf("Delete d'un objet absent: %v", err)
+	}
+	if _, err := s.Get(ctx, "bucket", "a.avif"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get après Delete = %v, ErrNotFound attendu", err)
+	}
+	if _, err := s.Stat(ctx, "bucket", "a.avif"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Stat après Delete = %v, ErrNotFound attendu", err)
+	}
+}
+
+func TestLocalPutSizeMismatch(t *testing.T) {
+	s := newTestStore(t)
+	err := s.Put(context.Background(), "bucket", "a.avif", strings.NewReader("abc"), 10, PutOptions{})
+	if err == nil {
+		t.Fatal("Put avec une taille erronée accepté")
+	}
+	if _, err := s.Stat(context.Background(), "bucket", "a.avif"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("objet partiel visible : %v", err)
+	}
+}
+
+func TestLocalRejectsEscapingKeys(t *testing.T) {
+	s := newTestStore(t)
+	for _, key := range []string{"../x", "a/../../x", "/abs", "a//b", "dir/", ""} {
+		err := s.Put(context.Background(), "bucket", key, strings.NewReader("x"), 1, PutOptions{})
+		if !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Put %q = %v, ErrInvalidKey attendu", key, err)
+		}
+	}
+	if err := s.Put(context.Background(), ".uploads", "x", strings.NewReader("x"), 1, PutOptions{}); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("bucket interne accepté : %v", err)
+	}
+}
+
+func TestLocalList(t *testing.T) {
+	s := newTestStore(t)
+	for _, key := range []string{"a.avif", "a_640.avif", "b.jpg", "video/x/index.m3u8", "video/x/seg0.ts", "staging/1"} {
+		put(t, s, key, "data")
+	}
+
+	cases := []struct {
+		prefix    string
+		recursive bool
+		want      string
+	}{
+		{"", false, "a.avif a_640.avif b.jpg staging/ video/"},
+		{"a", false, "a.avif a_640.avif"},
+		{"video/", true, "video/x/index.m3u8 video/x/seg0.ts"},
+		{"video/x/s", true, "video/x/seg0.ts"},
+		{"", true, "a.avif a_640.avif b.jpg staging/1 video/x/index.m3u8 video/x/seg0.ts"},
+		{"missing/", true, ""},
+	}
+	for _, c := range cases {
+		if got := strings.Join(listKeys(t, s, c.prefix, c.recursive), " "); got != c.want {
+			t.Errorf("List(%q, %v) = %q, attendu %q", c.prefix, c.recursive, got, c.want)
+		}
+	}
+
+	// Supprimer le dernier objet d'un dossier fait disparaître le préfixe commun
+	_ = s.Delete(context.Background(), "bucket", "staging/1")
+	if got := strings.Join(listKeys(t, s, "", false), " "); strings.Contains(got, "staging/") {
+		t.Errorf("dossier vide encore listé : %q", got)
+	}
+}
+
+func TestLocalCopy(t *testing.T) {
+	s := newTestStore(t)
+	put(t, s, "a.avif", "image")
+	if err := Copy(context.Background(), s, "bucket", "a.avif", "trash/a.avif"); err != nil {
+		t.Fatalf("Copy: %v", err)
+	}
+	if info, err := s.Stat(context.Background(), "bucket", "trash/a.avif"); err != nil || info.Size != 5 {
+		t.Fatalf("copie = %+v, %v", info, err)
+	}
+}
+
+func TestLocalMultipart(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	id, err := s.CreateMultipart(ctx, "bucket", "staging/42", "video/mp4")
+	if err != nil {
+		t.Fatalf("CreateMultipart: %v", err)
+	}
+	var parts []Part
+	for i, chunk := range []string{"hello ", "multipart ", "world"} {
+		etag, err := s.PutPart(ctx, "bucket", "staging/42", id, i+1, strings.NewReader(chunk), int64(len(chunk)))
+		if err != nil {
+			t.Fatalf("PutPart %d: %v", i+1, err)
+		}
+		parts = append(parts, Part{Number: i + 1, ETag: etag})
+	}
+	if _, err := s.PutPart(ctx, "bucket", "staging/other", id, 1, strings.NewReader("x"), 1); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("PutPart sur une autre clé = %v, ErrNotFound attendu", err)
+	}
+
+	var uploads []UploadInfo
+	for u := range s.ListMultipart(ctx, "bucket", "staging/") {
+		uploads = append(uploads, u)
+	}
+	if len(uploads) != 1 || uploads[0].UploadID != id || uploads[0].Size != 21 {
+		t.Fatalf("ListMultipart = %+v", uploads)
+	}
+
+	if err := s.CompleteMultipart(ctx, "bucket", "staging/42", id, parts); err != nil {
+		t.Fatalf("CompleteMultipart: %v", err)
+	}
+	r, err := s.Get(ctx, "bucket", "staging/42")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	data, _ := io.ReadAll(r)
+	_ = r.Close()
+	if string(data) != "hello multipart world" {
+		t.Fatalf("objet assemblé = %q", data)
+	}
+	for range s.ListMultipart(ctx, "bucket", "") {
+		t.Fatal("upload terminé encore listé")
+	}
+
+	id, _ = s.CreateMultipart(ctx, "bucket", "staging/43", "video/mp4")
+	if err := s.AbortMultipart(ctx, "bucket", "staging/43", id); err != nil {
+		t.Fatalf("AbortMultipart: %v", err)
+	}
+	if err := s.CompleteMultipart(ctx, "bucket", "staging/43", id, nil); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("CompleteMultipart après abandon = %v, ErrNotFound attendu", err)
+	}
+}
+
+func TestLocalPresign(t *testing.T) {
+	s := newTestStore(t)
+	put(t, s, "private/exports/1/2.zip", "archive")
+
+	params := url.Values{}
+	params.Set("response-content-disposition", `attachment; filename="export.zip"`)
+	signed, err := s.Presign(context.Background(), "bucket", "private/exports/1/2.zip", time.Minute, params)
+	if err != nil {
+		t.Fatalf("Presign: %v", err)
+	}
+	if signed.Host != "blobs.test" || signed.Path != "/blobs/bucket/private/exports/1/2.zip" {
+		t.Fatalf("lien = %s", signed)
+	}
+
+	serve := func(target string) *httptest.ResponseRecorder {
+		u, _ := url.Parse(target)
+		req := httptest.NewRequest(http.MethodGet, u.RequestURI(), nil)
+		rec := httptest.NewRecorder()
+		http.StripPrefix("/blobs", s).ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve(signed.String())
+	if rec.Code != http.StatusOK || !bytes.Equal(rec.Body.Bytes(), []byte("archive")) {
+		t.Fatalf("lien valide : %d %q", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Content-Disposition"); got != `attachment; filename="export.zip"` {
+		t.Errorf("Content-Disposition = %q", got)
+	}
+
+	// Clé changée : la signature ne couvre plus le lien
+	if rec := serve(strings.Replace(signed.String(), "2.zip", "3.zip", 1)); rec.Code != http.StatusForbidden {
+		t.Errorf("lien falsifié : %d, 403 attendu", rec.Code)
+	}
+
+	expired, _ := s.Presign(context.Background(), "bucket", "private/exports/1/2.zip", -time.Minute, nil)
+	if rec := serve(expired.String()); rec.Code != http.StatusForbidden {
+		t.Errorf("lien expiré : %d, 403 attendu", rec.Code)
+	}
+}
@@ -0,0 +1,197 @@
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+// ============================================================================
+// PILOTE MINIO / S3
+// ============================================================================
+
+// MinioStore réalise BlobStore, Copier, MultipartStore et Expirer sur un serveur compatible S3.
+type MinioStore struct {
+	client *minio.Client
+}
+
+// NewMinio ouvre un client S3. Dans le réseau Docker, l'API parle à MinIO en HTTP (UseSSL à false) :
+// c'est Nginx, en frontal, qui gère le HTTPS.
+func NewMinio(cfg Config) (*MinioStore, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la création du client MinIO: %w", err)
+	}
+	return &MinioStore{client: client}, nil
+}
+
+// BucketExists vérifie la présence d'un bucket (contrôle au démarrage).
+func (s *MinioStore) BucketExists(ctx context.Context, bucket string) (bool, error) {
+	return s.client.BucketExists(ctx, bucket)
+}
+
+func (s *MinioStore) Put(ctx context.Context, bucket, key string, r io.Reader, size int64, opts PutOptions) error {
+	_, err := s.client.PutObject(ctx, bucket, key, r, size,
+		minio.PutObjectOptions{ContentType: opts.ContentType, UserMetadata: opts.Metadata})
+	return err
+}
+
+func (s *MinioStore) Get(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	obj, err := s.client.GetObject(ctx, bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, mapMinioError(err)
+	}
+	// Stat force la requête : un objet absent échoue ici plutôt qu'à la première lecture
+	if _, err := obj.Stat(); err != nil {
+		_ = obj.Close()
+		return nil, mapMinioError(err)
+	}
+	return obj, nil
+}
+
+func (s *MinioStore) Stat(ctx context.Context, bucket, key string) (ObjectInfo, error) {
+	info, err := s.client.StatObject(ctx, bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		return ObjectInfo{}, mapMinioError(err)
+	}
+	return objectInfo(info), nil
+}
+
+func (s *MinioStore) Delete(ctx context.Context, bucket, key string) error {
+	return s.client.RemoveObject(ctx, bucket, key, minio.RemoveObjectOptions{})
+}
+
+func (s *MinioStore) List(ctx context.Context, bucket, prefix string, recursive bool) <-chan ObjectInfo {
+	out := make(chan ObjectInfo)
+	go func() {
+		defer close(out)
+		for obj := range s.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: recursive}) {
+			select {
+			case out <- objectInfo(obj):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func (s *MinioStore) Presign(ctx context.Context, bucket, key string, ttl time.Duration, params url.Values) (*url.URL, error) {
+	return s.client.PresignedGetObject(ctx, bucket, key, ttl, params)
+}
+
+func (s *MinioStore) Copy(ctx context.Context, bucket, srcKey, dstKey string) error {
+	_, err := s.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: bucket, Object: dstKey},
+		minio.CopySrcOptions{Bucket: bucket, Object: srcKey})
+	return mapMinioError(err)
+}
+
+// --- MULTIPART ---
+
+func (s *MinioStore) core() minio.Core {
+	return minio.Core{Client: s.client}
+}
+
+func (s *MinioStore) CreateMultipart(ctx context.Context, bucket, key, contentType string) (string, error) {
+	return s.core().NewMultipartUpload(ctx, bucket, key, minio.PutObjectOptions{ContentType: contentType})
+}
+
+func (s *MinioStore) PutPart(ctx context.Context, bucket, key, uploadID string, number int, r io.Reader, size int64) (string, error) {
+	part, err := s.core().PutObjectPart(ctx, bucket, key, uploadID, number, r, size, minio.PutObjectPartOptions{})
+	if err != nil {
+		return "", err
+	}
+	return part.ETag, nil
+}
+
+func (s *MinioStore) CompleteMultipart(ctx context.Context, bucket, key, uploadID string, parts []Part) error {
+	complete := make([]minio.CompletePart, len(parts))
+	for i, p := range parts {
+		complete[i] = minio.CompletePart{PartNumber: p.Number, ETag: p.ETag}
+	}
+	_, err := s.core().CompleteMultipartUpload(ctx, bucket, key, uploadID, complete, minio.PutObjectOptions{})
+	return err
+}
+
+func (s *MinioStore) AbortMultipart(ctx context.Context, bucket, key, uploadID string) error {
+	return s.core().AbortMultipartUpload(ctx, bucket, key, uploadID)
+}
+
+func (s *MinioStore) ListMultipart(ctx context.Context, bucket, prefix string) <-chan UploadInfo {
+	out := make(chan UploadInfo)
+	go func() {
+		defer close(out)
+		for upload := range s.client.ListIncompleteUploads(ctx, bucket, prefix, true) {
+			info := UploadInfo{Key: upload.Key, UploadID: upload.UploadID, Initiated: upload.Initiated, Size: upload.Size, Err: upload.Err}
+			select {
+			case out <- info:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// --- CYCLE DE VIE ---
+
+// ExpirePrefix installe (ou remplace) la règle ruleID sans toucher aux autres règles du bucket.
+func (s *MinioStore) ExpirePrefix(ctx context.Context, bucket, ruleID, prefix string, days int) error {
+	config, err := s.client.GetBucketLifecycle(ctx, bucket)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code != "NoSuchLifecycleConfiguration" {
+			return err
+		}
+		config = lifecycle.NewConfiguration()
+	}
+
+	rule := lifecycle.Rule{
+		ID:         ruleID,
+		Status:     "Enabled",
+		RuleFilter: lifecycle.Filter{Prefix: prefix},
+		Expiration: lifecycle.Expiration{Days: lifecycle.ExpirationDays(days)},
+	}
+	for i, existing := range config.Rules {
+		if existing.ID == ruleID {
+			config.Rules[i] = rule
+			return s.client.SetBucketLifecycle(ctx, bucket, config)
+		}
+	}
+	config.Rules = append(config.Rules, rule)
+	return s.client.SetBucketLifecycle(ctx, bucket, config)
+}
+
+// --- HELPERS ---
+
+func objectInfo(info minio.ObjectInfo) ObjectInfo {
+	return ObjectInfo{
+		Key:          info.Key,
+		Size:         info.Size,
+		LastModified: info.LastModified,
+		ContentType:  info.ContentType,
+		ETag:         info.ETag,
+		Err:          mapMinioError(info.Err),
+	}
+}
+
+// mapMinioError traduit les codes S3 d'objet ou de bucket absent en ErrNotFound.
+func mapMinioError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch minio.ToErrorResponse(err).Code {
+	case minio.NoSuchKey, minio.NoSuchBucket:
+		return fmt.Errorf("%w: %v", ErrNotFound, err)
+	}
+	return err
+}
@@ -0,0 +1,133 @@
+package imagemeta
+
+import (
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Tags lus (tout autre tag est ignoré, y compris le pointeur GPS 0x8825)
+const (
+	tagOrientation        = 0x0112
+	tagDateTime           = 0x0132
+	tagExifIFD            = 0x8769
+	tagDateTimeOriginal   = 0x9003
+	tagOffsetTimeOriginal = 0x9011
+)
+
+const (
+	typeASCII = 2
+	typeShort = 3
+	typeLong  = 4
+
+	maxIFDEntries = 512 // Garde-fou contre un EXIF forgé
+)
+
+var errMalformed = errors.New("exif mal formé")
+
+// ifdEntry est une entrée brute d'un répertoire TIFF.
+type ifdEntry struct {
+	typ   uint16
+	count uint32
+	value []byte // 4 octets : la valeur elle-même ou le décalage vers elle
+}
+
+// tiffReader lit un bloc TIFF en bornant chaque accès.
+type tiffReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+// parseTIFF lit l'orientation (IFD0) et l'instant de prise de vue (IFD Exif) d'un bloc TIFF.
+func parseTIFF(b []byte) (Metadata, error) {
+	var meta Metadata
+	if len(b) < 8 {
+		return meta, errMalformed
+	}
+	r := tiffReader{data: b}
+	switch string(b[:4]) {
+	case "II*\x00":
+		r.order = binary.LittleEndian
+	case "MM\x00*":
+		r.order = binary.BigEndian
+	default:
+		return meta, errMalformed
+	}
+
+	ifd0, err := r.readIFD(r.order.Uint32(b[4:8]))
+	if err != nil {
+		return meta, err
+	}
+	if e, ok := ifd0[tagOrientation]; ok && e.typ == typeShort {
+		meta.Orientation = int(r.order.Uint16(e.value))
+	}
+
+	dateTime, offset := r.ascii(ifd0[tagDateTime]), ""
+	if e, ok := ifd0[tagExifIFD]; ok && e.typ == typeLong {
+		if sub, err := r.readIFD(r.order.Uint32(e.value)); err == nil {
+			if original := r.ascii(sub[tagDateTimeOriginal]); original != "" {
+				dateTime = original
+			}
+			offset = r.ascii(sub[tagOffsetTimeOriginal])
+		}
+	}
+	meta.CapturedAt = parseExifTime(dateTime, offset)
+	return meta, nil
+}
+
+// readIFD lit les entrées d'un répertoire situé à off.
+func (r tiffReader) readIFD(off uint32) (map[uint16]ifdEntry, error) {
+	if uint64(off)+2 > uint64(len(r.data)) {
+		return nil, errMalformed
+	}
+	n := int(r.order.Uint16(r.data[off:]))
+	if n > maxIFDEntries || uint64(off)+2+uint64(n)*12 > uint64(len(r.data)) {
+		return nil, errMalformed
+	}
+	entries := make(map[uint16]ifdEntry, n)
+	for i := 0; i < n; i++ {
+		e := r.data[int(off)+2+i*12:]
+		entries[r.order.Uint16(e)] = ifdEntry{
+			typ:   r.order.Uint16(e[2:]),
+			count: r.order.Uint32(e[4:]),
+			value: e[8:12],
+		}
+	}
+	return entries, nil
+}
+
+// ascii renvoie la valeur texte d'une entrée ("" si absente ou hors limites).
+func (r tiffReader) ascii(e ifdEntry) string {
+	if e.typ != typeASCII || e.count == 0 {
+		return ""
+	}
+	var raw []byte
+	if e.count <= 4 {
+		raw = e.value[:e.count]
+	} else {
+		off := r.order.Uint32(e.value)
+		if uint64(off)+uint64(e.count) > uint64(len(r.data)) {
+			return ""
+		}
+		raw = r.data[off : off+e.count]
+	}
+	return strings.TrimRight(string(raw), "\x00 ")
+}
+
+// parseExifTime lit "2006:01:02 15:04:05" ; sans décalage horaire connu, l'heure est prise en UTC.
+func parseExifTime(value, offset string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	if offset != "" {
+		if t, err := time.Parse("2006:01:02 15:04:05-07:00", value+offset); err == nil {
+			return t.UTC()
+		}
+	}
+	t, err := time.Parse("2006:01:02 15:04:05", value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
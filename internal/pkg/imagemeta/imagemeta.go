@@ -0,0 +1,116 @@
+package imagemeta
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"time"
+
+	"github.com/disintegration/imaging"
+	"github.com/jdeng/goheif"
+)
+
+// ============================================================================
+// MÉTADONNÉES D'IMAGE (EXIF)
+// ============================================================================
+// Les métadonnées d'un original ne sont jamais conservées telles quelles : seuls l'orientation et
+// l'instant de prise de vue sont lus (liste blanche), tout le reste (GPS, modèle et numéro de série de
+// l'appareil, logiciel, miniatures...) est ignoré à la lecture puis retiré des octets par Strip.
+// Formats couverts : JPEG, PNG, WebP, TIFF et HEIC (noms renvoyés par image.DecodeConfig).
+
+// ErrNotStrippable signale un format dont les métadonnées font partie de la structure du fichier
+// (TIFF, HEIC) : l'image doit être décodée puis réencodée pour les perdre.
+var ErrNotStrippable = errors.New("métadonnées non séparables du format")
+
+// Orientations EXIF (tag 0x0112). 1 = déjà droite ; 5 à 8 échangent largeur et hauteur.
+const (
+	OrientationNormal     = 1
+	OrientationFlipH      = 2
+	OrientationRotate180  = 3
+	OrientationFlipV      = 4
+	OrientationTranspose  = 5
+	OrientationRotate90   = 6 // Rotation horaire nécessaire (portrait pris téléphone à la verticale)
+	OrientationTransverse = 7
+	OrientationRotate270  = 8
+)
+
+// Metadata regroupe les seuls champs lus d'un original.
+type Metadata struct {
+	Orientation int       // 1 à 8 (1 si absente ou invalide)
+	CapturedAt  time.Time // DateTimeOriginal (ou DateTime) ; zéro si absente
+}
+
+// Read extrait les métadonnées utiles d'un fichier image. Une image sans EXIF, ou dont l'EXIF est
+// illisible, renvoie des métadonnées neutres : l'absence de métadonnées n'est jamais une erreur.
+func Read(data []byte, format string) Metadata {
+	meta := Metadata{Orientation: OrientationNormal}
+
+	block := exifBlock(data, format)
+	if block == nil {
+		return meta
+	}
+	parsed, err := parseTIFF(block)
+	if err != nil {
+		return meta
+	}
+	if parsed.Orientation >= OrientationNormal && parsed.Orientation <= OrientationRotate270 {
+		meta.Orientation = parsed.Orientation
+	}
+	meta.CapturedAt = parsed.CapturedAt
+	return meta
+}
+
+// SwapsAxes indique si l'orientation échange largeur et hauteur.
+func SwapsAxes(orientation int) bool {
+	return orientation >= OrientationTranspose && orientation <= OrientationRotate270
+}
+
+// Orient applique l'orientation EXIF aux pixels : l'image renvoyée s'affiche droite sans métadonnée.
+func Orient(img image.Image, orientation int) image.Image {
+	switch orientation {
+	case OrientationFlipH:
+		return imaging.FlipH(img)
+	case OrientationRotate180:
+		return imaging.Rotate180(img)
+	case OrientationFlipV:
+		return imaging.FlipV(img)
+	case OrientationTranspose:
+		return imaging.Transpose(img)
+	case OrientationRotate90:
+		return imaging.Rotate270(img) // imaging tourne dans le sens antihoraire
+	case OrientationTransverse:
+		return imaging.Transverse(img)
+	case OrientationRotate270:
+		return imaging.Rotate90(img)
+	}
+	return img
+}
+
+// exifBlock localise le bloc TIFF de l'EXIF selon le conteneur (nil si absent).
+func exifBlock(data []byte, format string) []byte {
+	switch format {
+	case "jpeg":
+		return jpegExif(data)
+	case "png":
+		return pngExif(data)
+	case "webp":
+		return webpExif(data)
+	case "tiff":
+		return data
+	case "heic":
+		raw, err := goheif.ExtractExif(bytes.NewReader(data))
+		if err != nil {
+			return nil
+		}
+		return trimExifHeader(raw)
+	}
+	return nil
+}
+
+// trimExifHeader retire l'en-tête "Exif\0\0" qui précède parfois le bloc TIFF (APP1, HEIC, WebP).
+func trimExifHeader(b []byte) []byte {
+	if bytes.HasPrefix(b, exifHeader) {
+		return b[len(exifHeader):]
+	}
+	return b
+}
@@ -0,0 +1,259 @@
+package imagemeta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+var (
+	exifHeader = []byte("Exif\x00\x00")
+	mpfHeader  = []byte("MPF\x00")
+)
+
+var (
+	pngSignature = []byte("\x89PNG\r\n\x1a\n")
+	errTruncated = errors.New("fichier image tronqué")
+)
+
+// Strip renvoie les octets de l'image sans aucune métadonnée (EXIF, XMP, IPTC, commentaires). Les
+// pixels ne sont pas touchés ; le profil de couleur est conservé. L'orientation étant perdue avec
+// l'EXIF, elle doit avoir été lue au préalable (Read) et appliquée au rendu.
+// Renvoie ErrNotStrippable pour TIFF et HEIC.
+func Strip(data []byte, format string) ([]byte, error) {
+	switch format {
+	case "jpeg":
+		return stripJPEG(data)
+	case "png":
+		return stripPNG(data)
+	case "webp":
+		return stripWebP(data)
+	}
+	return nil, ErrNotStrippable
+}
+
+// --- JPEG ---
+
+// Segments conservés : JFIF (APP0), profil ICC (APP2), transformée Adobe (APP14) et segments non APPn.
+// Tous les autres APPn (EXIF/XMP en APP1, IPTC en APP13, notes constructeur) et les commentaires sautent.
+// L'APP2 "MPF" (index des images secondaires des photos de téléphone) saute aussi : ces images sont retirées.
+func keepJPEGSegment(marker byte, segment []byte) bool {
+	switch {
+	case marker == 0xE2:
+		return !bytes.HasPrefix(segment[4:], mpfHeader)
+	case marker == 0xE0, marker == 0xEE:
+		return true
+	case marker >= 0xE0 && marker <= 0xEF, marker == 0xFE:
+		return false
+	}
+	return true
+}
+
+// jpegSegments parcourt les segments précédant les données compressées (SOS) et appelle fn pour
+// chacun (marqueur + segment complet). Renvoie le décalage du SOS.
+func jpegSegments(data []byte, fn func(marker byte, segment []byte)) (int, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 0, errTruncated
+	}
+	pos := 2
+	for {
+		if pos+4 > len(data) || data[pos] != 0xFF {
+			return 0, errTruncated
+		}
+		marker := data[pos+1]
+		if marker == 0xFF { // Octet de remplissage
+			pos++
+			continue
+		}
+		if marker == 0xDA {
+			return pos, nil
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if length < 2 || pos+2+length > len(data) {
+			return 0, errTruncated
+		}
+		fn(marker, data[pos:pos+2+length])
+		pos += 2 + length
+	}
+}
+
+func jpegExif(data []byte) []byte {
+	var block []byte
+	_, _ = jpegSegments(data, func(marker byte, segment []byte) {
+		if block == nil && marker == 0xE1 && bytes.HasPrefix(segment[4:], exifHeader) {
+			block = segment[4+len(exifHeader):]
+		}
+	})
+	return block
+}
+
+// stripJPEG ne conserve que l'image principale : la copie s'arrête à son EOI. Les données ajoutées
+// au-delà (images secondaires MPF, cartes de profondeur, aperçus) portent leur propre EXIF, GPS compris.
+func stripJPEG(data []byte) ([]byte, error) {
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:2])
+	sos, err := jpegSegments(data, func(marker byte, segment []byte) {
+		if keepJPEGSegment(marker, segment) {
+			out.Write(segment)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := copyJPEGScans(out, data, sos); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// copyJPEGScans recopie les données compressées depuis le premier SOS jusqu'à l'EOI inclus. Les segments
+// intercalés entre les passes d'une image progressive (DHT, SOS...) suivent le même filtre que l'en-tête.
+func copyJPEGScans(out *bytes.Buffer, data []byte, pos int) error {
+	start := pos
+	for pos+1 < len(data) {
+		if data[pos] != 0xFF {
+			pos++
+			continue
+		}
+		marker := data[pos+1]
+		switch {
+		case marker == 0xFF: // Octet de remplissage
+			pos++
+		case marker == 0x00, marker >= 0xD0 && marker <= 0xD7: // Octet échappé, marqueur de resynchronisation
+			pos += 2
+		case marker == 0xD9: // EOI : fin de l'image principale
+			out.Write(data[start : pos+2])
+			return nil
+		default:
+			if pos+4 > len(data) {
+				return errTruncated
+			}
+			length := int(binary.BigEndian.Uint16(data[pos+2:]))
+			if length < 2 || pos+2+length > len(data) {
+				return errTruncated
+			}
+			segment := data[pos : pos+2+length]
+			out.Write(data[start:pos])
+			if keepJPEGSegment(marker, segment) {
+				out.Write(segment)
+			}
+			pos += 2 + length
+			start = pos
+		}
+	}
+	return errTruncated
+}
+
+// --- PNG ---
+
+// Blocs retirés : EXIF, textes libres (XMP inclus, en iTXt) et date de modification.
+var pngMetadataChunks = map[string]bool{"eXIf": true, "tEXt": true, "zTXt": true, "iTXt": true, "tIME": true}
+
+// pngChunks appelle fn pour chaque bloc (type, données, bloc complet avec longueur et CRC).
+func pngChunks(data []byte, fn func(typ string, payload, chunk []byte)) error {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return errTruncated
+	}
+	pos := len(pngSignature)
+	for pos < len(data) {
+		if pos+8 > len(data) {
+			return errTruncated
+		}
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		end := pos + 12 + length
+		if end > len(data) {
+			return errTruncated
+		}
+		typ := string(data[pos+4 : pos+8])
+		fn(typ, data[pos+8:pos+8+length], data[pos:end])
+		pos = end
+		if typ == "IEND" {
+			break
+		}
+	}
+	return nil
+}
+
+func pngExif(data []byte) []byte {
+	var block []byte
+	_ = pngChunks(data, func(typ string, payload, _ []byte) {
+		if typ == "eXIf" && block == nil {
+			block = payload
+		}
+	})
+	return block
+}
+
+func stripPNG(data []byte) ([]byte, error) {
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(pngSignature)
+	err := pngChunks(data, func(typ string, _, chunk []byte) {
+		if !pngMetadataChunks[typ] {
+			out.Write(chunk)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// --- WebP ---
+
+const (
+	webpFlagXMP  = 0x04 // Bits de l'en-tête VP8X annonçant les blocs retirés
+	webpFlagEXIF = 0x08
+)
+
+// webpChunks appelle fn pour chaque bloc RIFF (FourCC, données, bloc complet avec remplissage).
+func webpChunks(data []byte, fn func(fourCC string, payload, chunk []byte)) error {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return errTruncated
+	}
+	pos := 12
+	for pos+8 <= len(data) {
+		size := int(binary.LittleEndian.Uint32(data[pos+4:]))
+		if pos+8+size > len(data) {
+			return errTruncated
+		}
+		end := min(pos+8+size+size&1, len(data))
+		fn(string(data[pos:pos+4]), data[pos+8:pos+8+size], data[pos:end])
+		pos = end
+	}
+	return nil
+}
+
+func webpExif(data []byte) []byte {
+	var block []byte
+	_ = webpChunks(data, func(fourCC string, payload, _ []byte) {
+		if fourCC == "EXIF" && block == nil {
+			block = trimExifHeader(payload)
+		}
+	})
+	return block
+}
+
+func stripWebP(data []byte) ([]byte, error) {
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:12])
+	err := webpChunks(data, func(fourCC string, _, chunk []byte) {
+		switch fourCC {
+		case "EXIF", "XMP ":
+			return
+		case "VP8X":
+			if len(chunk) > 8 {
+				start := out.Len()
+				out.Write(chunk)
+				out.Bytes()[start+8] &^= webpFlagEXIF | webpFlagXMP
+				return
+			}
+		}
+		out.Write(chunk)
+	})
+	if err != nil {
+		return nil, err
+	}
+	stripped := out.Bytes()
+	binary.LittleEndian.PutUint32(stripped[4:], uint32(len(stripped)-8))
+	return stripped, nil
+}
@@ -0,0 +1,129 @@
+package imagemeta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+// Le numéro de série est rangé dans le tag Make : seule sa présence dans les octets compte ici.
+const testSerial = "GPS-CAM-SN-0042"
+
+// testExif construit un bloc EXIF (en-tête "Exif\0\0" + TIFF little-endian) : orientation 6, numéro de
+// série de l'appareil et pointeur vers un IFD GPS (latitude 48° N).
+func testExif() []byte {
+	le := binary.LittleEndian
+	tiff := []byte("II*\x00")
+	tiff = le.AppendUint32(tiff, 8)
+
+	// IFD0 (8) : 3 entrées, puis le numéro de série (50) et l'IFD GPS (66)
+	const serialOff, gpsOff = 50, 66
+	tiff = le.AppendUint16(tiff, 3)
+	tiff = appendEntry(tiff, 0x010F, typeASCII, uint32(len(testSerial)+1), serialOff)
+	tiff = appendEntry(tiff, tagOrientation, typeShort, 1, OrientationRotate90)
+	tiff = appendEntry(tiff, 0x8825, typeLong, 1, gpsOff)
+	tiff = le.AppendUint32(tiff, 0)
+	tiff = append(tiff, testSerial+"\x00"...)
+
+	// IFD GPS : GPSLatitudeRef "N", GPSLatitude (3 rationnels à 96)
+	tiff = le.AppendUint16(tiff, 2)
+	tiff = appendEntry(tiff, 0x0001, typeASCII, 2, uint32('N'))
+	tiff = appendEntry(tiff, 0x0002, 5, 3, 96)
+	tiff = le.AppendUint32(tiff, 0)
+	for _, v := range []uint32{48, 1, 51, 1, 24, 1} {
+		tiff = le.AppendUint32(tiff, v)
+	}
+	return append(append([]byte{}, exifHeader...), tiff...)
+}
+
+func appendEntry(b []byte, tag, typ uint16, count, value uint32) []byte {
+	b = binary.LittleEndian.AppendUint16(b, tag)
+	b = binary.LittleEndian.AppendUint16(b, typ)
+	b = binary.LittleEndian.AppendUint32(b, count)
+	return binary.LittleEndian.AppendUint32(b, value)
+}
+
+// appSegment encode un segment APPn (marqueur, longueur, charge utile).
+func appSegment(marker byte, payload []byte) []byte {
+	seg := []byte{0xFF, marker}
+	seg = binary.BigEndian.AppendUint16(seg, uint16(len(payload)+2))
+	return append(seg, payload...)
+}
+
+// phoneJPEG imite une photo de téléphone : EXIF avec GPS, profil ICC, index MPF et, après l'EOI de
+// l'image principale, une image secondaire (carte de profondeur) portant son propre EXIF.
+func phoneJPEG(t *testing.T) []byte {
+	t.Helper()
+	encode := func(w, h int) []byte {
+		img := image.NewGray(image.Rect(0, 0, w, h))
+		for i := range img.Pix {
+			img.Pix[i] = uint8(i * 7)
+		}
+		img.SetGray(0, 0, color.Gray{Y: 255})
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	withSegments := func(jpg []byte, segments ...[]byte) []byte {
+		out := append([]byte{}, jpg[:2]...)
+		for _, s := range segments {
+			out = append(out, s...)
+		}
+		return append(out, jpg[2:]...)
+	}
+
+	exif := appSegment(0xE1, testExif())
+	icc := appSegment(0xE2, []byte("ICC_PROFILE\x00\x01\x01profile"))
+	mpf := appSegment(0xE2, []byte("MPF\x00II*\x00\x08\x00\x00\x00"))
+
+	primary := withSegments(encode(32, 24), exif, icc, mpf)
+	depth := withSegments(encode(8, 8), exif)
+	return append(primary, depth...)
+}
+
+func TestStripJPEGRemovesGPSAndSecondaryImages(t *testing.T) {
+	data := phoneJPEG(t)
+	if got := Read(data, "jpeg").Orientation; got != OrientationRotate90 {
+		t.Fatalf("EXIF de test illisible : orientation %d", got)
+	}
+
+	stripped, err := Strip(data, "jpeg")
+	if err != nil {
+		t.Fatalf("Strip: %v", err)
+	}
+	for _, leak := range []string{"Exif", "GPS", "MPF", testSerial} {
+		if bytes.Contains(stripped, []byte(leak)) {
+			t.Errorf("%q survit au nettoyage", leak)
+		}
+	}
+	if !bytes.Contains(stripped, []byte("ICC_PROFILE")) {
+		t.Error("profil ICC retiré")
+	}
+	if !bytes.HasSuffix(stripped, []byte{0xFF, 0xD9}) || bytes.Count(stripped, []byte{0xFF, 0xD8}) != 1 {
+		t.Error("l'image secondaire ajoutée après l'EOI est conservée")
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(stripped))
+	if err != nil {
+		t.Fatalf("image nettoyée illisible : %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 32 || b.Dy() != 24 {
+		t.Errorf("dimensions = %v, 32x24 attendu", b)
+	}
+	if got := Read(stripped, "jpeg").Orientation; got != OrientationNormal {
+		t.Errorf("orientation = %d après nettoyage", got)
+	}
+}
+
+func TestStripJPEGTruncated(t *testing.T) {
+	data := phoneJPEG(t)
+	end := bytes.Index(data, []byte{0xFF, 0xD9})
+	if _, err := Strip(data[:end], "jpeg"); err == nil {
+		t.Error("JPEG sans EOI accepté")
+	}
+}
@@ -0,0 +1,114 @@
+// Package mediaurl définit les liens signés du micro-service de tatouage : format de la chaîne signée,
+// vérification et périmètres de révocation. Partagé par l'API (émission) et le micro-service (contrôle),
+// il garantit que les deux côtés signent exactement la même chaîne.
+package mediaurl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrMissing   = errors.New("mediaurl: paramètres manquants")
+	ErrMalformed = errors.New("mediaurl: paramètres invalides")
+	ErrSignature = errors.New("mediaurl: signature invalide")
+)
+
+// Claims décrit un lien : le média servi, le contexte tatoué (auteur, post, lecteur, émission) et l'échéance.
+type Claims struct {
+	Key       string // Chemin MinIO du média vierge
+	AuthorID  int64
+	PostID    int64 // 0 : média hors post (avatar)
+	ReaderID  int64
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// Payload renvoie la chaîne signée. L'ordre des paramètres fait partie du contrat.
+func (c Claims) Payload() string {
+	return fmt.Sprintf("key=%s&author=%d&post_service=%d&reader=%d&ts=%d&exp=%d",
+		c.Key, c.AuthorID, c.PostID, c.ReaderID, c.IssuedAt.Unix(), c.ExpiresAt.Unix())
+}
+
+// Sign renvoie la requête complète du lien (chaîne signée suivie de sa signature).
+func Sign(c Claims, secret string) string {
+	payload := c.Payload()
+	return payload + "&sig=" + signature(payload, secret)
+}
+
+// Verify contrôle la signature d'une requête et en extrait le lien. L'échéance n'est pas vérifiée ici :
+// le micro-service distingue un lien expiré (410) d'un lien forgé (403).
+func Verify(q url.Values, secret string) (Claims, error) {
+	names := []string{"key", "author", "post_service", "reader", "ts", "exp", "sig"}
+	for _, name := range names {
+		if q.Get(name) == "" {
+			return Claims{}, ErrMissing
+		}
+	}
+
+	// La chaîne est reconstruite telle que reçue : un identifiant non numérique échoue à la signature
+	payload := fmt.Sprintf("key=%s&author=%s&post_service=%s&reader=%s&ts=%s&exp=%s",
+		q.Get("key"), q.Get("author"), q.Get("post_service"), q.Get("reader"), q.Get("ts"), q.Get("exp"))
+	if !hmac.Equal([]byte(q.Get("sig")), []byte(signature(payload, secret))) {
+		return Claims{}, ErrSignature
+	}
+
+	var values [5]int64
+	for i, name := range names[1:6] {
+		v, err := strconv.ParseInt(q.Get(name), 10, 64)
+		if err != nil {
+			return Claims{}, ErrMalformed
+		}
+		values[i] = v
+	}
+	return Claims{
+		Key:       q.Get("key"),
+		AuthorID:  values[0],
+		PostID:    values[1],
+		ReaderID:  values[2],
+		IssuedAt:  time.Unix(values[3], 0),
+		ExpiresAt: time.Unix(values[4], 0),
+	}, nil
+}
+
+// ============================================================================
+// RÉVOCATION
+// ============================================================================
+// Une révocation enregistre son horodatage sous un périmètre : tout lien émis au plus tard à cet instant
+// et couvert par le périmètre est refusé. Quatre périmètres couvrent les changements de droits :
+// le post (passage en privé, suppression, retrait), l'auteur (compte masqué ou banni), le lecteur
+// (compte banni) et le couple auteur/lecteur (blocage, désabonnement).
+
+// PostScope, AuthorScope, ReaderScope et PairScope nomment les périmètres de révocation.
+func PostScope(postID int64) string   { return "post:" + strconv.FormatInt(postID, 10) }
+func AuthorScope(userID int64) string { return "author:" + strconv.FormatInt(userID, 10) }
+func ReaderScope(userID int64) string { return "reader:" + strconv.FormatInt(userID, 10) }
+func PairScope(authorID, readerID int64) string {
+	return fmt.Sprintf("pair:%d:%d", authorID, readerID)
+}
+
+// Scopes renvoie les périmètres qui couvrent un lien.
+func (c Claims) Scopes() []string {
+	scopes := []string{AuthorScope(c.AuthorID), ReaderScope(c.ReaderID), PairScope(c.AuthorID, c.ReaderID)}
+	if c.PostID != 0 {
+		scopes = append(scopes, PostScope(c.PostID))
+	}
+	return scopes
+}
+
+// RevokedBy indique si une révocation (horodatage Unix) couvre le lien.
+func (c Claims) RevokedBy(revokedAt int64) bool {
+	return revokedAt > 0 && c.IssuedAt.Unix() <= revokedAt
+}
+
+func signature(payload, secret string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(payload))
+	return hex.EncodeToString(h.Sum(nil))
+}
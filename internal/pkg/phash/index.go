@@ -0,0 +1,52 @@
+package phash
+
+import "fmt"
+
+// ============================================================================
+// INDEX MULTIPLE (Multi-Index Hashing)
+// ============================================================================
+// Le pHash est découpé en Bands tranches de 16 bits. Si deux empreintes sont à distance <= MaxIndexedDistance,
+// au moins une tranche diffère de probeRadius bits au plus (principe des tiroirs) : il suffit d'interroger,
+// pour chaque tranche, sa valeur exacte et ses voisines à 1 ou 2 bits (137 valeurs), puis de vérifier la
+// distance complète des candidats.
+
+const (
+	Bands              = 4
+	bandBits           = 64 / Bands
+	probeRadius        = 2
+	MaxIndexedDistance = (probeRadius+1)*Bands - 1 // Rappel garanti jusqu'à cette distance (11)
+)
+
+// BandKey identifie une tranche : "<rang>:<valeur hexadécimale>".
+type BandKey struct {
+	Band  int
+	Value uint16
+}
+
+func (k BandKey) String() string {
+	return fmt.Sprintf("%d:%04x", k.Band, k.Value)
+}
+
+// BandsOf découpe une empreinte en ses tranches.
+func BandsOf(h uint64) [Bands]BandKey {
+	var out [Bands]BandKey
+	for b := 0; b < Bands; b++ {
+		out[b] = BandKey{Band: b, Value: uint16(h >> uint(64-bandBits*(b+1)))}
+	}
+	return out
+}
+
+// ProbeKeys renvoie les tranches à interroger pour retrouver toute empreinte à distance <= MaxIndexedDistance.
+func ProbeKeys(h uint64) []BandKey {
+	keys := make([]BandKey, 0, Bands*(1+bandBits+bandBits*(bandBits-1)/2))
+	for _, k := range BandsOf(h) {
+		keys = append(keys, k)
+		for i := 0; i < bandBits; i++ {
+			keys = append(keys, BandKey{Band: k.Band, Value: k.Value ^ 1<<uint(i)})
+			for j := i + 1; j < bandBits; j++ {
+				keys = append(keys, BandKey{Band: k.Band, Value: k.Value ^ 1<<uint(i) ^ 1<<uint(j)})
+			}
+		}
+	}
+	return keys
+}
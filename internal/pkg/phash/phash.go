@@ -0,0 +1,159 @@
+package phash
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/disintegration/imaging"
+)
+
+// ============================================================================
+// EMPREINTES PERCEPTUELLES (pHash / dHash, 64 bits)
+// ============================================================================
+// Deux images visuellement identiques (réencodées, redimensionnées, légèrement recadrées ou retouchées)
+// ont des empreintes proches au sens de la distance de Hamming. pHash (basses fréquences de la DCT)
+// sert à la recherche, dHash (gradients horizontaux) confirme une correspondance.
+
+const (
+	dctSize  = 32 // L'image est réduite à 32x32 en niveaux de gris avant la DCT
+	dctKeep  = 8  // Seul le coin 8x8 des basses fréquences est conservé (64 bits)
+	dhashCol = 9  // dHash : 9x8 pixels, 8 comparaisons par ligne
+	dhashRow = 8
+)
+
+// Hash regroupe les deux empreintes d'une image.
+type Hash struct {
+	P uint64 // pHash
+	D uint64 // dHash
+}
+
+// Compute calcule les deux empreintes d'une image (déjà redressée).
+func Compute(img image.Image) Hash {
+	return Hash{P: PHash(img), D: DHash(img)}
+}
+
+// PHash compare chaque coefficient basse fréquence de la DCT à leur médiane (composante continue exclue).
+func PHash(img image.Image) uint64 {
+	gray := grayMatrix(img, dctSize, dctSize)
+	coeffs := dct2D(gray)
+
+	values := make([]float64, 0, dctKeep*dctKeep)
+	for y := 0; y < dctKeep; y++ {
+		for x := 0; x < dctKeep; x++ {
+			values = append(values, coeffs[y][x])
+		}
+	}
+	sorted := append([]float64(nil), values[1:]...)
+	sort.Float64s(sorted)
+	median := (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+
+	var h uint64
+	for i, v := range values {
+		if v > median {
+			h |= 1 << uint(63-i)
+		}
+	}
+	return h
+}
+
+// DHash encode le sens du gradient entre pixels voisins d'une vignette 9x8.
+func DHash(img image.Image) uint64 {
+	gray := grayMatrix(img, dhashCol, dhashRow)
+	var h uint64
+	i := 0
+	for y := 0; y < dhashRow; y++ {
+		for x := 0; x < dhashCol-1; x++ {
+			if gray[y][x] < gray[y][x+1] {
+				h |= 1 << uint(63-i)
+			}
+			i++
+		}
+	}
+	return h
+}
+
+// Distance est la distance de Hamming entre deux empreintes.
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// String sérialise l'empreinte ("<phash>:<dhash>" en hexadécimal).
+func (h Hash) String() string {
+	return fmt.Sprintf("%016x:%016x", h.P, h.D)
+}
+
+// Parse relit une empreinte sérialisée par String.
+func Parse(s string) (Hash, error) {
+	p, d, ok := strings.Cut(s, ":")
+	if !ok {
+		return Hash{}, fmt.Errorf("empreinte invalide: %q", s)
+	}
+	hp, err := strconv.ParseUint(p, 16, 64)
+	if err != nil {
+		return Hash{}, fmt.Errorf("empreinte invalide: %w", err)
+	}
+	hd, err := strconv.ParseUint(d, 16, 64)
+	if err != nil {
+		return Hash{}, fmt.Errorf("empreinte invalide: %w", err)
+	}
+	return Hash{P: hp, D: hd}, nil
+}
+
+// --- HELPERS ---
+
+// grayMatrix réduit l'image à w x h et renvoie la luminance de chaque pixel.
+func grayMatrix(img image.Image, w, h int) [][]float64 {
+	small := imaging.Grayscale(imaging.Resize(img, w, h, imaging.Lanczos))
+	out := make([][]float64, h)
+	for y := 0; y < h; y++ {
+		out[y] = make([]float64, w)
+		for x := 0; x < w; x++ {
+			out[y][x] = float64(small.Pix[y*small.Stride+x*4])
+		}
+	}
+	return out
+}
+
+// dct2D applique une DCT-II séparable (lignes puis colonnes) à une matrice carrée.
+func dct2D(m [][]float64) [][]float64 {
+	n := len(m)
+	cos := make([][]float64, n)
+	for k := 0; k < n; k++ {
+		cos[k] = make([]float64, n)
+		for i := 0; i < n; i++ {
+			cos[k][i] = math.Cos(math.Pi / float64(n) * (float64(i) + 0.5) * float64(k))
+		}
+	}
+
+	rows := make([][]float64, n)
+	for y := 0; y < n; y++ {
+		rows[y] = make([]float64, n)
+		for k := 0; k < n; k++ {
+			var sum float64
+			for i := 0; i < n; i++ {
+				sum += m[y][i] * cos[k][i]
+			}
+			rows[y][k] = sum
+		}
+	}
+
+	out := make([][]float64, n)
+	for k := 0; k < n; k++ {
+		out[k] = make([]float64, n)
+	}
+	for x := 0; x < n; x++ {
+		for k := 0; k < n; k++ {
+			var sum float64
+			for i := 0; i < n; i++ {
+				sum += rows[i][x] * cos[k][i]
+			}
+			out[k][x] = sum
+		}
+	}
+	return out
+}
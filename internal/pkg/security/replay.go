@@ -0,0 +1,66 @@
+package security
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// ErrReplayDetected signale une requête signée déjà consommée dans la fenêtre de tolérance.
+var ErrReplayDetected = errors.New("requête rejouée")
+
+// ErrInvalidRequestID signale un X-Request-Id hors format (1 à 128 caractères [A-Za-z0-9._-]).
+var ErrInvalidRequestID = errors.New("X-Request-Id invalide")
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// ValidateRequestID vérifie le format d'un identifiant de requête fourni par le client.
+func ValidateRequestID(requestID string) error {
+	if !requestIDPattern.MatchString(requestID) {
+		return ErrInvalidRequestID
+	}
+	return nil
+}
+
+// ReplayTTL calcule la durée pendant laquelle une requête horodatée à clientTs reste acceptable :
+// au-delà de clientTs + ToleranceTimeSeconds, le contrôle du timestamp la rejette de toute façon.
+func ReplayTTL(clientTs int64) time.Duration {
+	expiry := time.Unix(clientTs+variables.ToleranceTimeSeconds, 0)
+	ttl := time.Until(expiry)
+	if ttl < time.Second {
+		ttl = time.Second
+	}
+	return ttl
+}
+
+// ClaimSignatureNonce enregistre (SETNX) l'empreinte d'une signature valide pour une session.
+// Une deuxième présentation de la même signature dans la fenêtre retourne ErrReplayDetected.
+func ClaimSignatureNonce(ctx context.Context, sessionID int64, signature string, clientTs int64) error {
+	sum := sha256.Sum256([]byte(signature))
+	key := fmt.Sprintf("%d:sig:%s", sessionID, hex.EncodeToString(sum[:]))
+
+	ok, err := redis.HMACNonces.SetNX(ctx, key, clientTs, ReplayTTL(clientTs))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrReplayDetected
+	}
+	return nil
+}
+
+// IsMutatingMethod indique si la méthode HTTP modifie l'état serveur.
+func IsMutatingMethod(method string) bool {
+	switch method {
+	case "POST", "PUT", "PATCH", "DELETE":
+		return true
+	}
+	return false
+}
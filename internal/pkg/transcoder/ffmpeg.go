@@ -0,0 +1,157 @@
+package transcoder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+const (
+	hlsPlaylist      = "index.m3u8"
+	hlsSegmentPrefix = "seg_"
+)
+
+// FFmpeg pilote les binaires ffmpeg / ffprobe (chemins surchargeables par FFMPEG_PATH / FFPROBE_PATH).
+type FFmpeg struct {
+	FFmpegPath     string
+	FFprobePath    string
+	MaxHeight      int
+	SegmentSeconds int
+}
+
+// NewFFmpeg construit l'adaptateur avec les limites de la plateforme.
+func NewFFmpeg() *FFmpeg {
+	f := &FFmpeg{
+		FFmpegPath:     os.Getenv("FFMPEG_PATH"),
+		FFprobePath:    os.Getenv("FFPROBE_PATH"),
+		MaxHeight:      variables.VideoMaxHeight,
+		SegmentSeconds: variables.VideoSegmentSeconds,
+	}
+	if f.FFmpegPath == "" {
+		f.FFmpegPath = "ffmpeg"
+	}
+	if f.FFprobePath == "" {
+		f.FFprobePath = "ffprobe"
+	}
+	return f
+}
+
+// ffprobeOutput est le sous-ensemble lu dans la sortie JSON de ffprobe.
+type ffprobeOutput struct {
+	Format struct {
+		Duration string `json:"duration"`
+	} `json:"format"`
+	Streams []struct {
+		CodecType string `json:"codec_type"`
+		Width     int    `json:"width"`
+		Height    int    `json:"height"`
+	} `json:"streams"`
+}
+
+func (f *FFmpeg) Probe(ctx context.Context, input string) (Probe, error) {
+	out, err := f.run(ctx, f.FFprobePath, "-v", "error", "-print_format", "json", "-show_format", "-show_streams", input)
+	if err != nil {
+		return Probe{}, fmt.Errorf("%w: %v", ErrUnreadable, err)
+	}
+
+	var parsed ffprobeOutput
+	if err := json.Unmarshal(out, &parsed); err != nil {
+		return Probe{}, fmt.Errorf("%w: sortie ffprobe invalide: %v", ErrUnreadable, err)
+	}
+
+	seconds, err := strconv.ParseFloat(parsed.Format.Duration, 64)
+	if err != nil || seconds <= 0 {
+		return Probe{}, fmt.Errorf("%w: durée inconnue", ErrUnreadable)
+	}
+	p := Probe{Duration: time.Duration(seconds * float64(time.Second))}
+	for _, s := range parsed.Streams {
+		if s.CodecType == "video" {
+			p.Width, p.Height = s.Width, s.Height
+			break
+		}
+	}
+	if p.Width == 0 || p.Height == 0 {
+		return Probe{}, fmt.Errorf("%w: aucune piste vidéo", ErrUnreadable)
+	}
+	return p, nil
+}
+
+func (f *FFmpeg) Transcode(ctx context.Context, input string, outDir string) (Output, error) {
+	// H.264 + AAC, hauteur plafonnée (largeur paire conservant le ratio), segments de durée fixe
+	scale := fmt.Sprintf("scale=-2:'min(%d,ih)'", f.MaxHeight)
+	_, err := f.run(ctx, f.FFmpegPath,
+		"-v", "error", "-y", "-i", input,
+		"-map_metadata", "-1", // Aucune métadonnée de la source (GPS, appareil) dans les segments
+		"-vf", scale,
+		"-c:v", "libx264", "-preset", "veryfast", "-crf", "23", "-pix_fmt", "yuv420p",
+		"-c:a", "aac", "-b:a", "128k", "-ac", "2",
+		"-f", "hls",
+		"-hls_time", strconv.Itoa(f.SegmentSeconds),
+		"-hls_playlist_type", "vod",
+		"-hls_segment_filename", filepath.Join(outDir, hlsSegmentPrefix+"%03d.ts"),
+		filepath.Join(outDir, hlsPlaylist),
+	)
+	if err != nil {
+		return Output{}, fmt.Errorf("erreur lors du transcodage: %w", err)
+	}
+
+	entries, err := os.ReadDir(outDir)
+	if err != nil {
+		return Output{}, err
+	}
+	out := Output{Playlist: hlsPlaylist}
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), hlsSegmentPrefix) {
+			out.Segments = append(out.Segments, e.Name())
+		}
+	}
+	sort.Strings(out.Segments)
+	if len(out.Segments) == 0 {
+		return Output{}, fmt.Errorf("erreur lors du transcodage: aucun segment produit")
+	}
+
+	probe, err := f.Probe(ctx, filepath.Join(outDir, out.Segments[0]))
+	if err == nil {
+		out.Width, out.Height = probe.Width, probe.Height
+	}
+	return out, nil
+}
+
+func (f *FFmpeg) PosterFrame(ctx context.Context, input string, at time.Duration) (image.Image, error) {
+	out, err := f.run(ctx, f.FFmpegPath,
+		"-v", "error", "-ss", strconv.FormatFloat(at.Seconds(), 'f', 3, 64), "-i", input,
+		"-frames:v", "1", "-f", "image2pipe", "-c:v", "png", "pipe:1",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'extraction de l'affiche: %w", err)
+	}
+	img, err := png.Decode(bytes.NewReader(out))
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors du décodage de l'affiche: %w", err)
+	}
+	return img, nil
+}
+
+// run exécute un binaire et renvoie sa sortie standard (stderr est joint à l'erreur).
+func (f *FFmpeg) run(ctx context.Context, bin string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s: %v: %s", filepath.Base(bin), err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
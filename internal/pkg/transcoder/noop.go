@@ -0,0 +1,75 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Noop est un double sans ffmpeg (développement local, intégration continue) : la sonde renvoie des
+// valeurs fixes, le "rendu" recopie la source dans un segment unique et l'affiche est une image unie.
+type Noop struct {
+	Duration time.Duration
+	Width    int
+	Height   int
+}
+
+// NewNoop construit le double avec une vidéo fictive de 5 s en 640x360.
+func NewNoop() *Noop {
+	return &Noop{Duration: 5 * time.Second, Width: 640, Height: 360}
+}
+
+func (n *Noop) Probe(_ context.Context, input string) (Probe, error) {
+	if _, err := os.Stat(input); err != nil {
+		return Probe{}, fmt.Errorf("%w: %v", ErrUnreadable, err)
+	}
+	return Probe{Duration: n.Duration, Width: n.Width, Height: n.Height}, nil
+}
+
+func (n *Noop) Transcode(_ context.Context, input string, outDir string) (Output, error) {
+	segment := hlsSegmentPrefix + "000.ts"
+	if err := copyFile(input, filepath.Join(outDir, segment)); err != nil {
+		return Output{}, err
+	}
+
+	playlist := fmt.Sprintf("#EXTM3U\n#EXT-X-VERSION:3\n#EXT-X-TARGETDURATION:%d\n#EXT-X-PLAYLIST-TYPE:VOD\n#EXTINF:%.3f,\n%s\n#EXT-X-ENDLIST\n",
+		int(n.Duration.Seconds())+1, n.Duration.Seconds(), segment)
+	if err := os.WriteFile(filepath.Join(outDir, hlsPlaylist), []byte(playlist), 0o600); err != nil {
+		return Output{}, err
+	}
+	return Output{Playlist: hlsPlaylist, Segments: []string{segment}, Width: n.Width, Height: n.Height}, nil
+}
+
+func (n *Noop) PosterFrame(_ context.Context, _ string, _ time.Duration) (image.Image, error) {
+	img := image.NewRGBA(image.Rect(0, 0, n.Width, n.Height))
+	gray := color.RGBA{R: 128, G: 128, B: 128, A: 255}
+	for y := 0; y < n.Height; y++ {
+		for x := 0; x < n.Width; x++ {
+			img.SetRGBA(x, y, gray)
+		}
+	}
+	return img, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,67 @@
+package transcoder
+
+import (
+	"context"
+	"errors"
+	"image"
+	"os"
+	"sync"
+	"time"
+)
+
+// ============================================================================
+// TRANSCODAGE VIDÉO (interface interchangeable)
+// ============================================================================
+// Le service média ne connaît que cette interface : l'adaptateur ffmpeg (CLI) en production, le double
+// Noop pour les environnements sans ffmpeg. Le choix se fait par la variable MEDIA_TRANSCODER.
+
+// ErrUnreadable signale un fichier que la sonde ne sait pas lire (pas une vidéo, conteneur corrompu).
+var ErrUnreadable = errors.New("vidéo illisible")
+
+// Probe décrit la vidéo source.
+type Probe struct {
+	Duration time.Duration
+	Width    int
+	Height   int
+}
+
+// Output décrit un rendu HLS écrit dans le dossier de sortie (noms de fichiers relatifs à ce dossier).
+type Output struct {
+	Playlist string   // "index.m3u8"
+	Segments []string // "seg_000.ts", "seg_001.ts"... dans l'ordre de lecture
+	Width    int
+	Height   int
+}
+
+// Transcoder produit le rendu diffusable d'une vidéo et son image d'affiche.
+type Transcoder interface {
+	// Probe lit la durée et les dimensions de la source.
+	Probe(ctx context.Context, input string) (Probe, error)
+	// Transcode écrit une playlist HLS et ses segments dans outDir.
+	Transcode(ctx context.Context, input string, outDir string) (Output, error)
+	// PosterFrame extrait l'image affichée à l'instant at.
+	PosterFrame(ctx context.Context, input string, at time.Duration) (image.Image, error)
+}
+
+var (
+	defaultOnce sync.Once
+	defaultImpl Transcoder
+)
+
+// Default renvoie le transcodeur configuré par MEDIA_TRANSCODER ("ffmpeg" par défaut, "noop").
+func Default() Transcoder {
+	defaultOnce.Do(func() {
+		defaultImpl = New(os.Getenv("MEDIA_TRANSCODER"))
+	})
+	return defaultImpl
+}
+
+// New construit un transcodeur par son nom.
+func New(name string) Transcoder {
+	switch name {
+	case "noop":
+		return NewNoop()
+	default:
+		return NewFFmpeg()
+	}
+}
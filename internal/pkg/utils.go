@@ -168,3 +168,16 @@ func GetUserIDFromContext(c *gin.Context) (int64, error) {
 		return 0, fmt.Errorf("type userID inconnu: %T", v)
 	}
 }
+
+// GetDeviceTokenFromContext extrait le device token (claim "dev") placé par le JWTMiddleware.
+func GetDeviceTokenFromContext(c *gin.Context) (string, error) {
+	val, exists := c.Get("deviceToken")
+	if !exists {
+		return "", fmt.Errorf("deviceToken non trouvé dans le contexte")
+	}
+	deviceToken := fmt.Sprintf("%v", val)
+	if deviceToken == "" {
+		return "", fmt.Errorf("deviceToken vide dans le contexte")
+	}
+	return deviceToken, nil
+}
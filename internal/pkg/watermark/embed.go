@@ -0,0 +1,48 @@
+package watermark
+
+import (
+	"image"
+	"image/draw"
+	"math"
+)
+
+// Embed tatoue l'enregistrement sur toute l'image, tuile après tuile depuis le coin supérieur gauche.
+// Seule la luminance est modifiée (le même écart est ajouté aux trois canaux) : les couleurs sont conservées.
+func Embed(src image.Image, p Payload) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+
+	signs := tileLayout.tileSigns(p.marshal())
+	lum := lumaOf(dst)
+
+	for by := 0; by+BlockSize <= lum.h; by += BlockSize {
+		for bx := 0; bx+BlockSize <= lum.w; bx += BlockSize {
+			pos := (by/BlockSize%TileBlocks)*TileBlocks + bx/BlockSize%TileBlocks
+			s := signs[pos]
+
+			// Écart déjà dans le bon sens et assez marqué : le bloc est laissé intact
+			d := lum.blockDiff(bx, by)
+			if s*d >= Strength {
+				continue
+			}
+			delta := (Strength - s*d) / 2 // Réparti à parts égales sur les deux coefficients
+			applyBlock(dst, bx, by, s*delta)
+		}
+	}
+	return dst
+}
+
+// applyBlock ajoute +delta à c(1,2) et -delta à c(2,1) par IDCT, sur les trois canaux.
+func applyBlock(img *image.RGBA, x0, y0 int, delta float64) {
+	for y := 0; y < BlockSize; y++ {
+		off := img.PixOffset(x0, y0+y)
+		for x := 0; x < BlockSize; x++ {
+			df := 0.25 * delta * (cosTable[1][x]*cosTable[2][y] - cosTable[2][x]*cosTable[1][y])
+			for c := 0; c < 3; c++ {
+				v := math.Round(float64(img.Pix[off+4*x+c]) + df)
+				img.Pix[off+4*x+c] = uint8(math.Max(0, math.Min(255, v)))
+			}
+		}
+	}
+}
@@ -0,0 +1,396 @@
+package watermark
+
+import (
+	"errors"
+	"image"
+	"math"
+	"sort"
+)
+
+// ErrNotFound signale qu'aucun tatouage décodable n'a été trouvé (image trop petite, trop dégradée, ou vierge).
+var ErrNotFound = errors.New("watermark: aucun tatouage exploitable")
+
+const (
+	maxAnalysisSide    = 1536 // Côté maximal de la zone analysée (à l'échelle d'origine)
+	minAnalysisSide    = 64
+	candidatesPerScale = 3 // Alignements essayés au décodage pour une échelle donnée
+
+	// Redimensionnements recherchés : image fuitée de 0,4x à 2,5x l'image servie
+	minScale = 0.4
+	maxScale = 2.5
+
+	peaksPerAxis   = 4    // Pics d'autocorrélation retenus par axe
+	scaleTolerance = 0.02 // Deux périodes plus proches que 2 % sont considérées identiques
+	residualClip   = 3    // Écrêtage du résidu (niveaux), de l'ordre de l'amplitude du tatouage
+)
+
+// fineScaleSteps affine l'échelle estimée par autocorrélation (± 1,2 %).
+var fineScaleSteps = []float64{0, -0.004, 0.004, -0.008, 0.008, -0.012, 0.012}
+
+// Stats décrit la lecture : géométrie retrouvée et qualité du signal.
+type Stats struct {
+	Scale            float64 // Facteur d'échelle estimé (image fuitée / image servie)
+	OriginX, OriginY int     // Origine d'une tuile dans l'image fuitée (pixels)
+	Tiles            float64 // Nombre équivalent de tuiles lues (blocs cumulés / positions d'une tuile)
+	SyncScore        float64 // Corrélation normalisée du motif de synchronisation (0 à 1)
+	RawBitErrorRate  float64 // Bits lus bloc par bloc en désaccord avec le mot de code corrigé
+	FoldedBitErrors  int     // Bits du mot de code faux après cumul des tuiles (avant correction)
+	CorrectedSymbols int     // Octets corrigés par Reed-Solomon (capacité : 8)
+	Attempts         int     // Mots de code soumis au décodeur avant succès
+}
+
+// Result est un tatouage relu et corrigé.
+type Result struct {
+	Payload Payload
+	Stats   Stats
+}
+
+// Confidence estime la probabilité que l'enregistrement soit exact. Un mot quelconque n'est accepté
+// que s'il tombe à moins de CorrectedSymbols octets d'un mot de code valide et porte la bonne version :
+// probabilité V(e) / 256^17 par tentative, où V(e) = Σ C(n, i)·255^i pour i ≤ e.
+func (r Result) Confidence() float64 {
+	volume := 0.0
+	for i := 0; i <= r.Stats.CorrectedSymbols; i++ {
+		volume += binomial(codewordBytes, i) * math.Pow(255, float64(i))
+	}
+	falseAccept := float64(max(r.Stats.Attempts, 1)) * volume / math.Pow(256, paritySymbols+1)
+	return math.Max(0, 1-falseAccept)
+}
+
+// Extract retrouve le tatouage d'une image éventuellement recompressée, recadrée ou redimensionnée.
+// L'échelle d'origine est essayée en premier ; sinon, des périodes de tuile candidates sont estimées
+// par autocorrélation, puis affinées autour de chaque estimation.
+func Extract(img image.Image) (Result, error) {
+	lum := lumaOf(img)
+	if lum.w < minAnalysisSide || lum.h < minAnalysisSide {
+		return Result{}, ErrNotFound
+	}
+
+	attempts := 0
+	try := func(scale float64) (Result, bool) {
+		view, ox, oy := lum.analysisView(scale)
+		if view == nil {
+			return Result{}, false
+		}
+		for _, c := range view.align() {
+			attempts++
+			if res, ok := view.decode(c); ok {
+				res.Stats.Scale = scale
+				res.Stats.OriginX = ox + int(math.Round(float64(c.ox+c.tx*BlockSize)*scale))
+				res.Stats.OriginY = oy + int(math.Round(float64(c.oy+c.ty*BlockSize)*scale))
+				res.Stats.Attempts = attempts
+				return res, true
+			}
+		}
+		return Result{}, false
+	}
+
+	if res, ok := try(1); ok {
+		return res, nil
+	}
+	for _, estimate := range lum.candidateScales() {
+		for _, step := range fineScaleSteps {
+			if res, ok := try(estimate * (1 + step)); ok {
+				return res, nil
+			}
+		}
+	}
+	return Result{}, ErrNotFound
+}
+
+// --- ALIGNEMENT ---
+
+// alignment est une hypothèse de pavage : décalage des blocs (pixels) et origine de la tuile (blocs).
+type alignment struct {
+	ox, oy, tx, ty int
+	score          float64
+}
+
+// analysisView recadre le centre de l'image (au plus maxAnalysisSide à l'échelle d'origine) puis le
+// ramène à l'échelle d'origine. Retourne aussi l'origine de la zone dans l'image fuitée.
+func (p *plane) analysisView(scale float64) (*plane, int, int) {
+	side := int(math.Ceil(maxAnalysisSide * scale))
+	w, h := min(p.w, side), min(p.h, side)
+	x0, y0 := (p.w-w)/2, (p.h-h)/2
+
+	tw, th := int(math.Round(float64(w)/scale)), int(math.Round(float64(h)/scale))
+	if tw < minAnalysisSide || th < minAnalysisSide {
+		return nil, 0, 0
+	}
+	return p.resample(x0, y0, w, h, tw, th), x0, y0
+}
+
+// resample extrait la zone (x0, y0, w, h) et la rééchantillonne en tw x th (bilinéaire).
+func (p *plane) resample(x0, y0, w, h, tw, th int) *plane {
+	out := &plane{w: tw, h: th, pix: make([]float32, tw*th)}
+	if tw == w && th == h {
+		for y := 0; y < h; y++ {
+			copy(out.pix[y*tw:(y+1)*tw], p.pix[(y0+y)*p.w+x0:(y0+y)*p.w+x0+w])
+		}
+		return out
+	}
+
+	sx, sy := float64(w)/float64(tw), float64(h)/float64(th)
+	for y := 0; y < th; y++ {
+		fy := math.Max(0, math.Min(float64(h-1), (float64(y)+0.5)*sy-0.5))
+		iy := int(fy)
+		iy1 := min(iy+1, h-1)
+		wy := float32(fy - float64(iy))
+		for x := 0; x < tw; x++ {
+			fx := math.Max(0, math.Min(float64(w-1), (float64(x)+0.5)*sx-0.5))
+			ix := int(fx)
+			ix1 := min(ix+1, w-1)
+			wx := float32(fx - float64(ix))
+
+			r0 := (y0+iy)*p.w + x0
+			r1 := (y0+iy1)*p.w + x0
+			top := p.pix[r0+ix]*(1-wx) + p.pix[r0+ix1]*wx
+			bottom := p.pix[r1+ix]*(1-wx) + p.pix[r1+ix1]*wx
+			out.pix[y*tw+x] = top*(1-wy) + bottom*wy
+		}
+	}
+	return out
+}
+
+// fold cumule les écarts de tous les blocs d'un décalage (ox, oy) par position dans la tuile
+// (origine de tuile arbitraire : elle est cherchée ensuite par décalage circulaire).
+func (p *plane) fold(ox, oy int) (acc [tilePositions]float64, blocks int) {
+	for j := 0; (j+1)*BlockSize+oy <= p.h; j++ {
+		for i := 0; (i+1)*BlockSize+ox <= p.w; i++ {
+			d := p.blockDiff(ox+i*BlockSize, oy+j*BlockSize)
+			acc[(j%TileBlocks)*TileBlocks+i%TileBlocks] += math.Max(-softClip, math.Min(softClip, d))
+			blocks++
+		}
+	}
+	return acc, blocks
+}
+
+// align classe les hypothèses de pavage par corrélation du motif de synchronisation.
+func (p *plane) align() []alignment {
+	var best []alignment
+	for oy := 0; oy < BlockSize; oy++ {
+		for ox := 0; ox < BlockSize; ox++ {
+			acc, _ := p.fold(ox, oy)
+
+			energy := 0.0
+			for _, v := range acc {
+				energy += v * v
+			}
+			if energy == 0 {
+				continue
+			}
+			norm := float64(syncBits) * math.Sqrt(energy/tilePositions)
+
+			for ty := 0; ty < TileBlocks; ty++ {
+				for tx := 0; tx < TileBlocks; tx++ {
+					score := 0.0
+					for k, pos := range tileLayout.syncPos {
+						score += tileLayout.syncSign[k] * acc[shift(pos, tx, ty)]
+					}
+					best = keepBest(best, alignment{ox: ox, oy: oy, tx: tx, ty: ty, score: score / norm})
+				}
+			}
+		}
+	}
+	return best
+}
+
+// keepBest conserve les candidatesPerScale meilleures hypothèses.
+func keepBest(best []alignment, a alignment) []alignment {
+	if len(best) == candidatesPerScale && a.score <= best[len(best)-1].score {
+		return best
+	}
+	best = append(best, a)
+	sort.Slice(best, func(i, j int) bool { return best[i].score > best[j].score })
+	if len(best) > candidatesPerScale {
+		best = best[:candidatesPerScale]
+	}
+	return best
+}
+
+// shift renvoie l'indice, dans le cumul, de la position pos d'une tuile d'origine (tx, ty).
+func shift(pos, tx, ty int) int {
+	return ((pos/TileBlocks+ty)%TileBlocks)*TileBlocks + (pos%TileBlocks+tx)%TileBlocks
+}
+
+// --- DÉCODAGE ---
+
+// decode lit le mot de code selon une hypothèse de pavage, le corrige et mesure la qualité du signal.
+func (p *plane) decode(a alignment) (Result, bool) {
+	acc, blocks := p.fold(a.ox, a.oy)
+
+	// 1. Décision ferme sur le cumul des deux copies
+	cw := make([]byte, codewordBytes)
+	for b := 0; b < codewordBits; b++ {
+		sum := 0.0
+		for _, pos := range tileLayout.dataPos[b] {
+			sum += acc[shift(pos, a.tx, a.ty)]
+		}
+		if sum > 0 {
+			cw[b/8] |= 1 << (7 - uint(b%8))
+		}
+	}
+	folded := append([]byte(nil), cw...)
+
+	payload, corrected, err := unmarshalPayload(cw)
+	if err != nil {
+		return Result{}, false
+	}
+
+	stats := Stats{
+		Tiles:            float64(blocks) / tilePositions,
+		SyncScore:        a.score,
+		CorrectedSymbols: corrected,
+	}
+	for i := range cw {
+		for x := folded[i] ^ cw[i]; x != 0; x &= x - 1 {
+			stats.FoldedBitErrors++
+		}
+	}
+
+	// 2. Taux d'erreur brut : chaque bloc confronté au gabarit du mot de code corrigé
+	signs := tileLayout.tileSigns(cw)
+	errs := 0
+	for j := 0; (j+1)*BlockSize+a.oy <= p.h; j++ {
+		for i := 0; (i+1)*BlockSize+a.ox <= p.w; i++ {
+			pos := ((j-a.ty+TileBlocks*TileBlocks)%TileBlocks)*TileBlocks + (i-a.tx+TileBlocks*TileBlocks)%TileBlocks
+			if p.blockDiff(a.ox+i*BlockSize, a.oy+j*BlockSize)*signs[pos] <= 0 {
+				errs++
+			}
+		}
+	}
+	if blocks > 0 {
+		stats.RawBitErrorRate = float64(errs) / float64(blocks)
+	}
+	return Result{Payload: payload, Stats: stats}, true
+}
+
+// --- ÉCHELLE ---
+
+// candidateScales mesure la période des tuiles (TileSize × échelle) par autocorrélation du résidu
+// passe-haut de la luminance, en ligne et en colonne. Les motifs périodiques du contenu produisent
+// aussi des pics : plusieurs périodes sont donc proposées, celles vues sur les deux axes en premier,
+// et c'est la synchronisation qui tranche.
+func (p *plane) candidateScales() []float64 {
+	side := int(math.Ceil(maxAnalysisSide * maxScale))
+	w, h := min(p.w, side), min(p.h, side)
+	view := p.resample((p.w-w)/2, (p.h-h)/2, w, h, w, h).highPass(BlockSize)
+
+	horizontal, vertical := view.periods(false), view.periods(true)
+	var scales []float64
+	add := func(period float64) {
+		scale := period / TileSize
+		for _, s := range scales {
+			if math.Abs(s-scale)/s < scaleTolerance {
+				return
+			}
+		}
+		scales = append(scales, scale)
+	}
+
+	// 1. Périodes communes aux deux axes (un redimensionnement conserve les proportions)
+	for _, px := range horizontal {
+		for _, py := range vertical {
+			if math.Abs(px-py)/px < scaleTolerance {
+				add((px + py) / 2)
+			}
+		}
+	}
+	// 2. Puis les autres, du pic le plus marqué au plus faible, en alternant les axes
+	for i := 0; i < peaksPerAxis; i++ {
+		if i < len(horizontal) {
+			add(horizontal[i])
+		}
+		if i < len(vertical) {
+			add(vertical[i])
+		}
+	}
+	return scales
+}
+
+// highPass soustrait à chaque pixel la moyenne de son voisinage (fenêtre de 2r+1, image intégrale),
+// puis écrête le résidu pour que les contours francs du contenu ne dominent pas le tatouage.
+func (p *plane) highPass(r int) *plane {
+	integral := make([]float64, (p.w+1)*(p.h+1))
+	for y := 0; y < p.h; y++ {
+		row := 0.0
+		for x := 0; x < p.w; x++ {
+			row += float64(p.pix[y*p.w+x])
+			integral[(y+1)*(p.w+1)+x+1] = integral[y*(p.w+1)+x+1] + row
+		}
+	}
+
+	out := &plane{w: p.w, h: p.h, pix: make([]float32, len(p.pix))}
+	for y := 0; y < p.h; y++ {
+		y0, y1 := max(0, y-r), min(p.h, y+r+1)
+		for x := 0; x < p.w; x++ {
+			x0, x1 := max(0, x-r), min(p.w, x+r+1)
+			sum := integral[y1*(p.w+1)+x1] - integral[y0*(p.w+1)+x1] - integral[y1*(p.w+1)+x0] + integral[y0*(p.w+1)+x0]
+			v := p.pix[y*p.w+x] - float32(sum/float64((x1-x0)*(y1-y0)))
+			out.pix[y*p.w+x] = max(-residualClip, min(residualClip, v))
+		}
+	}
+	return out
+}
+
+// periods renvoie les pics d'autocorrélation compatibles avec une tuile redimensionnée, du plus
+// marqué au plus faible (précision sous-pixel par interpolation parabolique).
+func (p *plane) periods(vertical bool) []float64 {
+	length, lines := p.w, p.h
+	if vertical {
+		length, lines = p.h, p.w
+	}
+	minLag := int(math.Floor(TileSize * minScale))
+	maxLag := min(int(math.Ceil(TileSize*maxScale)), length*2/3)
+	if maxLag <= minLag+2 {
+		return nil
+	}
+
+	at := func(line, i int) float64 {
+		if vertical {
+			return float64(p.pix[i*p.w+line])
+		}
+		return float64(p.pix[line*p.w+i])
+	}
+
+	corr := make([]float64, maxLag+2)
+	for lag := minLag - 1; lag <= maxLag+1; lag++ {
+		sum := 0.0
+		for line := 0; line < lines; line += 2 {
+			for i := 0; i+lag < length; i += 2 {
+				sum += at(line, i) * at(line, i+lag)
+			}
+		}
+		corr[lag] = sum / float64(length-lag)
+	}
+
+	type peak struct{ lag, value float64 }
+	var peaks []peak
+	for lag := minLag; lag <= maxLag; lag++ {
+		c := corr[lag]
+		if c <= 0 || c <= corr[lag-1] || c < corr[lag+1] {
+			continue
+		}
+		offset := 0.0
+		if den := corr[lag-1] - 2*c + corr[lag+1]; den != 0 {
+			offset = 0.5 * (corr[lag-1] - corr[lag+1]) / den
+		}
+		peaks = append(peaks, peak{float64(lag) + offset, c})
+	}
+	sort.Slice(peaks, func(i, j int) bool { return peaks[i].value > peaks[j].value })
+
+	out := make([]float64, 0, peaksPerAxis)
+	for i := 0; i < len(peaks) && i < peaksPerAxis; i++ {
+		out = append(out, peaks[i].lag)
+	}
+	return out
+}
+
+// binomial renvoie C(n, k) en flottant.
+func binomial(n, k int) float64 {
+	r := 1.0
+	for i := 1; i <= k; i++ {
+		r = r * float64(n-k+i) / float64(i)
+	}
+	return r
+}
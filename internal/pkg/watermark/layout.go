@@ -0,0 +1,174 @@
+package watermark
+
+import (
+	"image"
+	"math"
+)
+
+// ============================================================================
+// GÉOMÉTRIE DU TATOUAGE
+// ============================================================================
+// L'image est découpée en blocs de 8x8 pixels ; chaque bloc porte un bit dans le signe de l'écart entre
+// deux coefficients DCT de basse fréquence de la luminance, c(1,2) - c(2,1) (Koch-Zhao). Les basses
+// fréquences survivent à la recompression et au redimensionnement, contrairement au milieu de bande.
+// Les blocs sont groupés en tuiles de 32x32 répétées sur toute l'image : chaque tuile contient un motif
+// de synchronisation (pour retrouver l'origine du pavage après un recadrage) et deux copies du mot de code,
+// à des positions tirées une fois pour toutes (le gabarit ne doit jamais changer).
+
+const (
+	BlockSize  = 8
+	TileBlocks = 32 // Tuile de 256x256 pixels
+	TileSize   = TileBlocks * BlockSize
+
+	tilePositions = TileBlocks * TileBlocks
+	dataCopies    = 2
+	syncBits      = tilePositions - dataCopies*codewordBits
+
+	// Strength est l'écart |c(1,2) - c(2,1)| imposé à chaque bloc (amplitude ≤ 0,5 × Strength par pixel).
+	Strength = 20.0
+
+	// softClip borne la contribution d'un bloc texturé, dont l'écart naturel écrase le tatouage.
+	softClip = 2 * Strength
+
+	layoutSeed = 0x4e55424f574d3031 // "NUBOWM01"
+)
+
+// tileLayout attribue chaque position de la tuile à la synchronisation ou à un bit du mot de code.
+var tileLayout = newLayout()
+
+type layout struct {
+	syncPos  [syncBits]int
+	syncSign [syncBits]float64
+	dataPos  [codewordBits][dataCopies]int
+}
+
+func newLayout() *layout {
+	// Permutation de Fisher-Yates sur un générateur figé (splitmix64), indépendant de math/rand
+	state := uint64(layoutSeed)
+	next := func() uint64 {
+		state += 0x9e3779b97f4a7c15
+		z := state
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		return z ^ (z >> 31)
+	}
+
+	perm := make([]int, tilePositions)
+	for i := range perm {
+		perm[i] = i
+	}
+	for i := len(perm) - 1; i > 0; i-- {
+		j := int(next() % uint64(i+1))
+		perm[i], perm[j] = perm[j], perm[i]
+	}
+
+	l := &layout{}
+	for k := 0; k < syncBits; k++ {
+		l.syncPos[k] = perm[k]
+		l.syncSign[k] = 1
+		if next()&1 == 0 {
+			l.syncSign[k] = -1
+		}
+	}
+	for b := 0; b < codewordBits; b++ {
+		for c := 0; c < dataCopies; c++ {
+			l.dataPos[b][c] = perm[syncBits+c*codewordBits+b]
+		}
+	}
+	return l
+}
+
+// tileSigns renvoie le signe attendu de chaque position de la tuile pour un mot de code.
+func (l *layout) tileSigns(cw []byte) [tilePositions]float64 {
+	var signs [tilePositions]float64
+	for k, pos := range l.syncPos {
+		signs[pos] = l.syncSign[k]
+	}
+	for b := 0; b < codewordBits; b++ {
+		s := -1.0
+		if cw[b/8]>>(7-uint(b%8))&1 == 1 {
+			s = 1
+		}
+		for _, pos := range l.dataPos[b] {
+			signs[pos] = s
+		}
+	}
+	return signs
+}
+
+// ============================================================================
+// LUMINANCE ET DCT
+// ============================================================================
+
+// cosTable[k][x] = cos((2x+1)kπ/16)
+var cosTable = func() (t [3][BlockSize]float64) {
+	for k := 0; k < 3; k++ {
+		for x := 0; x < BlockSize; x++ {
+			t[k][x] = math.Cos(float64((2*x+1)*k) * math.Pi / 16)
+		}
+	}
+	return t
+}()
+
+// plane est un plan de luminance (0-255, Rec. 601).
+type plane struct {
+	w, h int
+	pix  []float32
+}
+
+// lumaOf extrait la luminance d'une image (voie rapide pour les formats décodés les plus courants).
+func lumaOf(img image.Image) *plane {
+	b := img.Bounds()
+	p := &plane{w: b.Dx(), h: b.Dy(), pix: make([]float32, b.Dx()*b.Dy())}
+
+	switch src := img.(type) {
+	case *image.YCbCr: // JPEG : Y est déjà la luminance JFIF
+		for y := 0; y < p.h; y++ {
+			for x := 0; x < p.w; x++ {
+				p.pix[y*p.w+x] = float32(src.Y[src.YOffset(b.Min.X+x, b.Min.Y+y)])
+			}
+		}
+	case *image.RGBA:
+		for y := 0; y < p.h; y++ {
+			row := src.Pix[src.PixOffset(b.Min.X, b.Min.Y+y):]
+			for x := 0; x < p.w; x++ {
+				p.pix[y*p.w+x] = luma(row[4*x], row[4*x+1], row[4*x+2])
+			}
+		}
+	case *image.NRGBA:
+		for y := 0; y < p.h; y++ {
+			row := src.Pix[src.PixOffset(b.Min.X, b.Min.Y+y):]
+			for x := 0; x < p.w; x++ {
+				p.pix[y*p.w+x] = luma(row[4*x], row[4*x+1], row[4*x+2])
+			}
+		}
+	default:
+		for y := 0; y < p.h; y++ {
+			for x := 0; x < p.w; x++ {
+				r, g, bl, _ := img.At(b.Min.X+x, b.Min.Y+y).RGBA()
+				p.pix[y*p.w+x] = luma(uint8(r>>8), uint8(g>>8), uint8(bl>>8))
+			}
+		}
+	}
+	return p
+}
+
+func luma(r, g, b uint8) float32 {
+	return 0.299*float32(r) + 0.587*float32(g) + 0.114*float32(b)
+}
+
+// blockDiff renvoie c(1,2) - c(2,1) pour le bloc d'origine (x0, y0) (u le long de x, v le long de y).
+func (p *plane) blockDiff(x0, y0 int) float64 {
+	var c12, c21 float64
+	for y := 0; y < BlockSize; y++ {
+		row := p.pix[(y0+y)*p.w+x0 : (y0+y)*p.w+x0+BlockSize]
+		var a1, a2 float64
+		for x, v := range row {
+			a1 += float64(v) * cosTable[1][x]
+			a2 += float64(v) * cosTable[2][x]
+		}
+		c12 += a1 * cosTable[2][y]
+		c21 += a2 * cosTable[1][y]
+	}
+	return 0.25 * (c12 - c21)
+}
@@ -0,0 +1,53 @@
+package watermark
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// Payload est l'enregistrement tatoué dans chaque image servie : qui l'a publiée, dans quel post,
+// à qui elle a été remise et quand le lien a été émis.
+type Payload struct {
+	AuthorID  int64
+	PostID    int64
+	ReaderID  int64
+	Timestamp time.Time // Précision à la seconde (uint32 : jusqu'en 2106)
+}
+
+const (
+	payloadVersion = 1
+	messageBytes   = 1 + 8 + 8 + 8 + 4 // Version, trois identifiants, horodatage
+	paritySymbols  = 16                // Corrige jusqu'à 8 octets faux
+	codewordBytes  = messageBytes + paritySymbols
+	codewordBits   = codewordBytes * 8
+)
+
+// marshal sérialise l'enregistrement (gros-boutiste) et lui ajoute sa parité Reed-Solomon.
+func (p Payload) marshal() []byte {
+	msg := make([]byte, messageBytes)
+	msg[0] = payloadVersion
+	binary.BigEndian.PutUint64(msg[1:], uint64(p.AuthorID))
+	binary.BigEndian.PutUint64(msg[9:], uint64(p.PostID))
+	binary.BigEndian.PutUint64(msg[17:], uint64(p.ReaderID))
+	binary.BigEndian.PutUint32(msg[25:], uint32(p.Timestamp.Unix()))
+	return rsEncode(msg, paritySymbols)
+}
+
+// unmarshalPayload corrige le mot de code en place puis relit l'enregistrement.
+// Retourne le nombre d'octets corrigés.
+func unmarshalPayload(cw []byte) (Payload, int, error) {
+	corrected, err := rsDecode(cw, paritySymbols)
+	if err != nil {
+		return Payload{}, 0, err
+	}
+	if cw[0] != payloadVersion {
+		return Payload{}, 0, fmt.Errorf("watermark: version %d inconnue", cw[0])
+	}
+	return Payload{
+		AuthorID:  int64(binary.BigEndian.Uint64(cw[1:])),
+		PostID:    int64(binary.BigEndian.Uint64(cw[9:])),
+		ReaderID:  int64(binary.BigEndian.Uint64(cw[17:])),
+		Timestamp: time.Unix(int64(binary.BigEndian.Uint32(cw[25:])), 0).UTC(),
+	}, corrected, nil
+}
@@ -0,0 +1,198 @@
+package watermark
+
+import "errors"
+
+// ============================================================================
+// REED-SOLOMON SUR GF(256)
+// ============================================================================
+// Code systématique RS(n, n-2t) : le message est suivi de 2t octets de parité et jusqu'à t octets
+// quelconques peuvent être corrigés. Polynôme primitif x^8 + x^4 + x^3 + x^2 + 1 (0x11d), racines
+// consécutives α^0 .. α^(2t-1). Le mot de code est écrit coefficient de plus haut degré en tête.
+
+// ErrUncorrectable signale un mot de code trop abîmé pour être corrigé (ou qui n'en est pas un).
+var ErrUncorrectable = errors.New("watermark: mot de code non corrigible")
+
+var (
+	gfExp [512]byte
+	gfLog [256]byte
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		gfExp[i] = byte(x)
+		gfLog[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11d
+		}
+	}
+	for i := 255; i < 512; i++ {
+		gfExp[i] = gfExp[i-255]
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return gfExp[(int(gfLog[a])+255-int(gfLog[b]))%255]
+}
+
+// gfPow renvoie α^e (e quelconque, réduit modulo 255).
+func gfPow(e int) byte {
+	e %= 255
+	if e < 0 {
+		e += 255
+	}
+	return gfExp[e]
+}
+
+// polyEval évalue un polynôme petit-boutiste (indice = degré) en x.
+func polyEval(p []byte, x byte) byte {
+	var y byte
+	for i := len(p) - 1; i >= 0; i-- {
+		y = gfMul(y, x) ^ p[i]
+	}
+	return y
+}
+
+// rsGenerator renvoie g(x) = Π (x - α^i), i < nsym, coefficient de plus haut degré en tête.
+func rsGenerator(nsym int) []byte {
+	g := []byte{1}
+	for i := 0; i < nsym; i++ {
+		next := make([]byte, len(g)+1)
+		for j, c := range g {
+			next[j] ^= c
+			next[j+1] ^= gfMul(c, gfPow(i))
+		}
+		g = next
+	}
+	return g
+}
+
+// rsEncode ajoute nsym octets de parité au message.
+func rsEncode(msg []byte, nsym int) []byte {
+	gen := rsGenerator(nsym)
+	out := make([]byte, len(msg)+nsym)
+	copy(out, msg)
+
+	// Division polynomiale : le reste est la parité
+	for i := 0; i < len(msg); i++ {
+		coef := out[i]
+		if coef == 0 {
+			continue
+		}
+		for j := 1; j < len(gen); j++ {
+			out[i+j] ^= gfMul(gen[j], coef)
+		}
+	}
+	copy(out, msg)
+	return out
+}
+
+// rsDecode corrige le mot de code en place et renvoie le nombre d'octets corrigés.
+func rsDecode(cw []byte, nsym int) (int, error) {
+	n := len(cw)
+
+	// 1. Syndromes S_j = c(α^j)
+	synd := make([]byte, nsym)
+	clean := true
+	for j := 0; j < nsym; j++ {
+		var s byte
+		for i := 0; i < n; i++ {
+			s = gfMul(s, gfPow(j)) ^ cw[i]
+		}
+		synd[j] = s
+		if s != 0 {
+			clean = false
+		}
+	}
+	if clean {
+		return 0, nil
+	}
+
+	// 2. Berlekamp-Massey : polynôme localisateur Λ (petit-boutiste)
+	lambda := []byte{1}
+	prev := []byte{1}
+	l, m, b := 0, 1, byte(1)
+	for k := 0; k < nsym; k++ {
+		d := synd[k]
+		for i := 1; i <= l && i < len(lambda); i++ {
+			d ^= gfMul(lambda[i], synd[k-i])
+		}
+		if d == 0 {
+			m++
+			continue
+		}
+		coef := gfDiv(d, b)
+		next := make([]byte, max(len(lambda), len(prev)+m))
+		copy(next, lambda)
+		for i, c := range prev {
+			next[i+m] ^= gfMul(coef, c)
+		}
+		if 2*l <= k {
+			prev, lambda = lambda, next
+			l, b, m = k+1-l, d, 1
+		} else {
+			lambda = next
+			m++
+		}
+	}
+	if l == 0 || 2*l > nsym {
+		return 0, ErrUncorrectable
+	}
+
+	// 3. Chien : l'octet i (degré n-1-i) est faux si Λ(α^-(n-1-i)) = 0
+	var positions []int
+	for i := 0; i < n; i++ {
+		if polyEval(lambda, gfPow(-(n-1-i))) == 0 {
+			positions = append(positions, i)
+		}
+	}
+	if len(positions) != l {
+		return 0, ErrUncorrectable
+	}
+
+	// 4. Forney : Ω = S·Λ mod x^nsym, valeur = X·Ω(X^-1) / Λ'(X^-1)
+	omega := make([]byte, nsym)
+	for i, s := range synd {
+		for j, c := range lambda {
+			if i+j < nsym {
+				omega[i+j] ^= gfMul(s, c)
+			}
+		}
+	}
+	deriv := make([]byte, len(lambda))
+	for i := 1; i < len(lambda); i += 2 {
+		deriv[i-1] = lambda[i]
+	}
+	for _, i := range positions {
+		x := gfPow(n - 1 - i)
+		xInv := gfPow(-(n - 1 - i))
+		den := polyEval(deriv, xInv)
+		if den == 0 {
+			return 0, ErrUncorrectable
+		}
+		cw[i] ^= gfMul(x, gfDiv(polyEval(omega, xInv), den))
+	}
+
+	// 5. Vérification : un mot corrigé doit avoir des syndromes nuls
+	for j := 0; j < nsym; j++ {
+		var s byte
+		for i := 0; i < n; i++ {
+			s = gfMul(s, gfPow(j)) ^ cw[i]
+		}
+		if s != 0 {
+			return 0, ErrUncorrectable
+		}
+	}
+	return l, nil
+}
@@ -0,0 +1,156 @@
+package watermark
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/disintegration/imaging"
+	"github.com/gen2brain/avif"
+)
+
+var testPayload = Payload{
+	AuthorID:  1843201877654323201,
+	PostID:    1843202001122334455,
+	ReaderID:  1843209988776655443,
+	Timestamp: time.Date(2026, 3, 14, 15, 9, 26, 0, time.UTC),
+}
+
+// testImage produit une photo synthétique : dégradés, formes nettes et grain.
+func testImage(w, h int) *image.NRGBA {
+	rng := rand.New(rand.NewSource(42))
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			fx, fy := float64(x)/float64(w), float64(y)/float64(h)
+			r := 120 + 80*math.Sin(6*fx+2*fy)
+			g := 110 + 70*math.Cos(4*fy-3*fx)
+			b := 100 + 60*math.Sin(9*fx*fy)
+			if (x/90+y/70)%3 == 0 {
+				r, g = g*0.6, r*0.8
+			}
+			n := rng.NormFloat64() * 4
+			img.SetNRGBA(x, y, color.NRGBA{clamp(r + n), clamp(g + n), clamp(b + n), 255})
+		}
+	}
+	return img
+}
+
+func clamp(v float64) uint8 {
+	return uint8(math.Max(0, math.Min(255, math.Round(v))))
+}
+
+func recompressJPEG(t *testing.T, img image.Image, quality int) image.Image {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		t.Fatal(err)
+	}
+	out, err := jpeg.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func recompressAVIF(t *testing.T, img image.Image, quality int) image.Image {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := avif.Encode(&buf, img, avif.Options{Quality: quality, Speed: 8}); err != nil {
+		t.Fatal(err)
+	}
+	out, err := avif.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestReedSolomonCorrectsUpToCapacity(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	msg := make([]byte, messageBytes)
+	rng.Read(msg)
+	cw := rsEncode(msg, paritySymbols)
+
+	for errs := 0; errs <= paritySymbols/2; errs++ {
+		damaged := append([]byte(nil), cw...)
+		for _, i := range rng.Perm(len(cw))[:errs] {
+			damaged[i] ^= byte(rng.Intn(255) + 1)
+		}
+		corrected, err := rsDecode(damaged, paritySymbols)
+		if err != nil {
+			t.Fatalf("%d erreurs : %v", errs, err)
+		}
+		if corrected != errs || !bytes.Equal(damaged, cw) {
+			t.Fatalf("%d erreurs : %d corrigées, mot restauré = %v", errs, corrected, bytes.Equal(damaged, cw))
+		}
+	}
+}
+
+func TestPayloadRejectsTooManyErrors(t *testing.T) {
+	cw := testPayload.marshal()
+	for i := 0; i < paritySymbols/2+4; i++ {
+		cw[i*2] ^= 0x5a
+	}
+	if _, _, err := unmarshalPayload(cw); err == nil {
+		t.Fatal("mot de code trop abîmé accepté")
+	}
+}
+
+func TestExtractBlankImage(t *testing.T) {
+	if _, err := Extract(testImage(640, 480)); err != ErrNotFound {
+		t.Fatalf("image vierge : %v", err)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	marked := Embed(testImage(960, 720), testPayload)
+
+	cases := []struct {
+		name   string
+		attack func(t *testing.T) image.Image
+	}{
+		{"sans perte", func(t *testing.T) image.Image { return marked }},
+		{"jpeg q75", func(t *testing.T) image.Image { return recompressJPEG(t, marked, 75) }},
+		{"jpeg q50", func(t *testing.T) image.Image { return recompressJPEG(t, marked, 50) }},
+		{"webp q75", func(t *testing.T) image.Image { return loadWebPFixture(t, "75") }},
+		{"webp q55", func(t *testing.T) image.Image { return loadWebPFixture(t, "55") }},
+		{"avif q65", func(t *testing.T) image.Image { return recompressAVIF(t, marked, 65) }},
+		{"recadrage", func(t *testing.T) image.Image {
+			return imaging.Crop(marked, image.Rect(137, 81, 137+500, 81+420))
+		}},
+		{"réduction 0.8", func(t *testing.T) image.Image {
+			return imaging.Resize(marked, 768, 576, imaging.Lanczos)
+		}},
+		{"agrandissement 1.25", func(t *testing.T) image.Image {
+			return imaging.Resize(marked, 1200, 900, imaging.Linear)
+		}},
+		{"recadrage + réduction + jpeg", func(t *testing.T) image.Image {
+			cropped := imaging.Crop(marked, image.Rect(53, 29, 53+700, 29+560))
+			return recompressJPEG(t, imaging.Resize(cropped, 490, 392, imaging.CatmullRom), 80)
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Extract(tc.attack(t))
+			if err != nil {
+				t.Fatalf("extraction : %v", err)
+			}
+			got := res.Payload
+			if got.AuthorID != testPayload.AuthorID || got.PostID != testPayload.PostID ||
+				got.ReaderID != testPayload.ReaderID || !got.Timestamp.Equal(testPayload.Timestamp) {
+				t.Fatalf("enregistrement %+v, attendu %+v", got, testPayload)
+			}
+			if res.Confidence() < 0.999 {
+				t.Fatalf("confiance %.6f", res.Confidence())
+			}
+			t.Logf("%+v", res.Stats)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package watermark
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/webp"
+)
+
+// ============================================================================
+// RECOMPRESSION WEBP
+// ============================================================================
+// Aucune dépendance du module n'encode le WebP (golang.org/x/image/webp ne fait que décoder) : l'image
+// tatouée de TestRoundTrip est recompressée par libwebp hors des tests et les résultats sont versionnés
+// dans testdata (libwebp 1.2.4, réglages par défaut de cwebp). q55 est le plancher actuel : le code
+// Reed-Solomon y corrige déjà quelques symboles, et l'extraction échoue à q50.
+//
+// marked.sha256 est l'empreinte de l'image source : si Embed ou testImage changent, le test échoue
+// jusqu'à la régénération des fixtures :
+//
+//	go test ./internal/pkg/watermark -run TestWebPFixtures -webp-source=/tmp/marked.png
+//	cwebp -q 75 /tmp/marked.png -o internal/pkg/watermark/testdata/marked_q75.webp
+//	cwebp -q 55 /tmp/marked.png -o internal/pkg/watermark/testdata/marked_q55.webp
+
+var webpSource = flag.String("webp-source", "", "écrit l'image tatouée (PNG) à ce chemin et met à jour testdata/marked.sha256")
+
+const webpDigestFile = "testdata/marked.sha256"
+
+func TestWebPFixtures(t *testing.T) {
+	marked := Embed(testImage(960, 720), testPayload)
+	sum := sha256.Sum256(marked.Pix)
+	digest := hex.EncodeToString(sum[:])
+
+	if *webpSource != "" {
+		writeWebPSource(t, marked, digest)
+		t.Skipf("source écrite dans %s : régénérer les fixtures avec cwebp", *webpSource)
+	}
+
+	want, err := os.ReadFile(webpDigestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(string(want)) != digest {
+		t.Fatal("fixtures WebP périmées (image tatouée modifiée) : les régénérer, voir l'en-tête de webp_test.go")
+	}
+}
+
+// loadWebPFixture renvoie la fixture libwebp de l'image tatouée de TestRoundTrip à la qualité demandée.
+func loadWebPFixture(t *testing.T, quality string) image.Image {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", "marked_q"+quality+".webp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	img, err := webp.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return img
+}
+
+func writeWebPSource(t *testing.T, marked image.Image, digest string) {
+	t.Helper()
+	f, err := os.Create(*webpSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if err := png.Encode(f, marked); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(webpDigestFile, []byte(digest+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,42 @@
+package mongo
+
+import "fmt"
+
+// MongoExportUserDocs relit un jeu de données d'export depuis le stockage à froid (L2),
+// utilisé quand la table L3 correspondante est indisponible.
+func MongoExportUserDocs(dataset string, userID int64) ([]map[string]any, error) {
+	var coll *MongoCollection
+	var filter map[string]any
+
+	switch dataset {
+	case "profile":
+		coll, filter = Users, map[string]any{"id": userID}
+	case "settings":
+		coll, filter = UserSettings, map[string]any{"user_id": userID}
+	case "sessions":
+		coll, filter = Sessions, map[string]any{"user_id": userID}
+	case "relations":
+		coll, filter = Relations, map[string]any{"$or": []map[string]any{{"primary_id": userID}, {"secondary_id": userID}}}
+	case "posts":
+		coll, filter = Posts, map[string]any{"user_id": userID}
+	case "comments":
+		coll, filter = Comments, map[string]any{"user_id": userID}
+	case "likes":
+		coll, filter = Likes, map[string]any{"user_id": userID}
+	case "media":
+		coll, filter = Media, map[string]any{"owner_id": userID}
+	case "messages":
+		coll, filter = Messages, map[string]any{"sender_id": userID}
+	default:
+		return nil, fmt.Errorf("jeu de données d'export inconnu: %s", dataset)
+	}
+
+	docs, err := coll.Get(filter, map[string]any{"_id": 0})
+	if err != nil {
+		return nil, err
+	}
+	if docs == nil {
+		docs = []map[string]any{}
+	}
+	return docs, nil
+}
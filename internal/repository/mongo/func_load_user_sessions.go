@@ -0,0 +1,31 @@
+package mongo
+
+import (
+	"fmt"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+)
+
+// MongoLoadUserSessions charge toutes les sessions (tous appareils) d'un utilisateur depuis le stockage L2.
+func MongoLoadUserSessions(userID int64) ([]models.SessionsRequest, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("MongoLoadUserSessions: userID invalide (%d)", userID)
+	}
+
+	docs, err := Sessions.Get(map[string]any{"user_id": userID}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]models.SessionsRequest, 0, len(docs))
+	for _, doc := range docs {
+		var s models.SessionsRequest
+		if err := pkg.ToStruct(doc, &s); err != nil {
+			continue
+		}
+		sessions = append(sessions, s)
+	}
+
+	return sessions, nil
+}
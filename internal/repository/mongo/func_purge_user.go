@@ -0,0 +1,61 @@
+package mongo
+
+import (
+	"fmt"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/account_models"
+)
+
+// MongoPurgeUser efface toutes les traces d'un utilisateur du stockage à froid (L2).
+// Retourne le nombre total de documents supprimés ; la purge continue collection par collection
+// même si l'une d'elles échoue (la première erreur est renvoyée).
+func MongoPurgeUser(userID int64, fp account_models.UserFootprint) (int64, error) {
+	commentIDs := make([]int64, 0, len(fp.CommentRefs))
+	for id := range fp.CommentRefs {
+		commentIDs = append(commentIDs, id)
+	}
+	postIDs := fp.PostIDs
+	if postIDs == nil {
+		postIDs = []int64{}
+	}
+
+	steps := []struct {
+		coll   *MongoCollection
+		filter map[string]any
+	}{
+		{Likes, map[string]any{"$or": []map[string]any{
+			{"user_id": userID},
+			{"target_type": 0, "target_id": map[string]any{"$in": postIDs}},
+			{"target_type": 1, "target_id": map[string]any{"$in": commentIDs}},
+		}}},
+		{Comments, map[string]any{"$or": []map[string]any{
+			{"user_id": userID},
+			{"post_id": map[string]any{"$in": postIDs}},
+		}}},
+		{Posts, map[string]any{"user_id": userID}},
+		{Media, map[string]any{"owner_id": userID}},
+		{Relations, map[string]any{"$or": []map[string]any{
+			{"primary_id": userID},
+			{"secondary_id": userID},
+		}}},
+		{ConversationMembers, map[string]any{"user_id": userID}},
+		{Messages, map[string]any{"sender_id": userID}},
+		{Sessions, map[string]any{"user_id": userID}},
+		{UserSettings, map[string]any{"user_id": userID}},
+		{Users, map[string]any{"id": userID}},
+	}
+
+	var total int64
+	var firstErr error
+	for _, step := range steps {
+		n, err := step.coll.DeleteCount(step.filter)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("purge Mongo %s: %w", step.coll.Name, err)
+			}
+			continue
+		}
+		total += n
+	}
+	return total, firstErr
+}
@@ -169,6 +169,18 @@ func (c *MongoCollection) Delete(filter map[string]any) error {
 	return err
 }
 
+// DeleteCount supprime les objets correspondant au filtre et renvoie le nombre de documents effacés
+func (c *MongoCollection) DeleteCount(filter map[string]any) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	res, err := c.DB.Collection(c.Name).DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
 // Update met à jour les éléments correspondant au filtre avec les nouvelles valeurs fournies dans update
 func (c *MongoCollection) Update(filter map[string]any, update map[string]any) error {
 	// true = on valide seulement les champs qu'on veut mettre à jour
@@ -0,0 +1,217 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/admin_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/comment_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/post_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/postgres"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// FICHES D'ADMINISTRATION : LECTURES L3 BRUTES
+// ============================================================================
+// Contrairement aux fonctions SQL compilées utilisées par l'application, ces lectures ne filtrent
+// ni la visibilité ni la suppression : la modération doit voir le contenu tel qu'il est stocké.
+
+const adminPostColumns = `id, user_id, COALESCE(content, ''), hashtags, identifiers, media_ids, visibility, priority_level,
+	COALESCE(location, ''), like_count, comment_count, view_count, has_media, vector_version, created_at, updated_at`
+
+const adminCommentColumns = `id, post_id, user_id, content, visibility, like_count, score, created_at, updated_at`
+
+// FuncAdminLoadPost charge un post quelle que soit sa visibilité (sql.ErrNoRows s'il n'existe pas).
+func FuncAdminLoadPost(ctx context.Context, postID int64) (post_models.PostPayload, error) {
+	return scanAdminPost(postgres.PostgresDB.QueryRowContext(ctx,
+		fmt.Sprintf(`SELECT %s FROM content.posts WHERE id = $1`, adminPostColumns), postID))
+}
+
+// FuncAdminRecentPosts renvoie les derniers posts d'un utilisateur, supprimés et masqués compris.
+func FuncAdminRecentPosts(ctx context.Context, userID int64, limit int) ([]post_models.PostPayload, error) {
+	rows, err := postgres.PostgresDB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s FROM content.posts WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2
+	`, adminPostColumns), userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'exécution de FuncAdminRecentPosts: %w", err)
+	}
+	defer closeRows(rows, "FuncAdminRecentPosts")
+
+	var posts []post_models.PostPayload
+	for rows.Next() {
+		if p, err := scanAdminPost(rows); err == nil {
+			posts = append(posts, p)
+		}
+	}
+	return posts, rows.Err()
+}
+
+// FuncAdminLoadComment charge un commentaire quelle que soit sa visibilité (sql.ErrNoRows s'il n'existe pas).
+func FuncAdminLoadComment(ctx context.Context, commentID int64) (comment_models.CommentPayload, error) {
+	return scanAdminComment(postgres.PostgresDB.QueryRowContext(ctx,
+		fmt.Sprintf(`SELECT %s FROM content.comments WHERE id = $1`, adminCommentColumns), commentID))
+}
+
+// FuncAdminRecentComments renvoie les derniers commentaires d'un utilisateur, supprimés et masqués compris.
+func FuncAdminRecentComments(ctx context.Context, userID int64, limit int) ([]comment_models.CommentPayload, error) {
+	rows, err := postgres.PostgresDB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s FROM content.comments WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2
+	`, adminCommentColumns), userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'exécution de FuncAdminRecentComments: %w", err)
+	}
+	defer closeRows(rows, "FuncAdminRecentComments")
+
+	var comments []comment_models.CommentPayload
+	for rows.Next() {
+		if c, err := scanAdminComment(rows); err == nil {
+			comments = append(comments, c)
+		}
+	}
+	return comments, rows.Err()
+}
+
+// FuncAdminLoadMedia charge des médias par ID, supprimés compris (ordre non garanti).
+func FuncAdminLoadMedia(ctx context.Context, mediaIDs []int64) ([]models.MediaRequest, error) {
+	rows, err := postgres.PostgresDB.QueryContext(ctx, `
+		SELECT `+mediaColumns+`
+		FROM content.media WHERE id = ANY($1)
+	`, pq.Array(mediaIDs))
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'exécution de FuncAdminLoadMedia: %w", err)
+	}
+	defer closeRows(rows, "FuncAdminLoadMedia")
+
+	var media []models.MediaRequest
+	for rows.Next() {
+		if m, err := scanMedia(rows); err == nil {
+			media = append(media, m)
+		}
+	}
+	return media, rows.Err()
+}
+
+// FuncAdminLoadMessage charge un message brut (row_to_json : la messagerie n'a pas encore de modèle typé).
+// Retourne sql.ErrNoRows s'il n'existe pas.
+func FuncAdminLoadMessage(ctx context.Context, messageID int64) (map[string]any, error) {
+	var raw string
+	err := postgres.PostgresDB.QueryRowContext(ctx,
+		`SELECT row_to_json(t)::text FROM messaging.messages t WHERE t.id = $1`, messageID).Scan(&raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var message map[string]any
+	if err := json.Unmarshal([]byte(raw), &message); err != nil {
+		return nil, fmt.Errorf("décodage du message %d: %w", messageID, err)
+	}
+	return message, nil
+}
+
+// FuncCountTargetLikes recompte les likes réellement enregistrés sur une cible (0 = post, 1 = commentaire).
+func FuncCountTargetLikes(ctx context.Context, targetType int, targetID int64) (int64, error) {
+	var n int64
+	err := postgres.PostgresDB.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM content.likes WHERE target_type = $1 AND target_id = $2`, targetType, targetID).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("erreur lors de l'exécution de FuncCountTargetLikes: %w", err)
+	}
+	return n, nil
+}
+
+// FuncCountPostComments recompte les commentaires non supprimés d'un post (masqués compris, comme le compteur).
+func FuncCountPostComments(ctx context.Context, postID int64) (int64, error) {
+	var n int64
+	err := postgres.PostgresDB.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM content.comments WHERE post_id = $1 AND visibility <> -1`, postID).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("erreur lors de l'exécution de FuncCountPostComments: %w", err)
+	}
+	return n, nil
+}
+
+// FuncUserReportStats résume l'historique de signalement d'un utilisateur : comme signalant (avec l'issue
+// des dossiers) et comme cible (dossiers visant son compte, ses posts, ses commentaires ou ses messages).
+// Résolutions confirmant une infraction : 2 à 5.
+func FuncUserReportStats(ctx context.Context, userID int64) (admin_models.ReportStats, error) {
+	var s admin_models.ReportStats
+	err := postgres.PostgresDB.QueryRowContext(ctx, `
+		WITH filed AS (
+			SELECT rc.state, rc.resolution
+			FROM moderation.reports r
+			LEFT JOIN moderation.report_cases rc ON rc.id = r.case_id
+			WHERE r.reporter_id = $1
+		),
+		against AS (
+			SELECT rc.state, rc.resolution, rc.report_count
+			FROM moderation.report_cases rc
+			WHERE (rc.target_type = 5 AND rc.target_id = $1)
+			   OR (rc.target_type = 0 AND EXISTS (SELECT 1 FROM content.posts p WHERE p.id = rc.target_id AND p.user_id = $1))
+			   OR (rc.target_type = 1 AND EXISTS (SELECT 1 FROM content.comments c WHERE c.id = rc.target_id AND c.user_id = $1))
+			   OR (rc.target_type = 3 AND EXISTS (SELECT 1 FROM messaging.messages m WHERE m.id = rc.target_id AND m.sender_id = $1))
+		)
+		SELECT
+			(SELECT COUNT(*) FROM filed),
+			(SELECT COUNT(*) FROM filed WHERE state = -1 AND resolution BETWEEN 2 AND 5),
+			(SELECT COUNT(*) FROM filed WHERE state = -1 AND resolution = 1),
+			(SELECT COUNT(*) FROM against),
+			(SELECT COALESCE(SUM(report_count), 0) FROM against),
+			(SELECT COUNT(*) FROM against WHERE state <> -1),
+			(SELECT COUNT(*) FROM against WHERE state = -1 AND resolution BETWEEN 2 AND 5)
+	`, userID).Scan(
+		&s.Filed, &s.FiledConfirmed, &s.FiledRejected,
+		&s.CasesAgainst, &s.ReportsAgainst, &s.OpenAgainst, &s.ConfirmedAgainst,
+	)
+	if err != nil {
+		return admin_models.ReportStats{}, fmt.Errorf("erreur lors de l'exécution de FuncUserReportStats: %w", err)
+	}
+	return s, nil
+}
+
+// FuncUserRelationStats compte les relations d'un utilisateur en L3
+// (états : 1 = follow, 2 = ami, -1 = blocage ; un ami est aussi un abonné, comme pour le Fan-Out).
+func FuncUserRelationStats(ctx context.Context, userID int64) (admin_models.RelationStats, error) {
+	var s admin_models.RelationStats
+	err := postgres.PostgresDB.QueryRowContext(ctx, `
+		SELECT
+			COUNT(*) FILTER (WHERE primary_id = $1 AND state IN (1, 2)),
+			COUNT(*) FILTER (WHERE secondary_id = $1 AND state IN (1, 2)),
+			COUNT(*) FILTER (WHERE primary_id = $1 AND state = 2),
+			COUNT(*) FILTER (WHERE primary_id = $1 AND state = -1),
+			COUNT(*) FILTER (WHERE secondary_id = $1 AND state = -1)
+		FROM auth.relations
+		WHERE primary_id = $1 OR secondary_id = $1
+	`, userID).Scan(&s.Following, &s.Followers.L3, &s.Friends, &s.Blocking, &s.BlockedBy)
+	if err != nil {
+		return admin_models.RelationStats{}, fmt.Errorf("erreur lors de l'exécution de FuncUserRelationStats: %w", err)
+	}
+	return s, nil
+}
+
+// --- HELPERS ---
+
+// scanAdminPost lit une ligne de content.posts (colonnes adminPostColumns, sans le vecteur).
+func scanAdminPost(row interface{ Scan(dest ...any) error }) (post_models.PostPayload, error) {
+	var p post_models.PostPayload
+	err := row.Scan(
+		&p.ID, &p.UserID, &p.Content, pq.Array(&p.Hashtags), pq.Array(&p.Identifiers), pq.Array(&p.MediaIDs),
+		&p.Visibility, &p.PriorityLevel, &p.Location, &p.LikeCount, &p.CommentCount, &p.ViewCount,
+		&p.HasMedia, &p.VectorVersion, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		return post_models.PostPayload{}, err
+	}
+	return p, nil
+}
+
+// scanAdminComment lit une ligne de content.comments (colonnes adminCommentColumns).
+func scanAdminComment(row interface{ Scan(dest ...any) error }) (comment_models.CommentPayload, error) {
+	var c comment_models.CommentPayload
+	if err := row.Scan(&c.ID, &c.PostID, &c.UserID, &c.Content, &c.Visibility, &c.LikeCount, &c.Score, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return comment_models.CommentPayload{}, err
+	}
+	return c, nil
+}
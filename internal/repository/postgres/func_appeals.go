@@ -0,0 +1,170 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/appeal_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/postgres"
+	"github.com/lib/pq"
+)
+
+const appealColumns = `id, sanction_id, user_id, sanction_kind, message, state, assignee_id, outcome,
+	decision_note, decided_by, decided_at, due_at, sla_breached_at, created_at, updated_at`
+
+// FuncInsertAppeal enregistre un appel. inserted == false : la sanction a déjà été contestée.
+func FuncInsertAppeal(ctx context.Context, a appeal_models.AppealPayload) (appeal_models.AppealPayload, bool, error) {
+	created, err := scanAppeal(postgres.PostgresDB.QueryRowContext(ctx, fmt.Sprintf(`
+		INSERT INTO moderation.appeals (id, sanction_id, user_id, sanction_kind, message, state, due_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, 0, $6, NOW(), NOW())
+		ON CONFLICT (sanction_id) DO NOTHING
+		RETURNING %s
+	`, appealColumns), a.ID, a.SanctionID, a.UserID, a.SanctionKind, a.Message, a.DueAt))
+	if errors.Is(err, sql.ErrNoRows) {
+		return appeal_models.AppealPayload{}, false, nil
+	}
+	if err != nil {
+		return appeal_models.AppealPayload{}, false, fmt.Errorf("erreur lors de l'exécution de FuncInsertAppeal: %w", err)
+	}
+	return created, true, nil
+}
+
+// FuncLoadAppeal charge un appel (sql.ErrNoRows s'il n'existe pas).
+func FuncLoadAppeal(ctx context.Context, appealID int64) (appeal_models.AppealPayload, error) {
+	return scanAppeal(postgres.PostgresDB.QueryRowContext(ctx,
+		fmt.Sprintf(`SELECT %s FROM moderation.appeals WHERE id = $1`, appealColumns), appealID))
+}
+
+// FuncListUserAppeals renvoie les appels d'un utilisateur, les plus récents en tête.
+func FuncListUserAppeals(ctx context.Context, userID int64) ([]appeal_models.AppealPayload, error) {
+	return queryAppeals(ctx, "FuncListUserAppeals", fmt.Sprintf(
+		`SELECT %s FROM moderation.appeals WHERE user_id = $1 ORDER BY created_at DESC, id DESC`, appealColumns), userID)
+}
+
+// FuncListAppeals renvoie une page de la file des appels : échéance la plus proche d'abord.
+// Sans filtre d'état, seuls les appels non tranchés sont listés.
+func FuncListAppeals(ctx context.Context, in appeal_models.ListAppealsInput) ([]appeal_models.AppealPayload, error) {
+	var where []string
+	var args []any
+	add := func(clause string, value any) {
+		args = append(args, value)
+		where = append(where, fmt.Sprintf(clause, len(args)))
+	}
+
+	if in.State != nil {
+		add("state = $%d", *in.State)
+	} else {
+		where = append(where, "state <> -1")
+	}
+	if in.Overdue {
+		where = append(where, "due_at < NOW()")
+	}
+	if in.AssigneeID != 0 {
+		add("assignee_id = $%d", in.AssigneeID)
+	}
+
+	args = append(args, in.Limit, in.Offset)
+	query := fmt.Sprintf(`SELECT %s FROM moderation.appeals WHERE %s
+		ORDER BY due_at ASC, id ASC
+		LIMIT $%d OFFSET $%d`, appealColumns, strings.Join(where, " AND "), len(args)-1, len(args))
+
+	return queryAppeals(ctx, "FuncListAppeals", query, args...)
+}
+
+// FuncTransitionAppeal applique une transition d'état en compare-and-set : la ligne n'est modifiée que si
+// son état courant figure dans AllowedStates (et, si ExpectAssignee != 0, si elle lui est assignée).
+// ok == false : la condition n'était pas remplie.
+func FuncTransitionAppeal(ctx context.Context, t appeal_models.AppealTransition) (appeal_models.AppealPayload, bool, error) {
+	a, err := scanAppeal(postgres.PostgresDB.QueryRowContext(ctx, fmt.Sprintf(`
+		UPDATE moderation.appeals SET
+			state         = $2,
+			assignee_id   = NULLIF($3::bigint, 0),
+			outcome       = NULLIF($4::smallint, 0),
+			decision_note = $5,
+			decided_by    = NULLIF($6::bigint, 0),
+			decided_at    = CASE WHEN $2 = -1 THEN NOW() ELSE NULL END,
+			updated_at    = NOW()
+		WHERE id = $1 AND state = ANY($7) AND ($8::bigint = 0 OR assignee_id = $8)
+		RETURNING %s
+	`, appealColumns),
+		t.AppealID, t.NewState, t.AssigneeID, t.Outcome, t.DecisionNote, t.DecidedBy,
+		pq.Array(t.AllowedStates), t.ExpectAssignee))
+	if errors.Is(err, sql.ErrNoRows) {
+		return appeal_models.AppealPayload{}, false, nil
+	}
+	if err != nil {
+		return appeal_models.AppealPayload{}, false, fmt.Errorf("transition de l'appel %d: %w", t.AppealID, err)
+	}
+	return a, true, nil
+}
+
+// FuncMarkAppealSLABreaches horodate les appels non tranchés dont l'échéance vient d'être dépassée
+// et les renvoie (chaque dépassement n'est signalé qu'une fois).
+func FuncMarkAppealSLABreaches(ctx context.Context) ([]appeal_models.AppealPayload, error) {
+	return queryAppeals(ctx, "FuncMarkAppealSLABreaches", fmt.Sprintf(`
+		UPDATE moderation.appeals SET sla_breached_at = NOW()
+		WHERE state <> -1 AND due_at < NOW() AND sla_breached_at IS NULL
+		RETURNING %s
+	`, appealColumns))
+}
+
+// --- HELPERS ---
+
+func queryAppeals(ctx context.Context, origin string, query string, args ...any) ([]appeal_models.AppealPayload, error) {
+	rows, err := postgres.PostgresDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'exécution de %s: %w", origin, err)
+	}
+	defer closeRows(rows, origin)
+
+	var appeals []appeal_models.AppealPayload
+	for rows.Next() {
+		if a, err := scanAppeal(rows); err == nil {
+			appeals = append(appeals, a)
+		}
+	}
+	return appeals, rows.Err()
+}
+
+// scanAppeal lit une ligne de moderation.appeals (colonnes appealColumns).
+func scanAppeal(row interface{ Scan(dest ...any) error }) (appeal_models.AppealPayload, error) {
+	var a appeal_models.AppealPayload
+	var assignee, decidedBy sql.NullInt64
+	var outcome sql.NullInt32
+	var decidedAt, breachedAt sql.NullTime
+
+	err := row.Scan(
+		&a.ID,
+		&a.SanctionID,
+		&a.UserID,
+		&a.SanctionKind,
+		&a.Message,
+		&a.State,
+		&assignee,
+		&outcome,
+		&a.DecisionNote,
+		&decidedBy,
+		&decidedAt,
+		&a.DueAt,
+		&breachedAt,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+	)
+	if err != nil {
+		return appeal_models.AppealPayload{}, err
+	}
+
+	a.AssigneeID = assignee.Int64
+	a.DecidedBy = decidedBy.Int64
+	a.Outcome = int(outcome.Int32)
+	if decidedAt.Valid {
+		a.DecidedAt = &decidedAt.Time
+	}
+	if breachedAt.Valid {
+		a.SLABreachedAt = &breachedAt.Time
+	}
+	return a, nil
+}
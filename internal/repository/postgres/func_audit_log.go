@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/audit_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/postgres"
+)
+
+// FuncEnsureAuditLogPartitions crée (si besoin) les partitions mensuelles de moderation.audit_log
+// pour le mois de `now` et le suivant, afin qu'aucune écriture ne tombe dans la partition par défaut.
+// Les lignes déjà tombées dans la partition par défaut (cron manqué) rejoignent la partition créée.
+func FuncEnsureAuditLogPartitions(ctx context.Context, now time.Time) error {
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for _, month := range []time.Time{current, current.AddDate(0, 1, 0)} {
+		if _, err := postgres.PostgresDB.ExecContext(ctx, `SELECT moderation.ensure_audit_log_partition($1::date)`, month); err != nil {
+			return fmt.Errorf("erreur lors de l'exécution de FuncEnsureAuditLogPartitions (%s): %w", month.Format("2006-01"), err)
+		}
+	}
+	return nil
+}
+
+// FuncQueryAuditLog renvoie une page du journal d'audit, de la plus récente à la plus ancienne.
+// Les IDs Snowflake étant croissants dans le temps, le curseur est simplement le dernier ID reçu.
+func FuncQueryAuditLog(ctx context.Context, in audit_models.AuditQueryInput) ([]audit_models.AuditEntryPayload, error) {
+	var where []string
+	var args []any
+	add := func(clause string, value any) {
+		args = append(args, value)
+		where = append(where, fmt.Sprintf(clause, len(args)))
+	}
+
+	if in.ActorID != 0 {
+		add("actor_id = $%d", in.ActorID)
+	}
+	if in.Action != "" {
+		if strings.HasSuffix(in.Action, ".") {
+			add("action LIKE $%d", in.Action+"%")
+		} else {
+			add("action = $%d", in.Action)
+		}
+	}
+	if in.TargetType != "" {
+		add("target_type = $%d", in.TargetType)
+	}
+	if in.TargetID != 0 {
+		add("target_id = $%d", in.TargetID)
+	}
+	// Les bornes sur created_at permettent à Postgres d'élaguer les partitions
+	if !in.From.IsZero() {
+		add("created_at >= $%d", in.From)
+	}
+	if !in.To.IsZero() {
+		add("created_at < $%d", in.To)
+	}
+	if in.Cursor != 0 {
+		add("id < $%d", in.Cursor)
+	}
+
+	query := `SELECT id, actor_id, actor_ip, action, target_type, target_id, metadata, created_at FROM moderation.audit_log`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	args = append(args, in.Limit)
+	query += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args))
+
+	rows, err := postgres.PostgresDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'exécution de FuncQueryAuditLog: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			fmt.Println("⚠️ Erreur fermeture rows dans FuncQueryAuditLog:", err)
+		}
+	}(rows)
+
+	var entries []audit_models.AuditEntryPayload
+	for rows.Next() {
+		var e audit_models.AuditEntryPayload
+		var metadataBytes []byte
+
+		if err := rows.Scan(
+			&e.ID,
+			&e.ActorID,
+			&e.ActorIP,
+			&e.Action,
+			&e.TargetType,
+			&e.TargetID,
+			&metadataBytes,
+			&e.CreatedAt,
+		); err != nil {
+			continue
+		}
+
+		if len(metadataBytes) > 0 {
+			_ = json.Unmarshal(metadataBytes, &e.Metadata)
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/postgres"
+)
+
+// exportQueries liste, pour chaque jeu de données exportable, la requête L3 filtrée sur l'utilisateur.
+// row_to_json évite un scanner dédié par table : l'export restitue les colonnes telles qu'elles sont stockées.
+var exportQueries = map[string]string{
+	"profile":   `SELECT row_to_json(t)::text FROM auth.users t WHERE t.id = $1`,
+	"settings":  `SELECT row_to_json(t)::text FROM auth.user_settings t WHERE t.user_id = $1`,
+	"sessions":  `SELECT row_to_json(t)::text FROM auth.sessions t WHERE t.user_id = $1`,
+	"relations": `SELECT row_to_json(t)::text FROM auth.relations t WHERE t.primary_id = $1 OR t.secondary_id = $1`,
+	"posts":     `SELECT row_to_json(t)::text FROM content.posts t WHERE t.user_id = $1 ORDER BY t.created_at`,
+	"comments":  `SELECT row_to_json(t)::text FROM content.comments t WHERE t.user_id = $1 ORDER BY t.created_at`,
+	"likes":     `SELECT row_to_json(t)::text FROM content.likes t WHERE t.user_id = $1`,
+	"media":     `SELECT row_to_json(t)::text FROM content.media t WHERE t.owner_id = $1 ORDER BY t.created_at`,
+	"messages":  `SELECT row_to_json(t)::text FROM messaging.messages t WHERE t.sender_id = $1 ORDER BY t.created_at`,
+}
+
+// FuncExportUserRows renvoie toutes les lignes d'un jeu de données ("posts", "likes"...) appartenant à un utilisateur.
+func FuncExportUserRows(ctx context.Context, dataset string, userID int64) ([]map[string]any, error) {
+	query, ok := exportQueries[dataset]
+	if !ok {
+		return nil, fmt.Errorf("jeu de données d'export inconnu: %s", dataset)
+	}
+
+	rows, err := postgres.PostgresDB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'export %s: %w", dataset, err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			fmt.Println("⚠️ Erreur fermeture rows dans FuncExportUserRows:", err)
+		}
+	}(rows)
+
+	records := []map[string]any{}
+	for rows.Next() {
+		var raw string
+		if err := rows.Scan(&raw); err != nil {
+			return nil, fmt.Errorf("scan export %s: %w", dataset, err)
+		}
+		var record map[string]any
+		if err := json.Unmarshal([]byte(raw), &record); err != nil {
+			return nil, fmt.Errorf("décodage export %s: %w", dataset, err)
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/report_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+)
+
+const forensicEvidenceColumns = `id, case_id, investigator_id, author_id, post_id, culprit_id, leaked_at, object_path,
+	content_type, size_bytes, sha256, confidence, stats, created_at`
+
+// FuncOpenForensicCase verse une preuve au dossier ouvert contre le responsable (target_type = 5), en le créant
+// si besoin, dans une transaction unique. Le dossier n'agrège aucun signalement : report_count n'est pas touché.
+// inserted == false : la même image (sha256) figure déjà au dossier, la preuve existante est renvoyée.
+func FuncOpenForensicCase(ctx context.Context, e report_models.ForensicEvidencePayload, category, priority int) (
+	report_models.ReportCasePayload, report_models.ForensicEvidencePayload, bool, error,
+) {
+	tx, err := postgres.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return report_models.ReportCasePayload{}, report_models.ForensicEvidencePayload{}, false, fmt.Errorf("BeginTx FuncOpenForensicCase: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// 1. Dossier ouvert contre le responsable (même clé d'agrégation que le tri des signalements)
+	rc, err := scanReportCase(tx.QueryRowContext(ctx, fmt.Sprintf(`
+		INSERT INTO moderation.report_cases (id, target_type, target_id, target_ids, category, priority, state, report_count, created_at, updated_at)
+		VALUES ($1, 5, $2, ARRAY[$2::bigint], $3, $4, 0, 0, NOW(), NOW())
+		ON CONFLICT (target_type, target_id) WHERE state <> -1 DO UPDATE SET
+			category   = CASE WHEN EXCLUDED.priority > report_cases.priority THEN EXCLUDED.category ELSE report_cases.category END,
+			priority   = GREATEST(report_cases.priority, EXCLUDED.priority),
+			updated_at = NOW()
+		RETURNING %s
+	`, reportCaseColumns), pkg.GenerateID(), e.CulpritID, category, priority))
+	if err != nil {
+		return report_models.ReportCasePayload{}, report_models.ForensicEvidencePayload{}, false, fmt.Errorf("ouverture du dossier contre %d: %w", e.CulpritID, err)
+	}
+	e.CaseID = rc.ID
+
+	// 2. Preuve (idempotente par image)
+	stats, err := json.Marshal(e.Stats)
+	if err != nil {
+		return report_models.ReportCasePayload{}, report_models.ForensicEvidencePayload{}, false, fmt.Errorf("sérialisation des statistiques: %w", err)
+	}
+	inserted := true
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO moderation.forensic_evidence (id, case_id, investigator_id, author_id, post_id, culprit_id, leaked_at,
+			object_path, content_type, size_bytes, sha256, confidence, stats, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NOW())
+		ON CONFLICT (case_id, sha256) DO NOTHING
+		RETURNING created_at
+	`, e.ID, e.CaseID, e.InvestigatorID, e.AuthorID, e.PostID, e.CulpritID, e.LeakedAt,
+		e.ObjectPath, e.ContentType, e.SizeBytes, e.SHA256, e.Confidence, stats).Scan(&e.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		inserted = false
+		e, err = scanForensicEvidence(tx.QueryRowContext(ctx, fmt.Sprintf(
+			`SELECT %s FROM moderation.forensic_evidence WHERE case_id = $1 AND sha256 = $2`, forensicEvidenceColumns), e.CaseID, e.SHA256))
+	}
+	if err != nil {
+		return report_models.ReportCasePayload{}, report_models.ForensicEvidencePayload{}, false, fmt.Errorf("versement de la preuve au dossier %d: %w", rc.ID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return report_models.ReportCasePayload{}, report_models.ForensicEvidencePayload{}, false, fmt.Errorf("commit FuncOpenForensicCase: %w", err)
+	}
+	committed = true
+	return rc, e, inserted, nil
+}
+
+// FuncLoadCaseEvidence renvoie les preuves d'investigation versées à un dossier, de la plus ancienne à la plus récente.
+func FuncLoadCaseEvidence(ctx context.Context, caseID int64) ([]report_models.ForensicEvidencePayload, error) {
+	rows, err := postgres.PostgresDB.QueryContext(ctx, fmt.Sprintf(
+		`SELECT %s FROM moderation.forensic_evidence WHERE case_id = $1 ORDER BY created_at, id`, forensicEvidenceColumns), caseID)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'exécution de FuncLoadCaseEvidence: %w", err)
+	}
+	defer closeRows(rows, "FuncLoadCaseEvidence")
+
+	var evidence []report_models.ForensicEvidencePayload
+	for rows.Next() {
+		if e, err := scanForensicEvidence(rows); err == nil {
+			evidence = append(evidence, e)
+		}
+	}
+	return evidence, rows.Err()
+}
+
+// scanForensicEvidence lit une ligne de moderation.forensic_evidence (colonnes forensicEvidenceColumns).
+func scanForensicEvidence(row interface{ Scan(dest ...any) error }) (report_models.ForensicEvidencePayload, error) {
+	var e report_models.ForensicEvidencePayload
+	var stats []byte
+	err := row.Scan(
+		&e.ID, &e.CaseID, &e.InvestigatorID, &e.AuthorID, &e.PostID, &e.CulpritID, &e.LeakedAt, &e.ObjectPath,
+		&e.ContentType, &e.SizeBytes, &e.SHA256, &e.Confidence, &stats, &e.CreatedAt,
+	)
+	if err != nil {
+		return report_models.ForensicEvidencePayload{}, err
+	}
+	if err := json.Unmarshal(stats, &e.Stats); err != nil {
+		return report_models.ForensicEvidencePayload{}, fmt.Errorf("décodage des statistiques de la preuve %d: %w", e.ID, err)
+	}
+	return e, nil
+}
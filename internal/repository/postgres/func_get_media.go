@@ -3,24 +3,25 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/QuentinRegnier/nubo-backend/internal/domain/models" // ✅ Le bon import
 	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/postgres"
 )
 
+// mediaColumns est la liste des colonnes lues par scanMedia (dans cet ordre).
+const mediaColumns = "id, owner_id, type, storage_path, renditions, video, metadata, hash, visibility, status, created_at, updated_at"
+
 func FuncGetMedia(ctx context.Context, mediaID int64) (models.MediaRequest, error) {
-	query := `SELECT id, owner_id, storage_path, visibility, created_at, updated_at FROM content.get_media($1)`
+	// Les déclinaisons ne sont pas exposées par content.get_media : jointure sur la table
+	query := `
+		SELECT g.id, g.owner_id, m.type, g.storage_path, m.renditions, m.video, m.metadata, m.hash, g.visibility, m.status, g.created_at, g.updated_at
+		FROM content.get_media($1) g
+		JOIN content.media m ON m.id = g.id`
 
-	var m models.MediaRequest
-	err := postgres.PostgresDB.QueryRowContext(ctx, query, mediaID).Scan(
-		&m.ID,
-		&m.OwnerID,
-		&m.StoragePath,
-		&m.Visibility,
-		&m.CreatedAt,
-		&m.UpdatedAt,
-	)
+	m, err := scanMedia(postgres.PostgresDB.QueryRowContext(ctx, query, mediaID))
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -36,3 +37,25 @@ func FuncGetMedia(ctx context.Context, mediaID int64) (models.MediaRequest, erro
 
 	return m, nil
 }
+
+// scanMedia lit une ligne de content.media (colonnes mediaColumns).
+func scanMedia(row interface{ Scan(dest ...any) error }) (models.MediaRequest, error) {
+	var m models.MediaRequest
+	var renditions, video, metadata, hash []byte
+	if err := row.Scan(&m.ID, &m.OwnerID, &m.Type, &m.StoragePath, &renditions, &video, &metadata, &hash, &m.Visibility, &m.Status, &m.CreatedAt, &m.UpdatedAt); err != nil {
+		return m, err
+	}
+	if err := json.Unmarshal(renditions, &m.Renditions); err != nil {
+		return m, fmt.Errorf("erreur lors du décodage des déclinaisons du média %d: %w", m.ID, err)
+	}
+	if err := json.Unmarshal(video, &m.Video); err != nil {
+		return m, fmt.Errorf("erreur lors du décodage du rendu vidéo du média %d: %w", m.ID, err)
+	}
+	if err := json.Unmarshal(metadata, &m.Metadata); err != nil {
+		return m, fmt.Errorf("erreur lors du décodage des métadonnées du média %d: %w", m.ID, err)
+	}
+	if err := json.Unmarshal(hash, &m.Hash); err != nil {
+		return m, fmt.Errorf("erreur lors du décodage des empreintes du média %d: %w", m.ID, err)
+	}
+	return m, nil
+}
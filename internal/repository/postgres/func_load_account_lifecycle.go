@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/account_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/auth_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/postgres"
+)
+
+// FuncLoadAccountLifecycle renvoie le dernier événement de cycle de vie d'un compte (auth.account_lifecycle).
+// Un compte sans historique renvoie un payload vide (ID == 0) sans erreur.
+func FuncLoadAccountLifecycle(ctx context.Context, userID int64) (account_models.AccountLifecyclePayload, error) {
+	query := `
+		SELECT id, user_id, state, requested_at, scheduled_for, completed_at, report, digest, created_at
+		FROM auth.account_lifecycle
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+
+	var lc account_models.AccountLifecyclePayload
+	var requestedAt, scheduledFor, completedAt sql.NullTime
+	var reportBytes []byte
+	var digest sql.NullString
+
+	err := postgres.PostgresDB.QueryRowContext(ctx, query, userID).Scan(
+		&lc.ID,
+		&lc.UserID,
+		&lc.State,
+		&requestedAt,
+		&scheduledFor,
+		&completedAt,
+		&reportBytes,
+		&digest,
+		&lc.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return account_models.AccountLifecyclePayload{}, nil
+	}
+	if err != nil {
+		return account_models.AccountLifecyclePayload{}, fmt.Errorf("erreur lors de l'exécution de FuncLoadAccountLifecycle: %w", err)
+	}
+
+	if requestedAt.Valid {
+		lc.RequestedAt = requestedAt.Time
+	}
+	if scheduledFor.Valid {
+		lc.ScheduledFor = scheduledFor.Time
+	}
+	if completedAt.Valid {
+		lc.CompletedAt = completedAt.Time
+	}
+	if len(reportBytes) > 0 {
+		_ = json.Unmarshal(reportBytes, &lc.Report)
+	}
+	if digest.Valid {
+		lc.Digest = digest.String
+	}
+	return lc, nil
+}
+
+// FuncLoadDeactivatedUsers renvoie l'ID et le pseudo de tous les comptes masqués (auth.users.desactivated ou banned).
+// Utilisé pour reconstruire le SET des comptes masqués après un redémarrage à froid de Redis.
+func FuncLoadDeactivatedUsers(ctx context.Context) ([]auth_models.UserPayload, error) {
+	rows, err := postgres.PostgresDB.QueryContext(ctx, `SELECT id, username FROM auth.users WHERE desactivated = true OR banned = true`)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'exécution de FuncLoadDeactivatedUsers: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			fmt.Println("⚠️ Erreur fermeture rows dans FuncLoadDeactivatedUsers:", err)
+		}
+	}(rows)
+
+	var users []auth_models.UserPayload
+	for rows.Next() {
+		var u auth_models.UserPayload
+		if err := rows.Scan(&u.ID, &u.Username); err == nil {
+			u.Desactivated = true
+			users = append(users, u)
+		}
+	}
+	return users, rows.Err()
+}
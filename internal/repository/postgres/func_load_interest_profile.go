@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/profile_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/postgres"
+	"github.com/lib/pq"
+)
+
+// FuncLoadInterestProfile renvoie le profil d'intérêt d'un utilisateur (auth.interest_profiles).
+// Un utilisateur qui n'a jamais synchronisé renvoie un payload vide (Syncs == 0) sans erreur.
+func FuncLoadInterestProfile(ctx context.Context, userID int64) (profile_models.InterestProfilePayload, error) {
+	query := `
+		SELECT id, version, vector, interactions, syncs, computed_at, synced_at, created_at
+		FROM auth.interest_profiles
+		WHERE id = $1
+	`
+
+	var p profile_models.InterestProfilePayload
+	err := postgres.PostgresDB.QueryRowContext(ctx, query, userID).Scan(
+		&p.ID,
+		&p.Version,
+		(*pq.Float32Array)(&p.Vector),
+		&p.Interactions,
+		&p.Syncs,
+		&p.ComputedAt,
+		&p.SyncedAt,
+		&p.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return profile_models.InterestProfilePayload{}, nil
+	}
+	if err != nil {
+		return profile_models.InterestProfilePayload{}, fmt.Errorf("erreur lors de l'exécution de FuncLoadInterestProfile: %w", err)
+	}
+	return p, nil
+}
@@ -130,5 +130,10 @@ func FuncLoadUser(ID int64, Username string, Email string, Phone string) (auth_m
 		res.Work = work.String
 	}
 
+	// La couverture est postérieure à auth.func_load_user : relue à part (0 si absente)
+	if err := postgres.PostgresDB.QueryRow(`SELECT cover_picture_id FROM auth.users WHERE id = $1`, res.ID).Scan(&res.CoverPictureID); err != nil {
+		fmt.Printf("⚠️ FuncLoadUser : couverture non lue pour %d : %v\n", res.ID, err)
+	}
+
 	return res, nil
 }
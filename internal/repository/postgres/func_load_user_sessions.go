@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/postgres"
+	"github.com/lib/pq"
+)
+
+// FuncLoadUserSessions charge toutes les sessions (tous appareils) d'un utilisateur depuis auth.sessions (L3).
+func FuncLoadUserSessions(userID int64) ([]models.SessionsRequest, error) {
+	query := `
+		SELECT id, user_id, master_token, device_token, device_info, ip_history, created_at, expires_at
+		FROM auth.sessions
+		WHERE user_id = $1
+	`
+	rows, err := postgres.PostgresDB.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'exécution de FuncLoadUserSessions: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			fmt.Println("⚠️ Erreur fermeture rows dans FuncLoadUserSessions:", err)
+		}
+	}(rows)
+
+	var sessions []models.SessionsRequest
+	for rows.Next() {
+		var s models.SessionsRequest
+		var deviceToken sql.NullString
+		var deviceInfoBytes []byte
+
+		if err := rows.Scan(
+			&s.ID,
+			&s.UserID,
+			&s.MasterToken,
+			&deviceToken,
+			&deviceInfoBytes,
+			pq.Array(&s.IPHistory),
+			&s.CreatedAt,
+			&s.ExpiresAt,
+		); err != nil {
+			continue
+		}
+
+		if deviceToken.Valid {
+			s.DeviceToken = deviceToken.String
+		}
+		if len(deviceInfoBytes) > 0 {
+			_ = json.Unmarshal(deviceInfoBytes, &s.DeviceInfo)
+		}
+		sessions = append(sessions, s)
+	}
+
+	return sessions, rows.Err()
+}
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/media_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/postgres"
+	"github.com/lib/pq"
+)
+
+// FuncMediaGCOwners renvoie les médias propriétaires d'un lot d'objets MinIO, retrouvés par chemin AVIF
+// historique, par dossier de rendu vidéo ou par ID (zone de transit), avec leur état de référence.
+// Un média est référencé par un post non supprimé, par un post supprimé visé par un dossier de modération
+// ouvert (pièce à conserver), par une photo de profil ou par une couverture.
+func FuncMediaGCOwners(ctx context.Context, storagePaths []string, videoDirs []string, ids []int64) ([]media_models.MediaGCOwner, error) {
+	rows, err := postgres.PostgresDB.QueryContext(ctx, `
+		SELECT m.id, m.storage_path, regexp_replace(COALESCE(m.video->>'playlist_path', ''), '[^/]*$', ''),
+		       m.status, m.visibility, m.created_at,
+		       EXISTS (
+		           SELECT 1 FROM content.posts p
+		           WHERE p.media_ids @> ARRAY[m.id]
+		             AND (p.visibility <> -1 OR EXISTS (
+		                 SELECT 1 FROM moderation.report_cases rc
+		                 WHERE rc.target_type = 0 AND rc.target_id = p.id AND rc.state <> -1))
+		       ) OR EXISTS (
+		           SELECT 1 FROM auth.users u WHERE u.profile_picture_id = m.id
+		       ) OR EXISTS (
+		           SELECT 1 FROM auth.users u WHERE u.cover_picture_id = m.id
+		       )
+		FROM content.media m
+		WHERE m.storage_path = ANY($1)
+		   OR regexp_replace(m.video->>'playlist_path', '[^/]*$', '') = ANY($2)
+		   OR m.id = ANY($3)`,
+		pq.Array(storagePaths), pq.Array(videoDirs), pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'exécution de FuncMediaGCOwners: %w", err)
+	}
+	defer rows.Close()
+
+	var owners []media_models.MediaGCOwner
+	for rows.Next() {
+		var o media_models.MediaGCOwner
+		if err := rows.Scan(&o.ID, &o.StoragePath, &o.VideoDir, &o.Status, &o.Visibility, &o.CreatedAt, &o.Referenced); err != nil {
+			return nil, fmt.Errorf("erreur lors de la lecture de FuncMediaGCOwners: %w", err)
+		}
+		owners = append(owners, o)
+	}
+	return owners, rows.Err()
+}
@@ -0,0 +1,282 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/report_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/lib/pq"
+)
+
+const reportCaseColumns = `id, target_type, target_id, target_ids, category, priority, state, assignee_id, report_count,
+	escalation_note, resolution, resolution_note, resolved_by, resolved_at, created_at, updated_at`
+
+// ProcTriageReports rattache les signalements encore orphelins (case_id IS NULL) au dossier ouvert de leur cible,
+// en le créant si besoin, dans une transaction unique. SKIP LOCKED : plusieurs instances peuvent trier en parallèle.
+// Retourne le nombre de signalements rattachés.
+func ProcTriageReports(ctx context.Context, limit int, priority map[int]int) (int, error) {
+	tx, err := postgres.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("BeginTx ProcTriageReports: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// 1. Verrouillage du lot à trier
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, target_type, target_ids, category
+		FROM moderation.reports
+		WHERE case_id IS NULL
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`, limit)
+	if err != nil {
+		return 0, fmt.Errorf("lecture des signalements à trier: %w", err)
+	}
+	var pending []report_models.ReportPayload
+	for rows.Next() {
+		var r report_models.ReportPayload
+		if rows.Scan(&r.ID, &r.TargetType, pq.Array(&r.TargetIDs), &r.Category) == nil {
+			pending = append(pending, r)
+		}
+	}
+	closeRows(rows, "ProcTriageReports")
+
+	// 2. Agrégation : un dossier ouvert par (target_type, première cible)
+	attached := 0
+	for _, r := range pending {
+		if len(r.TargetIDs) == 0 {
+			// Signalement inexploitable : rattaché au dossier 0 pour ne plus jamais être relu
+			if _, err := tx.ExecContext(ctx, `UPDATE moderation.reports SET case_id = 0 WHERE id = $1`, r.ID); err != nil {
+				return 0, fmt.Errorf("signalement %d sans cible: %w", r.ID, err)
+			}
+			continue
+		}
+
+		var caseID int64
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO moderation.report_cases (id, target_type, target_id, target_ids, category, priority, state, report_count, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, 0, 1, NOW(), NOW())
+			ON CONFLICT (target_type, target_id) WHERE state <> -1 DO UPDATE SET
+				report_count = report_cases.report_count + 1,
+				target_ids   = ARRAY(SELECT DISTINCT unnest(report_cases.target_ids || EXCLUDED.target_ids)),
+				category     = CASE WHEN EXCLUDED.priority > report_cases.priority THEN EXCLUDED.category ELSE report_cases.category END,
+				priority     = GREATEST(report_cases.priority, EXCLUDED.priority),
+				updated_at   = NOW()
+			RETURNING id
+		`, pkg.GenerateID(), r.TargetType, r.TargetIDs[0], pq.Array(r.TargetIDs), r.Category, priority[r.Category]).Scan(&caseID)
+		if err != nil {
+			return 0, fmt.Errorf("agrégation du signalement %d: %w", r.ID, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE moderation.reports
+			SET case_id = $1, state = (SELECT state FROM moderation.report_cases WHERE id = $1)
+			WHERE id = $2
+		`, caseID, r.ID); err != nil {
+			return 0, fmt.Errorf("rattachement du signalement %d: %w", r.ID, err)
+		}
+		attached++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit ProcTriageReports: %w", err)
+	}
+	committed = true
+	return attached, nil
+}
+
+// FuncListReportCases renvoie une page de la file de modération : priorité décroissante, puis volume
+// de signalements, puis ancienneté. Sans filtre d'état, seuls les dossiers ouverts sont listés.
+func FuncListReportCases(ctx context.Context, in report_models.ListReportCasesInput) ([]report_models.ReportCasePayload, error) {
+	var where []string
+	var args []any
+	add := func(clause string, value any) {
+		args = append(args, value)
+		where = append(where, fmt.Sprintf(clause, len(args)))
+	}
+
+	if in.State != nil {
+		add("state = $%d", *in.State)
+	} else {
+		where = append(where, "state <> -1")
+	}
+	if in.Category != 0 {
+		add("category = $%d", in.Category)
+	}
+	if in.TargetType != nil {
+		add("target_type = $%d", *in.TargetType)
+	}
+	if in.TargetID != 0 {
+		add("$%d = ANY(target_ids)", in.TargetID)
+	}
+	if in.AssigneeID != 0 {
+		add("assignee_id = $%d", in.AssigneeID)
+	}
+
+	args = append(args, in.Limit, in.Offset)
+	query := fmt.Sprintf(`SELECT %s FROM moderation.report_cases WHERE %s
+		ORDER BY priority DESC, report_count DESC, created_at ASC, id ASC
+		LIMIT $%d OFFSET $%d`, reportCaseColumns, strings.Join(where, " AND "), len(args)-1, len(args))
+
+	rows, err := postgres.PostgresDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'exécution de FuncListReportCases: %w", err)
+	}
+	defer closeRows(rows, "FuncListReportCases")
+
+	var cases []report_models.ReportCasePayload
+	for rows.Next() {
+		if rc, err := scanReportCase(rows); err == nil {
+			cases = append(cases, rc)
+		}
+	}
+	return cases, rows.Err()
+}
+
+// FuncLoadReportCase charge un dossier (sql.ErrNoRows s'il n'existe pas).
+func FuncLoadReportCase(ctx context.Context, caseID int64) (report_models.ReportCasePayload, error) {
+	return scanReportCase(postgres.PostgresDB.QueryRowContext(ctx,
+		fmt.Sprintf(`SELECT %s FROM moderation.report_cases WHERE id = $1`, reportCaseColumns), caseID))
+}
+
+// FuncLoadTargetReportCases renvoie tous les dossiers (ouverts et clos) visant une cible, les plus récents en tête.
+// Une cible peut figurer dans un dossier ouvert sur une autre (signalement groupé) : target_ids est aussi consulté.
+func FuncLoadTargetReportCases(ctx context.Context, targetType int, targetID int64) ([]report_models.ReportCasePayload, error) {
+	rows, err := postgres.PostgresDB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s FROM moderation.report_cases
+		WHERE target_type = $1 AND (target_id = $2 OR $2 = ANY(target_ids))
+		ORDER BY created_at DESC, id DESC
+	`, reportCaseColumns), targetType, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'exécution de FuncLoadTargetReportCases: %w", err)
+	}
+	defer closeRows(rows, "FuncLoadTargetReportCases")
+
+	var cases []report_models.ReportCasePayload
+	for rows.Next() {
+		if rc, err := scanReportCase(rows); err == nil {
+			cases = append(cases, rc)
+		}
+	}
+	return cases, rows.Err()
+}
+
+// FuncLoadCaseReports charge les signalements rattachés à un dossier, du plus ancien au plus récent.
+func FuncLoadCaseReports(ctx context.Context, caseID int64) ([]report_models.ReportPayload, error) {
+	rows, err := postgres.PostgresDB.QueryContext(ctx, `
+		SELECT id, reporter_id, target_type, target_ids, category, reason, state, created_at, updated_at
+		FROM moderation.reports
+		WHERE case_id = $1
+		ORDER BY id
+	`, caseID)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'exécution de FuncLoadCaseReports: %w", err)
+	}
+	defer closeRows(rows, "FuncLoadCaseReports")
+
+	var reports []report_models.ReportPayload
+	for rows.Next() {
+		var r report_models.ReportPayload
+		if err := rows.Scan(&r.ID, &r.ReporterID, &r.TargetType, pq.Array(&r.TargetIDs), &r.Category, &r.Reason, &r.State, &r.CreatedAt, &r.UpdatedAt); err == nil {
+			reports = append(reports, r)
+		}
+	}
+	return reports, rows.Err()
+}
+
+// FuncTransitionReportCase applique une transition d'état en compare-and-set : la ligne n'est modifiée que si
+// son état courant figure dans AllowedStates (et, si ExpectAssignee != 0, si elle lui est assignée).
+// Les signalements du dossier suivent son état. ok == false : la condition n'était pas remplie.
+func FuncTransitionReportCase(ctx context.Context, t report_models.ReportCaseTransition) (report_models.ReportCasePayload, bool, error) {
+	tx, err := postgres.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return report_models.ReportCasePayload{}, false, fmt.Errorf("BeginTx FuncTransitionReportCase: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	rc, err := scanReportCase(tx.QueryRowContext(ctx, fmt.Sprintf(`
+		UPDATE moderation.report_cases SET
+			state           = $2,
+			assignee_id     = NULLIF($3::bigint, 0),
+			escalation_note = CASE WHEN $4 <> '' THEN $4 ELSE escalation_note END,
+			resolution      = NULLIF($5::smallint, 0),
+			resolution_note = $6,
+			resolved_by     = NULLIF($7::bigint, 0),
+			resolved_at     = CASE WHEN $2 = -1 THEN NOW() ELSE NULL END,
+			updated_at      = NOW()
+		WHERE id = $1 AND state = ANY($8) AND ($9::bigint = 0 OR assignee_id = $9)
+		RETURNING %s
+	`, reportCaseColumns),
+		t.CaseID, t.NewState, t.AssigneeID, t.EscalationNote, t.Resolution, t.ResolutionNote, t.ResolvedBy,
+		pq.Array(t.AllowedStates), t.ExpectAssignee))
+	if errors.Is(err, sql.ErrNoRows) {
+		return report_models.ReportCasePayload{}, false, nil
+	}
+	if err != nil {
+		return report_models.ReportCasePayload{}, false, fmt.Errorf("transition du dossier %d: %w", t.CaseID, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE moderation.reports SET state = $2, updated_at = NOW() WHERE case_id = $1`, t.CaseID, t.NewState); err != nil {
+		return report_models.ReportCasePayload{}, false, fmt.Errorf("synchronisation des signalements du dossier %d: %w", t.CaseID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return report_models.ReportCasePayload{}, false, fmt.Errorf("commit FuncTransitionReportCase: %w", err)
+	}
+	committed = true
+	return rc, true, nil
+}
+
+// scanReportCase lit une ligne de moderation.report_cases (colonnes reportCaseColumns).
+func scanReportCase(row interface{ Scan(dest ...any) error }) (report_models.ReportCasePayload, error) {
+	var rc report_models.ReportCasePayload
+	var assignee, resolvedBy sql.NullInt64
+	var resolution sql.NullInt32
+	var resolvedAt sql.NullTime
+
+	err := row.Scan(
+		&rc.ID,
+		&rc.TargetType,
+		&rc.TargetID,
+		pq.Array(&rc.TargetIDs),
+		&rc.Category,
+		&rc.Priority,
+		&rc.State,
+		&assignee,
+		&rc.ReportCount,
+		&rc.EscalationNote,
+		&resolution,
+		&rc.ResolutionNote,
+		&resolvedBy,
+		&resolvedAt,
+		&rc.CreatedAt,
+		&rc.UpdatedAt,
+	)
+	if err != nil {
+		return report_models.ReportCasePayload{}, err
+	}
+
+	rc.AssigneeID = assignee.Int64
+	rc.ResolvedBy = resolvedBy.Int64
+	rc.Resolution = int(resolution.Int32)
+	if resolvedAt.Valid {
+		rc.ResolvedAt = &resolvedAt.Time
+	}
+	return rc, nil
+}
@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/revision_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/postgres"
+)
+
+// FuncLoadRevisions renvoie l'historique des modifications d'un contenu, de la plus récente à la plus ancienne.
+func FuncLoadRevisions(ctx context.Context, targetType int, targetID int64, limit int) ([]revision_models.RevisionPayload, error) {
+	rows, err := postgres.PostgresDB.QueryContext(ctx, `
+		SELECT id, target_type, target_id, editor_id, snapshot, edited_at
+		FROM content.revisions
+		WHERE target_type = $1 AND target_id = $2
+		ORDER BY edited_at DESC, id DESC
+		LIMIT $3
+	`, targetType, targetID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'exécution de FuncLoadRevisions: %w", err)
+	}
+	defer closeRows(rows, "FuncLoadRevisions")
+
+	var revisions []revision_models.RevisionPayload
+	for rows.Next() {
+		var r revision_models.RevisionPayload
+		var snapshot []byte
+		if err := rows.Scan(&r.ID, &r.TargetType, &r.TargetID, &r.EditorID, &snapshot, &r.EditedAt); err != nil {
+			continue
+		}
+		_ = json.Unmarshal(snapshot, &r.Snapshot)
+		revisions = append(revisions, r)
+	}
+	return revisions, rows.Err()
+}
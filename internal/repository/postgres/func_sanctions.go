@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/sanction_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/postgres"
+)
+
+const sanctionColumns = `id, user_id, kind, scopes, reason, case_id, issued_by, automatic,
+	issued_at, expires_at, appealable_until, lifted_at, lifted_by, lift_reason`
+
+// activeSanctionClause sélectionne les sanctions en vigueur : non levées, non expirées,
+// et pour les avertissements, émis dans la fenêtre glissante des strikes ($1 jours).
+const activeSanctionClause = `lifted_at IS NULL AND (
+		(kind = 1 AND issued_at > NOW() - make_interval(days => $1))
+		OR (kind <> 1 AND (expires_at IS NULL OR expires_at > NOW()))
+	)`
+
+// FuncLoadSanction charge une sanction par son ID. Retourne sql.ErrNoRows si elle n'existe pas.
+func FuncLoadSanction(ctx context.Context, sanctionID int64) (sanction_models.SanctionPayload, error) {
+	return scanSanction(postgres.PostgresDB.QueryRowContext(ctx,
+		fmt.Sprintf(`SELECT %s FROM moderation.sanctions WHERE id = $1`, sanctionColumns), sanctionID))
+}
+
+// FuncLoadActiveSanctions renvoie les sanctions en vigueur d'un utilisateur, les plus récentes en tête.
+func FuncLoadActiveSanctions(ctx context.Context, userID int64, strikeWindowDays int) ([]sanction_models.SanctionPayload, error) {
+	return querySanctions(ctx, "FuncLoadActiveSanctions", fmt.Sprintf(
+		`SELECT %s FROM moderation.sanctions WHERE %s AND user_id = $2 ORDER BY issued_at DESC, id DESC`,
+		sanctionColumns, activeSanctionClause), strikeWindowDays, userID)
+}
+
+// FuncLoadUserSanctions renvoie tout l'historique des sanctions d'un utilisateur (levées et expirées comprises).
+func FuncLoadUserSanctions(ctx context.Context, userID int64) ([]sanction_models.SanctionPayload, error) {
+	return querySanctions(ctx, "FuncLoadUserSanctions", fmt.Sprintf(
+		`SELECT %s FROM moderation.sanctions WHERE user_id = $1 ORDER BY issued_at DESC, id DESC`,
+		sanctionColumns), userID)
+}
+
+// FuncLoadAllActiveSanctions renvoie toutes les sanctions en vigueur (reconstruction du cache après un redémarrage à froid).
+func FuncLoadAllActiveSanctions(ctx context.Context, strikeWindowDays int) ([]sanction_models.SanctionPayload, error) {
+	return querySanctions(ctx, "FuncLoadAllActiveSanctions", fmt.Sprintf(
+		`SELECT %s FROM moderation.sanctions WHERE %s ORDER BY issued_at`,
+		sanctionColumns, activeSanctionClause), strikeWindowDays)
+}
+
+// --- HELPERS ---
+
+func querySanctions(ctx context.Context, origin string, query string, args ...any) ([]sanction_models.SanctionPayload, error) {
+	rows, err := postgres.PostgresDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'exécution de %s: %w", origin, err)
+	}
+	defer closeRows(rows, origin)
+
+	var sanctions []sanction_models.SanctionPayload
+	for rows.Next() {
+		if s, err := scanSanction(rows); err == nil {
+			sanctions = append(sanctions, s)
+		}
+	}
+	return sanctions, rows.Err()
+}
+
+func scanSanction(row interface{ Scan(dest ...any) error }) (sanction_models.SanctionPayload, error) {
+	var s sanction_models.SanctionPayload
+	var caseID, liftedBy sql.NullInt64
+	var expiresAt, liftedAt sql.NullTime
+
+	err := row.Scan(
+		&s.ID,
+		&s.UserID,
+		&s.Kind,
+		&s.Scopes,
+		&s.Reason,
+		&caseID,
+		&s.IssuedBy,
+		&s.Automatic,
+		&s.IssuedAt,
+		&expiresAt,
+		&s.AppealableUntil,
+		&liftedAt,
+		&liftedBy,
+		&s.LiftReason,
+	)
+	if err != nil {
+		return sanction_models.SanctionPayload{}, err
+	}
+
+	s.CaseID = caseID.Int64
+	s.LiftedBy = liftedBy.Int64
+	if expiresAt.Valid {
+		s.ExpiresAt = expiresAt.Time
+	}
+	if liftedAt.Valid {
+		s.LiftedAt = liftedAt.Time
+	}
+	return s, nil
+}
@@ -0,0 +1,247 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/report_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/postgres"
+	"github.com/lib/pq"
+)
+
+const takedownColumns = `id, case_id, target_type, target_id, previous_visibility, score, hidden_at, released_at, restored`
+
+// FuncTakedownCandidates calcule le score pondéré des dossiers ouverts visant un post ou un commentaire
+// et sans retrait en cours, et renvoie ceux qui atteignent minScore (score décroissant).
+// Chaque signalant compte une fois par dossier (sa catégorie la plus lourde), pondérée par sa confiance :
+// 2 × (dossiers clos avec sanction du contenu + 1) / (dossiers clos + 2), bornée à [trustMin, trustMax].
+// Résolutions confirmant une infraction : 2 à 5 ; les doublons (6) ne comptent pas.
+func FuncTakedownCandidates(ctx context.Context, weights map[int]float64, trustMin, trustMax, minScore float64, limit int) ([]report_models.TakedownCandidate, error) {
+	categories := make([]int64, 0, len(weights))
+	values := make([]float64, 0, len(weights))
+	for category, weight := range weights {
+		categories = append(categories, int64(category))
+		values = append(values, weight)
+	}
+
+	rows, err := postgres.PostgresDB.QueryContext(ctx, `
+		WITH weights (category, weight) AS (
+			SELECT * FROM unnest($1::smallint[], $2::float8[])
+		),
+		open_cases AS (
+			SELECT rc.id, rc.target_type, rc.target_id
+			FROM moderation.report_cases rc
+			WHERE rc.state <> -1 AND rc.target_type IN (0, 1)
+			  AND NOT EXISTS (SELECT 1 FROM moderation.takedowns td WHERE td.case_id = rc.id)
+		),
+		reporters AS (
+			SELECT r.case_id, r.reporter_id, MAX(COALESCE(w.weight, 0)) AS weight
+			FROM moderation.reports r
+			JOIN open_cases oc ON oc.id = r.case_id
+			LEFT JOIN weights w ON w.category = r.category
+			GROUP BY r.case_id, r.reporter_id
+		),
+		trust AS (
+			SELECT r.reporter_id,
+			       2.0 * (COUNT(*) FILTER (WHERE rc.resolution BETWEEN 2 AND 5) + 1) / (COUNT(*) + 2) AS value
+			FROM moderation.reports r
+			JOIN moderation.report_cases rc ON rc.id = r.case_id
+			WHERE rc.state = -1 AND rc.resolution <> 6
+			  AND r.reporter_id IN (SELECT reporter_id FROM reporters)
+			GROUP BY r.reporter_id
+		)
+		SELECT oc.id, oc.target_type, oc.target_id,
+		       SUM(rp.weight * LEAST(GREATEST(COALESCE(t.value, 1.0), $3), $4)) AS score
+		FROM open_cases oc
+		JOIN reporters rp ON rp.case_id = oc.id
+		LEFT JOIN trust t ON t.reporter_id = rp.reporter_id
+		GROUP BY oc.id, oc.target_type, oc.target_id
+		HAVING SUM(rp.weight * LEAST(GREATEST(COALESCE(t.value, 1.0), $3), $4)) >= $5
+		ORDER BY score DESC
+		LIMIT $6
+	`, pq.Array(categories), pq.Array(values), trustMin, trustMax, minScore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'exécution de FuncTakedownCandidates: %w", err)
+	}
+	defer closeRows(rows, "FuncTakedownCandidates")
+
+	var candidates []report_models.TakedownCandidate
+	for rows.Next() {
+		var c report_models.TakedownCandidate
+		if err := rows.Scan(&c.CaseID, &c.TargetType, &c.TargetID, &c.Score); err == nil {
+			candidates = append(candidates, c)
+		}
+	}
+	return candidates, rows.Err()
+}
+
+// FuncInsertTakedown enregistre un retrait. inserted == false : le dossier a déjà donné lieu à un retrait.
+func FuncInsertTakedown(ctx context.Context, t report_models.TakedownPayload) (bool, error) {
+	res, err := postgres.PostgresDB.ExecContext(ctx, `
+		INSERT INTO moderation.takedowns (id, case_id, target_type, target_id, previous_visibility, score, hidden_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (case_id) DO NOTHING
+	`, t.ID, t.CaseID, t.TargetType, t.TargetID, t.PreviousVisibility, t.Score)
+	if err != nil {
+		return false, fmt.Errorf("erreur lors de l'exécution de FuncInsertTakedown: %w", err)
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// FuncDeleteTakedown annule un retrait qui n'a pas pu être appliqué (le prochain passage du cron le retentera).
+func FuncDeleteTakedown(ctx context.Context, caseID int64) error {
+	if _, err := postgres.PostgresDB.ExecContext(ctx, `DELETE FROM moderation.takedowns WHERE case_id = $1 AND released_at IS NULL`, caseID); err != nil {
+		return fmt.Errorf("erreur lors de l'exécution de FuncDeleteTakedown: %w", err)
+	}
+	return nil
+}
+
+// FuncLoadCaseTakedown charge le retrait d'un dossier (sql.ErrNoRows s'il n'y en a pas).
+func FuncLoadCaseTakedown(ctx context.Context, caseID int64) (report_models.TakedownPayload, error) {
+	return scanTakedown(postgres.PostgresDB.QueryRowContext(ctx,
+		fmt.Sprintf(`SELECT %s FROM moderation.takedowns WHERE case_id = $1`, takedownColumns), caseID))
+}
+
+// FuncLoadTargetTakedowns renvoie l'historique des retraits automatiques d'un contenu, les plus récents en tête.
+func FuncLoadTargetTakedowns(ctx context.Context, targetType int, targetID int64) ([]report_models.TakedownPayload, error) {
+	rows, err := postgres.PostgresDB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s FROM moderation.takedowns
+		WHERE target_type = $1 AND target_id = $2
+		ORDER BY hidden_at DESC
+	`, takedownColumns), targetType, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'exécution de FuncLoadTargetTakedowns: %w", err)
+	}
+	defer closeRows(rows, "FuncLoadTargetTakedowns")
+
+	var takedowns []report_models.TakedownPayload
+	for rows.Next() {
+		if t, err := scanTakedown(rows); err == nil {
+			takedowns = append(takedowns, t)
+		}
+	}
+	return takedowns, rows.Err()
+}
+
+// FuncReleaseTakedown clôt le retrait d'un dossier (restored : contenu rétabli ou retrait confirmé).
+// Compare-and-set sur released_at : ok == false si aucun retrait n'était en cours.
+func FuncReleaseTakedown(ctx context.Context, caseID int64, restored bool) (report_models.TakedownPayload, bool, error) {
+	t, err := scanTakedown(postgres.PostgresDB.QueryRowContext(ctx, fmt.Sprintf(`
+		UPDATE moderation.takedowns SET released_at = NOW(), restored = $2
+		WHERE case_id = $1 AND released_at IS NULL
+		RETURNING %s
+	`, takedownColumns), caseID, restored))
+	if errors.Is(err, sql.ErrNoRows) {
+		return report_models.TakedownPayload{}, false, nil
+	}
+	if err != nil {
+		return report_models.TakedownPayload{}, false, fmt.Errorf("erreur lors de l'exécution de FuncReleaseTakedown: %w", err)
+	}
+	return t, true, nil
+}
+
+// FuncRestoreTakedown rétablit le retrait d'un dossier après coup (appel accueilli), qu'il soit encore en cours
+// ou déjà confirmé. ok == false : aucun retrait, déjà rétabli, ou contenu supprimé définitivement.
+func FuncRestoreTakedown(ctx context.Context, caseID int64) (report_models.TakedownPayload, bool, error) {
+	t, err := scanTakedown(postgres.PostgresDB.QueryRowContext(ctx, fmt.Sprintf(`
+		UPDATE moderation.takedowns SET released_at = COALESCE(released_at, NOW()), restored = TRUE
+		WHERE case_id = $1 AND restored IS DISTINCT FROM TRUE AND purged_at IS NULL
+		RETURNING %s
+	`, takedownColumns), caseID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return report_models.TakedownPayload{}, false, nil
+	}
+	if err != nil {
+		return report_models.TakedownPayload{}, false, fmt.Errorf("erreur lors de l'exécution de FuncRestoreTakedown: %w", err)
+	}
+	return t, true, nil
+}
+
+// FuncUnreleasedClosedCases renvoie les dossiers clos depuis plus de graceSeconds dont le retrait est encore en
+// cours : leur libération a été interrompue et doit être rejouée.
+func FuncUnreleasedClosedCases(ctx context.Context, closedState int, graceSeconds int, limit int) ([]report_models.ReportCasePayload, error) {
+	rows, err := postgres.PostgresDB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s FROM moderation.report_cases
+		WHERE state = $1 AND resolved_at <= NOW() - make_interval(secs => $2)
+		  AND id IN (SELECT case_id FROM moderation.takedowns WHERE released_at IS NULL)
+		ORDER BY resolved_at
+		LIMIT $3
+	`, reportCaseColumns), closedState, graceSeconds, limit)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'exécution de FuncUnreleasedClosedCases: %w", err)
+	}
+	defer closeRows(rows, "FuncUnreleasedClosedCases")
+
+	var cases []report_models.ReportCasePayload
+	for rows.Next() {
+		if rc, err := scanReportCase(rows); err == nil {
+			cases = append(cases, rc)
+		}
+	}
+	return cases, rows.Err()
+}
+
+// FuncPurgeableTakedowns renvoie les retraits confirmés depuis plus de appealDays jours dont aucun appel
+// n'est en attente : leur contenu peut être supprimé définitivement.
+func FuncPurgeableTakedowns(ctx context.Context, appealDays int, limit int) ([]report_models.TakedownPayload, error) {
+	rows, err := postgres.PostgresDB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s FROM moderation.takedowns td
+		WHERE td.restored = FALSE AND td.purged_at IS NULL
+		  AND td.released_at <= NOW() - make_interval(days => $1)
+		  AND NOT EXISTS (
+		      SELECT 1 FROM moderation.sanctions s
+		      JOIN moderation.appeals a ON a.sanction_id = s.id
+		      WHERE s.case_id = td.case_id AND a.state <> -1
+		  )
+		ORDER BY td.released_at
+		LIMIT $2
+	`, takedownColumns), appealDays, limit)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'exécution de FuncPurgeableTakedowns: %w", err)
+	}
+	defer closeRows(rows, "FuncPurgeableTakedowns")
+
+	var takedowns []report_models.TakedownPayload
+	for rows.Next() {
+		if t, err := scanTakedown(rows); err == nil {
+			takedowns = append(takedowns, t)
+		}
+	}
+	return takedowns, rows.Err()
+}
+
+// FuncMarkTakedownPurged enregistre la suppression définitive du contenu d'un retrait confirmé.
+// ok == false : le retrait a été rétabli entre-temps (appel accueilli) ou déjà purgé.
+func FuncMarkTakedownPurged(ctx context.Context, caseID int64) (bool, error) {
+	res, err := postgres.PostgresDB.ExecContext(ctx, `
+		UPDATE moderation.takedowns SET purged_at = NOW()
+		WHERE case_id = $1 AND restored = FALSE AND purged_at IS NULL
+	`, caseID)
+	if err != nil {
+		return false, fmt.Errorf("erreur lors de l'exécution de FuncMarkTakedownPurged: %w", err)
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// scanTakedown lit une ligne de moderation.takedowns (colonnes takedownColumns).
+func scanTakedown(row interface{ Scan(dest ...any) error }) (report_models.TakedownPayload, error) {
+	var t report_models.TakedownPayload
+	var releasedAt sql.NullTime
+	var restored sql.NullBool
+
+	err := row.Scan(&t.ID, &t.CaseID, &t.TargetType, &t.TargetID, &t.PreviousVisibility, &t.Score, &t.HiddenAt, &releasedAt, &restored)
+	if err != nil {
+		return report_models.TakedownPayload{}, err
+	}
+	if releasedAt.Valid {
+		t.ReleasedAt = &releasedAt.Time
+	}
+	if restored.Valid {
+		t.Restored = &restored.Bool
+	}
+	return t, nil
+}
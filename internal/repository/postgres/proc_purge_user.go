@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/account_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/postgres"
+	"github.com/lib/pq"
+)
+
+// FuncLoadUserFootprint inventorie les posts, commentaires et médias d'un utilisateur (L3, source de vérité).
+// Toute erreur de lecture fait échouer l'inventaire : une purge sur un inventaire partiel laisserait des orphelins.
+func FuncLoadUserFootprint(ctx context.Context, userID int64) (account_models.UserFootprint, error) {
+	fp := account_models.UserFootprint{CommentRefs: make(map[int64]int64)}
+
+	// 1. Posts
+	rows, err := postgres.PostgresDB.QueryContext(ctx, `SELECT id FROM content.posts WHERE user_id = $1`, userID)
+	if err != nil {
+		return fp, fmt.Errorf("inventaire posts: %w", err)
+	}
+	defer closeRows(rows, "FuncLoadUserFootprint (posts)")
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return fp, fmt.Errorf("inventaire posts: %w", err)
+		}
+		fp.PostIDs = append(fp.PostIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return fp, fmt.Errorf("inventaire posts: %w", err)
+	}
+
+	// 2. Commentaires (les siens + ceux posés sous ses posts)
+	rows, err = postgres.PostgresDB.QueryContext(ctx,
+		`SELECT id, post_id FROM content.comments WHERE user_id = $1 OR post_id = ANY($2)`,
+		userID, pq.Array(fp.PostIDs))
+	if err != nil {
+		return fp, fmt.Errorf("inventaire commentaires: %w", err)
+	}
+	defer closeRows(rows, "FuncLoadUserFootprint (comments)")
+	for rows.Next() {
+		var id, postID int64
+		if err := rows.Scan(&id, &postID); err != nil {
+			return fp, fmt.Errorf("inventaire commentaires: %w", err)
+		}
+		fp.CommentRefs[id] = postID
+	}
+	if err := rows.Err(); err != nil {
+		return fp, fmt.Errorf("inventaire commentaires: %w", err)
+	}
+
+	// 3. Médias (chemins MinIO inclus)
+	rows, err = postgres.PostgresDB.QueryContext(ctx,
+		`SELECT `+mediaColumns+` FROM content.media WHERE owner_id = $1`, userID)
+	if err != nil {
+		return fp, fmt.Errorf("inventaire médias: %w", err)
+	}
+	defer closeRows(rows, "FuncLoadUserFootprint (media)")
+	for rows.Next() {
+		m, err := scanMedia(rows)
+		if err != nil {
+			return fp, fmt.Errorf("inventaire médias: %w", err)
+		}
+		fp.Media = append(fp.Media, m)
+	}
+	if err := rows.Err(); err != nil {
+		return fp, fmt.Errorf("inventaire médias: %w", err)
+	}
+
+	return fp, nil
+}
+
+// ProcPurgeUser efface définitivement un utilisateur de L3 dans une transaction unique :
+// compteurs des posts tiers corrigés, likes, commentaires, posts, médias, puis auth.proc_delete_user
+// (utilisateur, réglages, sessions, relations).
+// Retourne le nombre de lignes supprimées et les posts tiers dont les compteurs ont changé (à invalider en L1).
+func ProcPurgeUser(ctx context.Context, userID int64, fp account_models.UserFootprint) (int64, []int64, error) {
+	tx, err := postgres.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("BeginTx ProcPurgeUser: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	commentIDs := make([]int64, 0, len(fp.CommentRefs))
+	for id := range fp.CommentRefs {
+		commentIDs = append(commentIDs, id)
+	}
+
+	// 1. Correction des compteurs des posts tiers (likes et commentaires laissés par l'utilisateur)
+	var touched []int64
+	counterQueries := []string{
+		`UPDATE content.posts p SET like_count = GREATEST(0, p.like_count - x.n)
+		 FROM (SELECT target_id, COUNT(*) AS n FROM content.likes WHERE user_id = $1 AND target_type = 0 GROUP BY target_id) x
+		 WHERE p.id = x.target_id AND p.user_id <> $1 RETURNING p.id`,
+		`UPDATE content.posts p SET comment_count = GREATEST(0, p.comment_count - x.n)
+		 FROM (SELECT post_id, COUNT(*) AS n FROM content.comments WHERE user_id = $1 GROUP BY post_id) x
+		 WHERE p.id = x.post_id AND p.user_id <> $1 RETURNING p.id`,
+	}
+	for _, q := range counterQueries {
+		rows, err := tx.QueryContext(ctx, q, userID)
+		if err != nil {
+			return 0, nil, fmt.Errorf("correction compteurs: %w", err)
+		}
+		for rows.Next() {
+			var id int64
+			if rows.Scan(&id) == nil {
+				touched = append(touched, id)
+			}
+		}
+		closeRows(rows, "ProcPurgeUser (compteurs)")
+	}
+
+	// 2. Suppressions en ordre topologique (enfants avant parents)
+	var deleted int64
+	steps := []struct {
+		query string
+		args  []any
+	}{
+		{`DELETE FROM content.likes WHERE user_id = $1 OR (target_type = 0 AND target_id = ANY($2)) OR (target_type = 1 AND target_id = ANY($3))`,
+			[]any{userID, pq.Array(fp.PostIDs), pq.Array(commentIDs)}},
+		{`DELETE FROM content.comments WHERE user_id = $1 OR post_id = ANY($2)`, []any{userID, pq.Array(fp.PostIDs)}},
+		{`DELETE FROM content.posts WHERE user_id = $1`, []any{userID}},
+		{`DELETE FROM content.media WHERE owner_id = $1`, []any{userID}},
+	}
+	for _, step := range steps {
+		res, err := tx.ExecContext(ctx, step.query, step.args...)
+		if err != nil {
+			return 0, nil, fmt.Errorf("purge contenu: %w", err)
+		}
+		if n, err := res.RowsAffected(); err == nil {
+			deleted += n
+		}
+	}
+
+	// 3. Procédure du schéma auth (utilisateur + dépendances)
+	if _, err := tx.ExecContext(ctx, `CALL auth.proc_delete_user($1, NULL, NULL, NULL, NULL, NULL, NULL)`, userID); err != nil {
+		return 0, nil, fmt.Errorf("erreur lors de l'exécution de auth.proc_delete_user: %w", err)
+	}
+	deleted++
+
+	if err := tx.Commit(); err != nil {
+		return 0, nil, fmt.Errorf("commit ProcPurgeUser: %w", err)
+	}
+	committed = true
+	return deleted, touched, nil
+}
+
+// closeRows ferme un curseur en journalisant l'éventuelle erreur.
+func closeRows(rows *sql.Rows, origin string) {
+	if err := rows.Close(); err != nil {
+		fmt.Printf("⚠️ Erreur fermeture rows dans %s: %v\n", origin, err)
+	}
+}
@@ -43,6 +43,12 @@ const (
 	EntityView         EntityType = "VIEW"
 	EntityFeed         EntityType = "Feeds"
 	EntityReport       EntityType = "Reports"
+	EntityRevision     EntityType = "Revisions"
+
+	EntityAccountLifecycle EntityType = "AccountLifecycle"
+	EntityInterestProfile  EntityType = "InterestProfiles"
+	EntityAuditLog         EntityType = "AuditLog"
+	EntitySanction         EntityType = "Sanctions"
 )
 
 // DBTarget : Bitmask pour savoir où envoyer (Mongo, Postgres, ou les deux)
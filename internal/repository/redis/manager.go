@@ -72,6 +72,47 @@ var (
 
 	// --- Activity Feed ---
 	FeedSchedule *Collection
+
+	// --- AUTH / RÉCUPÉRATION DE COMPTE ---
+	PasswordResets *Collection
+
+	// --- ANTI-REJEU HMAC ---
+	HMACNonces *Collection
+
+	// --- IDEMPOTENCE DES ROUTES MUTATRICES ---
+	IdempotencyKeys *Collection
+
+	// --- CYCLE DE VIE DES COMPTES ---
+	AccountLifecycle *Collection
+	HiddenAccounts   *Collection
+	AccountDeletions *Collection
+	AccountExports   *Collection
+	ExportQueue      *Collection
+
+	// --- PROFIL D'INTÉRÊT ---
+	InterestProfiles *Collection
+	ProfileSyncs     *Collection
+
+	// --- TRAITEMENT DES MÉDIAS ---
+	MediaJobs        *Collection
+	MediaQueue       *Collection
+	MediaPostGates   *Collection
+	ProfileImages    *Collection
+	UploadSessions   *Collection
+	UploadParts      *Collection
+	MediaHashes      *Collection
+	MediaHashBands   *Collection
+	MediaRevocations *Collection
+	MediaGC          *Collection
+
+	// --- SANCTIONS ---
+	Sanctions        *Collection
+	UserRestrictions *Collection
+	SanctionStrikes  *Collection
+	UserBans         *Collection
+	BanExpiries      *Collection
+	AppealTokens     *Collection
+	FlaggedPosts     *Collection
 )
 
 func InitCacheDatabase() {
@@ -130,6 +171,59 @@ func InitCacheDatabase() {
 
 	// --- Activity Feed ---
 	FeedSchedule = NewCollection("feed:precompute:schedule", 0)
+
+	// --- AUTH / RÉCUPÉRATION DE COMPTE (TTL = durée de validité du jeton) ---
+	PasswordResets = NewCollection("auth:password_reset", time.Duration(variables.PasswordResetTokenTTLSeconds)*time.Second)
+
+	// --- ANTI-REJEU HMAC (TTL = fenêtre de tolérance du timestamp) ---
+	HMACNonces = NewCollection("hmac:nonce", time.Duration(variables.ToleranceTimeSeconds)*time.Second)
+
+	// --- IDEMPOTENCE (Réponses mémorisées 24h) ---
+	IdempotencyKeys = NewCollection("idempotency", time.Duration(variables.IdempotencyTTLSeconds)*time.Second)
+
+	// --- CYCLE DE VIE DES COMPTES (TTL infini : l'état doit survivre jusqu'à la cascade) ---
+	AccountLifecycle = NewCollection("account:lifecycle", 0)
+	HiddenAccounts = NewCollection("account:hidden", 0)     // SET unique "all" des comptes masqués
+	AccountDeletions = NewCollection("account:deletion", 0) // ZSET "schedule" (score = échéance Unix)
+
+	// --- EXPORT RGPD (TTL = durée de conservation de l'archive) ---
+	AccountExports = NewCollection("account:export", time.Duration(variables.ExportRetentionHours)*time.Hour)
+	ExportQueue = NewCollection("account:export:queue", 0) // ZSET "pending" (score = date de demande Unix)
+
+	// --- PROFIL D'INTÉRÊT (TTL = intervalle minimal entre deux synchronisations pour le verrou) ---
+	InterestProfiles = NewCollection("profile:interest", variables.StandardTTL)
+	ProfileSyncs = NewCollection("profile:sync", time.Duration(variables.ProfileSyncMinIntervalSeconds)*time.Second)
+
+	// --- TRAITEMENT DES MÉDIAS (TTL infini tant que le job n'est pas terminé) ---
+	MediaJobs = NewCollection("media:job", 0)
+	MediaQueue = NewCollection("media:job:queue", 0)     // ZSET "pending" (score = échéance Unix) et "running" (score = fin du bail)
+	MediaPostGates = NewCollection("media:post_gate", 0) // HASH par post : médias restants, échecs, visibilité demandée
+
+	// --- PHOTOS DE PROFIL EN TRAITEMENT ("<user_id>:<layout>" → ID du média qui remplacera l'image courante) ---
+	ProfileImages = NewCollection("media:profile_pending", time.Duration(variables.MediaJobRetentionHours)*time.Hour)
+
+	// --- UPLOADS VIDÉO PAR MORCEAUX (TTL = durée de vie d'une session inachevée) ---
+	UploadSessions = NewCollection("media:upload", time.Duration(variables.VideoUploadTTLHours)*time.Hour)
+	UploadParts = NewCollection("media:upload:parts", time.Duration(variables.VideoUploadTTLHours)*time.Hour) // HASH index -> ETag
+
+	// --- EMPREINTES PERCEPTUELLES (TTL infini : reconstructibles depuis content.media.hash) ---
+	MediaHashes = NewCollection("media:phash", 0)         // HASH "media" (ID → empreinte) et "blocked" (source → entrée bloquée)
+	MediaHashBands = NewCollection("media:phash:band", 0) // SET "<media|blocked>:<rang>:<tranche>" → IDs (index multiple)
+
+	// --- RÉVOCATION DES LIENS MÉDIAS (TTL = durée de vie maximale d'un lien) ---
+	MediaRevocations = NewCollection(variables.MediaRevocationPrefix, (variables.WatermarkURLMaxAgeSeconds+variables.WatermarkURLClockSkewSeconds)*time.Second) // Périmètre → révocation Unix
+
+	// --- RAMASSE-MIETTES DU STOCKAGE ---
+	MediaGC = NewCollection("media:gc", 0) // "lock" (verrou d'exécution) et "last" (dernier rapport)
+
+	// --- SANCTIONS (les restrictions expirent d'elles-mêmes : TTL posé à l'écriture) ---
+	Sanctions = NewCollection("moderation:sanction", variables.StandardTTL)
+	UserRestrictions = NewCollection("moderation:restriction", 0) // Clé "<user_id>:<scope>" → sanction la plus longue
+	SanctionStrikes = NewCollection("moderation:strikes", 0)      // ZSET par utilisateur (score = émission Unix)
+	UserBans = NewCollection("moderation:bans", 0)                // SET par utilisateur des bans émis (élagué à la lecture)
+	BanExpiries = NewCollection("moderation:ban_expiry", 0)       // ZSET "schedule" (score = échéance Unix)
+	AppealTokens = NewCollection("moderation:appeal_token", time.Duration(variables.AppealTokenTTLMinutes)*time.Minute)
+	FlaggedPosts = NewCollection("moderation:flagged", time.Duration(variables.TakedownFlagTTLHours)*time.Hour) // Post → dossier ouvert (pénalité φ_mod)
 }
 
 // IsReady isole l'état de l'infrastructure pour les routines de maintenance administratives.
@@ -229,6 +323,28 @@ func (c *Collection) GetObject(ctx context.Context, id any, dest any) error {
 	return msgpack.Unmarshal(val, dest)
 }
 
+// SetObjectTTL stocke une struct en MsgPack avec un TTL explicite (objets à échéance propre).
+func (c *Collection) SetObjectTTL(ctx context.Context, id any, data any, ttl time.Duration) error {
+	msgpackBytes, err := msgpack.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("redis marshal nubo_error: %w", err)
+	}
+	return c.Client.Set(ctx, c.Key(id), msgpackBytes, ttl).Err()
+}
+
+// SetObjectNX stocke une struct en MsgPack uniquement si la clé est absente (verrou applicatif).
+// ttl <= 0 applique le TTL par défaut de la collection. Retourne false si la clé existait déjà.
+func (c *Collection) SetObjectNX(ctx context.Context, id any, data any, ttl time.Duration) (bool, error) {
+	msgpackBytes, err := msgpack.Marshal(data)
+	if err != nil {
+		return false, fmt.Errorf("redis marshal nubo_error: %w", err)
+	}
+	if ttl <= 0 {
+		ttl = c.DefaultTTL
+	}
+	return c.Client.SetNX(ctx, c.Key(id), msgpackBytes, ttl).Result()
+}
+
 // DeleteObject supprime un objet du cache_service.
 func (c *Collection) DeleteObject(ctx context.Context, id any) error {
 	return c.Client.Del(ctx, c.Key(id)).Err()
@@ -286,6 +402,11 @@ func (c *Collection) SMembers(ctx context.Context, id any) ([]string, error) {
 	return c.Client.SMembers(ctx, c.Key(id)).Result()
 }
 
+// SIsMember teste l'appartenance d'un membre au SET en O(1).
+func (c *Collection) SIsMember(ctx context.Context, id any, member any) (bool, error) {
+	return c.Client.SIsMember(ctx, c.Key(id), member).Result()
+}
+
 func (c *Collection) SCard(ctx context.Context, id any) (int64, error) {
 	return c.Client.SCard(ctx, c.Key(id)).Result()
 }
@@ -300,6 +421,21 @@ func (c *Collection) GetInt64(ctx context.Context, id any) (int64, error) {
 	return c.Client.Get(ctx, c.Key(id)).Int64()
 }
 
+// SetNX pose une valeur uniquement si la clé est absente (verrou / nonce).
+// ttl <= 0 applique le TTL par défaut de la collection. Retourne false si la clé existait déjà.
+func (c *Collection) SetNX(ctx context.Context, id any, val any, ttl time.Duration) (bool, error) {
+	if ttl <= 0 {
+		ttl = c.DefaultTTL
+	}
+	return c.Client.SetNX(ctx, c.Key(id), val, ttl).Result()
+}
+
+// GetDelInt64 lit puis supprime atomiquement une valeur primitive (jetons à usage unique).
+// Retourne redis.Nil si la clé est absente ou déjà consommée.
+func (c *Collection) GetDelInt64(ctx context.Context, id any) (int64, error) {
+	return c.Client.GetDel(ctx, c.Key(id)).Int64()
+}
+
 // MGet expose l'accès multiple brut. Préférer GetMany quand c'est possible, mais vital pour les clés composites.
 func (c *Collection) MGet(ctx context.Context, ids ...any) ([]interface{}, error) {
 	if len(ids) == 0 {
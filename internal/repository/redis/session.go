@@ -0,0 +1,108 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+)
+
+// ---------------- SESSIONS (Ratchet) ----------------
+
+// RedisLoadSession retrouve une session active en L1 à partir de l'index "session_cache:<userID>:<deviceToken>".
+// Si deviceToken est vide, toutes les sessions de l'utilisateur sont parcourues (SCAN) et la première
+// qui correspond aux filtres masterToken / currentSecret est retournée.
+func RedisLoadSession(userID int64, deviceToken string, masterToken string, currentSecret string) (models.SessionsRequest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var sessionIDs []int64
+
+	if deviceToken != "" {
+		id, err := SessionIndexes.GetInt64(ctx, fmt.Sprintf("%d:%s", userID, deviceToken))
+		if err != nil {
+			return models.SessionsRequest{}, fmt.Errorf("session introuvable dans redis (index miss): %w", err)
+		}
+		sessionIDs = append(sessionIDs, id)
+	} else {
+		ids, err := ListUserSessionIDs(ctx, userID)
+		if err != nil {
+			return models.SessionsRequest{}, err
+		}
+		sessionIDs = ids
+	}
+
+	for _, id := range sessionIDs {
+		var s models.SessionsRequest
+		if err := Sessions.GetObject(ctx, id, &s); err != nil {
+			continue
+		}
+		if masterToken != "" && s.MasterToken != masterToken {
+			continue
+		}
+		if currentSecret != "" && s.CurrentSecret != currentSecret {
+			continue
+		}
+		return s, nil
+	}
+
+	return models.SessionsRequest{}, errors.New("session introuvable dans redis")
+}
+
+// RedisUpdateSession réécrit l'objet session et son index de recherche (TTL réinitialisé).
+func RedisUpdateSession(s models.SessionsRequest) error {
+	if s.ID == 0 {
+		return errors.New("RedisUpdateSession: ID de session manquant")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := Sessions.SetObject(ctx, s.ID, s); err != nil {
+		return err
+	}
+	if s.UserID != 0 && s.DeviceToken != "" {
+		return SessionIndexes.SetPrimitive(ctx, fmt.Sprintf("%d:%s", s.UserID, s.DeviceToken), s.ID)
+	}
+	return nil
+}
+
+// ListUserSessionIDs renvoie les IDs de toutes les sessions indexées en L1 pour un utilisateur (SCAN non bloquant).
+func ListUserSessionIDs(ctx context.Context, userID int64) ([]int64, error) {
+	pattern := SessionIndexes.Key(fmt.Sprintf("%d:*", userID))
+
+	var ids []int64
+	var cursor uint64
+	for {
+		keys, next, err := SessionIndexes.Client.Scan(ctx, cursor, pattern, 100).Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range keys {
+			id, err := SessionIndexes.Client.Get(ctx, k).Int64()
+			if err == nil && id != 0 {
+				ids = append(ids, id)
+			}
+		}
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+	return ids, nil
+}
+
+// DeleteSessionFromCache purge l'objet session et son index de L1. Réservé aux sessions déjà absentes de
+// L2/L3 (compte purgé) : une révocation ordinaire garde un tombstone (voir auth_service.RevokeUserSessions).
+func DeleteSessionFromCache(ctx context.Context, s models.SessionsRequest) error {
+	if err := Sessions.DeleteObject(ctx, s.ID); err != nil {
+		return err
+	}
+	if s.UserID != 0 && strings.TrimSpace(s.DeviceToken) != "" {
+		return SessionIndexes.DeleteObject(ctx, fmt.Sprintf("%d:%s", s.UserID, s.DeviceToken))
+	}
+	return nil
+}
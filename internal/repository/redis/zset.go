@@ -204,3 +204,42 @@ func ZScores(ctx context.Context, key string, members []string) ([]float64, erro
 	}
 	return scores, nil
 }
+
+// ZRemFromMatching retire des membres de tous les ZSETs dont la clé correspond au motif (SCAN non bloquant + Pipeline).
+// Complexité : O(K) clés parcourues, un aller-retour par lot de 100 clés.
+// Utilisé pour purger un post de tous les classements et tendances du MOST Cache.
+// Retourne le nombre total de membres effectivement retirés.
+func ZRemFromMatching(ctx context.Context, pattern string, members ...interface{}) (int64, error) {
+	if len(members) == 0 {
+		return 0, nil
+	}
+
+	var removed int64
+	var cursor uint64
+	for {
+		keys, next, err := redisgo.Rdb.ScanType(ctx, cursor, pattern, 100, "zset").Result()
+		if err != nil {
+			return removed, err
+		}
+
+		if len(keys) > 0 {
+			pipe := redisgo.Rdb.Pipeline()
+			cmds := make([]*redis.IntCmd, len(keys))
+			for i, k := range keys {
+				cmds[i] = pipe.ZRem(ctx, k, members...)
+			}
+			if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+				return removed, err
+			}
+			for _, cmd := range cmds {
+				removed += cmd.Val()
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+	return removed, nil
+}
@@ -0,0 +1,268 @@
+package admin_service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/admin_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/comment_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/post_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/report_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/revision_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service/object_cache_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/media_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// ============================================================================
+// FICHES D'ADMINISTRATION : POST, COMMENTAIRE, MESSAGE
+// ============================================================================
+
+// LoadPostInformation construit la fiche consolidée d'un post : version L3 et L1, compteurs confrontés
+// (L1, L3 dénormalisé, recomptage des lignes), médias, historique des modifications, dossiers et retraits.
+func LoadPostInformation(ctx context.Context, viewer audit.Actor, postID int64) (admin_models.PostInformationOutput, error) {
+	post, err := postgres.FuncAdminLoadPost(ctx, postID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return admin_models.PostInformationOutput{}, nubo_error.ErrNotFound
+	}
+	if err != nil {
+		return admin_models.PostInformationOutput{}, err
+	}
+
+	out := admin_models.PostInformationOutput{Post: post, Discrepancies: []string{}}
+
+	// 1. Overlay L1
+	var l1Likes, l1Views, l1Comments *int64
+	if cached, err := object_cache_service.GetPostFromObjectCache(ctx, postID); err == nil {
+		cached.Vector = nil // Volumineux et sans intérêt pour la modération
+		out.Cached = &cached
+		out.Discrepancies = postDiscrepancies(post, cached)
+		l1Likes, l1Views, l1Comments = count(cached.LikeCount), count(cached.ViewCount), count(cached.CommentCount)
+	}
+
+	// 2. Compteurs (les vues ne sont pas conservées ligne à ligne : pas de recomptage possible)
+	likeRows, err := postgres.FuncCountTargetLikes(ctx, 0, postID) // 0 = Post
+	if err != nil {
+		return admin_models.PostInformationOutput{}, err
+	}
+	commentRows, err := postgres.FuncCountPostComments(ctx, postID)
+	if err != nil {
+		return admin_models.PostInformationOutput{}, err
+	}
+	out.Likes = counter(l1Likes, int64(post.LikeCount), &likeRows)
+	out.Views = counter(l1Views, int64(post.ViewCount), nil)
+	out.Comments = counter(l1Comments, int64(post.CommentCount), &commentRows)
+
+	// 3. Médias (supprimés compris, lien tatoué au nom du modérateur)
+	if out.Media, err = loadMedia(ctx, viewer, post); err != nil {
+		return admin_models.PostInformationOutput{}, err
+	}
+
+	// 4. Historique et modération
+	if out.Revisions, out.Cases, out.Takedowns, err = loadModeration(ctx, variables.ReportTargetPost, postID); err != nil {
+		return admin_models.PostInformationOutput{}, err
+	}
+
+	audit.Record(ctx, viewer, audit.ActionPrivateInfo, audit.On(audit.TargetPost, postID), map[string]any{
+		"author_id":     post.UserID,
+		"discrepancies": out.Discrepancies,
+	})
+	return out, nil
+}
+
+// LoadCommentInformation construit la fiche consolidée d'un commentaire.
+func LoadCommentInformation(ctx context.Context, viewer audit.Actor, commentID int64) (admin_models.CommentInformationOutput, error) {
+	comment, err := postgres.FuncAdminLoadComment(ctx, commentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return admin_models.CommentInformationOutput{}, nubo_error.ErrNotFound
+	}
+	if err != nil {
+		return admin_models.CommentInformationOutput{}, err
+	}
+
+	out := admin_models.CommentInformationOutput{Comment: comment, Discrepancies: []string{}}
+
+	var l1Likes *int64
+	if cached, err := object_cache_service.GetCommentFromObjectCache(ctx, commentID); err == nil {
+		out.Cached = &cached
+		out.Discrepancies = commentDiscrepancies(comment, cached)
+		l1Likes = count(cached.LikeCount)
+	}
+
+	likeRows, err := postgres.FuncCountTargetLikes(ctx, 1, commentID) // 1 = Commentaire
+	if err != nil {
+		return admin_models.CommentInformationOutput{}, err
+	}
+	out.Likes = counter(l1Likes, int64(comment.LikeCount), &likeRows)
+
+	if out.Revisions, out.Cases, out.Takedowns, err = loadModeration(ctx, variables.ReportTargetComment, commentID); err != nil {
+		return admin_models.CommentInformationOutput{}, err
+	}
+
+	audit.Record(ctx, viewer, audit.ActionPrivateInfo, audit.On(audit.TargetComment, commentID), map[string]any{
+		"author_id":     comment.UserID,
+		"post_id":       comment.PostID,
+		"discrepancies": out.Discrepancies,
+	})
+	return out, nil
+}
+
+// LoadMessageInformation construit la fiche d'un message privé : ligne L3, objet L1 et dossiers de modération.
+func LoadMessageInformation(ctx context.Context, viewer audit.Actor, messageID int64) (admin_models.MessageInformationOutput, error) {
+	message, err := postgres.FuncAdminLoadMessage(ctx, messageID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return admin_models.MessageInformationOutput{}, nubo_error.ErrNotFound
+	}
+	if err != nil {
+		return admin_models.MessageInformationOutput{}, err
+	}
+
+	out := admin_models.MessageInformationOutput{Message: message}
+
+	var cached map[string]any
+	if err := redis.Messages.GetObject(ctx, messageID, &cached); err == nil {
+		out.Cached = cached
+	}
+
+	cases, err := postgres.FuncLoadTargetReportCases(ctx, variables.ReportTargetMessage, messageID)
+	if err != nil {
+		return admin_models.MessageInformationOutput{}, err
+	}
+	out.Cases = cases
+	if out.Cases == nil {
+		out.Cases = []report_models.ReportCasePayload{}
+	}
+
+	audit.Record(ctx, viewer, audit.ActionPrivateInfo, audit.On(audit.TargetMessage, messageID), map[string]any{
+		"conversation_id": message["conversation_id"],
+		"sender_id":       message["sender_id"],
+	})
+	return out, nil
+}
+
+// --- HELPERS ---
+
+// loadModeration charge l'historique des modifications, les dossiers et les retraits automatiques d'un contenu.
+func loadModeration(ctx context.Context, targetType int, targetID int64) (
+	[]revision_models.RevisionPayload, []report_models.ReportCasePayload, []report_models.TakedownPayload, error,
+) {
+	revisions, err := postgres.FuncLoadRevisions(ctx, targetType, targetID, variables.AdminRevisionLimit)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	cases, err := postgres.FuncLoadTargetReportCases(ctx, targetType, targetID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	takedowns, err := postgres.FuncLoadTargetTakedowns(ctx, targetType, targetID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if revisions == nil {
+		revisions = []revision_models.RevisionPayload{}
+	}
+	if cases == nil {
+		cases = []report_models.ReportCasePayload{}
+	}
+	if takedowns == nil {
+		takedowns = []report_models.TakedownPayload{}
+	}
+	return revisions, cases, takedowns, nil
+}
+
+// loadMedia charge les médias d'un post en L3 (ordre du post conservé), marqués s'ils sont en L1.
+// Le lien est tatoué au nom du modérateur : une fuite depuis la console reste traçable.
+func loadMedia(ctx context.Context, viewer audit.Actor, post post_models.PostPayload) ([]admin_models.MediaInformation, error) {
+	out := []admin_models.MediaInformation{}
+	if len(post.MediaIDs) == 0 {
+		return out, nil
+	}
+
+	media, err := postgres.FuncAdminLoadMedia(ctx, post.MediaIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range post.MediaIDs {
+		i := slices.IndexFunc(media, func(m models.MediaRequest) bool { return m.ID == id })
+		if i < 0 {
+			continue
+		}
+		m := media[i]
+		info := admin_models.MediaInformation{Media: m}
+		if _, err := object_cache_service.GetMediaFromObjectCache(ctx, id); err == nil {
+			info.Cached = true
+		}
+		if m.Visibility && m.Status == variables.MediaStatusReady {
+			info.URL = media_service.GenerateWatermarkedURL(m.StoragePath, post.UserID, post.ID, viewer.UserID)
+		}
+		out = append(out, info)
+	}
+	return out, nil
+}
+
+// postDiscrepancies liste les champs qui divergent entre L3 et L1 (hors compteurs, confrontés à part).
+func postDiscrepancies(l3, l1 post_models.PostPayload) []string {
+	diff := []string{}
+	if l3.Content != l1.Content {
+		diff = append(diff, "content")
+	}
+	if l3.Visibility != l1.Visibility {
+		diff = append(diff, "visibility")
+	}
+	if !slices.Equal(l3.Hashtags, l1.Hashtags) {
+		diff = append(diff, "hashtags")
+	}
+	if !slices.Equal(l3.Identifiers, l1.Identifiers) {
+		diff = append(diff, "identifiers")
+	}
+	if !slices.Equal(l3.MediaIDs, l1.MediaIDs) {
+		diff = append(diff, "media_ids")
+	}
+	if l3.Location != l1.Location {
+		diff = append(diff, "location")
+	}
+	if l3.VectorVersion != l1.VectorVersion {
+		diff = append(diff, "vector_version")
+	}
+	return diff
+}
+
+// commentDiscrepancies liste les champs qui divergent entre L3 et L1 (hors compteurs).
+func commentDiscrepancies(l3, l1 comment_models.CommentPayload) []string {
+	diff := []string{}
+	if l3.Content != l1.Content {
+		diff = append(diff, "content")
+	}
+	if l3.Visibility != l1.Visibility {
+		diff = append(diff, "visibility")
+	}
+	if l3.Score != l1.Score {
+		diff = append(diff, "score")
+	}
+	return diff
+}
+
+// counter confronte un compteur entre L1, sa valeur dénormalisée en L3 et un éventuel recomptage.
+func counter(l1 *int64, l3 int64, rows *int64) admin_models.CounterView {
+	return admin_models.CounterView{
+		L1:    l1,
+		L3:    l3,
+		Rows:  rows,
+		Drift: (l1 != nil && *l1 != l3) || (rows != nil && *rows != l3),
+	}
+}
+
+// count convertit un compteur de payload en valeur optionnelle de CounterView.
+func count(n int) *int64 {
+	v := int64(n)
+	return &v
+}
@@ -0,0 +1,216 @@
+package admin_service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/admin_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/auth_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/sanction_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service/object_cache_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// ============================================================================
+// FICHES D'ADMINISTRATION : UTILISATEUR
+// ============================================================================
+// Chaque fiche est lue directement en L3 (source de vérité, sans filtre de visibilité), puis confrontée
+// à L1 : les écarts sont signalés plutôt que corrigés, c'est au modérateur d'en juger. Chaque consultation
+// est auditée (données personnelles).
+
+// LoadUserInformation construit la fiche consolidée d'un utilisateur : compte, sessions et IP,
+// sanctions, historique de signalement, graphe social et contenus récents.
+func LoadUserInformation(ctx context.Context, viewer audit.Actor, userID int64) (admin_models.UserInformationOutput, error) {
+	user, err := postgres.FuncLoadUser(userID, "", "", "")
+	if err != nil {
+		return admin_models.UserInformationOutput{}, err
+	}
+	if user.ID == 0 {
+		return admin_models.UserInformationOutput{}, nubo_error.ErrNotFound
+	}
+	// L'empreinte du mot de passe n'est jamais exposée, même aux administrateurs
+	user.PasswordHash = ""
+
+	out := admin_models.UserInformationOutput{
+		User:          user,
+		Hidden:        cache_service.IsAccountHidden(ctx, userID),
+		Discrepancies: []string{},
+	}
+
+	// 1. Overlay L1 : fiche publique du Speed Cache et masquage
+	var lite models.UserLiteRequest
+	if err := redis.UsersLite.GetObject(ctx, userID, &lite); err == nil {
+		out.Cached = &lite
+		out.Discrepancies = userDiscrepancies(user, lite)
+	}
+	if (user.Desactivated || user.Banned) && !out.Hidden {
+		out.Discrepancies = append(out.Discrepancies, "hidden")
+	}
+
+	// 2. Sessions et historique IP
+	if out.Sessions, out.IPAddresses, err = loadSessions(ctx, userID); err != nil {
+		return admin_models.UserInformationOutput{}, err
+	}
+
+	// 3. Modération : sanctions et signalements
+	sanctions, err := postgres.FuncLoadUserSanctions(ctx, userID)
+	if err != nil {
+		return admin_models.UserInformationOutput{}, err
+	}
+	out.Sanctions = sanctions
+	if out.Sanctions == nil {
+		out.Sanctions = []sanction_models.SanctionPayload{}
+	}
+	if out.Reports, err = postgres.FuncUserReportStats(ctx, userID); err != nil {
+		return admin_models.UserInformationOutput{}, err
+	}
+
+	// 4. Graphe social (abonnés confrontés au Speed Cache du Fan-Out)
+	if out.Relations, err = postgres.FuncUserRelationStats(ctx, userID); err != nil {
+		return admin_models.UserInformationOutput{}, err
+	}
+	out.Relations.Followers = counter(speedFollowersCount(ctx, userID), out.Relations.Followers.L3, nil)
+
+	// 5. Contenus récents (version L1 si présente : L3 accuse le délai du Write-Behind)
+	if out.RecentPosts, err = recentPosts(ctx, userID); err != nil {
+		return admin_models.UserInformationOutput{}, err
+	}
+	if out.RecentComments, err = recentComments(ctx, userID); err != nil {
+		return admin_models.UserInformationOutput{}, err
+	}
+
+	audit.Record(ctx, viewer, audit.ActionPrivateInfo, audit.On(audit.TargetUser, userID), map[string]any{
+		"sessions":  len(out.Sessions),
+		"sanctions": len(out.Sanctions),
+	})
+	return out, nil
+}
+
+// --- HELPERS ---
+
+// loadSessions renvoie les sessions L3 (sans secrets), marquées si elles sont indexées en L1,
+// et l'union dédupliquée des IP rencontrées (ordre de première apparition).
+func loadSessions(ctx context.Context, userID int64) ([]admin_models.SessionInformation, []string, error) {
+	sessions, err := postgres.FuncLoadUserSessions(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	cachedIDs, _ := redis.ListUserSessionIDs(ctx, userID)
+
+	now := time.Now().UTC()
+	out := make([]admin_models.SessionInformation, 0, len(sessions))
+	ips := []string{}
+	for _, s := range sessions {
+		out = append(out, admin_models.SessionInformation{
+			ID:         s.ID,
+			DeviceInfo: s.DeviceInfo,
+			IPHistory:  s.IPHistory,
+			CreatedAt:  s.CreatedAt,
+			ExpiresAt:  s.ExpiresAt,
+			Expired:    !s.ExpiresAt.After(now),
+			Cached:     slices.Contains(cachedIDs, s.ID),
+		})
+		for _, ip := range s.IPHistory {
+			if !slices.Contains(ips, ip) {
+				ips = append(ips, ip)
+			}
+		}
+	}
+	return out, ips, nil
+}
+
+// speedFollowersCount lit le nombre d'abonnés connu du Fan-Out (nil : ensemble absent de L1).
+func speedFollowersCount(ctx context.Context, userID int64) *int64 {
+	exists, err := redis.SpeedFollowers.Client.Exists(ctx, redis.SpeedFollowers.Key(userID)).Result()
+	if err != nil || exists == 0 {
+		return nil
+	}
+	n, err := redis.SpeedFollowers.SCard(ctx, userID)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+// recentPosts renvoie les derniers posts d'un utilisateur, supprimés et masqués compris.
+func recentPosts(ctx context.Context, userID int64) ([]admin_models.ContentSummary, error) {
+	posts, err := postgres.FuncAdminRecentPosts(ctx, userID, variables.AdminRecentContentLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]admin_models.ContentSummary, 0, len(posts))
+	for _, p := range posts {
+		cached := false
+		if l1, err := object_cache_service.GetPostFromObjectCache(ctx, p.ID); err == nil {
+			p, cached = l1, true
+		}
+		out = append(out, admin_models.ContentSummary{
+			ID:         p.ID,
+			Content:    p.Content,
+			Visibility: p.Visibility,
+			LikeCount:  p.LikeCount,
+			CreatedAt:  p.CreatedAt,
+			Cached:     cached,
+		})
+	}
+	return out, nil
+}
+
+// recentComments renvoie les derniers commentaires d'un utilisateur, supprimés et masqués compris.
+func recentComments(ctx context.Context, userID int64) ([]admin_models.ContentSummary, error) {
+	comments, err := postgres.FuncAdminRecentComments(ctx, userID, variables.AdminRecentContentLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]admin_models.ContentSummary, 0, len(comments))
+	for _, c := range comments {
+		cached := false
+		if l1, err := object_cache_service.GetCommentFromObjectCache(ctx, c.ID); err == nil {
+			c, cached = l1, true
+		}
+		createdAt, _ := time.Parse(time.RFC3339, c.CreatedAt)
+		out = append(out, admin_models.ContentSummary{
+			ID:         c.ID,
+			PostID:     c.PostID,
+			Content:    c.Content,
+			Visibility: c.Visibility,
+			LikeCount:  c.LikeCount,
+			CreatedAt:  createdAt,
+			Cached:     cached,
+		})
+	}
+	return out, nil
+}
+
+// userDiscrepancies liste les champs publics qui divergent entre L3 et le Speed Cache.
+func userDiscrepancies(u auth_models.UserPayload, lite models.UserLiteRequest) []string {
+	diff := []string{}
+	if u.Username != lite.Username {
+		diff = append(diff, "username")
+	}
+	if u.FirstName != lite.FirstName || u.LastName != lite.LastName {
+		diff = append(diff, "name")
+	}
+	if u.Bio != lite.Bio {
+		diff = append(diff, "bio")
+	}
+	if u.ProfilePictureID != lite.ProfilePictureID {
+		diff = append(diff, "profile_picture_id")
+	}
+	if u.Grade != lite.Grade {
+		diff = append(diff, "grade")
+	}
+	if !slices.Equal(u.Badges, lite.Badges) {
+		diff = append(diff, "badges")
+	}
+	return diff
+}
@@ -0,0 +1,29 @@
+package admin_service
+
+import (
+	"context"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/admin_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/media_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/media_service"
+)
+
+// ============================================================================
+// RAMASSE-MIETTES DES MÉDIAS (déclenchement manuel et dernier rapport)
+// ============================================================================
+
+// RunMediaGC exécute un passage du ramasse-miettes à la demande d'un administrateur (audité par le passage).
+func RunMediaGC(ctx context.Context, actor audit.Actor, in admin_models.MediaGCInput) (media_models.MediaGCReport, error) {
+	return media_service.CollectOrphanMedia(ctx, actor, in.DryRun)
+}
+
+// LastMediaGC renvoie le rapport du dernier passage, planifié ou manuel.
+func LastMediaGC(ctx context.Context) (media_models.MediaGCReport, error) {
+	report, found := media_service.LastMediaGCReport(ctx)
+	if !found {
+		return media_models.MediaGCReport{}, nubo_error.ErrNotFound
+	}
+	return report, nil
+}
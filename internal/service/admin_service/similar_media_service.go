@@ -0,0 +1,41 @@
+package admin_service
+
+import (
+	"context"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/admin_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/media_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/media_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// ============================================================================
+// RECHERCHE DE MÉDIAS SIMILAIRES (empreintes perceptuelles)
+// ============================================================================
+
+// FindSimilarMedia renvoie les médias proches d'un média et les empreintes bloquées qu'il approche.
+// Chaque recherche est auditée : elle révèle les envois d'autres comptes.
+func FindSimilarMedia(ctx context.Context, viewer audit.Actor, in admin_models.SimilarMediaInput) (media_models.SimilarMediaOutput, error) {
+	maxDistance := variables.MediaHashMaxDistance
+	if in.MaxDistance != nil {
+		maxDistance = *in.MaxDistance
+	}
+	limit := in.Limit
+	if limit <= 0 {
+		limit = variables.MediaSimilarDefaultLimit
+	}
+	limit = min(limit, variables.MediaSimilarMaxLimit)
+
+	out, err := media_service.FindSimilarMedia(ctx, in.MediaID, maxDistance, limit)
+	if err != nil {
+		return media_models.SimilarMediaOutput{}, err
+	}
+
+	audit.Record(ctx, viewer, audit.ActionMediaSimilar, audit.On(audit.TargetMedia, in.MediaID), map[string]any{
+		"max_distance": maxDistance,
+		"matches":      len(out.Matches),
+		"blocked":      len(out.Blocked),
+	})
+	return out, nil
+}
@@ -0,0 +1,241 @@
+package appeal_service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/appeal_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/sanction_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/rbac"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/report_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/sanction_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// ============================================================================
+// FILE DES APPELS : LISTE, PRISE EN CHARGE, DÉCISION, SLA
+// ============================================================================
+// File distincte des signalements, triée par échéance (DueAt). Les transitions sont des
+// compare-and-set en L3, comme pour les dossiers de modération. Un appel accueilli lève la
+// sanction par le même chemin qu'une levée manuelle (sanction_service.LiftSanction), puis rétablit
+// le contenu retiré au titre du même dossier (report_service.RestoreCaseContent). Ces effets précèdent
+// la transition : un appel n'est tranché qu'une fois la sanction effectivement annulée.
+
+// ListAppeals renvoie une page de la file, échéances les plus proches en tête.
+func ListAppeals(ctx context.Context, in appeal_models.ListAppealsInput) (appeal_models.ListAppealsOutput, error) {
+	if in.Limit <= 0 {
+		in.Limit = variables.AppealQueueDefaultLimit
+	}
+	if in.Limit > variables.AppealQueueMaxLimit {
+		in.Limit = variables.AppealQueueMaxLimit
+	}
+	if in.Offset < 0 {
+		in.Offset = 0
+	}
+
+	appeals, err := postgres.FuncListAppeals(ctx, in)
+	if err != nil {
+		return appeal_models.ListAppealsOutput{}, err
+	}
+	if appeals == nil {
+		appeals = []appeal_models.AppealPayload{}
+	}
+	return appeal_models.ListAppealsOutput{Appeals: appeals}, nil
+}
+
+// GetAppeal renvoie un appel et la sanction contestée.
+func GetAppeal(ctx context.Context, appealID int64) (appeal_models.AppealDetailOutput, error) {
+	a, err := loadAppeal(ctx, appealID)
+	if err != nil {
+		return appeal_models.AppealDetailOutput{}, err
+	}
+	s, err := sanction_service.LoadSanction(ctx, a.SanctionID)
+	if err != nil && !errors.Is(err, nubo_error.ErrNotFound) {
+		return appeal_models.AppealDetailOutput{}, err
+	}
+	return appeal_models.AppealDetailOutput{Appeal: a, Sanction: s}, nil
+}
+
+// AssignAppeal prend en charge un appel pour soi (AssigneeID == 0) ou l'assigne à un autre modérateur.
+// L'auteur de la sanction contestée ne peut jamais la juger. Reprendre l'appel d'un autre exige reports:override.
+func AssignAppeal(ctx context.Context, m sanction_service.Moderator, in appeal_models.AssignAppealInput) (appeal_models.AppealPayload, error) {
+	assigneeID := in.AssigneeID
+	if assigneeID == 0 {
+		assigneeID = m.UserID
+	}
+	override := rbac.Can(m.Grade, rbac.PermReportsOverride)
+
+	return transitionAppeal(ctx, m, in.AppealID, func(a appeal_models.AppealPayload, s sanction_models.SanctionPayload) (appeal_models.AppealTransition, error) {
+		switch {
+		case a.State == variables.AppealStateDecided:
+			return appeal_models.AppealTransition{}, nubo_error.ErrAppealUnavailable
+		case a.State == variables.AppealStateInReview && a.AssigneeID != m.UserID && !override:
+			return appeal_models.AppealTransition{}, nubo_error.ErrAppealNotAssignee
+		case assigneeID == s.IssuedBy:
+			return appeal_models.AppealTransition{}, nubo_error.ErrAppealConflict
+		}
+
+		if assigneeID != m.UserID {
+			subject, err := rbac.LoadSubject(ctx, assigneeID)
+			if err != nil || !rbac.Can(subject.Grade, rbac.PermAppealsResolve) {
+				return appeal_models.AppealTransition{}, nubo_error.ErrInvalidAssignee
+			}
+		}
+
+		return appeal_models.AppealTransition{
+			NewState:   variables.AppealStateInReview,
+			AssigneeID: assigneeID,
+		}, nil
+	}, nil)
+}
+
+// DecideAppeal tranche un appel pris en charge. Une sanction annulée est levée immédiatement
+// (ban : compte rétabli et de nouveau visible ; restriction et avertissement : retirés), et le contenu
+// masqué ou retiré au titre de son dossier retrouve sa visibilité d'origine.
+func DecideAppeal(ctx context.Context, m sanction_service.Moderator, in appeal_models.DecideAppealInput) (appeal_models.AppealPayload, error) {
+	override := rbac.Can(m.Grade, rbac.PermReportsOverride)
+
+	rule := func(a appeal_models.AppealPayload, s sanction_models.SanctionPayload) (appeal_models.AppealTransition, error) {
+		if a.State == variables.AppealStateDecided {
+			return appeal_models.AppealTransition{}, nubo_error.ErrAppealUnavailable
+		}
+		if m.UserID == s.IssuedBy {
+			return appeal_models.AppealTransition{}, nubo_error.ErrAppealConflict
+		}
+		if !override {
+			if a.State != variables.AppealStateInReview {
+				return appeal_models.AppealTransition{}, nubo_error.ErrAppealUnavailable
+			}
+			if a.AssigneeID != m.UserID {
+				return appeal_models.AppealTransition{}, nubo_error.ErrAppealNotAssignee
+			}
+		}
+		return appeal_models.AppealTransition{
+			NewState:     variables.AppealStateDecided,
+			AssigneeID:   a.AssigneeID,
+			Outcome:      in.Outcome,
+			DecisionNote: in.Note,
+			DecidedBy:    m.UserID,
+		}, nil
+	}
+
+	// L'annulation précède la décision : si elle échoue, l'appel reste ouvert et peut être retranché
+	return transitionAppeal(ctx, m, in.AppealID, rule, func(a appeal_models.AppealPayload, s sanction_models.SanctionPayload) error {
+		if in.Outcome != variables.AppealOutcomeOverturned {
+			return nil
+		}
+		return overturn(ctx, m, a, s, "Appel accueilli : "+in.Note)
+	})
+}
+
+// FlagOverdueAppeals signale (une seule fois) les appels dont l'échéance de traitement est dépassée (cron).
+// Retourne le nombre de nouveaux dépassements.
+func FlagOverdueAppeals(ctx context.Context) int {
+	breached, err := postgres.FuncMarkAppealSLABreaches(ctx)
+	if err != nil {
+		log.Printf("⚠️ SLA des appels : %v", err)
+		return 0
+	}
+	for _, a := range breached {
+		audit.Record(ctx, audit.System, audit.ActionAppealSLABreached, audit.On(audit.TargetAppeal, a.ID), map[string]any{
+			"sanction_id": a.SanctionID,
+			"state":       a.State,
+			"assignee_id": a.AssigneeID,
+			"due_at":      a.DueAt.Format(time.RFC3339),
+		})
+	}
+	return len(breached)
+}
+
+// --- HELPERS ---
+
+// overturn annule une sanction contestée : levée de la sanction et des sanctions automatiques qu'elle avait
+// déclenchées, puis rétablissement du contenu retiré au titre de son dossier. Chaque étape est idempotente :
+// une décision renouvelée après un échec rejoue les étapes restantes.
+func overturn(ctx context.Context, m sanction_service.Moderator, a appeal_models.AppealPayload, s sanction_models.SanctionPayload, reason string) error {
+	_, err := sanction_service.LiftSanction(ctx, m, sanction_models.LiftSanctionInput{SanctionID: s.ID, Reason: reason})
+	if errors.Is(err, nubo_error.ErrSanctionInactive) {
+		// Déjà levée (tentative précédente) ou expirée : seule l'échelle d'un avertissement reste à réévaluer
+		err = nil
+		if s.Kind == variables.SanctionKindWarning {
+			err = sanction_service.LiftEscalations(ctx, m, s, reason)
+		}
+	}
+	if err != nil {
+		log.Printf("❌ Appel %d : sanction %d non levée : %v", a.ID, s.ID, err)
+		return err
+	}
+
+	if s.CaseID == 0 {
+		return nil
+	}
+	if _, err := report_service.RestoreCaseContent(ctx, report_service.Moderator(m), s.CaseID, reason); err != nil {
+		log.Printf("❌ Appel %d : contenu du dossier %d non rétabli : %v", a.ID, s.CaseID, err)
+		return err
+	}
+	return nil
+}
+
+// transitionAppeal lit l'appel et sa sanction, laisse `rule` valider et construire la transition, exécute
+// `effect` (nil : aucun) puis applique la transition en compare-and-set sur l'état et l'assigné observés.
+func transitionAppeal(
+	ctx context.Context,
+	m sanction_service.Moderator,
+	appealID int64,
+	rule func(a appeal_models.AppealPayload, s sanction_models.SanctionPayload) (appeal_models.AppealTransition, error),
+	effect func(a appeal_models.AppealPayload, s sanction_models.SanctionPayload) error,
+) (appeal_models.AppealPayload, error) {
+	a, err := loadAppeal(ctx, appealID)
+	if err != nil {
+		return appeal_models.AppealPayload{}, err
+	}
+	s, err := sanction_service.LoadSanction(ctx, a.SanctionID)
+	if err != nil {
+		return appeal_models.AppealPayload{}, err
+	}
+
+	t, err := rule(a, s)
+	if err != nil {
+		return appeal_models.AppealPayload{}, err
+	}
+	if effect != nil {
+		if err := effect(a, s); err != nil {
+			return appeal_models.AppealPayload{}, err
+		}
+	}
+	t.AppealID = a.ID
+	t.AllowedStates = []int{a.State}
+	t.ExpectAssignee = a.AssigneeID
+
+	updated, ok, err := postgres.FuncTransitionAppeal(ctx, t)
+	if err != nil {
+		return appeal_models.AppealPayload{}, err
+	}
+	if !ok {
+		return appeal_models.AppealPayload{}, nubo_error.ErrAppealUnavailable
+	}
+
+	audit.Record(ctx, audit.User(m.UserID, m.IP), audit.ActionAppealStateChange, audit.On(audit.TargetAppeal, a.ID), map[string]any{
+		"from":        a.State,
+		"to":          updated.State,
+		"assignee_id": updated.AssigneeID,
+		"outcome":     updated.Outcome,
+		"sanction_id": a.SanctionID,
+	})
+	return updated, nil
+}
+
+// loadAppeal charge un appel (ErrNotFound s'il n'existe pas).
+func loadAppeal(ctx context.Context, appealID int64) (appeal_models.AppealPayload, error) {
+	a, err := postgres.FuncLoadAppeal(ctx, appealID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return appeal_models.AppealPayload{}, nubo_error.ErrNotFound
+	}
+	return a, err
+}
@@ -0,0 +1,74 @@
+package appeal_service
+
+import (
+	"context"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/appeal_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/sanction_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// ============================================================================
+// APPELS : SOUMISSION PAR L'UTILISATEUR SANCTIONNÉ
+// ============================================================================
+// Un utilisateur conteste une sanction en vigueur, une seule fois, avant AppealableUntil.
+// Les comptes bannis passent par le jeton d'appel remis au login (auth_service.ResolveAppealToken).
+
+// SubmitAppeal enregistre l'appel d'un utilisateur contre l'une de ses sanctions et l'inscrit dans la file.
+func SubmitAppeal(ctx context.Context, userID int64, in appeal_models.SubmitAppealInput, ipAddress string) (appeal_models.AppealPayload, error) {
+	s, err := sanction_service.LoadSanction(ctx, in.SanctionID)
+	if err != nil {
+		return appeal_models.AppealPayload{}, err
+	}
+	// La sanction d'un autre utilisateur est traitée comme inexistante (aucune fuite d'information)
+	if s.UserID != userID {
+		return appeal_models.AppealPayload{}, nubo_error.ErrNotFound
+	}
+
+	now := time.Now().UTC()
+	if !now.Before(s.AppealableUntil) {
+		return appeal_models.AppealPayload{}, nubo_error.ErrAppealWindowClosed
+	}
+	if !sanction_service.IsActive(s, now) {
+		return appeal_models.AppealPayload{}, nubo_error.ErrSanctionInactive
+	}
+
+	a, inserted, err := postgres.FuncInsertAppeal(ctx, appeal_models.AppealPayload{
+		ID:           pkg.GenerateID(),
+		SanctionID:   s.ID,
+		UserID:       userID,
+		SanctionKind: s.Kind,
+		Message:      in.Message,
+		DueAt:        now.Add(time.Duration(variables.AppealSLAHours[s.Kind]) * time.Hour),
+	})
+	if err != nil {
+		return appeal_models.AppealPayload{}, err
+	}
+	if !inserted {
+		return appeal_models.AppealPayload{}, nubo_error.ErrAppealExists
+	}
+
+	audit.Record(ctx, audit.User(userID, ipAddress), audit.ActionAppealSubmit, audit.On(audit.TargetAppeal, a.ID), map[string]any{
+		"sanction_id": s.ID,
+		"kind":        s.Kind,
+		"due_at":      a.DueAt.Format(time.RFC3339),
+	})
+	return a, nil
+}
+
+// ListMyAppeals renvoie les appels d'un utilisateur et leur issue.
+func ListMyAppeals(ctx context.Context, userID int64) (appeal_models.MyAppealsOutput, error) {
+	appeals, err := postgres.FuncListUserAppeals(ctx, userID)
+	if err != nil {
+		return appeal_models.MyAppealsOutput{}, err
+	}
+	if appeals == nil {
+		appeals = []appeal_models.AppealPayload{}
+	}
+	return appeal_models.MyAppealsOutput{Appeals: appeals}, nil
+}
@@ -0,0 +1,149 @@
+package audit
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/audit_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+)
+
+// ============================================================================
+// 🛡️ JOURNAL D'AUDIT (append-only, moderation.audit_log)
+// ============================================================================
+// Qui a fait quoi, sur quoi, depuis où. Les entrées partent dans la file Write-Behind
+// (TargetPostgres uniquement) : aucune lecture L1/L2, la consultation se fait en L3.
+// Règle d'or : ne jamais placer de secret (jeton, hash, URL signée) dans les métadonnées.
+
+// Action est le type fermé des événements audités ("domaine.verbe[.issue]").
+type Action string
+
+const (
+	// --- Authentification & sessions ---
+	ActionLogin          Action = "auth.login"
+	ActionLoginFailed    Action = "auth.login.failed"
+	ActionSessionRevoke  Action = "session.revoke"
+	ActionMasterRefresh  Action = "session.refresh_master"
+	ActionReplayDetected Action = "security.replay_detected"
+
+	// --- Mot de passe ---
+	ActionPasswordForgot         Action = "password.forgot"
+	ActionPasswordForgotIgnored  Action = "password.forgot.ignored"
+	ActionPasswordReset          Action = "password.reset"
+	ActionPasswordResetRejected  Action = "password.reset.rejected"
+	ActionPasswordChange         Action = "password.change"
+	ActionPasswordChangeRejected Action = "password.change.rejected"
+
+	// --- Profil ---
+	ActionProfileImageUpdate Action = "profile.image.update"
+	ActionProfileImageRemove Action = "profile.image.remove"
+
+	// --- Cycle de vie du compte ---
+	ActionAccountDeactivate         Action = "account.deactivate"
+	ActionAccountDeactivateRejected Action = "account.deactivate.rejected"
+	ActionAccountDeleteScheduled    Action = "account.delete.scheduled"
+	ActionAccountDeleteRejected     Action = "account.delete.rejected"
+	ActionAccountDeleteCompleted    Action = "account.delete.completed"
+	ActionAccountReactivate         Action = "account.reactivate"
+
+	// --- Export RGPD ---
+	ActionExportRequested Action = "account.export.requested"
+	ActionExportReady     Action = "account.export.ready"
+	ActionExportLink      Action = "account.export.link"
+
+	// --- Modération ---
+	ActionReportCreate      Action = "report.create"
+	ActionReportStateChange Action = "report.state"
+	ActionUserBan           Action = "user.ban"
+	ActionUserUnban         Action = "user.unban"
+	ActionUserRestrict      Action = "user.restrict"
+	ActionUserWarn          Action = "user.warn"
+	ActionSanctionLift      Action = "user.sanction.lift"
+	ActionGradeChange       Action = "user.grade"
+	ActionAppealSubmit      Action = "appeal.submit"
+	ActionAppealStateChange Action = "appeal.state"
+	ActionAppealSLABreached Action = "appeal.sla_breached"
+	ActionContentTakedown   Action = "content.takedown"
+	ActionContentRestore    Action = "content.restore"
+	ActionContentRemove     Action = "content.remove"
+	ActionMediaQuarantine   Action = "media.quarantine"
+	ActionMediaHashBlock    Action = "media.hash_block"
+	ActionMediaRelease      Action = "media.release"
+
+	// --- Administration ---
+	ActionAuditQuery   Action = "audit.query"
+	ActionPrivateInfo  Action = "admin.information"
+	ActionMediaSimilar Action = "admin.media_similar"
+	ActionMediaGC      Action = "admin.media_gc"
+	ActionForensic     Action = "admin.forensic"
+	ActionAccessDenied Action = "rbac.denied"
+)
+
+// TargetType désigne la nature de l'objet visé par une action.
+type TargetType string
+
+const (
+	TargetNone     TargetType = ""
+	TargetUser     TargetType = "user"
+	TargetSession  TargetType = "session"
+	TargetPost     TargetType = "post"
+	TargetComment  TargetType = "comment"
+	TargetReport   TargetType = "report"
+	TargetCase     TargetType = "report_case"
+	TargetExport   TargetType = "export"
+	TargetSanction TargetType = "sanction"
+	TargetAppeal   TargetType = "appeal"
+	TargetMessage  TargetType = "message"
+	TargetMedia    TargetType = "media"
+)
+
+// Actor est l'auteur d'une action : un utilisateur (UserID > 0) ou le système (UserID == 0).
+type Actor struct {
+	UserID int64
+	IP     string
+}
+
+// Target est l'objet visé par une action.
+type Target struct {
+	Type TargetType
+	ID   int64
+}
+
+// System est l'acteur des tâches de fond (crons, workers).
+var System = Actor{}
+
+// User construit un acteur utilisateur.
+func User(userID int64, ip string) Actor {
+	return Actor{UserID: userID, IP: ip}
+}
+
+// On construit une cible typée.
+func On(targetType TargetType, id int64) Target {
+	return Target{Type: targetType, ID: id}
+}
+
+// Record ajoute une entrée au journal d'audit.
+// Un échec d'écriture ne doit jamais bloquer l'action auditée : il est tracé en CRITICAL et l'entrée
+// est conservée dans les logs applicatifs pour reconstitution.
+func Record(ctx context.Context, actor Actor, action Action, target Target, metadata map[string]any) {
+	entry := audit_models.AuditEntryPayload{
+		ID:         pkg.GenerateID(),
+		ActorID:    actor.UserID,
+		ActorIP:    actor.IP,
+		Action:     string(action),
+		TargetType: string(target.Type),
+		TargetID:   target.ID,
+		Metadata:   metadata,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	log.Printf("🛡️ [AUDIT] action=%s actor=%d ip=%s target=%s:%d", entry.Action, entry.ActorID, entry.ActorIP, entry.TargetType, entry.TargetID)
+
+	// Le contexte de la requête peut être annulé juste après la réponse : l'audit doit survivre
+	if err := redis.EnqueueDB(context.WithoutCancel(ctx), entry.ID, 0, redis.EntityAuditLog, redis.ActionCreate, entry, redis.TargetPostgres); err != nil {
+		log.Printf("❌ CRITICAL: Entrée d'audit perdue (action=%s actor=%d target=%s:%d meta=%v) : %v",
+			entry.Action, entry.ActorID, entry.TargetType, entry.TargetID, entry.Metadata, err)
+	}
+}
@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/audit_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// Query renvoie une page filtrée du journal d'audit (lecture L3 uniquement).
+// La consultation est elle-même auditée : on sait qui a regardé quoi.
+func Query(ctx context.Context, viewer Actor, in audit_models.AuditQueryInput) (audit_models.AuditQueryOutput, error) {
+	if in.Limit <= 0 {
+		in.Limit = variables.AuditQueryDefaultLimit
+	}
+	if in.Limit > variables.AuditQueryMaxLimit {
+		in.Limit = variables.AuditQueryMaxLimit
+	}
+
+	entries, err := postgres.FuncQueryAuditLog(ctx, in)
+	if err != nil {
+		return audit_models.AuditQueryOutput{}, err
+	}
+
+	out := audit_models.AuditQueryOutput{Entries: entries}
+	if out.Entries == nil {
+		out.Entries = []audit_models.AuditEntryPayload{}
+	}
+	// Page pleine : il reste potentiellement des entrées plus anciennes
+	if len(entries) == in.Limit {
+		out.NextCursor = entries[len(entries)-1].ID
+	}
+
+	Record(ctx, viewer, ActionAuditQuery, Target{}, map[string]any{
+		"actor_id":    in.ActorID,
+		"action":      in.Action,
+		"target_type": in.TargetType,
+		"target_id":   in.TargetID,
+		"results":     len(entries),
+	})
+	return out, nil
+}
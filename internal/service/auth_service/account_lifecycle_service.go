@@ -0,0 +1,233 @@
+package auth_service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/account_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/auth_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	postgresgo "github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// deletionScheduleID est l'identifiant du ZSET des suppressions planifiées (score = échéance Unix).
+const deletionScheduleID = "schedule"
+
+// ============================================================================
+// DÉSACTIVATION, SUPPRESSION PLANIFIÉE ET RÉACTIVATION
+// ============================================================================
+
+// DeactivateAccount masque le compte partout (profil, posts, commentaires, recherche) sans rien effacer.
+// Toutes les sessions sont révoquées ; un login dans les AccountReactivationGraceDays réactive le compte.
+func DeactivateAccount(ctx context.Context, userID int64, input account_models.DeactivateAccountInput, ipAddress string) (account_models.AccountLifecycleOutput, error) {
+	user, lc, err := loadAccountForTransition(ctx, userID, input.PasswordHash, ipAddress, audit.ActionAccountDeactivateRejected)
+	if err != nil {
+		return account_models.AccountLifecycleOutput{}, err
+	}
+	if lc.State == variables.AccountStatePendingDeletion {
+		return account_models.AccountLifecycleOutput{}, nubo_error.ErrDeletionPending
+	}
+	if user.Desactivated {
+		return account_models.AccountLifecycleOutput{}, nubo_error.ErrAccountNotActive
+	}
+
+	now := time.Now().UTC()
+	if err := setAccountHidden(ctx, user, true); err != nil {
+		return account_models.AccountLifecycleOutput{}, err
+	}
+	if err := recordAccountLifecycle(ctx, account_models.AccountLifecyclePayload{
+		UserID:      user.ID,
+		State:       variables.AccountStateDeactivated,
+		RequestedAt: now,
+	}); err != nil {
+		return account_models.AccountLifecycleOutput{}, err
+	}
+
+	revoked := RevokeUserSessions(ctx, audit.User(user.ID, ipAddress), user.ID, 0)
+	audit.Record(ctx, audit.User(user.ID, ipAddress), audit.ActionAccountDeactivate, audit.On(audit.TargetUser, user.ID), map[string]any{
+		"sessions_revoked": revoked,
+	})
+
+	reactivableUntil := now.AddDate(0, 0, variables.AccountReactivationGraceDays)
+	return account_models.AccountLifecycleOutput{
+		State:            variables.AccountStateDeactivated,
+		ReactivableUntil: &reactivableUntil,
+	}, nil
+}
+
+// ScheduleAccountDeletion masque immédiatement le compte puis planifie la cascade de suppression
+// définitive après AccountDeletionGraceDays. Un login avant l'échéance annule la demande.
+func ScheduleAccountDeletion(ctx context.Context, userID int64, input account_models.DeleteAccountInput, ipAddress string) (account_models.AccountLifecycleOutput, error) {
+	user, lc, err := loadAccountForTransition(ctx, userID, input.PasswordHash, ipAddress, audit.ActionAccountDeleteRejected)
+	if err != nil {
+		return account_models.AccountLifecycleOutput{}, err
+	}
+	if lc.State == variables.AccountStatePendingDeletion {
+		return account_models.AccountLifecycleOutput{}, nubo_error.ErrDeletionPending
+	}
+
+	now := time.Now().UTC()
+	scheduledFor := now.AddDate(0, 0, variables.AccountDeletionGraceDays)
+
+	if err := setAccountHidden(ctx, user, true); err != nil {
+		return account_models.AccountLifecycleOutput{}, err
+	}
+	if err := recordAccountLifecycle(ctx, account_models.AccountLifecyclePayload{
+		UserID:       user.ID,
+		State:        variables.AccountStatePendingDeletion,
+		RequestedAt:  now,
+		ScheduledFor: scheduledFor,
+	}); err != nil {
+		return account_models.AccountLifecycleOutput{}, err
+	}
+	if err := redis.AccountDeletions.ZAdd(ctx, deletionScheduleID, float64(scheduledFor.Unix()), user.ID); err != nil {
+		return account_models.AccountLifecycleOutput{}, fmt.Errorf("planification de la suppression: %w", err)
+	}
+
+	revoked := RevokeUserSessions(ctx, audit.User(user.ID, ipAddress), user.ID, 0)
+	audit.Record(ctx, audit.User(user.ID, ipAddress), audit.ActionAccountDeleteScheduled, audit.On(audit.TargetUser, user.ID), map[string]any{
+		"scheduled_for":    scheduledFor.Format(time.RFC3339),
+		"sessions_revoked": revoked,
+	})
+
+	return account_models.AccountLifecycleOutput{
+		State:        variables.AccountStatePendingDeletion,
+		ScheduledFor: &scheduledFor,
+	}, nil
+}
+
+// reactivateOnLogin réactive un compte désactivé par son propriétaire (dans le délai de grâce)
+// ou annule une suppression planifiée non encore exécutée. Tout autre compte désactivé
+// (sans historique, désactivé par la modération, délai dépassé) reste refusé avec ErrDesactivated.
+func reactivateOnLogin(ctx context.Context, user auth_models.UserPayload, ipAddress string) (auth_models.UserPayload, error) {
+	lc, err := loadAccountLifecycle(ctx, user.ID)
+	if err != nil {
+		return auth_models.UserPayload{}, err
+	}
+
+	now := time.Now().UTC()
+	switch lc.State {
+	case variables.AccountStateDeactivated:
+		if now.After(lc.RequestedAt.AddDate(0, 0, variables.AccountReactivationGraceDays)) {
+			return auth_models.UserPayload{}, nubo_error.ErrDesactivated
+		}
+	case variables.AccountStatePendingDeletion:
+		if !now.Before(lc.ScheduledFor) {
+			return auth_models.UserPayload{}, nubo_error.ErrDesactivated
+		}
+		// Retrait du planning AVANT de rendre le compte visible : si le cron l'a déjà réclamé, on s'arrête là
+		removed, errZ := redis.AccountDeletions.Client.ZRem(ctx, redis.AccountDeletions.Key(deletionScheduleID), user.ID).Result()
+		if errZ != nil {
+			return auth_models.UserPayload{}, fmt.Errorf("annulation de la suppression: %w", errZ)
+		}
+		if removed == 0 {
+			return auth_models.UserPayload{}, nubo_error.ErrDesactivated
+		}
+	default:
+		return auth_models.UserPayload{}, nubo_error.ErrDesactivated
+	}
+
+	if err := setAccountHidden(ctx, user, false); err != nil {
+		return auth_models.UserPayload{}, err
+	}
+	if err := recordAccountLifecycle(ctx, account_models.AccountLifecyclePayload{
+		UserID:      user.ID,
+		State:       variables.AccountStateActive,
+		RequestedAt: now,
+	}); err != nil {
+		log.Printf("⚠️ Réactivation: échec de journalisation du cycle de vie (user %d) : %v", user.ID, err)
+	}
+
+	audit.Record(ctx, audit.User(user.ID, ipAddress), audit.ActionAccountReactivate, audit.On(audit.TargetUser, user.ID), map[string]any{
+		"previous_state": lc.State,
+	})
+	user.Desactivated = false
+	return user, nil
+}
+
+// --- HELPERS ---
+
+// loadAccountForTransition charge l'utilisateur et son cycle de vie après confirmation du mot de passe.
+func loadAccountForTransition(ctx context.Context, userID int64, passwordHash string, ipAddress string, rejected audit.Action) (auth_models.UserPayload, account_models.AccountLifecyclePayload, error) {
+	user, err := LoadUserCascade(userID, "")
+	if err != nil {
+		return auth_models.UserPayload{}, account_models.AccountLifecyclePayload{}, err
+	}
+	if user.ID == 0 {
+		return auth_models.UserPayload{}, account_models.AccountLifecyclePayload{}, nubo_error.ErrNotFound
+	}
+	if strings.TrimSpace(user.PasswordHash) != strings.TrimSpace(passwordHash) {
+		audit.Record(ctx, audit.User(user.ID, ipAddress), rejected, audit.On(audit.TargetUser, user.ID), map[string]any{
+			"reason": "mot de passe incorrect",
+		})
+		return auth_models.UserPayload{}, account_models.AccountLifecyclePayload{}, nubo_error.ErrInvalidCredentials
+	}
+
+	lc, err := loadAccountLifecycle(ctx, user.ID)
+	if err != nil {
+		return auth_models.UserPayload{}, account_models.AccountLifecyclePayload{}, err
+	}
+	return user, lc, nil
+}
+
+// loadAccountLifecycle lit l'état courant du cycle de vie (L1 puis L3 avec réhydratation).
+func loadAccountLifecycle(ctx context.Context, userID int64) (account_models.AccountLifecyclePayload, error) {
+	var lc account_models.AccountLifecyclePayload
+	if err := redis.AccountLifecycle.GetObject(ctx, userID, &lc); err == nil && lc.UserID != 0 {
+		return lc, nil
+	}
+
+	lc, err := postgresgo.FuncLoadAccountLifecycle(ctx, userID)
+	if err != nil {
+		return account_models.AccountLifecyclePayload{}, err
+	}
+	if lc.ID != 0 {
+		_ = redis.AccountLifecycle.SetObject(ctx, userID, lc)
+	}
+	return lc, nil
+}
+
+// recordAccountLifecycle mémorise le nouvel état en L1 et ajoute l'événement au journal L3 (append-only).
+func recordAccountLifecycle(ctx context.Context, lc account_models.AccountLifecyclePayload) error {
+	lc.ID = pkg.GenerateID()
+	lc.CreatedAt = time.Now().UTC()
+
+	if err := redis.AccountLifecycle.SetObject(ctx, lc.UserID, lc); err != nil {
+		return fmt.Errorf("état du cycle de vie L1: %w", err)
+	}
+	if err := redis.EnqueueDB(ctx, lc.ID, lc.UserID, redis.EntityAccountLifecycle, redis.ActionCreate, lc, redis.TargetPostgres); err != nil {
+		log.Printf("❌ CRITICAL: Rupture du Write-Behind (cycle de vie du compte %d) : %v", lc.UserID, err)
+		return err
+	}
+	return nil
+}
+
+// setAccountHidden bascule auth.users.desactivated sur L2/L3 et synchronise le SET des comptes masqués.
+func setAccountHidden(ctx context.Context, user auth_models.UserPayload, hidden bool) error {
+	user.Desactivated = hidden
+	user.UpdatedAt = time.Now().UTC()
+
+	if err := redis.EnqueueDB(ctx, user.ID, 0, redis.EntityUser, redis.ActionUpdate, user, redis.TargetAll); err != nil {
+		log.Printf("❌ CRITICAL: Rupture du Write-Behind (désactivation utilisateur %d) : %v", user.ID, err)
+		return err
+	}
+	_ = redis.Users.DeleteObject(ctx, user.ID)
+
+	if hidden {
+		// Le masquage L1 conditionne la promesse faite à l'utilisateur : son échec remonte
+		if err := cache_service.HideAccount(ctx, user); err != nil {
+			return err
+		}
+	} else if err := cache_service.UnhideAccount(ctx, user); err != nil {
+		log.Printf("⚠️ Comptes masqués: réindexation L1 impossible pour %d : %v", user.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package auth_service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/account_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/blobstore"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/mongo"
+	postgresgo "github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/algorithm_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service/object_cache_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/export_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/media_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// deletionRetryDelay repousse une cascade interrompue (le prochain passage reprend là où elle s'est arrêtée).
+const deletionRetryDelay = time.Hour
+
+// ============================================================================
+// CASCADE DE SUPPRESSION DÉFINITIVE
+// ============================================================================
+
+// ExecuteDueAccountDeletions exécute les suppressions dont le délai de grâce est écoulé.
+// Chaque compte est "réclamé" par un ZREM atomique : un login concurrent ou une autre instance
+// ne peut plus l'annuler ni le traiter en double. Retourne le nombre de comptes supprimés.
+func ExecuteDueAccountDeletions(ctx context.Context) int {
+	now := time.Now().UTC()
+	due, err := redis.AccountDeletions.ZRangeByScoreWithLimit(ctx, deletionScheduleID, now.Unix(), variables.AccountDeletionBatchSize)
+	if err != nil || len(due) == 0 {
+		return 0
+	}
+
+	done := 0
+	for _, member := range due {
+		userID, errParse := strconv.ParseInt(member, 10, 64)
+		if errParse != nil {
+			_ = redis.AccountDeletions.ZRem(ctx, deletionScheduleID, member)
+			continue
+		}
+
+		claimed, errZ := redis.AccountDeletions.Client.ZRem(ctx, redis.AccountDeletions.Key(deletionScheduleID), member).Result()
+		if errZ != nil || claimed == 0 {
+			continue
+		}
+
+		// Double contrôle : la demande a pu être annulée (réactivation) entre la planification et l'échéance
+		lc, errLc := loadAccountLifecycle(ctx, userID)
+		if errLc != nil {
+			log.Printf("⚠️ Suppression du compte %d : état illisible, nouvel essai dans %s : %v", userID, deletionRetryDelay, errLc)
+			_ = redis.AccountDeletions.ZAdd(ctx, deletionScheduleID, float64(now.Add(deletionRetryDelay).Unix()), userID)
+			continue
+		}
+		if lc.State != variables.AccountStatePendingDeletion {
+			continue
+		}
+
+		receipt, errPurge := PurgeAccount(ctx, userID, lc)
+		if errPurge != nil {
+			log.Printf("❌ Suppression du compte %d interrompue, nouvel essai dans %s : %v", userID, deletionRetryDelay, errPurge)
+			_ = redis.AccountDeletions.ZAdd(ctx, deletionScheduleID, float64(now.Add(deletionRetryDelay).Unix()), userID)
+			continue
+		}
+
+		log.Printf("🗑️ Compte %d supprimé définitivement (reçu %s)", userID, receipt.Digest)
+		done++
+	}
+	return done
+}
+
+// PurgeAccount efface toutes les traces d'un utilisateur puis enregistre le reçu de suppression.
+//
+// Ordre : l'inventaire est lu en L3, donc L3 est purgé en DERNIER. Une cascade interrompue
+// (MinIO ou Mongo indisponible) peut être rejouée sans rien perdre : chaque étape est idempotente.
+func PurgeAccount(ctx context.Context, userID int64, lc account_models.AccountLifecyclePayload) (account_models.AccountLifecyclePayload, error) {
+	user, err := LoadUserCascade(userID, "")
+	if err != nil {
+		return account_models.AccountLifecyclePayload{}, err
+	}
+
+	fp, err := postgresgo.FuncLoadUserFootprint(ctx, userID)
+	if err != nil {
+		return account_models.AccountLifecyclePayload{}, err
+	}
+
+	report := map[string]int64{
+		"posts":    int64(len(fp.PostIDs)),
+		"comments": int64(len(fp.CommentRefs)),
+		"media":    int64(len(fp.Media)),
+	}
+
+	// 1. Stockage objet (MinIO)
+	removedObjects, err := purgeUserMedia(ctx, fp.Media)
+	report["minio_objects"] = removedObjects
+	if err != nil {
+		return account_models.AccountLifecyclePayload{}, err
+	}
+
+	// 1 bis. Archives d'export RGPD éventuellement encore stockées
+	removedExports, err := export_service.PurgeUserExports(ctx, userID)
+	report["minio_objects"] += removedExports
+	if err != nil {
+		return account_models.AccountLifecyclePayload{}, err
+	}
+
+	// 2. Caches de contenu (Object Cache, LSH, MOST Cache)
+	report["redis_keys"], report["lsh_vectors"] = purgeUserContentFromCache(ctx, fp)
+	if n, errMost := cache_service.RemovePostsFromMostCache(ctx, fp.PostIDs); errMost == nil {
+		report["most_cache_entries"] = n
+	}
+
+	// 3. Sessions (révocation déjà faite à la demande ; purge physique des objets L1)
+	report["sessions"] = purgeUserSessionsFromCache(ctx, userID)
+
+	// 4. Stockage à froid (MongoDB)
+	mongoDocs, err := mongo.MongoPurgeUser(userID, fp)
+	report["mongo_documents"] = mongoDocs
+	if err != nil {
+		return account_models.AccountLifecyclePayload{}, err
+	}
+
+	// 5. Source de vérité (PostgreSQL) : contenu puis auth.proc_delete_user
+	pgRows, touched, err := postgresgo.ProcPurgeUser(ctx, userID, fp)
+	if err != nil {
+		return account_models.AccountLifecyclePayload{}, err
+	}
+	report["postgres_rows"] = pgRows
+	for _, postID := range touched {
+		// Les compteurs de ces posts tiers ont changé en L3 : on force leur réhydratation
+		_ = object_cache_service.DeletePostFromObjectCache(ctx, postID)
+	}
+
+	// 6. Profil, recherche, feeds et timeline (en dernier : le compte reste masqué jusqu'ici)
+	report["redis_keys"] += cache_service.PurgeAccountFromSpeedCache(ctx, userID, user.Username)
+
+	// 7. Reçu vérifiable
+	receipt := account_models.AccountLifecyclePayload{
+		UserID:       userID,
+		State:        variables.AccountStateDeleted,
+		RequestedAt:  lc.RequestedAt,
+		ScheduledFor: lc.ScheduledFor,
+		CompletedAt:  time.Now().UTC().Truncate(time.Microsecond), // Précision TIMESTAMPTZ : le reçu relu en L3 doit se revérifier
+		Report:       report,
+	}
+	receipt.Digest = DeletionReceiptDigest(receipt)
+
+	if err := recordAccountLifecycle(ctx, receipt); err != nil {
+		return receipt, fmt.Errorf("enregistrement du reçu de suppression: %w", err)
+	}
+	audit.Record(ctx, audit.System, audit.ActionAccountDeleteCompleted, audit.On(audit.TargetUser, userID), map[string]any{
+		"digest": receipt.Digest,
+		"report": report,
+	})
+	return receipt, nil
+}
+
+// DeletionReceiptDigest calcule l'empreinte SHA-256 d'un reçu de suppression :
+// sha256("<user_id>|<completed_at RFC3339Nano>|<report JSON>") (clés du rapport triées par encoding/json).
+func DeletionReceiptDigest(lc account_models.AccountLifecyclePayload) string {
+	reportJSON, _ := json.Marshal(lc.Report)
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d|%s|%s", lc.UserID, lc.CompletedAt.UTC().Format(time.RFC3339Nano), reportJSON)))
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyDeletionReceipt confirme qu'un reçu relu depuis L1/L3 n'a pas été altéré.
+func VerifyDeletionReceipt(lc account_models.AccountLifecyclePayload) bool {
+	return lc.State == variables.AccountStateDeleted && lc.Digest != "" && lc.Digest == DeletionReceiptDigest(lc)
+}
+
+// --- ÉTAPES DE LA CASCADE ---
+
+// purgeUserMedia supprime les fichiers MinIO des médias. Un objet déjà absent n'est pas une erreur.
+func purgeUserMedia(ctx context.Context, media []models.MediaRequest) (int64, error) {
+	bucketName := os.Getenv("MINIO_BUCKET_NAME")
+
+	var removed int64
+	for _, m := range media {
+		for _, path := range mediaObjectPaths(m) {
+			if err := blobstore.Store.Delete(ctx, bucketName, path); err != nil {
+				return removed, fmt.Errorf("suppression MinIO %s: %w", path, err)
+			}
+			removed++
+		}
+		_ = object_cache_service.DeleteMediaFromObjectCache(ctx, m.ID)
+		media_service.UnindexMediaHash(ctx, m) // Les empreintes bloquées, elles, survivent au compte
+	}
+	return removed, nil
+}
+
+// mediaObjectPaths renvoie les objets MinIO d'un média : le chemin historique, chaque déclinaison et,
+// tant que le traitement n'est pas terminé, l'original en zone de transit.
+func mediaObjectPaths(m models.MediaRequest) []string {
+	var paths []string
+	if m.Status == variables.MediaStatusPending {
+		paths = append(paths, media_service.StagingObjectPath(m.ID))
+	}
+	if m.StoragePath != "" {
+		paths = append(paths, m.StoragePath)
+	}
+	for _, r := range m.Renditions {
+		if r.StoragePath != "" && r.StoragePath != m.StoragePath {
+			paths = append(paths, r.StoragePath)
+		}
+	}
+	if m.Video.PlaylistPath != "" {
+		paths = append(paths, m.Video.PlaylistPath)
+		paths = append(paths, m.Video.SegmentPaths...)
+	}
+	return paths
+}
+
+// purgeUserContentFromCache retire posts, commentaires et vecteurs de la RAM.
+// Retourne le nombre de posts/commentaires purgés et le nombre de vecteurs LSH effacés.
+func purgeUserContentFromCache(ctx context.Context, fp account_models.UserFootprint) (int64, int64) {
+	var keys, vectors int64
+
+	for _, postID := range fp.PostIDs {
+		_ = object_cache_service.DeletePostFromObjectCache(ctx, postID)
+		object_cache_service.PurgePostCommentsFromL1(ctx, postID)
+		_ = redis.PostLikesSet.DeleteObject(ctx, postID)
+		if err := algorithm_service.PurgePostVectors(ctx, postID); err == nil {
+			vectors++
+		}
+		keys++
+	}
+
+	for commentID, postID := range fp.CommentRefs {
+		_ = object_cache_service.DeleteCommentFromObjectCache(ctx, commentID)
+		_ = object_cache_service.RemoveCommentFromZSET(ctx, postID, commentID)
+		_ = redis.CommentLikesSet.DeleteObject(ctx, commentID)
+		keys++
+	}
+
+	return keys, vectors
+}
+
+// purgeUserSessionsFromCache efface les objets session et leurs index en L1.
+func purgeUserSessionsFromCache(ctx context.Context, userID int64) int64 {
+	ids, err := redis.ListUserSessionIDs(ctx, userID)
+	if err != nil {
+		return 0
+	}
+
+	var purged int64
+	for _, id := range ids {
+		var s models.SessionsRequest
+		if errGet := redis.Sessions.GetObject(ctx, id, &s); errGet != nil {
+			_ = redis.Sessions.DeleteObject(ctx, id)
+			continue
+		}
+		if errDel := redis.DeleteSessionFromCache(ctx, s); errDel == nil {
+			purged++
+		}
+	}
+	return purged
+}
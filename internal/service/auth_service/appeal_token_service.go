@@ -0,0 +1,38 @@
+package auth_service
+
+import (
+	"context"
+	"log"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+)
+
+// ============================================================================
+// JETON D'APPEL (COMPTES BANNIS)
+// ============================================================================
+// Un compte banni n'a plus de session : le login refusé lui remet un jeton court
+// (AppealTokenTTLMinutes) qui n'ouvre que les routes publiques /appeal.
+// Comme pour la récupération de compte, seule l'empreinte SHA-256 est stockée.
+
+// issueAppealToken émet un jeton d'appel ("" si Redis est indisponible : le refus de login reste valable).
+func issueAppealToken(ctx context.Context, userID int64) string {
+	token := randomHex(32)
+	if err := redis.AppealTokens.SetPrimitive(ctx, hashResetToken(token), userID); err != nil {
+		log.Printf("⚠️ Jeton d'appel: stockage impossible pour l'utilisateur %d : %v", userID, err)
+		return ""
+	}
+	return token
+}
+
+// ResolveAppealToken renvoie l'utilisateur porteur d'un jeton d'appel encore valide.
+func ResolveAppealToken(ctx context.Context, token string) (int64, error) {
+	if token == "" {
+		return 0, nubo_error.ErrInvalidCredentials
+	}
+	userID, err := redis.AppealTokens.GetInt64(ctx, hashResetToken(token))
+	if err != nil || userID == 0 {
+		return 0, nubo_error.ErrInvalidCredentials
+	}
+	return userID, nil
+}
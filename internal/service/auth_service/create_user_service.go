@@ -124,9 +124,19 @@ func CreateUser(
 		return auth_models.SignUpResponse{}, fmt.Errorf("internal nubo_error (jwt generation): %w", err)
 	}
 
+	ctx := context.Background()
+
+	// 2.5 DÉPÔT DE L'AVATAR (synchrone : un fichier illisible refuse l'inscription avant toute écriture)
+	// Gabarit "avatar" sans recadrage fourni : le plus grand carré centré de l'image
+	// --------------------------------------------------------
+	if errFile == nil {
+		if _, err := media_service.StageProfileUpload(ctx, fileHeader, userID, mediaID, models.MediaLayoutAvatar, nil); err != nil {
+			return auth_models.SignUpResponse{}, err
+		}
+	}
+
 	// 3. MISE EN CACHE IMMÉDIATE (Lecture instantanée L1 - USER & SPEED Caches)
 	// --------------------------------------------------------
-	ctx := context.Background()
 
 	// [USER CACHE] : On crée un ZSET vide pour la timeline de l'utilisateur (optimisation future)
 	if err := cache_service.MarkUserTimelineEmpty(ctx, req.ID); err != nil {
@@ -156,25 +166,11 @@ func CreateUser(
 		log.Printf("❌ CRITICAL: Impossible d'enqueue la Session %d : %v", sessionID, err)
 	}
 
-	// C. Upload de l'avatar si présent
+	// C. Traitement de l'avatar si présent (l'original est déjà en zone de transit)
 	if errFile == nil {
-		file, err := fileHeader.Open()
-		if err != nil {
-			return auth_models.SignUpResponse{}, fmt.Errorf("cannot read file: %w", err)
+		if err := media_service.EnqueueMediaJobs(ctx, userID, 0, 0, []int64{mediaID}); err != nil {
+			log.Printf("internal nubo_error (image upload): %v", err)
 		}
-
-		go func() {
-			defer func(file multipart.File) {
-				err := file.Close()
-				if err != nil {
-					fmt.Println(err)
-				}
-			}(file)
-			err = media_service.UploadMedia(file, userID, mediaID)
-			if err != nil {
-				log.Printf("internal nubo_error (image upload): %v", err)
-			}
-		}()
 	}
 
 	// 5. CUCKOO FILTERS (Prévention O(1) Mémoire)
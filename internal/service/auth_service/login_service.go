@@ -15,8 +15,8 @@ import (
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/mongo"
 	postgresgo "github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service"
-	"github.com/QuentinRegnier/nubo-backend/internal/service/media_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/variables"
 )
 
@@ -32,6 +32,11 @@ func Login(
 	var err error
 	ctx := context.Background()
 
+	ip := ""
+	if len(IPAddress) > 0 {
+		ip = IPAddress[0]
+	}
+
 	// -------------------------------------------------------------------------
 	// 1. CHARGEMENT DE L'UTILISATEUR (Bases de Persistance uniquement)
 	// -------------------------------------------------------------------------
@@ -51,6 +56,7 @@ func Login(
 		}
 
 		if user.ID == 0 {
+			audit.Record(ctx, audit.User(0, ip), audit.ActionLoginFailed, audit.Target{}, map[string]any{"reason": "unknown_account"})
 			return auth_models.UserPayload{}, models.SessionsRequest{}, "", "", nubo_error.ErrNotFound
 		}
 
@@ -64,14 +70,21 @@ func Login(
 	// 2. CONTRÔLE SÉCURITÉ ET STATUT DU COMPTE
 	// -------------------------------------------------------------------------
 	if strings.TrimSpace(user.PasswordHash) != strings.TrimSpace(input.PasswordHash) {
+		audit.Record(ctx, audit.User(0, ip), audit.ActionLoginFailed, audit.On(audit.TargetUser, user.ID), map[string]any{"reason": "invalid_credentials"})
 		return auth_models.UserPayload{}, models.SessionsRequest{}, "", "", nubo_error.ErrInvalidCredentials
 	}
 
-	if user.Desactivated || user.Banned {
-		if user.Desactivated {
-			return auth_models.UserPayload{}, models.SessionsRequest{}, "", "", nubo_error.ErrDesactivated
+	if user.Banned {
+		audit.Record(ctx, audit.User(user.ID, ip), audit.ActionLoginFailed, audit.On(audit.TargetUser, user.ID), map[string]any{"reason": "banned"})
+		return auth_models.UserPayload{}, models.SessionsRequest{}, "", "", banError(ctx, user)
+	}
+
+	// Compte désactivé par son propriétaire ou suppression planifiée : le login vaut réactivation (délai de grâce)
+	if user.Desactivated {
+		user, err = reactivateOnLogin(ctx, user, ip)
+		if err != nil {
+			return auth_models.UserPayload{}, models.SessionsRequest{}, "", "", err
 		}
-		return auth_models.UserPayload{}, models.SessionsRequest{}, "", "", nubo_error.ErrBanned
 	}
 
 	// -------------------------------------------------------------------------
@@ -90,6 +103,11 @@ func Login(
 		}
 	}
 
+	// Une session révoquée (tombstone L1, ExpiresAt dépassé) n'est jamais réutilisée : on en recrée une neuve
+	if sessions.ID != 0 && !sessions.ExpiresAt.IsZero() && sessions.ExpiresAt.Before(now) {
+		sessions = models.SessionsRequest{}
+	}
+
 	// Traitement structurel de la session
 	if sessions.ID != 0 {
 		sessions.DeviceInfo = input.DeviceInfo
@@ -161,20 +179,7 @@ func Login(
 	}
 
 	// 🔐 D. [PROFILE PICTURE URL] : Extraction du storage path et signature du lien
-	var profilePictureURL string
-	if user.ProfilePictureID != 0 {
-		// On interroge la cascade L1->L2->L3 pour obtenir le storage path de l'avatar
-		mediaPayload, errMedia := media_service.GetMediaCascade(ctx, user.ProfilePictureID)
-		if errMedia == nil && mediaPayload.Visibility {
-			// On génère l'URL signée avec le lecteur, l'auteur (lui-même) et l'ID de post à 0
-			profilePictureURL = media_service.GenerateWatermarkedURL(
-				mediaPayload.StoragePath,
-				user.ID,
-				0,
-				user.ID,
-			)
-		}
-	}
+	profilePictureURL := ProfileImageURL(ctx, user.ID, user.ProfilePictureID)
 
 	// -------------------------------------------------------------------------
 	// 5. ENREGISTREMENT SUR LA QUEUE DE PERSISTANCE (Write-Behind)
@@ -189,5 +194,30 @@ func Login(
 		log.Printf("❌ CRITICAL: Rupture du Write-Behind pour la session %d : %v", sessions.ID, err)
 	}
 
+	audit.Record(ctx, audit.User(user.ID, ip), audit.ActionLogin, audit.On(audit.TargetSession, sessions.ID), map[string]any{
+		"new_session": isNewSession,
+	})
+
 	return user, sessions, newJWT, profilePictureURL, nil
 }
+
+// banError joint à ErrBanned la notice du ban en vigueur (motif, échéance, délai de contestation)
+// et un jeton d'appel : le compte n'a plus de session pour contester autrement.
+// Si la sanction n'est pas (encore) lisible en L3, la notice est reconstruite depuis le profil.
+func banError(ctx context.Context, user auth_models.UserPayload) error {
+	notice := nubo_error.SanctionNotice{Kind: variables.SanctionKindBan, Reason: user.BanReason}
+	if !user.BanExpiresAt.IsZero() {
+		expiresAt := user.BanExpiresAt
+		notice.ExpiresAt = &expiresAt
+	}
+
+	if active, err := postgresgo.FuncLoadActiveSanctions(ctx, user.ID, variables.StrikeWindowDays); err == nil {
+		for _, s := range active {
+			if s.Kind == variables.SanctionKindBan {
+				notice = s.Notice()
+				break
+			}
+		}
+	}
+	return &nubo_error.SanctionError{Cause: nubo_error.ErrBanned, Notice: notice, AppealToken: issueAppealToken(ctx, user.ID)}
+}
@@ -0,0 +1,199 @@
+package auth_service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/auth_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/mongo"
+	postgresgo "github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/verification_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// ============================================================================
+// RÉCUPÉRATION ET CHANGEMENT DE MOT DE PASSE
+// ============================================================================
+
+// ForgotPassword émet un jeton de récupération à usage unique (TTL court) via le canal de vérification.
+// Aucune information n'est renvoyée sur l'existence du compte (anti-énumération) : seul une panne
+// d'infrastructure remonte une erreur.
+func ForgotPassword(ctx context.Context, input auth_models.ForgotPasswordInput, ipAddress string) error {
+	user, err := LoadUserCascade(-1, strings.TrimSpace(input.Email))
+	if err != nil {
+		return err
+	}
+	if user.ID == 0 || user.Banned {
+		audit.Record(ctx, audit.User(0, ipAddress), audit.ActionPasswordForgotIgnored, audit.On(audit.TargetUser, user.ID), map[string]any{
+			"reason": "compte inexistant ou banni",
+		})
+		return nil
+	}
+
+	// 1. Génération du jeton (32 octets aléatoires) : seule son empreinte SHA-256 est stockée en Redis
+	token := randomHex(32)
+	tokenHash := hashResetToken(token)
+	ttl := time.Duration(variables.PasswordResetTokenTTLSeconds) * time.Second
+
+	// 2. Un seul jeton actif par utilisateur : le pointeur "user:<id>" invalide l'émission précédente
+	pointerKey := redis.PasswordResets.Key(fmt.Sprintf("user:%d", user.ID))
+	previous, errPrev := redis.PasswordResets.Client.GetSet(ctx, pointerKey, tokenHash).Result()
+	if errPrev == nil && previous != "" {
+		_ = redis.PasswordResets.DeleteObject(ctx, previous)
+	}
+	_ = redis.PasswordResets.Client.Expire(ctx, pointerKey, ttl).Err()
+
+	if err := redis.PasswordResets.SetPrimitive(ctx, tokenHash, user.ID); err != nil {
+		return fmt.Errorf("stockage du jeton de récupération: %w", err)
+	}
+
+	// 3. Expédition via le canal de vérification (mailer / SMS)
+	if err := verification_service.Send(ctx, verification_service.VerificationMessage{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Phone:     user.Phone,
+		Purpose:   verification_service.PurposePasswordReset,
+		Token:     token,
+		ExpiresAt: time.Now().UTC().Add(ttl),
+	}); err != nil {
+		_ = redis.PasswordResets.DeleteObject(ctx, tokenHash)
+		return fmt.Errorf("canal de vérification indisponible: %w", err)
+	}
+
+	audit.Record(ctx, audit.User(0, ipAddress), audit.ActionPasswordForgot, audit.On(audit.TargetUser, user.ID), nil)
+	return nil
+}
+
+// ResetPassword consomme le jeton (GETDEL atomique), applique le nouveau hash et révoque toutes les sessions.
+func ResetPassword(ctx context.Context, input auth_models.ResetPasswordInput, ipAddress string) error {
+	tokenHash := hashResetToken(strings.ToLower(strings.TrimSpace(input.Token)))
+
+	// 1. Consommation atomique : un second appel avec le même jeton échoue
+	userID, err := redis.PasswordResets.GetDelInt64(ctx, tokenHash)
+	if err != nil || userID == 0 {
+		audit.Record(ctx, audit.User(0, ipAddress), audit.ActionPasswordResetRejected, audit.Target{}, map[string]any{
+			"reason": "jeton invalide ou expiré",
+		})
+		return nubo_error.ErrInvalidToken
+	}
+	_ = redis.PasswordResets.DeleteObject(ctx, fmt.Sprintf("user:%d", userID))
+
+	user, err := LoadUserCascade(userID, "")
+	if err != nil {
+		return err
+	}
+	if user.ID == 0 {
+		return nubo_error.ErrNotFound
+	}
+
+	// 2. Application du nouveau hash (Write-Behind L2 + L3)
+	if err := applyPasswordHash(ctx, user, input.NewPasswordHash); err != nil {
+		return err
+	}
+
+	// 3. Révocation de toutes les sessions (rotation Ratchet + purge)
+	revoked := RevokeUserSessions(ctx, audit.User(user.ID, ipAddress), user.ID, 0)
+
+	audit.Record(ctx, audit.User(user.ID, ipAddress), audit.ActionPasswordReset, audit.On(audit.TargetUser, user.ID), map[string]any{
+		"sessions_revoked": revoked,
+	})
+	return nil
+}
+
+// ChangePassword modifie le mot de passe d'un utilisateur authentifié après vérification de l'ancien.
+// La session de l'appareil courant est conservée, toutes les autres sont révoquées.
+func ChangePassword(ctx context.Context, userID int64, deviceToken string, input auth_models.ChangePasswordInput, ipAddress string) (int, error) {
+	user, err := LoadUserCascade(userID, "")
+	if err != nil {
+		return 0, err
+	}
+	if user.ID == 0 {
+		return 0, nubo_error.ErrNotFound
+	}
+
+	if !samePasswordHash(user.PasswordHash, input.OldPasswordHash) {
+		audit.Record(ctx, audit.User(user.ID, ipAddress), audit.ActionPasswordChangeRejected, audit.On(audit.TargetUser, user.ID), map[string]any{
+			"reason": "ancien mot de passe incorrect",
+		})
+		return 0, nubo_error.ErrInvalidCredentials
+	}
+	if samePasswordHash(input.OldPasswordHash, input.NewPasswordHash) {
+		return 0, nubo_error.ErrSamePassword
+	}
+
+	if err := applyPasswordHash(ctx, user, input.NewPasswordHash); err != nil {
+		return 0, err
+	}
+
+	// Un éventuel jeton de récupération en attente devient caduc
+	pointerKey := fmt.Sprintf("user:%d", user.ID)
+	if pending, errPtr := redis.PasswordResets.Client.GetDel(ctx, redis.PasswordResets.Key(pointerKey)).Result(); errPtr == nil && pending != "" {
+		_ = redis.PasswordResets.DeleteObject(ctx, pending)
+	}
+
+	var keepSessionID int64
+	if current, errSess := cache_service.LoadSessionFromCache(ctx, user.ID, deviceToken, ""); errSess == nil {
+		keepSessionID = current.ID
+	} else if current, errSess := mongo.MongoLoadSession(user.ID, deviceToken, "", ""); errSess == nil && current.ID != 0 {
+		keepSessionID = current.ID
+	} else if current, errSess := postgresgo.FuncLoadSession(-1, user.ID, deviceToken, ""); errSess == nil {
+		keepSessionID = current.ID
+	}
+
+	revoked := RevokeUserSessions(ctx, audit.User(user.ID, ipAddress), user.ID, keepSessionID)
+
+	audit.Record(ctx, audit.User(user.ID, ipAddress), audit.ActionPasswordChange, audit.On(audit.TargetUser, user.ID), map[string]any{
+		"sessions_revoked": revoked,
+		"kept_session_id":  keepSessionID,
+	})
+	return revoked, nil
+}
+
+// --- HELPERS ---
+
+// LoadUserCascade charge un utilisateur par ID (id > 0) ou par email depuis Mongo (L2) puis Postgres (L3).
+func LoadUserCascade(id int64, email string) (auth_models.UserPayload, error) {
+	user, err := mongo.MongoLoadUser(id, "", email, "")
+	if err == nil && user.ID != 0 {
+		return user, nil
+	}
+
+	user, err = postgresgo.FuncLoadUser(id, "", email, "")
+	if err != nil {
+		return auth_models.UserPayload{}, fmt.Errorf("postgres critical failure: %w", err)
+	}
+	return user, nil
+}
+
+// samePasswordHash compare deux hashes en temps constant (pas d'oracle temporel sur le hash stocké).
+func samePasswordHash(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(strings.TrimSpace(a)), []byte(strings.TrimSpace(b))) == 1
+}
+
+// applyPasswordHash persiste le nouveau hash sur L2 et L3 via le Write-Behind.
+func applyPasswordHash(ctx context.Context, user auth_models.UserPayload, newHash string) error {
+	user.PasswordHash = strings.TrimSpace(newHash)
+	user.UpdatedAt = time.Now().UTC()
+
+	if err := redis.EnqueueDB(ctx, user.ID, 0, redis.EntityUser, redis.ActionUpdate, user, redis.TargetAll); err != nil {
+		log.Printf("❌ CRITICAL: Rupture du Write-Behind (mot de passe utilisateur %d) : %v", user.ID, err)
+		return err
+	}
+	return nil
+}
+
+// hashResetToken renvoie l'empreinte SHA-256 (hex) d'un jeton de récupération.
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,146 @@
+package auth_service
+
+import (
+	"context"
+	"mime/multipart"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/auth_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/media_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// ============================================================================
+// PHOTO DE PROFIL & COUVERTURE
+// ============================================================================
+// Une nouvelle image ne remplace l'ancienne qu'une fois traitée : le compte garde l'image courante jusqu'à ce
+// que le média soit prêt (événement "media.ready"), puis porte le nouvel ID ; l'ancien média est alors retiré
+// et ses objets seront collectés par le ramasse-miettes. Un échec ou une quarantaine laisse l'image courante
+// en place. L'empreinte Lite du SPEED cache est réécrite à la substitution : l'avatar change partout
+// (fil, recherche, commentaires) sans attendre l'expiration du cache.
+
+// ReplaceProfileImage dépose une photo de profil ou une couverture (layout = models.MediaLayout*), recadrée
+// selon la demande, et la met en file : elle sera substituée à l'image courante une fois prête.
+func ReplaceProfileImage(ctx context.Context, userID int64, ip string, layout string, fileHeader *multipart.FileHeader, input auth_models.ProfileImageInput) (auth_models.ProfileImageOutput, error) {
+	crop, err := requestedCrop(input)
+	if err != nil {
+		return auth_models.ProfileImageOutput{}, err
+	}
+	user, err := loadProfileUser(userID)
+	if err != nil {
+		return auth_models.ProfileImageOutput{}, err
+	}
+
+	// 1. Dépôt synchrone (fichier illisible ou recadrage invalide refusés avant toute écriture)
+	mediaID := pkg.GenerateID()
+	fitted, err := media_service.StageProfileUpload(ctx, fileHeader, userID, mediaID, layout, crop)
+	if err != nil {
+		return auth_models.ProfileImageOutput{}, err
+	}
+
+	// 2. La substitution sur le compte et le retrait de l'ancienne image ont lieu à la fin du traitement
+	if err := media_service.EnqueueProfileImageJob(ctx, userID, mediaID, layout); err != nil {
+		media_service.CancelPendingProfileImage(ctx, userID, layout)
+		media_service.DiscardStagedMedia(ctx, userID, []int64{mediaID})
+		return auth_models.ProfileImageOutput{}, err
+	}
+	previous := currentProfileImage(user, layout)
+
+	audit.Record(ctx, audit.User(userID, ip), audit.ActionProfileImageUpdate, audit.On(audit.TargetUser, userID), map[string]any{
+		"layout":            layout,
+		"media_id":          mediaID,
+		"previous_media_id": previous,
+	})
+	return auth_models.ProfileImageOutput{
+		MediaID: mediaID,
+		Layout:  layout,
+		Crop:    fitted,
+		Status:  variables.MediaStatusPending,
+	}, nil
+}
+
+// RemoveProfileImage retire la photo de profil ou la couverture du compte (ErrNoProfileImage si absente).
+func RemoveProfileImage(ctx context.Context, userID int64, ip string, layout string) error {
+	user, err := loadProfileUser(userID)
+	if err != nil {
+		return err
+	}
+
+	// Une image encore en traitement ne doit pas reprendre la place retirée
+	media_service.CancelPendingProfileImage(ctx, userID, layout)
+	if currentProfileImage(user, layout) == 0 {
+		return nubo_error.ErrNoProfileImage
+	}
+	previous, err := media_service.SwapProfileImage(ctx, userID, layout, 0)
+	if err != nil {
+		return err
+	}
+	if previous != 0 {
+		media_service.RetireMedia(ctx, previous)
+	}
+
+	audit.Record(ctx, audit.User(userID, ip), audit.ActionProfileImageRemove, audit.On(audit.TargetUser, userID), map[string]any{
+		"layout":            layout,
+		"previous_media_id": previous,
+	})
+	return nil
+}
+
+// ProfileImageURL renvoie le lien de l'image de profil d'un utilisateur, vue par lui-même ("" : aucune image
+// servable, encore en traitement par exemple). L'utilisateur est l'auteur de l'image : lien présigné de
+// courte durée (ID de post à 0).
+func ProfileImageURL(ctx context.Context, userID int64, mediaID int64) string {
+	if mediaID == 0 {
+		return ""
+	}
+	// On interroge la cascade L1->L2->L3 pour obtenir le storage path de l'image
+	media, err := media_service.GetMediaCascade(ctx, mediaID)
+	if err != nil || !media.Visibility || media.Status != variables.MediaStatusReady {
+		return ""
+	}
+	return media_service.MediaURL(ctx, media.StoragePath, userID, 0, userID)
+}
+
+// --- HELPERS ---
+
+// requestedCrop renvoie le recadrage demandé (nil : aucun). Un rectangle partiel est refusé.
+func requestedCrop(input auth_models.ProfileImageInput) (*models.MediaCrop, error) {
+	fields := []*int{input.CropX, input.CropY, input.CropWidth, input.CropHeight}
+	provided := 0
+	for _, f := range fields {
+		if f != nil {
+			provided++
+		}
+	}
+	switch provided {
+	case 0:
+		return nil, nil
+	case len(fields):
+		return &models.MediaCrop{X: *input.CropX, Y: *input.CropY, Width: *input.CropWidth, Height: *input.CropHeight}, nil
+	default:
+		return nil, nubo_error.ErrInvalidCrop
+	}
+}
+
+// loadProfileUser charge le compte à modifier (ErrNotFound s'il n'existe pas).
+func loadProfileUser(userID int64) (auth_models.UserPayload, error) {
+	user, err := LoadUserCascade(userID, "")
+	if err != nil {
+		return auth_models.UserPayload{}, err
+	}
+	if user.ID == 0 {
+		return auth_models.UserPayload{}, nubo_error.ErrNotFound
+	}
+	return user, nil
+}
+
+// currentProfileImage renvoie l'image du gabarit portée par le compte (0 : aucune image).
+func currentProfileImage(user auth_models.UserPayload, layout string) int64 {
+	if layout == models.MediaLayoutCover {
+		return user.CoverPictureID
+	}
+	return user.ProfilePictureID
+}
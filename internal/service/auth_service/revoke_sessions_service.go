@@ -0,0 +1,96 @@
+package auth_service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg/security"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/mongo"
+	postgresgo "github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+)
+
+// RevokeUserSessions révoque toutes les sessions d'un utilisateur sur L1/L2/L3, sauf keepSessionID (0 = aucune exception).
+// Retourne le nombre de sessions révoquées. Chaque révocation est inscrite au journal d'audit au nom d'actor.
+//
+// Chaque session est d'abord ré-initialisée via security.ResetRatchet avec un MasterToken jetable :
+// la version "tombstone" écrite en L1 ne peut plus valider aucune signature HMAC, ce qui ferme
+// la fenêtre pendant laquelle le Write-Behind n'a pas encore purgé Mongo/Postgres. Elle est volontairement
+// conservée en L1 : sans elle, HMACMiddleware retomberait sur la session encore valide en L2/L3.
+func RevokeUserSessions(ctx context.Context, actor audit.Actor, userID int64, keepSessionID int64) int {
+	// 1. Agrégation des sessions connues sur les trois niveaux (dédupliquées par ID)
+	sessions := make(map[int64]models.SessionsRequest)
+
+	if ids, err := redis.ListUserSessionIDs(ctx, userID); err == nil {
+		for _, id := range ids {
+			var s models.SessionsRequest
+			if errGet := redis.Sessions.GetObject(ctx, id, &s); errGet == nil && s.ID != 0 {
+				sessions[s.ID] = s
+			}
+		}
+	}
+	if l2, err := mongo.MongoLoadUserSessions(userID); err == nil {
+		for _, s := range l2 {
+			if _, ok := sessions[s.ID]; !ok && s.ID != 0 {
+				sessions[s.ID] = s
+			}
+		}
+	}
+	if l3, err := postgresgo.FuncLoadUserSessions(userID); err == nil {
+		for _, s := range l3 {
+			if _, ok := sessions[s.ID]; !ok && s.ID != 0 {
+				sessions[s.ID] = s
+			}
+		}
+	}
+
+	// 2. Rotation forcée puis suppression
+	revoked := 0
+	now := time.Now().UTC()
+	for id, s := range sessions {
+		if id == keepSessionID {
+			continue
+		}
+
+		if s.DeviceToken != "" {
+			throwaway := randomHex(32)
+			if secret, err := security.ResetRatchet(ctx, s.ID, throwaway, s.DeviceToken, s.LastJWT); err == nil {
+				s.MasterToken = throwaway
+				s.CurrentSecret = secret
+				s.LastSecret = ""
+				s.LastJWT = ""
+				s.ToleranceTime = time.Time{}
+				s.ExpiresAt = now
+				if errSet := redis.RedisUpdateSession(s); errSet != nil {
+					log.Printf("⚠️ Revocation: échec de l'écriture tombstone L1 pour la session %d : %v", s.ID, errSet)
+				}
+			}
+		}
+
+		if err := redis.EnqueueDB(ctx, s.ID, userID, redis.EntitySession, redis.ActionDelete, s, redis.TargetAll); err != nil {
+			log.Printf("❌ CRITICAL: Rupture du Write-Behind (revocation session %d) : %v", s.ID, err)
+			continue
+		}
+		audit.Record(ctx, actor, audit.ActionSessionRevoke, audit.On(audit.TargetSession, s.ID), map[string]any{
+			"user_id": userID,
+		})
+		revoked++
+	}
+
+	return revoked
+}
+
+// randomHex génère une chaîne hexadécimale cryptographiquement sûre de n octets.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand ne doit jamais échouer sur un OS sain : on préfère paniquer qu'émettre un jeton prévisible
+		panic("crypto/rand indisponible: " + err.Error())
+	}
+	return hex.EncodeToString(b)
+}
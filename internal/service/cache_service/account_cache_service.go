@@ -0,0 +1,102 @@
+package cache_service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/auth_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+)
+
+// hiddenAccountsSetID est l'identifiant unique du SET des comptes masqués (désactivés, bannis ou en attente de suppression).
+const hiddenAccountsSetID = "all"
+
+// ============================================================================
+// 7. COMPTES MASQUÉS (Désactivation / Suppression planifiée / Ban)
+// ============================================================================
+
+// IsAccountHidden indique si le contenu d'un utilisateur doit être masqué aux autres (O(1)).
+// Fail-Open : en cas de panne Redis, le contenu reste visible plutôt que de vider tous les feeds.
+func IsAccountHidden(ctx context.Context, userID int64) bool {
+	hidden, err := redis.HiddenAccounts.SIsMember(ctx, hiddenAccountsSetID, userID)
+	return err == nil && hidden
+}
+
+// HideAccount masque un compte partout : SET des comptes masqués, retrait de l'index de recherche
+// et révocation des liens médias déjà émis (comme auteur et comme lecteur).
+func HideAccount(ctx context.Context, u auth_models.UserPayload) error {
+	if err := redis.HiddenAccounts.SAdd(ctx, hiddenAccountsSetID, u.ID); err != nil {
+		return fmt.Errorf("failed to hide account %d: %w", u.ID, err)
+	}
+	_ = redis.ZRem(ctx, "speed_cache:search:lex", fmt.Sprintf("%s:%d", strings.ToLower(u.Username), u.ID))
+	_ = RevokeAccountMedia(ctx, u.ID)
+	return nil
+}
+
+// UnhideAccount rend un compte de nouveau visible et le réindexe dans la recherche.
+func UnhideAccount(ctx context.Context, u auth_models.UserPayload) error {
+	if err := redis.HiddenAccounts.SRem(ctx, hiddenAccountsSetID, u.ID); err != nil {
+		return fmt.Errorf("failed to unhide account %d: %w", u.ID, err)
+	}
+	u.Desactivated = false
+	return AddUserToSpeedCache(ctx, u)
+}
+
+// PurgeAccountFromSpeedCache efface l'empreinte SPEED/USER d'un compte supprimé définitivement.
+// Retourne le nombre de clés ou membres Redis effacés.
+func PurgeAccountFromSpeedCache(ctx context.Context, userID int64, username string) int64 {
+	var purged int64
+
+	if n, err := redis.HiddenAccounts.SRemCount(ctx, hiddenAccountsSetID, userID); err == nil {
+		purged += n
+	}
+	if err := redis.ZRem(ctx, "speed_cache:search:lex", fmt.Sprintf("%s:%d", strings.ToLower(username), userID)); err == nil {
+		purged++
+	}
+
+	for _, c := range []*redis.Collection{
+		redis.Users, redis.UserSettings, redis.UsersLite,
+		redis.SpeedFollowers, redis.SpeedRelations,
+		redis.FeedsObject, redis.FeedsMailbox, redis.FeedsPersonalized,
+		redis.CuckooSeen, redis.UserInbox,
+		redis.InterestProfiles, redis.ProfileSyncs,
+	} {
+		if n, err := c.Client.Del(ctx, c.Key(userID)).Result(); err == nil {
+			purged += n
+		}
+	}
+
+	if err := PurgeUserTimeline(ctx, userID); err == nil {
+		purged++
+	}
+	return purged
+}
+
+// RemovePostsFromMostCache retire des posts de tous les classements stricts, tendances et index de tags.
+func RemovePostsFromMostCache(ctx context.Context, postIDs []int64) (int64, error) {
+	if len(postIDs) == 0 {
+		return 0, nil
+	}
+	members := make([]interface{}, len(postIDs))
+	for i, id := range postIDs {
+		members[i] = strconv.FormatInt(id, 10)
+	}
+	return redis.ZRemFromMatching(ctx, "most_cache:*", members...)
+}
+
+// SeedHiddenAccounts reconstruit le SET des comptes masqués depuis L3 (redémarrage à froid de Redis).
+func SeedHiddenAccounts(ctx context.Context) error {
+	users, err := postgres.FuncLoadDeactivatedUsers(ctx)
+	if err != nil {
+		return err
+	}
+	for _, u := range users {
+		_ = HideAccount(ctx, u)
+	}
+	log.Printf("✅ Comptes masqués: %d rechargés.", len(users))
+	return nil
+}
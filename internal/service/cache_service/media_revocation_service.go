@@ -0,0 +1,46 @@
+package cache_service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg/mediaurl"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+)
+
+// ============================================================================
+// 8. RÉVOCATION DES LIENS MÉDIAS
+// ============================================================================
+// Les liens tatoués déjà émis restent valides jusqu'à leur échéance ; quand un droit d'accès disparaît,
+// on enregistre l'instant de la révocation sous le périmètre concerné (mediaurl.*Scope) et le micro-service
+// refuse tout lien couvert émis avant. Les liens présignés de l'auteur ne sont pas concernés : il garde
+// l'accès à ses propres médias.
+
+// RevokePostMedia invalide les liens des médias d'un post (passage en privé, suppression, retrait).
+func RevokePostMedia(ctx context.Context, postID int64) error {
+	return revokeMedia(ctx, mediaurl.PostScope(postID))
+}
+
+// RevokeAccountMedia invalide les liens d'un compte masqué, comme auteur et comme lecteur.
+func RevokeAccountMedia(ctx context.Context, userID int64) error {
+	return revokeMedia(ctx, mediaurl.AuthorScope(userID), mediaurl.ReaderScope(userID))
+}
+
+// RevokeReaderMedia invalide les liens émis à un lecteur pour les médias d'un auteur (blocage, désabonnement).
+func RevokeReaderMedia(ctx context.Context, authorID int64, readerID int64) error {
+	return revokeMedia(ctx, mediaurl.PairScope(authorID, readerID))
+}
+
+// revokeMedia horodate la révocation des périmètres (TTL : durée de vie maximale d'un lien).
+func revokeMedia(ctx context.Context, scopes ...string) error {
+	now := time.Now().Unix()
+	pipe := redis.MediaRevocations.Pipeline()
+	for _, scope := range scopes {
+		pipe.Set(ctx, redis.MediaRevocations.Key(scope), now, redis.MediaRevocations.DefaultTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("erreur lors de la révocation des liens médias: %w", err)
+	}
+	return nil
+}
@@ -29,8 +29,16 @@ func UpdatePostRecommendationScore(ctx context.Context, p post_models.PostPayloa
 	}
 
 	// ✅ AJOUT : Transmission du PriorityLevel dans la chaîne de calcul
-	// TODO rendre dynamique isFlagged
-	UpdateScoreWithMetrics(ctx, p.ID, p.LikeCount, p.CommentCount, p.ViewCount, mediaCount, p.CreatedAt, p.Hashtags, p.Visibility, 0, p.PriorityLevel)
+	UpdateScoreWithMetrics(ctx, p.ID, p.LikeCount, p.CommentCount, p.ViewCount, mediaCount, p.CreatedAt, p.Hashtags, p.Visibility, IsPostFlagged(ctx, p.ID), p.PriorityLevel)
+}
+
+// IsPostFlagged renvoie 1 si le post fait l'objet d'un dossier de modération ouvert au score suffisant
+// (marquage posé par report_service.EvaluateTakedowns), 0 sinon. Fail-Open : une panne Redis ne pénalise personne.
+func IsPostFlagged(ctx context.Context, postID int64) int {
+	if _, err := redis.FlaggedPosts.GetInt64(ctx, postID); err != nil {
+		return 0
+	}
+	return 1
 }
 
 // EvaluatePostAfterLike force l'insertion du post_service avec sa valeur absolue dans les classements stricts.
@@ -162,7 +170,12 @@ func UpdateTrendZSETs(ctx context.Context, postID int64, score float64, hashtags
 }
 
 // UpdateScoreWithMetrics orchestre le calcul et la distribution des scores.
+// isFlagged = 1 applique la pénalité de modération φ_mod ; un post supprimé ou masqué (visibilité négative)
+// n'est jamais (ré)inséré dans les classements.
 func UpdateScoreWithMetrics(ctx context.Context, postID int64, likes, comments, views, mediaCount int, createdAt time.Time, hashtags []string, visibility int, isFlagged int, priorityLevel int) {
+	if visibility < 0 {
+		return
+	}
 	ageSeconds := time.Since(createdAt).Seconds()
 
 	baseOpts := service.ScoreOptions{
@@ -171,8 +184,8 @@ func UpdateScoreWithMetrics(ctx context.Context, postID int64, likes, comments,
 		ViewCount:     views,
 		MediaCount:    mediaCount,
 		AgeSeconds:    ageSeconds,
-		IsDeleted:     visibility == 0,
-		IsReported:    isFlagged == 0,
+		IsDeleted:     visibility < 0,
+		IsReported:    isFlagged == 1,
 	}
 	scoreGlobal := service.CalculateRecommendationScore(postID, baseOpts)
 
@@ -38,7 +38,22 @@ func fetchAndHydrateFromZSET(ctx context.Context, key string, offset int64, limi
 		ids = append(ids, id)
 	}
 
-	return object_cache_service.GetPostsView(ids)
+	posts, err := object_cache_service.GetPostsView(ids)
+	if err != nil {
+		return nil, err
+	}
+	return filterHiddenAuthors(ctx, posts), nil
+}
+
+// filterHiddenAuthors retire des classements les posts dont l'auteur est désactivé.
+func filterHiddenAuthors(ctx context.Context, posts []post_models.PostPayload) []post_models.PostPayload {
+	visible := posts[:0]
+	for _, p := range posts {
+		if !IsAccountHidden(ctx, p.UserID) {
+			visible = append(visible, p)
+		}
+	}
+	return visible
 }
 
 func getPostsFromMongoPaginated(field string, value any, offset int64, limit int64) ([]post_models.PostPayload, error) {
@@ -141,15 +156,17 @@ func HydrateFeed(ctx context.Context, postIDs []int64) ([]post_models.PostPayloa
 		// ─────────────────────────────────────────────────────────────────────
 		// Si le post_service a été modéré, ou l'auteur banni entre la création du buffer et la lecture.
 		// On s'aligne sur ta logique Postgres où la visibilité '2' équivaut à un post_service supprimé/masqué.
-		if post.Visibility == -1 {
+		if post.Visibility < 0 {
 			// Le post_service est ignoré silencieusement côté backend.
 			// Le frontend ne le recevra même pas, ce qui économise de la bande passante
 			// et garantit qu'aucune donnée d'un utilisateur banni ne fuite.
 			continue
 		}
 
-		// (Optionnel) : Tu pourrais aussi vérifier le statut de l'auteur ici
-		// via un appel ultra-rapide au cache_service Utilisateur si nécessaire.
+		// Auteur désactivé ou en attente de suppression : vérification O(1) du SET des comptes masqués
+		if IsAccountHidden(ctx, post.UserID) {
+			continue
+		}
 
 		hydratedPosts = append(hydratedPosts, post)
 	}
@@ -175,6 +175,11 @@ func SeedSpeedCache() error {
 	}
 	log.Printf("✅ SPEED Cache Users: %d chargés.", offsetUsers)
 
+	// Les comptes désactivés ne doivent ni apparaître dans la recherche ni exposer leur contenu
+	if err := SeedHiddenAccounts(ctx); err != nil {
+		log.Printf("⚠️ Avertissement: Erreur DB lors du chargement des comptes masqués: %v", err)
+	}
+
 	// --- 2. Relations ---
 	log.Println("⚡ Amorçage SPEED Cache: Chargement des relations...")
 	offsetRels := 0
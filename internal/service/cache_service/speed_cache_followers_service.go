@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/mongo"
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
@@ -55,6 +56,21 @@ func RelationValue(ctx context.Context, targetID int64, callerID int64) int {
 
 // UpdateRelationState met à jour l'état de la relation (à appeler depuis les handlers de follow/unfollow/ban)
 func UpdateRelationState(ctx context.Context, targetID int64, callerID int64, newState int) error {
+	// 0. Un droit perdu (désabonnement, fin d'amitié, blocage) révoque les liens médias déjà émis.
+	// État précédent inconnu en RAM (amorçage compris) : seule la perte de tout lien révoque
+	previous := 1
+	if val, errGet := redis.SpeedRelations.HGet(ctx, targetID, strconv.FormatInt(callerID, 10)).Result(); errGet == nil {
+		if state, errConv := strconv.Atoi(val); errConv == nil {
+			previous = state
+		}
+	}
+	if newState < previous {
+		_ = RevokeReaderMedia(ctx, targetID, callerID)
+		if newState == -1 { // Le blocage vaut dans les deux sens
+			_ = RevokeReaderMedia(ctx, callerID, targetID)
+		}
+	}
+
 	// 1. Met à jour le dictionnaire d'accès
 	err := redis.SpeedRelations.HSet(ctx, targetID, strconv.FormatInt(callerID, 10), newState)
 
@@ -111,3 +127,62 @@ func GetSpeedFriends(ctx context.Context, userID int64) ([]int64, error) {
 
 	return friends, nil
 }
+
+// FanOutPostToMailboxes distribue un post publié dans les boîtes aux lettres Redis de son audience :
+// les amis pour un post privé (visibilité 2), les abonnés sinon. Appelé à la création d'un post sans média
+// (worker) et à la publication d'un post dont les médias viennent d'être traités.
+func FanOutPostToMailboxes(ctx context.Context, authorID int64, postID int64, visibility int) {
+	if visibility < 0 {
+		return // Post masqué (traitement, échec média, examen) : rien à distribuer
+	}
+
+	var targetIDs []int64
+	var err error
+
+	// LE FILTRE DE VISIBILITÉ ET LE FAN-OUT HYBRIDE
+	if visibility == 2 {
+		// ✅ CAS 1 : Post Privé (Amis Uniquement)
+		// On ne l'envoie qu'aux amis (graphe bidirectionnel) pour ne pas polluer les simples abonnés.
+		targetIDs, err = GetSpeedFriends(ctx, authorID)
+	} else {
+		// ✅ CAS 2 : Post Public ou Abonnés (Visibility 0 ou 1)
+		// Protection Anti-Crash "Justin Bieber" : On compte avant de charger en RAM
+		followerCount := GetFollowerCount(ctx, authorID)
+		if followerCount > 50000 {
+			log.Printf("🛡️ [FanOut] Annulé pour le VIP %d (%d abonnés). Délégation au Most Cache Global.", authorID, followerCount)
+			return
+		}
+
+		targetIDs, err = GetSpeedFollowers(ctx, authorID)
+	}
+
+	if err != nil {
+		log.Printf("⚠️ [FanOut] Impossible de lire le graphe de l'user %d: %v", authorID, err)
+		return
+	}
+
+	if len(targetIDs) == 0 {
+		return // L'utilisateur n'a pas d'audience (Ville fantôme locale), rien à distribuer
+	}
+
+	// Distribution de masse via Redis Pipeline encapsulé (DDD)
+	pipe := redis.FeedsMailbox.Pipeline()
+	score := float64(time.Now().UnixMilli()) // Le score chronologique absolu
+
+	for _, followerID := range targetIDs {
+		mailboxKey := redis.FeedsMailbox.Key(followerID)
+
+		// ✅ On utilise pipe.Do() pour éviter les erreurs de structure avec redisgo.Z{}
+		pipe.Do(ctx, "ZADD", mailboxKey, score, postID)
+
+		// ✅ ZREMRANGEBYRANK remplace LTRIM.
+		// En supprimant du rang 0 au rang -501, on demande à Redis de ne conserver que
+		// les 500 posts avec le score le plus élevé (les plus récents).
+		pipe.Do(ctx, "ZREMRANGEBYRANK", mailboxKey, 0, -501)
+	}
+
+	// Exécution atomique du lot de distribution
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("❌ [FanOut] Échec de l'exécution du pipeline de distribution pour le post_service %d: %v", postID, err)
+	}
+}
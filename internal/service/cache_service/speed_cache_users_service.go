@@ -17,9 +17,12 @@ import (
 // AddUserToSpeedCache insère un nouvel utilisateur dans l'index de recherche et le store SPEED cache
 func AddUserToSpeedCache(ctx context.Context, u auth_models.UserPayload) error {
 	// 1. Insertion dans l'index lexicographique (Score à 0 pour le tri par chaînes)
-	lexValue := fmt.Sprintf("%s:%d", strings.ToLower(u.Username), u.ID)
-	if err := redis.ZAdd(ctx, "speed_cache:search:lex", 0, lexValue); err != nil {
-		return fmt.Errorf("failed to index user lexically in speed cache: %w", err)
+	// Un compte désactivé n'est jamais réindexé (la réhydratation L2/L3 ne doit pas le faire réapparaître)
+	if !u.Desactivated && !IsAccountHidden(ctx, u.ID) {
+		lexValue := fmt.Sprintf("%s:%d", strings.ToLower(u.Username), u.ID)
+		if err := redis.ZAdd(ctx, "speed_cache:search:lex", 0, lexValue); err != nil {
+			return fmt.Errorf("failed to index user lexically in speed cache: %w", err)
+		}
 	}
 
 	// 2. Construction de la structure d'empreinte minimale (Lite)
@@ -76,6 +79,9 @@ func SearchUserByPrefix(ctx context.Context, prefix string, limit int64) ([]mode
 	var users []models.UserLiteRequest
 	// 4. On boucle sur ids pour conserver l'ordre alphabétique exact renvoyé par l'index
 	for _, id := range ids {
+		if IsAccountHidden(ctx, id) {
+			continue // Filet de sécurité si l'index n'a pas encore été nettoyé
+		}
 		if data, ok := getRes.Found[id]; ok {
 			var u models.UserLiteRequest
 			if err := msgpack.Unmarshal(data, &u); err == nil {
@@ -9,9 +9,16 @@ import (
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service/object_cache_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/sanction_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
 )
 
 func CreateComment(ctx context.Context, input comment_models.CreateCommentInput) error {
+	// 0. Sanctions : un utilisateur restreint ne commente pas
+	if err := sanction_service.CheckRestriction(ctx, input.UserID, variables.RestrictionScopeComment); err != nil {
+		return err
+	}
+
 	// 1. Temps
 	now := time.Now().UTC().Format(time.RFC3339)
 
@@ -7,6 +7,7 @@ import (
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service/object_cache_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/security_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
 )
 
 // DeleteComment gère la rétractation d'un commentaire (Purge L1, Soft Delete asynchrone et décrémentation).
@@ -20,6 +21,12 @@ func DeleteComment(ctx context.Context, input comment_models.DeleteCommentInput)
 		return err
 	}
 
+	return RemoveComment(ctx, comment)
+}
+
+// RemoveComment purge un commentaire du cache L1 puis délègue sa suppression aux workers.
+// Partagé par la rétractation de l'auteur et le retrait confirmé par la modération.
+func RemoveComment(ctx context.Context, comment comment_models.CommentPayload) error {
 	// ─────────────────────────────────────────────────────────────────────────
 	// 2. PURGE DU CACHE L1 ET PRÉPARATION DU SOFT DELETE
 	// ─────────────────────────────────────────────────────────────────────────
@@ -28,7 +35,7 @@ func DeleteComment(ctx context.Context, input comment_models.DeleteCommentInput)
 	_ = object_cache_service.DeleteCommentFromObjectCache(ctx, comment.ID)
 	_ = object_cache_service.RemoveCommentFromZSET(ctx, comment.PostID, comment.ID)
 
-	comment.Visibility = -1
+	comment.Visibility = variables.VisibilityDeleted
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// 3. ENVOI AUX WORKERS POUR DÉCRÉMENTATION ET MISE À JOUR BDD
@@ -48,9 +48,14 @@ func GetComments(ctx context.Context, input comment_models.GetCommentsInput) ([]
 	// ⚡ MATRICE DE VISIBILITÉ EXACTE DE NUBO
 	isAuthor := post.UserID == input.UserID
 	if !isAuthor {
+		// Auteur désactivé ou en attente de suppression : le post n'existe plus pour les autres
+		if cache_service.IsAccountHidden(ctx, post.UserID) {
+			return []comment_models.GetCommentOutput{}, errors.New("post parent introuvable ou supprimé")
+		}
+
 		relationState := cache_service.RelationValue(ctx, post.UserID, input.UserID)
 
-		if relationState == -1 || post.Visibility == -1 {
+		if relationState == -1 || post.Visibility < 0 {
 			return []comment_models.GetCommentOutput{}, errors.New("accès refusé") // Bloqué ou Supprimé
 		}
 		if post.Visibility == 1 && relationState < 1 {
@@ -81,7 +86,7 @@ func GetComments(ctx context.Context, input comment_models.GetCommentsInput) ([]
 				c, ok := commentsMap[id]
 
 				// Si introuvable ou Soft-Delete, on renvoie une erreur encapsulée pour cet ID
-				if !ok || c.Visibility == -1 {
+				if !ok || c.Visibility < 0 || isCommentAuthorHidden(ctx, c, input.UserID) {
 					results = append(results, comment_models.GetCommentOutput{
 						CommentID: id,
 						Error:     "Commentaire introuvable ou supprimé",
@@ -114,6 +119,10 @@ func GetComments(ctx context.Context, input comment_models.GetCommentsInput) ([]
 				_ = object_cache_service.AddCommentToZSET(ctx, c.PostID, c.ID, float64(c.Score))
 			}
 
+			if c.Visibility < 0 || isCommentAuthorHidden(ctx, c, input.UserID) {
+				continue
+			}
+
 			val := c
 			results = append(results, comment_models.GetCommentOutput{
 				CommentID: c.ID,
@@ -137,6 +146,10 @@ func GetComments(ctx context.Context, input comment_models.GetCommentsInput) ([]
 				// ✅ Réparation L1 (Index ZSET)
 				_ = object_cache_service.AddCommentToZSET(ctx, c.PostID, c.ID, float64(c.Score))
 
+				if isCommentAuthorHidden(ctx, c, input.UserID) {
+					continue
+				}
+
 				val := c
 				results = append(results, comment_models.GetCommentOutput{
 					CommentID: c.ID,
@@ -150,6 +163,11 @@ func GetComments(ctx context.Context, input comment_models.GetCommentsInput) ([]
 	return []comment_models.GetCommentOutput{}, nil
 }
 
+// isCommentAuthorHidden masque les commentaires d'un compte désactivé (sauf pour leur propre auteur).
+func isCommentAuthorHidden(ctx context.Context, c comment_models.CommentPayload, callerID int64) bool {
+	return c.UserID != callerID && cache_service.IsAccountHidden(ctx, c.UserID)
+}
+
 // fetchCommentsCascade gère l'hydratation L1 -> L2 -> L3 pour un batch d'IDs
 func fetchCommentsCascade(ctx context.Context, ids []int64) map[int64]comment_models.CommentPayload {
 	commentsMap := make(map[int64]comment_models.CommentPayload)
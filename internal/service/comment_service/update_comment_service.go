@@ -5,9 +5,12 @@ import (
 	"time"
 
 	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/comment_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/revision_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service/object_cache_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/security_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
 )
 
 // UpdateComment gère la modification en récupérant l'objet complet pour le Bulk Update des workers.
@@ -24,8 +27,19 @@ func UpdateComment(ctx context.Context, input comment_models.UpdateCommentInput)
 	// ─────────────────────────────────────────────────────────────────────────
 	// 2. APPLICATION DES MODIFICATIONS
 	// ─────────────────────────────────────────────────────────────────────────
+	// L'état d'avant est archivé pour l'historique des modifications (fiche d'administration)
+	now := time.Now().UTC()
+	revision := revision_models.RevisionPayload{
+		ID:         pkg.GenerateID(),
+		TargetType: variables.ReportTargetComment,
+		TargetID:   comment.ID,
+		EditorID:   input.UserID,
+		Snapshot:   revision_models.CommentSnapshot(comment),
+		EditedAt:   now,
+	}
+
 	comment.Content = input.Content
-	comment.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	comment.UpdatedAt = now.Format(time.RFC3339)
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// 3. SAUVEGARDE ET DÉLÉGATION AUX WORKERS BATCH
@@ -37,5 +51,10 @@ func UpdateComment(ctx context.Context, input comment_models.UpdateCommentInput)
 	}
 
 	// 2. Envoi de l'objet COMPLET dans la file asynchrone pour les bulkUpdate
-	return redis.EnqueueDB(ctx, comment.ID, 0, redis.EntityComment, redis.ActionUpdate, comment, redis.TargetAll)
+	if err := redis.EnqueueDB(ctx, comment.ID, 0, redis.EntityComment, redis.ActionUpdate, comment, redis.TargetAll); err != nil {
+		return err
+	}
+
+	// 3. Historique (L3 uniquement, append-only)
+	return redis.EnqueueDB(ctx, revision.ID, 0, redis.EntityRevision, redis.ActionCreate, revision, redis.TargetPostgres)
 }
@@ -0,0 +1,54 @@
+package event_service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	redisgo "github.com/QuentinRegnier/nubo-backend/internal/infrastructure/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+)
+
+// ============================================================================
+// ÉVÉNEMENTS UTILISATEUR (WebSocket)
+// ============================================================================
+// Un événement vise un utilisateur : il est publié sur un flux Redis commun (pattern Claim Check) et
+// chaque hub WebSocket ne le relaie qu'aux connexions de cet utilisateur, quelle que soit l'instance.
+
+// EventsChannelName est le flux Redis écouté par les hubs WebSocket.
+const EventsChannelName = "nubo-events"
+
+// Types d'événements
+const (
+	EventMediaReady      = "media.ready"       // Déclinaisons d'un média disponibles
+	EventMediaFailed     = "media.failed"      // Média illisible ou traitement abandonné
+	EventPostPublished   = "post.published"    // Tous les médias du post sont prêts, le post est visible
+	EventPostMediaFailed = "post.media_failed" // Au moins un média a échoué, le post reste masqué
+)
+
+// Event est l'enveloppe transmise au client.
+type Event struct {
+	UserID    int64     `json:"user_id"`
+	Type      string    `json:"type"`
+	Data      any       `json:"data"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Publish pousse un événement vers les connexions WebSocket de l'utilisateur.
+// Un utilisateur hors ligne ne le reçoit pas : l'état reste consultable par les routes habituelles.
+func Publish(ctx context.Context, userID int64, eventType string, data any) error {
+	payload, err := json.Marshal(Event{
+		UserID:    userID,
+		Type:      eventType,
+		Data:      data,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("erreur lors de l'encodage de l'événement %s: %w", eventType, err)
+	}
+
+	messageID := fmt.Sprintf("event:%d", pkg.GenerateID())
+	return redis.PushFluxWithTTL(redisgo.Rdb, EventsChannelName, messageID, payload, redis.DefaultFluxTTL)
+}
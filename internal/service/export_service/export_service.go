@@ -0,0 +1,420 @@
+package export_service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/account_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/blobstore"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/mongo"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// Identifiants des ZSET de la file d'export.
+const (
+	exportQueueID      = "pending" // score = date de demande Unix
+	exportQueueRunning = "running" // score = fin du bail Unix
+)
+
+// exportDatasets est l'ordre des fichiers JSON de l'archive.
+var exportDatasets = []string{
+	"profile", "settings", "sessions", "relations",
+	"posts", "comments", "likes", "media", "messages",
+}
+
+// redactedFields ne quittent jamais le serveur : ce sont des secrets d'authentification, pas des données personnelles.
+var redactedFields = map[string]bool{
+	"password_hash":  true,
+	"master_token":   true,
+	"device_token":   true,
+	"current_secret": true,
+	"last_secret":    true,
+	"last_jwt":       true,
+}
+
+// ============================================================================
+// 1. DEMANDE ET SUIVI (API)
+// ============================================================================
+
+// RequestExport met en file une nouvelle archive de données pour l'utilisateur.
+// Une demande en cours, ou une archive prête de moins de ExportCooldownHours, est renvoyée telle quelle
+// (created = false) pour éviter de reconstruire des archives volumineuses en boucle. Une construction dont
+// le bail a expiré est traitée comme un échec : elle ne bloque pas une nouvelle demande.
+func RequestExport(ctx context.Context, userID int64) (account_models.ExportJobPayload, bool, error) {
+	now := time.Now().UTC()
+
+	var previous account_models.ExportJobPayload
+	if err := redis.AccountExports.GetObject(ctx, userID, &previous); err == nil && previous.ID != 0 {
+		switch previous.State {
+		case variables.ExportStatePending:
+			return previous, false, nil
+		case variables.ExportStateRunning:
+			// Bail dépassé : l'instance est tombée pendant la construction, la demande est considérée en échec
+			if !leaseExpired(previous, now) {
+				return previous, false, nil
+			}
+		case variables.ExportStateReady:
+			if now.Before(previous.RequestedAt.Add(variables.ExportCooldownHours * time.Hour)) {
+				return previous, false, nil
+			}
+		}
+	}
+
+	job := account_models.ExportJobPayload{
+		ID:          pkg.GenerateID(),
+		UserID:      userID,
+		State:       variables.ExportStatePending,
+		RequestedAt: now,
+	}
+	if err := redis.AccountExports.SetObject(ctx, userID, job); err != nil {
+		return account_models.ExportJobPayload{}, false, fmt.Errorf("enregistrement de la demande d'export: %w", err)
+	}
+	if err := redis.ExportQueue.ZAdd(ctx, exportQueueID, float64(now.Unix()), userID); err != nil {
+		return account_models.ExportJobPayload{}, false, fmt.Errorf("mise en file de l'export: %w", err)
+	}
+
+	// L'ancienne archive est remplacée : on ne la laisse pas dormir dans le bucket
+	if previous.ObjectPath != "" {
+		_ = blobstore.Store.Delete(ctx, exportBucket(), previous.ObjectPath)
+	}
+
+	audit.Record(ctx, audit.User(userID, ""), audit.ActionExportRequested, audit.On(audit.TargetExport, job.ID), nil)
+	return job, true, nil
+}
+
+// GetExportStatus renvoie l'état de la dernière demande et, si l'archive est prête,
+// un lien de téléchargement MinIO signé valable ExportURLTTLSeconds.
+func GetExportStatus(ctx context.Context, userID int64) (account_models.ExportStatusOutput, error) {
+	var job account_models.ExportJobPayload
+	if err := redis.AccountExports.GetObject(ctx, userID, &job); err != nil || job.ID == 0 {
+		return account_models.ExportStatusOutput{}, nubo_error.ErrNotFound
+	}
+
+	out := account_models.ExportStatusOutput{
+		JobID:       job.ID,
+		State:       job.State,
+		RequestedAt: job.RequestedAt,
+		SizeBytes:   job.SizeBytes,
+		Counts:      job.Counts,
+	}
+	if job.State == variables.ExportStateRunning && leaseExpired(job, time.Now().UTC()) {
+		out.State = variables.ExportStateFailed
+	}
+	if job.State != variables.ExportStateReady {
+		return out, nil
+	}
+
+	now := time.Now().UTC()
+	if now.After(job.ExpiresAt) {
+		return account_models.ExportStatusOutput{}, nubo_error.ErrNotFound
+	}
+
+	ttl := time.Duration(variables.ExportURLTTLSeconds) * time.Second
+	params := url.Values{}
+	params.Set("response-content-disposition", fmt.Sprintf(`attachment; filename="nubo-export-%d.zip"`, job.ID))
+	signed, err := blobstore.Store.Presign(ctx, exportBucket(), job.ObjectPath, ttl, params)
+	if err != nil {
+		return account_models.ExportStatusOutput{}, fmt.Errorf("signature du lien d'export: %w", err)
+	}
+
+	urlExpiresAt := now.Add(ttl)
+	out.CompletedAt = &job.CompletedAt
+	out.ExpiresAt = &job.ExpiresAt
+	out.DownloadURL = signed.String()
+	out.URLExpiresAt = &urlExpiresAt
+
+	audit.Record(ctx, audit.User(userID, ""), audit.ActionExportLink, audit.On(audit.TargetExport, job.ID), nil)
+	return out, nil
+}
+
+// ============================================================================
+// 2. CONSTRUCTION DES ARCHIVES (Worker)
+// ============================================================================
+
+// ProcessPendingExports construit les archives en attente. Chaque demande est réclamée par un ZREM
+// atomique pour qu'une seule instance la traite. Retourne le nombre d'archives produites.
+func ProcessPendingExports(ctx context.Context) int {
+	due, err := redis.ExportQueue.ZRangeByScoreWithLimit(ctx, exportQueueID, time.Now().Unix(), variables.ExportBatchSize)
+	if err != nil || len(due) == 0 {
+		return 0
+	}
+
+	done := 0
+	for _, member := range due {
+		claimed, errZ := redis.ExportQueue.Client.ZRem(ctx, redis.ExportQueue.Key(exportQueueID), member).Result()
+		if errZ != nil || claimed == 0 {
+			continue
+		}
+		userID, errParse := strconv.ParseInt(member, 10, 64)
+		if errParse != nil {
+			continue
+		}
+
+		var job account_models.ExportJobPayload
+		if err := redis.AccountExports.GetObject(ctx, userID, &job); err != nil || job.State != variables.ExportStatePending {
+			continue
+		}
+
+		// Bail : si l'instance s'arrête pendant la construction, RequeueExpiredExports remet la demande en file
+		job.State = variables.ExportStateRunning
+		job.StartedAt = time.Now().UTC()
+		job.Attempts++
+		lease := job.StartedAt.Add(variables.ExportLeaseSeconds * time.Second)
+		if err := redis.ExportQueue.ZAdd(ctx, exportQueueRunning, float64(lease.Unix()), userID); err != nil {
+			_ = redis.ExportQueue.ZAdd(ctx, exportQueueID, float64(job.RequestedAt.Unix()), userID)
+			continue
+		}
+		_ = redis.AccountExports.SetObject(ctx, userID, job)
+
+		errBuild := buildArchive(ctx, &job)
+		_ = redis.ExportQueue.ZRem(ctx, exportQueueRunning, userID)
+
+		// Une nouvelle demande a remplacé celle-ci (bail dépassé) : son état ne doit pas être écrasé
+		var current account_models.ExportJobPayload
+		if err := redis.AccountExports.GetObject(ctx, userID, &current); err != nil || current.ID != job.ID {
+			if job.ObjectPath != "" {
+				_ = blobstore.Store.Delete(ctx, exportBucket(), job.ObjectPath)
+			}
+			continue
+		}
+
+		if errBuild != nil {
+			log.Printf("❌ Export RGPD %d (user %d) en échec : %v", job.ID, userID, errBuild)
+			job.State = variables.ExportStateFailed
+			job.Error = errBuild.Error()
+		} else {
+			job.State = variables.ExportStateReady
+			job.CompletedAt = time.Now().UTC()
+			job.ExpiresAt = job.CompletedAt.Add(variables.ExportRetentionHours * time.Hour)
+			done++
+			audit.Record(ctx, audit.System, audit.ActionExportReady, audit.On(audit.TargetExport, job.ID), map[string]any{
+				"user_id": userID,
+			})
+		}
+		_ = redis.AccountExports.SetObject(ctx, userID, job)
+	}
+	return done
+}
+
+// RequeueExpiredExports remet en file les archives dont le bail a expiré (instance arrêtée en pleine
+// construction). Au-delà de ExportMaxAttempts, la demande passe en échec pour que l'utilisateur puisse en refaire une.
+func RequeueExpiredExports(ctx context.Context) int {
+	now := time.Now().UTC()
+	expired, err := redis.ExportQueue.ZRangeByScoreWithLimit(ctx, exportQueueRunning, now.Unix(), 100)
+	if err != nil {
+		return 0
+	}
+
+	requeued := 0
+	for _, member := range expired {
+		userID, errParse := strconv.ParseInt(member, 10, 64)
+		if errParse != nil {
+			_ = redis.ExportQueue.ZRem(ctx, exportQueueRunning, member)
+			continue
+		}
+
+		var job account_models.ExportJobPayload
+		if err := redis.AccountExports.GetObject(ctx, userID, &job); err != nil || job.State != variables.ExportStateRunning {
+			_ = redis.ExportQueue.ZRem(ctx, exportQueueRunning, member)
+			continue
+		}
+
+		if job.Attempts >= variables.ExportMaxAttempts {
+			job.State = variables.ExportStateFailed
+			job.Error = "bail de construction expiré"
+			if err := redis.AccountExports.SetObject(ctx, userID, job); err != nil {
+				continue
+			}
+			_ = redis.ExportQueue.ZRem(ctx, exportQueueRunning, member)
+			continue
+		}
+
+		job.State = variables.ExportStatePending
+		if err := redis.AccountExports.SetObject(ctx, userID, job); err != nil {
+			continue
+		}
+		if err := redis.ExportQueue.ZAdd(ctx, exportQueueID, float64(now.Unix()), userID); err != nil {
+			continue
+		}
+		_ = redis.ExportQueue.ZRem(ctx, exportQueueRunning, member)
+		requeued++
+	}
+	return requeued
+}
+
+// buildArchive rassemble les données L3 (fallback L2), les médias originaux MinIO, puis dépose le ZIP
+// dans le préfixe privé du bucket. L'archive transite par un fichier temporaire pour ne pas saturer la RAM.
+func buildArchive(ctx context.Context, job *account_models.ExportJobPayload) error {
+	tmp, err := os.CreateTemp("", "nubo-export-*.zip")
+	if err != nil {
+		return fmt.Errorf("fichier temporaire: %w", err)
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	zw := zip.NewWriter(tmp)
+	counts := make(map[string]int64, len(exportDatasets)+1)
+	var mediaPaths []string
+
+	// 1. Jeux de données JSON
+	for _, dataset := range exportDatasets {
+		records, errLoad := loadDataset(ctx, dataset, job.UserID)
+		if errLoad != nil {
+			return errLoad
+		}
+		for _, r := range records {
+			for field := range redactedFields {
+				delete(r, field)
+			}
+			if dataset == "media" {
+				if p, ok := r["storage_path"].(string); ok && p != "" {
+					mediaPaths = append(mediaPaths, p)
+				}
+			}
+		}
+		if err := writeJSON(zw, dataset+".json", records); err != nil {
+			return err
+		}
+		counts[dataset] = int64(len(records))
+	}
+
+	// 2. Médias originaux (un fichier manquant n'invalide pas l'export, il est simplement compté)
+	bucket := os.Getenv("MINIO_BUCKET_NAME")
+	for _, p := range mediaPaths {
+		if errCopy := copyMediaIntoArchive(ctx, zw, bucket, p); errCopy != nil {
+			log.Printf("⚠️ Export RGPD %d : média %s ignoré (%v)", job.ID, p, errCopy)
+			counts["media_missing"]++
+			continue
+		}
+		counts["media_files"]++
+	}
+
+	// 3. Manifeste
+	if err := writeJSON(zw, "manifest.json", map[string]any{
+		"format_version": 1,
+		"job_id":         job.ID,
+		"user_id":        job.UserID,
+		"generated_at":   time.Now().UTC(),
+		"counts":         counts,
+	}); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("finalisation du zip: %w", err)
+	}
+
+	// 4. Dépôt dans le préfixe privé
+	info, err := tmp.Stat()
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	objectPath := fmt.Sprintf("%s/%d/%d.zip", variables.ExportObjectPrefix, job.UserID, job.ID)
+	if err := blobstore.Store.Put(ctx, exportBucket(), objectPath, tmp, info.Size(), blobstore.PutOptions{
+		ContentType: "application/zip",
+	}); err != nil {
+		return fmt.Errorf("dépôt de l'archive: %w", err)
+	}
+
+	job.ObjectPath = objectPath
+	job.SizeBytes = info.Size()
+	job.Counts = counts
+	return nil
+}
+
+// PurgeUserExports supprime les archives et l'état d'export d'un utilisateur (suppression de compte).
+// Retourne le nombre d'objets MinIO effacés.
+func PurgeUserExports(ctx context.Context, userID int64) (int64, error) {
+	_ = redis.ExportQueue.ZRem(ctx, exportQueueID, userID)
+	_ = redis.ExportQueue.ZRem(ctx, exportQueueRunning, userID)
+	_ = redis.AccountExports.DeleteObject(ctx, userID)
+
+	var removed int64
+	prefix := fmt.Sprintf("%s/%d/", variables.ExportObjectPrefix, userID)
+	for obj := range blobstore.Store.List(ctx, exportBucket(), prefix, true) {
+		if obj.Err != nil {
+			return removed, obj.Err
+		}
+		if err := blobstore.Store.Delete(ctx, exportBucket(), obj.Key); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// --- HELPERS ---
+
+// leaseExpired indique qu'une archive en construction a dépassé son bail (instance arrêtée en cours de route).
+func leaseExpired(job account_models.ExportJobPayload, now time.Time) bool {
+	return now.After(job.StartedAt.Add(variables.ExportLeaseSeconds * time.Second))
+}
+
+// loadDataset lit un jeu de données en L3 (source de vérité) puis bascule sur L2 si la table est indisponible.
+func loadDataset(ctx context.Context, dataset string, userID int64) ([]map[string]any, error) {
+	records, err := postgres.FuncExportUserRows(ctx, dataset, userID)
+	if err == nil {
+		return records, nil
+	}
+
+	docs, errMongo := mongo.MongoExportUserDocs(dataset, userID)
+	if errMongo != nil {
+		return nil, fmt.Errorf("export %s indisponible (L3: %v, L2: %w)", dataset, err, errMongo)
+	}
+	return docs, nil
+}
+
+// copyMediaIntoArchive recopie un objet MinIO sous media/ dans l'archive, en flux.
+func copyMediaIntoArchive(ctx context.Context, zw *zip.Writer, bucket string, storagePath string) error {
+	// Get échoue sur un objet absent : jamais au milieu d'une entrée zip
+	obj, err := blobstore.Store.Get(ctx, bucket, storagePath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = obj.Close() }()
+
+	w, err := zw.Create("media/" + path.Base(storagePath))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, obj)
+	return err
+}
+
+// writeJSON ajoute une entrée JSON indentée à l'archive.
+func writeJSON(zw *zip.Writer, name string, v any) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("entrée %s: %w", name, err)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// exportBucket renvoie le bucket des archives (privé dédié si configuré, sinon bucket principal).
+func exportBucket() string {
+	if b := os.Getenv("MINIO_EXPORT_BUCKET_NAME"); b != "" {
+		return b
+	}
+	return os.Getenv("MINIO_BUCKET_NAME")
+}
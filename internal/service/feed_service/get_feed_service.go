@@ -10,6 +10,7 @@ import (
 	"github.com/QuentinRegnier/nubo-backend/internal/service/algorithm_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/post_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/profile_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/variables"
 )
 
@@ -25,6 +26,9 @@ func GetFeed(ctx context.Context, input feed_models.GetFeedInput) ([]post_models
 		friendMap[id] = true
 	}
 
+	// Profil d'intérêt synchronisé par le client (nil sans profil : le feed reste trié par tendance)
+	userVec, confidence := profile_service.LoadUserVector(ctx, input.UserID)
+
 	// Configuration du contexte pour l'algorithme
 	opts := algorithm_service.RefreshOptions{
 		UserID:        input.UserID,
@@ -37,8 +41,8 @@ func GetFeed(ctx context.Context, input feed_models.GetFeedInput) ([]post_models
 		},
 		PersonalOpts: algorithm_service.PersonalizedFeedOptions{
 			UserID:         input.UserID,
-			UserVec:        nil, // TODO: Profile Service (Architecture Microservice / Edge en attente)
-			UserConfidence: 1.0,
+			UserVec:        userVec,
+			UserConfidence: confidence,
 			FriendIDs:      friendMap, // ✅ Connexion du Speed Cache pour le paramètre B(u,p)
 			Date:           time.Now(),
 			Limit:          variables.TDDFeedSize,
@@ -33,7 +33,7 @@ func GetPostLikes(ctx context.Context, input post_models.GetPostLikesInput) (pos
 		}
 	}
 
-	if !found || post.Visibility == -1 {
+	if !found || post.Visibility < 0 {
 		return post_models.GetPostLikesOutput{}, errors.New("not found")
 	}
 
@@ -34,7 +34,7 @@ func ToggleCommentLike(ctx context.Context, input like_models.LikeCommentInput)
 	// 2. RÉCUPÉRATION EN CASCADE DU COMMENTAIRE (Pour avoir le PostID)
 	// ─────────────────────────────────────────────────────────────────────────
 	comment, err := getCommentCascade(ctx, input.CommentID)
-	if err != nil || comment.Visibility == -1 {
+	if err != nil || comment.Visibility < 0 {
 		// Le commentaire a été supprimé, on annule l'idempotence au cas où et on rejette
 		_ = cache_service.TryRemoveLikeIdempotency(ctx, 1, input.CommentID, input.UserID)
 		return errors.New("not found")
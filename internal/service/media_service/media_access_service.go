@@ -0,0 +1,93 @@
+package media_service
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/media_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/post_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/blobstore"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// ============================================================================
+// POLITIQUE D'ACCÈS AUX MÉDIAS
+// ============================================================================
+// Un média n'est servi qu'à travers le post qui le porte : la politique combine la visibilité du post,
+// la relation lecteur/auteur (abonné, ami, blocage) et l'état des comptes (désactivé, banni, en attente
+// de suppression). Elle est évaluée à chaque émission de lien ; les liens expirent vite (MediaURLTTLSeconds)
+// et ceux déjà émis sont révoqués (cache_service.Revoke*) quand l'un de ces facteurs change.
+// L'auteur reçoit des liens MinIO présignés vers ses originaux, les autres lecteurs des liens tatoués.
+
+// CheckPostAccess applique la politique d'accès d'un lecteur à un post (nil : accès accordé).
+func CheckPostAccess(ctx context.Context, post post_models.PostPayload, readerID int64) error {
+	// Un post supprimé n'existe plus pour personne, auteur compris
+	if post.Visibility == variables.VisibilityDeleted {
+		return nubo_error.ErrPostHidden
+	}
+	// L'auteur voit toujours son propre post (retiré, en traitement ou en échec compris)
+	if post.UserID == readerID {
+		return nil
+	}
+
+	// state: 0 = Rien, 1 = Follower, 2 = Ami, -1 = Banni
+	relation := cache_service.RelationValue(ctx, post.UserID, readerID)
+
+	// Blocage (dans un sens ou dans l'autre), auteur ou lecteur masqué : mode furtif
+	if relation == -1 || cache_service.IsAccountHidden(ctx, post.UserID) || cache_service.IsAccountHidden(ctx, readerID) {
+		return nubo_error.ErrPostHidden
+	}
+
+	switch post.Visibility {
+	case 0: // Public
+		return nil
+	case 1: // Abonnés : au moins Abonné (1) ou Ami (2)
+		if relation < 1 {
+			return nubo_error.ErrPostFollowersOnly
+		}
+		return nil
+	case 2: // Amis : strictement Ami (2)
+		if relation != 2 {
+			return nubo_error.ErrPostFriendsOnly
+		}
+		return nil
+	}
+	// Masqué par la modération, médias en traitement ou en échec : seul l'auteur le voit
+	return nubo_error.ErrPostHidden
+}
+
+// PostMediaSources évalue la politique d'accès puis signe les médias servables du post pour ce lecteur.
+func PostMediaSources(ctx context.Context, post post_models.PostPayload, readerID int64) ([]media_models.MediaSourceSet, error) {
+	if err := CheckPostAccess(ctx, post, readerID); err != nil {
+		return nil, err
+	}
+
+	var visibleMedia []models.MediaRequest
+	for _, mediaID := range post.MediaIDs {
+		m, err := GetMediaCascade(ctx, mediaID)
+		// Si le média existe, n'a pas été supprimé par l'auteur et a fini son traitement
+		if err == nil && m.Visibility && m.Status == variables.MediaStatusReady {
+			visibleMedia = append(visibleMedia, m)
+		}
+	}
+	return FormatMediaURLs(ctx, visibleMedia, post.UserID, post.ID, readerID), nil
+}
+
+// MediaURL signe un lien de courte durée vers un fichier : présigné MinIO pour l'auteur (original sans
+// tatouage), tatoué au nom du lecteur sinon. L'appelant a déjà vérifié le droit d'accès.
+func MediaURL(ctx context.Context, key string, authorID, postID, readerID int64) string {
+	if authorID == readerID {
+		ttl := variables.MediaURLTTLSeconds * time.Second
+		signed, err := blobstore.Store.Presign(ctx, os.Getenv("MINIO_BUCKET_NAME"), key, ttl, nil)
+		if err == nil {
+			return signed.String()
+		}
+		log.Printf("⚠️ Lien présigné de %s impossible, repli sur le lien tatoué : %v", key, err)
+	}
+	return GenerateWatermarkedURL(key, authorID, postID, readerID)
+}
@@ -0,0 +1,349 @@
+package media_service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/media_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/blobstore"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service/object_cache_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// ============================================================================
+// RAMASSE-MIETTES DES OBJETS MINIO
+// ============================================================================
+// Les objets médias survivent à tout ce qui se passe après leur dépôt : post jamais créé ou supprimé,
+// avatar remplacé, média déposé mais jamais rattaché, upload vidéo abandonné. Chaque passage confronte
+// le listing MinIO (racine : images et déclinaisons, "video/" : rendus HLS, "staging/" : originaux en
+// transit) à content.media et aux références (media_ids des posts, profile_picture_id des comptes).
+// Les autres préfixes (corbeille, cache de tatouage, preuves, exports) ne sont jamais examinés.
+//
+// Un objet orphelin est déplacé dans la corbeille, puis supprimé après MediaTrashRetentionDays.
+// Un média vivant est référencé, en quarantaine (pièce de modération) ou plus jeune que le délai de grâce.
+
+// Clés de la collection MediaGC
+const (
+	mediaGCLock = "lock"
+	mediaGCLast = "last"
+)
+
+// CollectOrphanMedia exécute un passage du ramasse-miettes (ErrMediaGCRunning si un autre est en cours).
+// En simulation, rien n'est déplacé ni supprimé : le rapport décrit ce qui l'aurait été.
+func CollectOrphanMedia(ctx context.Context, actor audit.Actor, dryRun bool) (media_models.MediaGCReport, error) {
+	locked, err := redis.MediaGC.SetNX(ctx, mediaGCLock, time.Now().Unix(), variables.MediaGCLockSeconds*time.Second)
+	if err != nil {
+		return media_models.MediaGCReport{}, fmt.Errorf("erreur lors de la prise du verrou du ramasse-miettes: %w", err)
+	}
+	if !locked {
+		return media_models.MediaGCReport{}, nubo_error.ErrMediaGCRunning
+	}
+	defer func() { _ = redis.MediaGC.DeleteObject(context.Background(), mediaGCLock) }()
+
+	gc := &mediaGC{
+		ctx:     ctx,
+		bucket:  os.Getenv("MINIO_BUCKET_NAME"),
+		cutoff:  time.Now().Add(-variables.MediaGCGraceHours * time.Hour),
+		orphans: make(map[int64]bool),
+		report:  media_models.MediaGCReport{DryRun: dryRun, StartedAt: time.Now().UTC()},
+	}
+
+	// 1. Corbeille échue, 2. uploads multipart abandonnés, 3. objets orphelins, 4. médias sans référence
+	gc.purgeTrash()
+	gc.abortStaleUploads()
+	gc.collect()
+	gc.retireOrphanMedia()
+
+	gc.report.FinishedAt = time.Now().UTC()
+	if err := redis.MediaGC.SetObject(ctx, mediaGCLast, gc.report); err != nil {
+		log.Printf("⚠️ Rapport du ramasse-miettes non enregistré : %v", err)
+	}
+	audit.Record(ctx, actor, audit.ActionMediaGC, audit.On(audit.TargetNone, 0), map[string]any{
+		"dry_run":         dryRun,
+		"orphans":         gc.report.Orphans,
+		"trashed_bytes":   gc.report.TrashedBytes,
+		"reclaimed_bytes": gc.report.ReclaimedBytes,
+		"orphan_media":    gc.report.OrphanMedia,
+		"errors":          gc.report.Errors,
+	})
+	return gc.report, nil
+}
+
+// RetireMedia marque supprimé un média qui n'a plus d'usage (photo de profil remplacée, média orphelin) :
+// il n'est plus servi, son empreinte quitte l'index, et ses objets seront collectés au passage suivant
+// le délai de grâce.
+func RetireMedia(ctx context.Context, mediaID int64) {
+	m, err := GetMediaCascade(ctx, mediaID)
+	if err != nil || !m.Visibility {
+		return
+	}
+	m.Visibility = false
+	saveMedia(ctx, m)
+	_ = object_cache_service.DeleteMediaFromObjectCache(ctx, mediaID)
+	UnindexMediaHash(ctx, m)
+}
+
+// LastMediaGCReport renvoie le rapport du dernier passage (false : aucun passage enregistré).
+func LastMediaGCReport(ctx context.Context) (media_models.MediaGCReport, bool) {
+	var report media_models.MediaGCReport
+	if err := redis.MediaGC.GetObject(ctx, mediaGCLast, &report); err != nil {
+		return media_models.MediaGCReport{}, false
+	}
+	return report, true
+}
+
+// --- HELPERS ---
+
+// mediaGC porte l'état d'un passage.
+type mediaGC struct {
+	ctx     context.Context
+	bucket  string
+	cutoff  time.Time      // Fin du délai de grâce
+	orphans map[int64]bool // Médias sans référence, à marquer supprimés
+	report  media_models.MediaGCReport
+}
+
+// purgeTrash supprime définitivement les objets restés MediaTrashRetentionDays dans la corbeille.
+func (gc *mediaGC) purgeTrash() {
+	expiry := time.Now().Add(-variables.MediaTrashRetentionDays * 24 * time.Hour)
+	ctx, cancel := context.WithCancel(gc.ctx)
+	defer cancel()
+
+	for obj := range blobstore.Store.List(ctx, gc.bucket, variables.MediaTrashPrefix, true) {
+		if obj.Err != nil {
+			gc.fail("listing de la corbeille", obj.Err)
+			return
+		}
+		if obj.LastModified.After(expiry) {
+			continue
+		}
+		if gc.report.Purged >= variables.MediaGCMaxTrashed {
+			gc.report.Truncated = true
+			return
+		}
+		if !gc.report.DryRun {
+			if err := blobstore.Store.Delete(gc.ctx, gc.bucket, obj.Key); err != nil {
+				gc.fail("suppression de "+obj.Key, err)
+				continue
+			}
+		}
+		gc.report.Purged++
+		gc.report.ReclaimedBytes += obj.Size
+	}
+}
+
+// abortStaleUploads annule les uploads multipart de la zone de transit plus vieux qu'une session d'upload
+// (la session Redis a expiré : l'upload ne peut plus être terminé).
+func (gc *mediaGC) abortStaleUploads() {
+	expiry := time.Now().Add(-variables.VideoUploadTTLHours * time.Hour)
+	ctx, cancel := context.WithCancel(gc.ctx)
+	defer cancel()
+
+	uploads, ok := blobstore.Store.(blobstore.MultipartStore)
+	if !ok {
+		return // Pilote sans upload multipart : rien ne peut rester inachevé
+	}
+	for upload := range uploads.ListMultipart(ctx, gc.bucket, variables.MediaStagingPrefix) {
+		if upload.Err != nil {
+			gc.fail("listing des uploads multipart", upload.Err)
+			return
+		}
+		if upload.Initiated.After(expiry) {
+			continue
+		}
+		if !gc.report.DryRun {
+			if err := uploads.AbortMultipart(gc.ctx, gc.bucket, upload.Key, upload.UploadID); err != nil {
+				gc.fail("annulation de l'upload "+upload.Key, err)
+				continue
+			}
+		}
+		gc.report.AbortedUploads++
+		gc.report.ReclaimedBytes += upload.Size
+	}
+}
+
+// collect liste les objets médias et traite par lots ceux dont le délai de grâce est écoulé.
+func (gc *mediaGC) collect() {
+	ctx, cancel := context.WithCancel(gc.ctx)
+	defer cancel()
+
+	sources := []struct {
+		prefix    string
+		recursive bool
+	}{
+		{"", false}, // Racine : images et déclinaisons (les sous-dossiers sont ignorés)
+		{variables.VideoObjectPrefix, true},
+		{variables.MediaStagingPrefix, true},
+	}
+
+	batch := make([]blobstore.ObjectInfo, 0, variables.MediaGCBatchSize)
+	for _, source := range sources {
+		for obj := range blobstore.Store.List(ctx, gc.bucket, source.prefix, source.recursive) {
+			if obj.Err != nil {
+				gc.fail("listing de "+source.prefix, obj.Err)
+				break
+			}
+			if strings.HasSuffix(obj.Key, "/") {
+				continue // Préfixe commun (listing non récursif)
+			}
+			if gc.report.Scanned >= variables.MediaGCMaxScanned || gc.report.Trashed >= variables.MediaGCMaxTrashed {
+				gc.report.Truncated = true
+				gc.settle(batch)
+				return
+			}
+			gc.report.Scanned++
+			if obj.LastModified.After(gc.cutoff) {
+				continue
+			}
+			if batch = append(batch, obj); len(batch) == variables.MediaGCBatchSize {
+				gc.settle(batch)
+				batch = batch[:0]
+			}
+		}
+	}
+	gc.settle(batch)
+}
+
+// settle rattache un lot d'objets à leurs médias et met les orphelins à la corbeille.
+// Un lot dont le rattachement échoue est laissé en place : dans le doute, on ne supprime rien.
+func (gc *mediaGC) settle(batch []blobstore.ObjectInfo) {
+	if len(batch) == 0 {
+		return
+	}
+
+	var storagePaths, videoDirs []string
+	var ids []int64
+	for _, obj := range batch {
+		storagePath, videoDir, id := mediaObjectOwner(obj.Key)
+		switch {
+		case id != 0:
+			ids = append(ids, id)
+		case videoDir != "":
+			videoDirs = append(videoDirs, videoDir)
+		default:
+			storagePaths = append(storagePaths, storagePath)
+		}
+	}
+
+	owners, err := postgres.FuncMediaGCOwners(gc.ctx, storagePaths, videoDirs, ids)
+	if err != nil {
+		gc.fail("rattachement des objets", err)
+		return
+	}
+	byPath := make(map[string]media_models.MediaGCOwner, len(owners))
+	byDir := make(map[string]media_models.MediaGCOwner)
+	byID := make(map[int64]media_models.MediaGCOwner, len(owners))
+	for _, o := range owners {
+		byPath[o.StoragePath], byID[o.ID] = o, o
+		if o.VideoDir != "" {
+			byDir[o.VideoDir] = o
+		}
+	}
+
+	for _, obj := range batch {
+		storagePath, videoDir, id := mediaObjectOwner(obj.Key)
+		var owner media_models.MediaGCOwner
+		var found bool
+		switch {
+		case id != 0:
+			owner, found = byID[id]
+		case videoDir != "":
+			owner, found = byDir[videoDir]
+		default:
+			owner, found = byPath[storagePath]
+		}
+
+		live := found && gc.isLive(owner)
+		if id != 0 && live {
+			// Un original en transit ne sert qu'à un média encore en attente de traitement
+			live = owner.Status == variables.MediaStatusPending
+		}
+		if live {
+			continue
+		}
+
+		gc.report.Orphans++
+		if found && owner.Visibility && !gc.isLive(owner) {
+			gc.orphans[owner.ID] = true
+		}
+		if gc.report.Trashed >= variables.MediaGCMaxTrashed {
+			gc.report.Truncated = true
+			continue
+		}
+		if !gc.report.DryRun {
+			if err := gc.trash(obj.Key); err != nil {
+				gc.fail("mise à la corbeille de "+obj.Key, err)
+				continue
+			}
+		}
+		gc.report.Trashed++
+		gc.report.TrashedBytes += obj.Size
+	}
+}
+
+// isLive indique si un média doit conserver ses objets.
+func (gc *mediaGC) isLive(o media_models.MediaGCOwner) bool {
+	if !o.Visibility {
+		return false
+	}
+	return o.Referenced || o.Status == variables.MediaStatusQuarantined || o.CreatedAt.After(gc.cutoff)
+}
+
+// trash déplace un objet dans la corbeille (copie puis suppression de l'original).
+func (gc *mediaGC) trash(key string) error {
+	if err := blobstore.Copy(gc.ctx, blobstore.Store, gc.bucket, key, variables.MediaTrashPrefix+key); err != nil {
+		return err
+	}
+	return blobstore.Store.Delete(gc.ctx, gc.bucket, key)
+}
+
+// retireOrphanMedia marque supprimés les médias qu'aucun post ni avatar ne référence plus : ils ne
+// réapparaîtront pas, et leur empreinte quitte l'index de recherche.
+func (gc *mediaGC) retireOrphanMedia() {
+	for mediaID := range gc.orphans {
+		gc.report.OrphanMedia++
+		if gc.report.DryRun {
+			continue
+		}
+		RetireMedia(gc.ctx, mediaID)
+	}
+}
+
+// fail comptabilise une erreur sans interrompre le passage.
+func (gc *mediaGC) fail(step string, err error) {
+	gc.report.Errors++
+	log.Printf("⚠️ Ramasse-miettes (%s) : %v", step, err)
+}
+
+// mediaObjectOwner déduit d'un chemin la clé de rattachement à son média : l'ID pour un original en transit
+// ("staging/<id>"), le dossier du rendu pour une vidéo ("video/<base>/"), sinon le chemin AVIF historique
+// ("<base>.avif", commun à toutes les déclinaisons "<base>_<largeur>.<ext>").
+func mediaObjectOwner(key string) (storagePath string, videoDir string, id int64) {
+	if rest, ok := strings.CutPrefix(key, variables.MediaStagingPrefix); ok {
+		if id, err := strconv.ParseInt(rest, 10, 64); err == nil {
+			return "", "", id
+		}
+		return key, "", 0
+	}
+	if strings.HasPrefix(key, variables.VideoObjectPrefix) {
+		return "", path.Dir(key) + "/", 0
+	}
+
+	base := strings.TrimSuffix(key, path.Ext(key))
+	if i := strings.LastIndex(base, "_"); i > 0 {
+		if _, err := strconv.Atoi(base[i+1:]); err == nil {
+			base = base[:i]
+		}
+	}
+	return base + ".avif", "", 0
+}
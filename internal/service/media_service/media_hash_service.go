@@ -0,0 +1,325 @@
+package media_service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/media_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/report_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg/phash"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/event_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// ============================================================================
+// EMPREINTES PERCEPTUELLES : INDEX, LISTE DE BLOCAGE, QUARANTAINE
+// ============================================================================
+// Deux index multiples (phash.ProbeKeys) partagent les mêmes structures Redis : "media" (tous les médias
+// encodés, pour la recherche de doublons) et "blocked" (empreintes issues de dossiers clos pour infraction).
+// Un média proche d'une empreinte bloquée est mis en quarantaine par l'ouvrier de traitement, avant toute
+// publication : ses déclinaisons existent (pour la modération) mais il n'est jamais servi.
+
+// Index des empreintes (HASH MediaHashes et préfixe des SET MediaHashBands)
+const (
+	hashSetMedia   = "media"
+	hashSetBlocked = "blocked"
+)
+
+// IndexMediaHash inscrit l'empreinte d'un média dans l'index de recherche.
+func IndexMediaHash(ctx context.Context, mediaID int64, h phash.Hash) error {
+	return indexHash(ctx, hashSetMedia, mediaID, h.String(), h)
+}
+
+// UnindexMediaHash retire un média de l'index (suppression définitive).
+func UnindexMediaHash(ctx context.Context, m models.MediaRequest) {
+	if m.Hash == (models.MediaHash{}) {
+		return
+	}
+	h := toHash(m.Hash)
+	pipe := redis.MediaHashes.Pipeline()
+	pipe.HDel(ctx, redis.MediaHashes.Key(hashSetMedia), strconv.FormatInt(m.ID, 10))
+	for _, k := range phash.BandsOf(h.P) {
+		pipe.SRem(ctx, redis.MediaHashBands.Key(hashSetMedia+":"+k.String()), m.ID)
+	}
+	_, _ = pipe.Exec(ctx)
+}
+
+// BlockMediaHash ajoute une empreinte à la liste de blocage. sourceID identifie l'image d'origine
+// (média ou preuve d'investigation), caseID le dossier qui a confirmé l'infraction.
+func BlockMediaHash(ctx context.Context, sourceID int64, caseID int64, category int, h phash.Hash) error {
+	entry, err := json.Marshal(media_models.BlockedHashEntry{Hash: h.String(), CaseID: caseID, Category: category, BlockedAt: time.Now().UTC()})
+	if err != nil {
+		return fmt.Errorf("erreur lors de l'encodage de l'empreinte bloquée: %w", err)
+	}
+	if err := indexHash(ctx, hashSetBlocked, sourceID, string(entry), h); err != nil {
+		return err
+	}
+	audit.Record(ctx, audit.System, audit.ActionMediaHashBlock, audit.On(audit.TargetCase, caseID), map[string]any{
+		"source_id": sourceID,
+		"hash":      h.String(),
+	})
+	return nil
+}
+
+// BlockCaseMedia bloque les empreintes des médias d'un contenu retiré. Retourne le nombre bloqué
+// (un média sans empreinte, antérieur au calcul, est ignoré).
+func BlockCaseMedia(ctx context.Context, caseID int64, category int, mediaIDs []int64) int {
+	blocked := 0
+	for _, mediaID := range mediaIDs {
+		m, err := GetMediaCascade(ctx, mediaID)
+		if err != nil || m.Hash == (models.MediaHash{}) {
+			continue
+		}
+		if err := BlockMediaHash(ctx, mediaID, caseID, category, toHash(m.Hash)); err != nil {
+			log.Printf("⚠️ Empreinte du média %d non bloquée (dossier %d) : %v", mediaID, caseID, err)
+			continue
+		}
+		blocked++
+	}
+	return blocked
+}
+
+// ReleaseQuarantinedMedia lève la quarantaine des médias d'un dossier clos sans infraction : ils redeviennent
+// servables et leur auteur est prévenu. Le post qui les porte reste masqué jusqu'à ce que son auteur choisisse
+// à nouveau sa visibilité (UpdatePost). Retourne le nombre de médias libérés.
+func ReleaseQuarantinedMedia(ctx context.Context, caseID int64, mediaIDs []int64) int {
+	released := 0
+	for _, mediaID := range mediaIDs {
+		m, err := GetMediaCascade(ctx, mediaID)
+		if err != nil || m.Status != variables.MediaStatusQuarantined {
+			continue
+		}
+		m.Status = variables.MediaStatusReady
+		saveMedia(ctx, m)
+		released++
+
+		audit.Record(ctx, audit.System, audit.ActionMediaRelease, audit.On(audit.TargetMedia, m.ID), map[string]any{
+			"owner_id": m.OwnerID,
+			"case_id":  caseID,
+		})
+		_ = event_service.Publish(ctx, m.OwnerID, event_service.EventMediaReady, media_models.MediaEvent{MediaID: m.ID})
+	}
+	return released
+}
+
+// AllMediaReady indique si tous les médias d'une liste sont servables (quarantaine levée, par exemple).
+func AllMediaReady(ctx context.Context, mediaIDs []int64) bool {
+	for _, mediaID := range mediaIDs {
+		m, err := GetMediaCascade(ctx, mediaID)
+		if err != nil || m.Status != variables.MediaStatusReady {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchBlockedHash renvoie l'empreinte bloquée la plus proche d'une empreinte, si elle correspond.
+func MatchBlockedHash(ctx context.Context, h phash.Hash) (media_models.BlockedHashMatch, bool, error) {
+	matches, err := searchIndex(ctx, hashSetBlocked, h, variables.MediaHashMaxDistance)
+	if err != nil || len(matches) == 0 {
+		return media_models.BlockedHashMatch{}, false, err
+	}
+	return matches[0].blocked(), true, nil
+}
+
+// FindSimilarMedia renvoie les médias indexés proches d'un média (lui-même exclu, ErrNotFound s'il n'existe pas),
+// du plus proche au plus lointain, ainsi que les empreintes bloquées correspondantes.
+func FindSimilarMedia(ctx context.Context, mediaID int64, maxDistance int, limit int) (media_models.SimilarMediaOutput, error) {
+	m, err := GetMediaCascade(ctx, mediaID)
+	if err != nil {
+		return media_models.SimilarMediaOutput{}, nubo_error.ErrNotFound
+	}
+	if m.Hash == (models.MediaHash{}) {
+		return media_models.SimilarMediaOutput{}, nubo_error.ErrMediaNotHashed
+	}
+	h := toHash(m.Hash)
+	out := media_models.SimilarMediaOutput{MediaID: mediaID, Hash: h.String(), Matches: []media_models.SimilarMedia{}, Blocked: []media_models.BlockedHashMatch{}}
+
+	matches, err := searchIndex(ctx, hashSetMedia, h, maxDistance)
+	if err != nil {
+		return media_models.SimilarMediaOutput{}, err
+	}
+	for _, match := range matches {
+		if match.id == mediaID {
+			continue
+		}
+		if len(out.Matches) >= limit {
+			break
+		}
+		similar := media_models.SimilarMedia{MediaID: match.id, Distance: match.distance, DHashDistance: match.dDistance}
+		if other, err := GetMediaCascade(ctx, match.id); err == nil {
+			similar.OwnerID, similar.Status, similar.Type = other.OwnerID, other.Status, other.Type
+		}
+		out.Matches = append(out.Matches, similar)
+	}
+
+	blocked, err := searchIndex(ctx, hashSetBlocked, h, maxDistance)
+	if err != nil {
+		return media_models.SimilarMediaOutput{}, err
+	}
+	for _, match := range blocked {
+		out.Blocked = append(out.Blocked, match.blocked())
+	}
+	return out, nil
+}
+
+// ============================================================================
+// QUARANTAINE (Worker)
+// ============================================================================
+
+// quarantineJob retient un média proche d'une empreinte bloquée : il n'est jamais servi, le post qui le
+// porte n'est pas publié (la barrière le compte en échec) et un signalement système ouvre un dossier.
+// L'auteur reçoit le même événement qu'un échec de traitement.
+func quarantineJob(ctx context.Context, job media_models.MediaJobPayload, media models.MediaRequest, result renderedMedia, match media_models.BlockedHashMatch) {
+	media.StoragePath = result.storagePath
+	media.Renditions = result.renditions
+	media.Video = result.video
+	media.Status = variables.MediaStatusQuarantined
+	saveMedia(ctx, media)
+	_ = IndexMediaHash(ctx, media.ID, result.hash)
+
+	removeObjects(os.Getenv("MINIO_BUCKET_NAME"), []string{job.StagingPath})
+
+	report := report_models.ReportPayload{
+		ID:         pkg.GenerateID(),
+		ReporterID: 0, // Signalement système
+		TargetType: variables.ReportTargetUser,
+		TargetIDs:  []int64{job.OwnerID},
+		Category:   match.Category,
+		Reason: fmt.Sprintf("Quarantaine automatique : média %d proche d'une empreinte bloquée (dossier %d, distance %d/%d)",
+			media.ID, match.CaseID, match.Distance, match.DHashDistance),
+		State:     variables.ReportStatePending,
+		CreatedAt: time.Now().UTC(),
+	}
+	if job.PostID != 0 {
+		report.TargetType, report.TargetIDs = variables.ReportTargetPost, []int64{job.PostID}
+	}
+	report.UpdatedAt = report.CreatedAt
+	if err := redis.EnqueueDB(ctx, report.ID, 0, redis.EntityReport, redis.ActionCreate, report, redis.TargetPostgres); err != nil {
+		log.Printf("❌ CRITICAL: Signalement de quarantaine du média %d non enregistré : %v", media.ID, err)
+	}
+
+	job.State = variables.MediaStatusQuarantined
+	job.Error = "quarantaine"
+	job.CompletedAt = time.Now().UTC()
+	finishJob(ctx, job)
+
+	audit.Record(ctx, audit.System, audit.ActionMediaQuarantine, audit.On(audit.TargetMedia, media.ID), map[string]any{
+		"owner_id":  job.OwnerID,
+		"post_id":   job.PostID,
+		"case_id":   match.CaseID,
+		"source_id": match.SourceID,
+		"distance":  match.Distance,
+		"report_id": report.ID,
+	})
+	_ = event_service.Publish(ctx, job.OwnerID, event_service.EventMediaFailed, media_models.MediaEvent{MediaID: job.MediaID, PostID: job.PostID})
+	log.Printf("⛔ Media %d mis en quarantaine (Owner: %d, dossier %d)", job.MediaID, job.OwnerID, match.CaseID)
+}
+
+// --- HELPERS ---
+
+// hashMatch est un candidat retenu par searchIndex.
+type hashMatch struct {
+	id        int64
+	distance  int
+	dDistance int
+	value     string // Empreinte ("media") ou entrée JSON ("blocked")
+}
+
+func (m hashMatch) blocked() media_models.BlockedHashMatch {
+	var entry media_models.BlockedHashEntry
+	_ = json.Unmarshal([]byte(m.value), &entry)
+	return media_models.BlockedHashMatch{
+		SourceID:      m.id,
+		CaseID:        entry.CaseID,
+		Category:      entry.Category,
+		Distance:      m.distance,
+		DHashDistance: m.dDistance,
+	}
+}
+
+// indexHash enregistre une valeur sous id et inscrit l'empreinte dans chaque tranche de l'index.
+func indexHash(ctx context.Context, set string, id int64, value string, h phash.Hash) error {
+	pipe := redis.MediaHashes.Pipeline()
+	pipe.HSet(ctx, redis.MediaHashes.Key(set), strconv.FormatInt(id, 10), value)
+	for _, k := range phash.BandsOf(h.P) {
+		pipe.SAdd(ctx, redis.MediaHashBands.Key(set+":"+k.String()), id)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("erreur lors de l'indexation de l'empreinte %d: %w", id, err)
+	}
+	return nil
+}
+
+// searchIndex interroge les tranches voisines, puis vérifie la distance complète des candidats
+// (pHash <= maxDistance, dHash <= MediaDHashMaxDistance). Résultat trié par distance croissante.
+func searchIndex(ctx context.Context, set string, h phash.Hash, maxDistance int) ([]hashMatch, error) {
+	probes := phash.ProbeKeys(h.P)
+	keys := make([]string, len(probes))
+	for i, k := range probes {
+		keys[i] = redis.MediaHashBands.Key(set + ":" + k.String())
+	}
+	ids, err := redis.MediaHashBands.Client.SUnion(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la recherche d'empreintes: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	values, err := redis.MediaHashes.Client.HMGet(ctx, redis.MediaHashes.Key(set), ids...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la lecture des empreintes: %w", err)
+	}
+
+	var matches []hashMatch
+	for i, raw := range values {
+		value, ok := raw.(string)
+		if !ok {
+			continue
+		}
+		encoded := value
+		if set == hashSetBlocked {
+			var entry media_models.BlockedHashEntry
+			if json.Unmarshal([]byte(value), &entry) != nil {
+				continue
+			}
+			encoded = entry.Hash
+		}
+		other, err := phash.Parse(encoded)
+		if err != nil {
+			continue
+		}
+		id, _ := strconv.ParseInt(ids[i], 10, 64)
+		match := hashMatch{id: id, distance: phash.Distance(h.P, other.P), dDistance: phash.Distance(h.D, other.D), value: value}
+		if match.distance <= maxDistance && match.dDistance <= variables.MediaDHashMaxDistance {
+			matches = append(matches, match)
+		}
+	}
+	sort.Slice(matches, func(a, b int) bool {
+		if matches[a].distance != matches[b].distance {
+			return matches[a].distance < matches[b].distance
+		}
+		return matches[a].dDistance < matches[b].dDistance
+	})
+	return matches, nil
+}
+
+func toHash(h models.MediaHash) phash.Hash {
+	return phash.Hash{P: uint64(h.PHash), D: uint64(h.DHash)}
+}
+
+func fromHash(h phash.Hash) models.MediaHash {
+	return models.MediaHash{PHash: int64(h.P), DHash: int64(h.D)}
+}
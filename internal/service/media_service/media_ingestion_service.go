@@ -3,123 +3,402 @@ package media_service
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
 	"io"
 	"log"
+	"mime/multipart"
 	"os"
 	"time"
 
 	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
-	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/minio"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/blobstore"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg/imagemeta"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg/phash"
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service/object_cache_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
 	"github.com/google/uuid"
 
 	"github.com/disintegration/imaging"
 	"github.com/gen2brain/avif"
-	miniogo "github.com/minio/minio-go/v7"
+	_ "github.com/jdeng/goheif" // HEIC/HEIF (iPhone)
+	_ "golang.org/x/image/tiff" // TIFF
+	_ "golang.org/x/image/webp" // WebP (Android)
 )
 
 // ... (Les constantes et SetMinioClient restent inchangés) ...
 const (
 	MaxPixels = 2000 * 2000
 	MaxWidth  = 1920
+
+	AVIFQuality = 65
+	JPEGQuality = 82 // Repli des clients sans décodeur AVIF (aucun encodeur WebP disponible côté Go)
 )
 
-func UploadMedia(file io.ReadSeeker, ownerID int64, mediaID int64) error {
+// RenditionWidths liste les largeurs produites : miniature, grille, fil, plein écran.
+// Une largeur supérieure à l'original n'est jamais produite (pas d'agrandissement).
+var RenditionWidths = []int{160, 480, 1080, MaxWidth}
+
+// encodedRendition est une déclinaison encodée, prête à être envoyée au stockage.
+type encodedRendition struct {
+	meta        models.MediaRendition
+	contentType string
+	data        []byte
+}
 
-	// --- 1. ANALYSE & OPTIMISATION IMAGE (CPU Heavy) ---
-	config, _, err := image.DecodeConfig(file)
+// StageUpload dépose un fichier reçu en multipart dans la zone de transit (voir StageMedia).
+func StageUpload(ctx context.Context, fileHeader *multipart.FileHeader, ownerID int64, mediaID int64) error {
+	file, err := fileHeader.Open()
 	if err != nil {
-		return fmt.Errorf("fichier invalide: %v", err)
+		return fmt.Errorf("%w: %v", nubo_error.ErrInvalidMedia, err)
 	}
+	defer func(file multipart.File) {
+		if err := file.Close(); err != nil {
+			log.Printf("⚠️ Erreur fermeture fichier uploadé: %v", err)
+		}
+	}(file)
+	return StageMedia(ctx, file, ownerID, mediaID)
+}
 
-	if config.Width*config.Height > MaxPixels {
-		return fmt.Errorf("image trop grande")
+// StageProfileUpload dépose une photo de profil ou une couverture (layout = models.MediaLayout*).
+// Le recadrage demandé (nil : image entière) est validé et ramené au ratio du gabarit avant tout dépôt ;
+// le recadrage retenu est renvoyé.
+func StageProfileUpload(ctx context.Context, fileHeader *multipart.FileHeader, ownerID int64, mediaID int64, layout string, crop *models.MediaCrop) (models.MediaCrop, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return models.MediaCrop{}, fmt.Errorf("%w: %v", nubo_error.ErrInvalidMedia, err)
 	}
+	defer func(file multipart.File) {
+		if err := file.Close(); err != nil {
+			log.Printf("⚠️ Erreur fermeture fichier uploadé: %v", err)
+		}
+	}(file)
 
-	// ✅ CORRECTION SEEK : Gestion de l'erreur
-	if _, err := file.Seek(0, io.SeekStart); err != nil {
-		return fmt.Errorf("erreur lors de la réinitialisation du flux: %v", err)
+	metadata, err := stageImage(ctx, file, ownerID, mediaID, layout, crop)
+	if err != nil {
+		return models.MediaCrop{}, err
 	}
+	return *metadata.Crop, nil
+}
 
-	img, _, err := image.Decode(file)
+// StageMedia valide l'en-tête de l'image, dépose l'original sous MediaStagingPrefix et crée le média
+// en statut "en attente". Tout est synchrone : une fois la réponse HTTP envoyée, l'original est durable
+// et seul l'encodage reste à faire (EnqueueMediaJobs puis ouvriers de traitement).
+// L'original déposé ne porte plus aucune métadonnée : l'orientation et l'instant de prise de vue sont
+// relevés sur le média, le reste (GPS, appareil...) est retiré des octets avant tout stockage.
+func StageMedia(ctx context.Context, file io.ReadSeeker, ownerID int64, mediaID int64) error {
+	_, err := stageImage(ctx, file, ownerID, mediaID, "", nil)
+	return err
+}
+
+// stageImage implémente StageMedia ; un gabarit de profil y ajoute le recadrage, relevé sur le média.
+// Retourne les métadonnées enregistrées sur le média.
+func stageImage(ctx context.Context, file io.Reader, ownerID int64, mediaID int64, layout string, crop *models.MediaCrop) (models.MediaMetadata, error) {
+	raw, err := io.ReadAll(file)
 	if err != nil {
-		return fmt.Errorf("erreur decode: %v", err)
+		return models.MediaMetadata{}, fmt.Errorf("%w: %v", nubo_error.ErrInvalidMedia, err)
 	}
 
-	if bounds := img.Bounds(); bounds.Dx() > MaxWidth {
-		img = imaging.Resize(img, MaxWidth, 0, imaging.Lanczos)
+	// --- 1. CONTRÔLE DE L'EN-TÊTE (O(1), sans décoder les pixels) ---
+	config, format, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil {
+		return models.MediaMetadata{}, fmt.Errorf("%w: %v", nubo_error.ErrInvalidMedia, err)
 	}
 
-	var buf bytes.Buffer
-	// ✅ CORRECTION AVIF : Retrait du '&'
-	if err := avif.Encode(&buf, img, avif.Options{Quality: 65, Speed: 5}); err != nil {
-		return fmt.Errorf("erreur encodage avif: %v", err)
+	if config.Width*config.Height > MaxPixels {
+		return models.MediaMetadata{}, nubo_error.ErrMediaTooLarge
+	}
+
+	// --- 2. MÉTADONNÉES (liste blanche) & NETTOYAGE DE L'ORIGINAL ---
+	meta := imagemeta.Read(raw, format)
+	clean, contentType, orientation, err := stripOriginal(raw, format, meta.Orientation)
+	if err != nil {
+		return models.MediaMetadata{}, err
+	}
+	metadata := models.MediaMetadata{
+		Orientation: orientation,
+		CapturedAt:  meta.CapturedAt,
+		Width:       config.Width,
+		Height:      config.Height,
+	}
+	if imagemeta.SwapsAxes(meta.Orientation) {
+		metadata.Width, metadata.Height = config.Height, config.Width
+	}
+	if layout != "" {
+		if metadata.Crop, err = fitCrop(layout, crop, metadata.Width, metadata.Height); err != nil {
+			return models.MediaMetadata{}, err
+		}
+		metadata.Layout = layout
 	}
 
-	// --- 2. UPLOAD VERS LE S3 (IO Network) ---
-	// Nous utilisons désormais l'extension .avif
-	objectName := fmt.Sprintf("%s.avif", uuid.New().String())
+	// --- 3. DÉPÔT DE L'ORIGINAL (IO Network) ---
 	bucketName := os.Getenv("MINIO_BUCKET_NAME")
-	storagePath := objectName
-
-	// Configuration indispensable du ContentType en "image/avif" pour Scaleway/MinIO
-	_, err = minio.MinioClient.PutObject(
-		context.Background(),
-		bucketName,
-		objectName,
-		&buf,
-		int64(buf.Len()),
-		miniogo.PutObjectOptions{
-			ContentType: "image/avif",
-			// Optionnel : On peut ajouter des métadonnées personnalisées ici si besoin
-		},
-	)
+	stagingPath := StagingObjectPath(mediaID)
+	err = blobstore.Store.Put(ctx, bucketName, stagingPath, bytes.NewReader(clean), int64(len(clean)),
+		blobstore.PutOptions{ContentType: contentType})
 	if err != nil {
-		return fmt.Errorf("erreur lors de l'envoi vers le stockage S3: %v", err)
+		return models.MediaMetadata{}, fmt.Errorf("erreur lors de l'envoi vers la zone de transit S3: %v", err)
 	}
 
-	// --- 3. CRÉATION DE L'OBJET & ID SNOWFLAKE (Go Authority) ---
-	now := time.Now().UTC()
+	// --- 4. CRÉATION DE L'OBJET EN ATTENTE (Go Authority) ---
+	if err := registerStagedMedia(ctx, ownerID, mediaID, models.MediaTypeImage, metadata); err != nil {
+		removeObjects(bucketName, []string{stagingPath})
+		return models.MediaMetadata{}, err
+	}
+	return metadata, nil
+}
 
-	media := models.MediaRequest{
-		ID:          mediaID,
-		OwnerID:     ownerID,
-		StoragePath: storagePath,
-		Visibility:  true, // Par défaut visible, à ajuster selon ta logique
-		CreatedAt:   now,
-		UpdatedAt:   now,
+// stripOriginal renvoie l'original sans métadonnées, son type MIME et l'orientation restant à appliquer
+// au rendu. JPEG, PNG et WebP sont nettoyés sans toucher aux pixels ; TIFF et HEIC, dont l'EXIF fait
+// partie de la structure, sont décodés, redressés et réencodés en PNG (sans perte).
+func stripOriginal(raw []byte, format string, orientation int) ([]byte, string, int, error) {
+	clean, err := imagemeta.Strip(raw, format)
+	if err == nil {
+		return clean, "image/" + format, orientation, nil
+	}
+	if !errors.Is(err, imagemeta.ErrNotStrippable) {
+		return nil, "", 0, fmt.Errorf("%w: %v", nubo_error.ErrInvalidMedia, err)
 	}
 
-	// On initialise le contexte ici pour qu'il serve au Cache ET à la Queue Asynchrone
-	ctx := context.Background()
+	img, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("%w: %v", nubo_error.ErrInvalidMedia, err)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, imagemeta.Orient(img, orientation)); err != nil {
+		return nil, "", 0, fmt.Errorf("erreur lors du réencodage de l'original: %v", err)
+	}
+	return buf.Bytes(), "image/png", imagemeta.OrientationNormal, nil
+}
+
+// registerStagedMedia crée le média en statut "en attente" (L1 immédiat, L2/L3 via Write-Behind)
+// une fois son original durable en zone de transit.
+func registerStagedMedia(ctx context.Context, ownerID int64, mediaID int64, mediaType string, metadata models.MediaMetadata) error {
+	now := time.Now().UTC()
+	media := models.MediaRequest{
+		ID:         mediaID,
+		OwnerID:    ownerID,
+		Type:       mediaType,
+		Renditions: []models.MediaRendition{},
+		Metadata:   metadata,
+		Visibility: true, // Par défaut visible, à ajuster selon ta logique
+		Status:     variables.MediaStatusPending,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
 
-	// --- 5. CACHE REDIS (Immédiat) ---
 	if err := object_cache_service.SetMediaInObjectCache(ctx, media); err != nil {
 		fmt.Printf("⚠️ Erreur Redis Media Set: %v\n", err)
 	}
 
-	// --- 6. PERSISTANCE ASYNCHRONE (Mongo + Postgres) ---
-	err = redis.EnqueueDB(
-		ctx,
-		mediaID,
-		ownerID,
-		redis.EntityMedia, // Assure-toi d'avoir défini cette constante dans async_queue.go
-		redis.ActionCreate,
-		media,           // Le payload complet
-		redis.TargetAll, // Mongo ET Postgres
-	)
-
-	if err != nil {
-		// Cas critique : Si Redis échoue, on supprime l'image de Minio pour ne pas laisser de fichiers orphelins
-		// (Ou on logge une erreur critique)
+	if err := redis.EnqueueDB(ctx, mediaID, ownerID, redis.EntityMedia, redis.ActionCreate, media, redis.TargetAll); err != nil {
 		log.Printf("❌ CRITICAL: Impossible d'enqueue le Media %d : %v", mediaID, err)
-		_ = minio.MinioClient.RemoveObject(context.Background(), bucketName, objectName, miniogo.RemoveObjectOptions{})
 		return fmt.Errorf("erreur systeme persistance: %v", err)
 	}
-
-	log.Printf("✅ Media %d uploadé et mis en file d'attente (Owner: %d)", mediaID, ownerID)
 	return nil
 }
+
+// StagingObjectPath est le chemin MinIO de l'original d'un média en attente de traitement.
+func StagingObjectPath(mediaID int64) string {
+	return fmt.Sprintf("%s%d", variables.MediaStagingPrefix, mediaID)
+}
+
+// renderedMedia est le résultat d'un traitement, reporté sur le média.
+type renderedMedia struct {
+	storagePath string
+	renditions  []models.MediaRendition
+	video       models.MediaVideo
+	hash        phash.Hash // Empreintes de l'image (de l'affiche pour une vidéo)
+}
+
+// renderStaged décode l'original en transit, le redresse selon l'orientation relevée au dépôt (puis le recadre
+// pour un gabarit de profil), produit les déclinaisons et les envoie au stockage (réencodées : aucune
+// métadonnée n'y est écrite).
+// Un original illisible ou absent est une erreur définitive (errUnprocessable) : inutile de réessayer.
+func renderStaged(ctx context.Context, stagingPath string, metadata models.MediaMetadata) (renderedMedia, error) {
+	bucketName := os.Getenv("MINIO_BUCKET_NAME")
+
+	// --- 1. LECTURE DE L'ORIGINAL (IO Network) ---
+	object, err := blobstore.Store.Get(ctx, bucketName, stagingPath)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return renderedMedia{}, fmt.Errorf("%w: original absent de la zone de transit", errUnprocessable)
+		}
+		return renderedMedia{}, fmt.Errorf("erreur lors de la lecture de la zone de transit: %v", err)
+	}
+	defer func(object io.ReadCloser) {
+		if err := object.Close(); err != nil {
+			log.Printf("⚠️ Erreur fermeture stream S3: %v", err)
+		}
+	}(object)
+
+	raw, err := io.ReadAll(object)
+	if err != nil {
+		return renderedMedia{}, fmt.Errorf("erreur lors de la lecture de la zone de transit: %v", err)
+	}
+
+	// --- 2. ANALYSE & OPTIMISATION IMAGE (CPU Heavy) ---
+	img, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return renderedMedia{}, fmt.Errorf("%w: erreur decode: %v", errUnprocessable, err)
+	}
+	img = imagemeta.Orient(img, metadata.Orientation)
+	if c := metadata.Crop; c != nil {
+		rect := image.Rect(c.X, c.Y, c.X+c.Width, c.Y+c.Height).Add(img.Bounds().Min)
+		if !rect.In(img.Bounds()) {
+			return renderedMedia{}, fmt.Errorf("%w: recadrage hors de l'image", errUnprocessable)
+		}
+		img = imaging.Crop(img, rect)
+	}
+
+	// --- 3. DÉCLINAISONS & UPLOAD VERS LE S3 ---
+	return renderImage(ctx, bucketName, img, layoutWidths(metadata.Layout))
+}
+
+// renderImage encode les déclinaisons d'une image, les envoie au stockage (tout ou rien) et calcule
+// ses empreintes perceptuelles.
+func renderImage(ctx context.Context, bucketName string, img image.Image, widths []int) (renderedMedia, error) {
+	// Une déclinaison par largeur et par format ; la plus large en AVIF garde le nom historique
+	baseName := uuid.New().String()
+	renditions, err := buildRenditions(img, baseName, widths)
+	if err != nil {
+		return renderedMedia{}, err
+	}
+
+	var uploaded []string
+	metas := make([]models.MediaRendition, 0, len(renditions))
+	for _, r := range renditions {
+		// Configuration indispensable du ContentType pour Scaleway/MinIO
+		err := blobstore.Store.Put(
+			ctx,
+			bucketName,
+			r.meta.StoragePath,
+			bytes.NewReader(r.data),
+			int64(len(r.data)),
+			blobstore.PutOptions{ContentType: r.contentType},
+		)
+		if err != nil {
+			removeObjects(bucketName, uploaded)
+			return renderedMedia{}, fmt.Errorf("erreur lors de l'envoi vers le stockage S3: %v", err)
+		}
+		uploaded = append(uploaded, r.meta.StoragePath)
+		metas = append(metas, r.meta)
+	}
+
+	return renderedMedia{storagePath: fmt.Sprintf("%s.avif", baseName), renditions: metas, hash: phash.Compute(img)}, nil
+}
+
+// buildRenditions produit chaque largeur de la liste (croissante) inférieure à l'original, plus l'original
+// plafonné à la dernière, en AVIF et en JPEG. La plus large en AVIF est nommée "<base>.avif".
+func buildRenditions(img image.Image, baseName string, candidates []int) ([]encodedRendition, error) {
+	srcWidth := img.Bounds().Dx()
+	largest := min(srcWidth, candidates[len(candidates)-1])
+
+	var widths []int
+	for _, w := range candidates {
+		if w < largest {
+			widths = append(widths, w)
+		}
+	}
+	widths = append(widths, largest)
+
+	var out []encodedRendition
+	for _, w := range widths {
+		resized := img
+		if w != srcWidth {
+			resized = imaging.Resize(img, w, 0, imaging.Lanczos)
+		}
+		height := resized.Bounds().Dy()
+
+		// AVIF (format natif)
+		var avifBuf bytes.Buffer
+		// ✅ CORRECTION AVIF : Retrait du '&'
+		if err := avif.Encode(&avifBuf, resized, avif.Options{Quality: AVIFQuality, Speed: 5}); err != nil {
+			return nil, fmt.Errorf("erreur encodage avif: %v", err)
+		}
+		avifPath := fmt.Sprintf("%s_%d.avif", baseName, w)
+		if w == largest {
+			avifPath = fmt.Sprintf("%s.avif", baseName)
+		}
+		out = append(out, encodedRendition{
+			meta: models.MediaRendition{Width: w, Height: height, Format: models.MediaFormatAVIF,
+				StoragePath: avifPath, SizeBytes: int64(avifBuf.Len())},
+			contentType: "image/avif",
+			data:        avifBuf.Bytes(),
+		})
+
+		// JPEG (repli) : la transparence est aplatie sur fond blanc
+		flat := imaging.New(resized.Bounds().Dx(), height, color.White)
+		flat = imaging.Overlay(flat, resized, image.Pt(0, 0), 1)
+		var jpegBuf bytes.Buffer
+		if err := jpeg.Encode(&jpegBuf, flat, &jpeg.Options{Quality: JPEGQuality}); err != nil {
+			return nil, fmt.Errorf("erreur encodage jpeg: %v", err)
+		}
+		out = append(out, encodedRendition{
+			meta: models.MediaRendition{Width: w, Height: height, Format: models.MediaFormatJPEG,
+				StoragePath: fmt.Sprintf("%s_%d.jpg", baseName, w), SizeBytes: int64(jpegBuf.Len())},
+			contentType: "image/jpeg",
+			data:        jpegBuf.Bytes(),
+		})
+	}
+	return out, nil
+}
+
+// layoutWidths renvoie les largeurs produites pour un gabarit (RenditionWidths pour une image de post).
+func layoutWidths(layout string) []int {
+	switch layout {
+	case models.MediaLayoutAvatar:
+		return variables.AvatarRenditionSizes
+	case models.MediaLayoutCover:
+		return variables.CoverRenditionWidths
+	default:
+		return RenditionWidths
+	}
+}
+
+// fitCrop valide le rectangle demandé (nil : image entière) puis le ramène au ratio du gabarit : le plus
+// grand rectangle au bon ratio, centré dans celui demandé. Un carré pour une photo de profil, un bandeau
+// CoverAspectWidth:CoverAspectHeight pour une couverture. Retourne ErrInvalidCrop si le rectangle déborde
+// de l'original ou si le résultat a un côté inférieur à ProfileImageMinSide.
+func fitCrop(layout string, crop *models.MediaCrop, width int, height int) (*models.MediaCrop, error) {
+	rect := models.MediaCrop{Width: width, Height: height}
+	if crop != nil {
+		rect = *crop
+	}
+	if rect.X < 0 || rect.Y < 0 || rect.Width <= 0 || rect.Height <= 0 ||
+		rect.X+rect.Width > width || rect.Y+rect.Height > height {
+		return nil, nubo_error.ErrInvalidCrop
+	}
+
+	ratioW, ratioH := 1, 1
+	if layout == models.MediaLayoutCover {
+		ratioW, ratioH = variables.CoverAspectWidth, variables.CoverAspectHeight
+	}
+	w, h := rect.Width, rect.Width*ratioH/ratioW
+	if h > rect.Height {
+		w, h = rect.Height*ratioW/ratioH, rect.Height
+	}
+	if min(w, h) < variables.ProfileImageMinSide {
+		return nil, nubo_error.ErrInvalidCrop
+	}
+
+	return &models.MediaCrop{
+		X:      rect.X + (rect.Width-w)/2,
+		Y:      rect.Y + (rect.Height-h)/2,
+		Width:  w,
+		Height: h,
+	}, nil
+}
+
+// removeObjects supprime des objets déjà envoyés (nettoyage après échec, best effort).
+func removeObjects(bucketName string, paths []string) {
+	for _, p := range paths {
+		_ = blobstore.Store.Delete(context.Background(), bucketName, p)
+	}
+}
@@ -0,0 +1,394 @@
+package media_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/media_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/post_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/mongo"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service/object_cache_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/event_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// Identifiants des ZSET de la file (membre = ID du média)
+const (
+	mediaQueuePending = "pending" // score = échéance Unix (immédiate, ou différée après un échec transitoire)
+	mediaQueueRunning = "running" // score = fin du bail Unix
+)
+
+// Champs de la barrière d'un post (HASH MediaPostGates)
+const (
+	gateFieldOwner      = "owner_id"
+	gateFieldVisibility = "visibility" // Visibilité demandée par l'auteur, appliquée à la publication
+	gateFieldTotal      = "total"
+	gateFieldRemaining  = "remaining"
+	gateFieldFailed     = "failed"
+	gateFieldSettled    = "settled:" // + ID du média : garantit un seul décompte par média
+)
+
+// errUnprocessable marque un échec définitif (fichier illisible) : le job n'est pas réessayé.
+var errUnprocessable = errors.New("média illisible")
+
+// ============================================================================
+// 1. MISE EN FILE (API)
+// ============================================================================
+
+// EnqueueMediaJobs met en file le traitement de médias déjà déposés par StageMedia.
+// Pour un post (postID != 0), la barrière est ouverte avant la mise en file : le post passera à la
+// visibilité demandée quand le dernier média sera prêt, ou à VisibilityMediaFailed si l'un échoue.
+func EnqueueMediaJobs(ctx context.Context, ownerID int64, postID int64, visibility int, mediaIDs []int64) error {
+	if len(mediaIDs) == 0 {
+		return nil
+	}
+
+	if postID != 0 {
+		err := redis.MediaPostGates.HSet(ctx, postID,
+			gateFieldOwner, ownerID,
+			gateFieldVisibility, visibility,
+			gateFieldTotal, len(mediaIDs),
+			gateFieldRemaining, len(mediaIDs),
+			gateFieldFailed, 0,
+		)
+		if err != nil {
+			return fmt.Errorf("erreur lors de l'ouverture de la barrière du post %d: %w", postID, err)
+		}
+	}
+
+	now := time.Now().UTC()
+	for _, mediaID := range mediaIDs {
+		job := media_models.MediaJobPayload{
+			MediaID:     mediaID,
+			OwnerID:     ownerID,
+			PostID:      postID,
+			StagingPath: StagingObjectPath(mediaID),
+			State:       variables.MediaStatusPending,
+			EnqueuedAt:  now,
+		}
+		if err := redis.MediaJobs.SetObject(ctx, mediaID, job); err != nil {
+			return fmt.Errorf("erreur lors de l'enregistrement du job média %d: %w", mediaID, err)
+		}
+		if err := redis.MediaQueue.ZAdd(ctx, mediaQueuePending, float64(now.Unix()), mediaID); err != nil {
+			return fmt.Errorf("erreur lors de la mise en file du média %d: %w", mediaID, err)
+		}
+	}
+	return nil
+}
+
+// DiscardStagedMedia abandonne des médias déposés dont la requête a échoué avant leur mise en file :
+// les originaux sont retirés de la zone de transit et les médias passent en échec.
+func DiscardStagedMedia(ctx context.Context, ownerID int64, mediaIDs []int64) {
+	bucketName := os.Getenv("MINIO_BUCKET_NAME")
+	for _, mediaID := range mediaIDs {
+		removeObjects(bucketName, []string{StagingObjectPath(mediaID)})
+		media := loadJobMedia(ctx, media_models.MediaJobPayload{MediaID: mediaID, OwnerID: ownerID})
+		media.Status = variables.MediaStatusFailed
+		saveMedia(ctx, media)
+	}
+}
+
+// CancelMediaJobs défait une mise en file interrompue (EnqueueMediaJobs en échec) : barrière du post,
+// jobs et entrées de la file d'attente sont retirés. Les médias eux-mêmes sont rendus par l'appelant.
+func CancelMediaJobs(ctx context.Context, postID int64, mediaIDs []int64) {
+	if postID != 0 {
+		_ = redis.MediaPostGates.DeleteObject(ctx, postID)
+	}
+	for _, mediaID := range mediaIDs {
+		_ = redis.MediaQueue.ZRem(ctx, mediaQueuePending, mediaID)
+		_ = redis.MediaJobs.DeleteObject(ctx, mediaID)
+	}
+}
+
+// DeferPostVisibility enregistre la visibilité choisie par l'auteur d'un post dont les médias sont encore
+// en traitement. Retourne false si la barrière n'existe plus (le post a déjà été finalisé).
+func DeferPostVisibility(ctx context.Context, postID int64, visibility int) bool {
+	key := redis.MediaPostGates.Key(postID)
+	exists, err := redis.MediaPostGates.Client.Exists(ctx, key).Result()
+	if err != nil || exists == 0 {
+		return false
+	}
+	return redis.MediaPostGates.HSet(ctx, postID, gateFieldVisibility, visibility) == nil
+}
+
+// ============================================================================
+// 2. TRAITEMENT (Worker)
+// ============================================================================
+
+// ClaimMediaJobs réclame au plus limit jobs échus. Le bail est posé avant le retrait de la file
+// d'attente : une instance arrêtée entre les deux laisse le job dans les deux ZSET, jamais dans aucun.
+// Le ZREM atomique désigne l'unique instance qui traite le job.
+func ClaimMediaJobs(ctx context.Context, limit int) []int64 {
+	now := time.Now().Unix()
+	due, err := redis.MediaQueue.ZRangeByScoreWithLimit(ctx, mediaQueuePending, now, int64(limit))
+	if err != nil || len(due) == 0 {
+		return nil
+	}
+
+	lease := float64(now + variables.MediaJobLeaseSeconds)
+	var claimed []int64
+	for _, member := range due {
+		mediaID, errParse := strconv.ParseInt(member, 10, 64)
+		if errParse != nil {
+			_ = redis.MediaQueue.ZRem(ctx, mediaQueuePending, member)
+			continue
+		}
+		if err := redis.MediaQueue.ZAdd(ctx, mediaQueueRunning, lease, mediaID); err != nil {
+			continue
+		}
+		removed, errZ := redis.MediaQueue.Client.ZRem(ctx, redis.MediaQueue.Key(mediaQueuePending), member).Result()
+		if errZ != nil || removed == 0 {
+			continue
+		}
+		claimed = append(claimed, mediaID)
+	}
+	return claimed
+}
+
+// RequeueExpiredMediaJobs remet en file les jobs dont le bail a expiré (instance arrêtée en plein
+// encodage). Retourne le nombre de jobs récupérés.
+func RequeueExpiredMediaJobs(ctx context.Context) int {
+	now := time.Now().Unix()
+	expired, err := redis.MediaQueue.ZRangeByScoreWithLimit(ctx, mediaQueueRunning, now, 100)
+	if err != nil {
+		return 0
+	}
+
+	requeued := 0
+	for _, member := range expired {
+		if err := redis.MediaQueue.ZAdd(ctx, mediaQueuePending, float64(now), member); err != nil {
+			continue
+		}
+		_ = redis.MediaQueue.ZRem(ctx, mediaQueueRunning, member)
+		requeued++
+	}
+	return requeued
+}
+
+// ProcessMediaJob encode les déclinaisons d'un média réclamé par ClaimMediaJobs.
+// Un échec transitoire (stockage indisponible) est réessayé avec un délai croissant jusqu'à
+// MediaJobMaxAttempts ; un fichier illisible échoue immédiatement.
+func ProcessMediaJob(ctx context.Context, mediaID int64) error {
+	var job media_models.MediaJobPayload
+	if err := redis.MediaJobs.GetObject(ctx, mediaID, &job); err != nil || job.MediaID == 0 {
+		_ = redis.MediaQueue.ZRem(ctx, mediaQueueRunning, mediaID)
+		return fmt.Errorf("job du média %d introuvable", mediaID)
+	}
+
+	// Job déjà terminé (bail expiré pendant la finalisation) : on rejoue seulement le décompte, idempotent
+	if job.State != variables.MediaStatusPending {
+		settlePostGate(ctx, job)
+		_ = redis.MediaQueue.ZRem(ctx, mediaQueueRunning, mediaID)
+		return nil
+	}
+
+	job.Attempts++
+	_ = redis.MediaJobs.SetObject(ctx, mediaID, job)
+
+	// Image : décodage, redressement + déclinaisons ; vidéo : sonde, rendu HLS, puis déclinaisons de l'affiche
+	var result renderedMedia
+	var err error
+	if media := loadJobMedia(ctx, job); media.IsVideo() {
+		result, err = renderStagedVideo(ctx, job.StagingPath)
+	} else {
+		result, err = renderStaged(ctx, job.StagingPath, media.Metadata)
+	}
+	if err == nil {
+		completeJob(ctx, job, result)
+		return nil
+	}
+
+	if !errors.Is(err, errUnprocessable) && job.Attempts < variables.MediaJobMaxAttempts {
+		job.Error = err.Error()
+		_ = redis.MediaJobs.SetObject(ctx, mediaID, job)
+		retryAt := time.Now().Unix() + int64(job.Attempts*variables.MediaJobRetryDelaySeconds)
+		_ = redis.MediaQueue.ZAdd(ctx, mediaQueuePending, float64(retryAt), mediaID)
+		_ = redis.MediaQueue.ZRem(ctx, mediaQueueRunning, mediaID)
+		return fmt.Errorf("média %d, tentative %d/%d : %w", mediaID, job.Attempts, variables.MediaJobMaxAttempts, err)
+	}
+
+	failJob(ctx, job, err)
+	return fmt.Errorf("média %d abandonné : %w", mediaID, err)
+}
+
+// completeJob enregistre les déclinaisons, libère la zone de transit et prévient l'auteur.
+// Un média proche d'une empreinte bloquée est mis en quarantaine à la place (quarantineJob).
+func completeJob(ctx context.Context, job media_models.MediaJobPayload, result renderedMedia) {
+	media := loadJobMedia(ctx, job)
+	media.Hash = fromHash(result.hash)
+
+	match, blocked, err := MatchBlockedHash(ctx, result.hash)
+	if err != nil {
+		log.Printf("⚠️ Media %d : liste de blocage indisponible : %v", job.MediaID, err)
+	}
+	if blocked {
+		quarantineJob(ctx, job, media, result, match)
+		return
+	}
+
+	media.StoragePath = result.storagePath
+	media.Renditions = result.renditions
+	media.Video = result.video
+	media.Status = variables.MediaStatusReady
+	saveMedia(ctx, media)
+	if err := IndexMediaHash(ctx, job.MediaID, result.hash); err != nil {
+		log.Printf("⚠️ Media %d : empreinte non indexée : %v", job.MediaID, err)
+	}
+
+	removeObjects(os.Getenv("MINIO_BUCKET_NAME"), []string{job.StagingPath})
+
+	job.State = variables.MediaStatusReady
+	job.Error = ""
+	job.CompletedAt = time.Now().UTC()
+	finishJob(ctx, job)
+
+	// Photo de profil ou couverture : elle remplace l'image courante seulement maintenant qu'elle est servable
+	if media.Metadata.Layout != "" {
+		applyProfileImage(ctx, job, media)
+	}
+
+	_ = event_service.Publish(ctx, job.OwnerID, event_service.EventMediaReady, media_models.MediaEvent{MediaID: job.MediaID, PostID: job.PostID})
+	log.Printf("✅ Media %d traité (%d déclinaisons, Owner: %d)", job.MediaID, len(result.renditions), job.OwnerID)
+}
+
+// failJob marque le média en échec, libère la zone de transit et prévient l'auteur.
+func failJob(ctx context.Context, job media_models.MediaJobPayload, cause error) {
+	media := loadJobMedia(ctx, job)
+	media.Status = variables.MediaStatusFailed
+	saveMedia(ctx, media)
+
+	removeObjects(os.Getenv("MINIO_BUCKET_NAME"), []string{job.StagingPath})
+
+	job.State = variables.MediaStatusFailed
+	job.Error = cause.Error()
+	job.CompletedAt = time.Now().UTC()
+	finishJob(ctx, job)
+
+	_ = event_service.Publish(ctx, job.OwnerID, event_service.EventMediaFailed, media_models.MediaEvent{MediaID: job.MediaID, PostID: job.PostID})
+	log.Printf("❌ Media %d en échec (Owner: %d) : %v", job.MediaID, job.OwnerID, cause)
+}
+
+// finishJob fige l'état terminal du job (conservé MediaJobRetentionHours), décompte le média sur la
+// barrière de son post puis libère le bail. L'état est écrit avant le décompte : un arrêt entre les
+// deux est rattrapé au prochain passage du job, le décompte étant idempotent.
+func finishJob(ctx context.Context, job media_models.MediaJobPayload) {
+	_ = redis.MediaJobs.SetObjectTTL(ctx, job.MediaID, job, time.Duration(variables.MediaJobRetentionHours)*time.Hour)
+	settlePostGate(ctx, job)
+	_ = redis.MediaQueue.ZRem(ctx, mediaQueueRunning, job.MediaID)
+}
+
+// loadJobMedia relit le média en cascade ; à défaut (persistance pas encore écrite, cache évincé),
+// il est reconstruit à partir du job.
+func loadJobMedia(ctx context.Context, job media_models.MediaJobPayload) models.MediaRequest {
+	if m, err := GetMediaCascade(ctx, job.MediaID); err == nil {
+		return m
+	}
+	return models.MediaRequest{
+		ID:         job.MediaID,
+		OwnerID:    job.OwnerID,
+		Renditions: []models.MediaRendition{},
+		Visibility: true,
+		Status:     variables.MediaStatusPending,
+		CreatedAt:  job.EnqueuedAt,
+	}
+}
+
+// saveMedia écrase le média en L1 et délègue la mise à jour L2/L3 aux workers.
+func saveMedia(ctx context.Context, media models.MediaRequest) {
+	media.UpdatedAt = time.Now().UTC()
+	if err := object_cache_service.SetMediaInObjectCache(ctx, media); err != nil {
+		fmt.Printf("⚠️ Erreur Redis Media Set: %v\n", err)
+	}
+	if err := redis.EnqueueDB(ctx, media.ID, media.OwnerID, redis.EntityMedia, redis.ActionUpdate, media, redis.TargetAll); err != nil {
+		log.Printf("❌ CRITICAL: Impossible d'enqueue la mise à jour du Media %d : %v", media.ID, err)
+	}
+}
+
+// ============================================================================
+// 3. BARRIÈRE DE PUBLICATION DES POSTS
+// ============================================================================
+
+// settlePostGate décompte un média terminé sur la barrière de son post. L'instance qui décompte le
+// dernier média publie le post (ou le laisse masqué si l'un des médias a échoué).
+func settlePostGate(ctx context.Context, job media_models.MediaJobPayload) {
+	if job.PostID == 0 {
+		return
+	}
+
+	key := redis.MediaPostGates.Key(job.PostID)
+	first, err := redis.MediaPostGates.Client.HSetNX(ctx, key, gateFieldSettled+strconv.FormatInt(job.MediaID, 10), job.State).Result()
+	if err != nil || !first {
+		return
+	}
+	if job.State != variables.MediaStatusReady {
+		_ = redis.MediaPostGates.Client.HIncrBy(ctx, key, gateFieldFailed, 1).Err()
+	}
+	remaining, err := redis.MediaPostGates.Client.HIncrBy(ctx, key, gateFieldRemaining, -1).Result()
+	if err != nil || remaining > 0 {
+		return
+	}
+
+	gate, err := redis.MediaPostGates.HGetAll(ctx, job.PostID).Result()
+	if err != nil {
+		return
+	}
+	_ = redis.MediaPostGates.DeleteObject(ctx, job.PostID)
+
+	visibility, _ := strconv.Atoi(gate[gateFieldVisibility])
+	total, _ := strconv.Atoi(gate[gateFieldTotal])
+	failed, _ := strconv.Atoi(gate[gateFieldFailed])
+	finalizePost(ctx, job.PostID, job.OwnerID, visibility, total, failed)
+}
+
+// finalizePost applique l'issue du traitement à un post resté en VisibilityProcessing.
+// Un post supprimé ou masqué par la modération entre-temps n'est pas touché.
+func finalizePost(ctx context.Context, postID int64, ownerID int64, visibility int, total int, failed int) {
+	post, found := loadProcessingPost(ctx, postID)
+	if !found || post.Visibility != variables.VisibilityProcessing {
+		return
+	}
+
+	eventType := event_service.EventPostPublished
+	if failed > 0 {
+		post.Visibility = variables.VisibilityMediaFailed
+		eventType = event_service.EventPostMediaFailed
+	} else {
+		post.Visibility = visibility
+	}
+
+	if err := object_cache_service.SetPostInObjectCache(ctx, post); err != nil {
+		fmt.Printf("⚠️ Erreur Redis Post Set: %v\n", err)
+	}
+	if err := redis.EnqueueDB(ctx, post.ID, 0, redis.EntityPost, redis.ActionUpdate, post, redis.TargetAll); err != nil {
+		log.Printf("❌ CRITICAL: Impossible d'enqueue la publication du Post %d : %v", post.ID, err)
+	}
+
+	// Le post, créé masqué, n'a pas été distribué à la création : il rejoint maintenant les boîtes aux lettres
+	cache_service.FanOutPostToMailboxes(ctx, post.UserID, post.ID, post.Visibility)
+
+	_ = event_service.Publish(ctx, ownerID, eventType, media_models.PostMediaEvent{
+		PostID:      post.ID,
+		ReadyMedia:  total - failed,
+		FailedMedia: failed,
+		Visibility:  post.Visibility,
+	})
+}
+
+// loadProcessingPost lit un post en cascade L1 -> L2 (L3 ne renvoie que les posts visibles).
+func loadProcessingPost(ctx context.Context, postID int64) (post_models.PostPayload, bool) {
+	if p, err := object_cache_service.GetPostFromObjectCache(ctx, postID); err == nil {
+		return p, true
+	}
+	if posts, err := mongo.MongoLoadPosts([]int64{postID}); err == nil && len(posts) > 0 {
+		return posts[0], true
+	}
+	return post_models.PostPayload{}, false
+}
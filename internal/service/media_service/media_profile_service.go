@@ -0,0 +1,132 @@
+package media_service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/auth_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/media_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/mongo"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service"
+)
+
+// ============================================================================
+// PHOTOS DE PROFIL & COUVERTURES
+// ============================================================================
+// Une nouvelle image ne remplace l'ancienne qu'une fois prête : jusque-là, le compte garde l'image courante
+// et seule la demande est mémorisée (ProfileImages). Si le traitement échoue ou si l'image part en
+// quarantaine, l'image courante reste en place. Quand plusieurs images se succèdent avant la fin du
+// traitement, seule la dernière demandée est substituée ; les autres sont retirées.
+
+// EnqueueProfileImageJob met en file le traitement d'une photo de profil ou d'une couverture déposée par
+// StageProfileUpload, et la désigne comme remplaçante de l'image courante du gabarit.
+func EnqueueProfileImageJob(ctx context.Context, ownerID int64, mediaID int64, layout string) error {
+	if err := redis.ProfileImages.SetPrimitive(ctx, profileImageKey(ownerID, layout), mediaID); err != nil {
+		return fmt.Errorf("erreur lors de l'enregistrement de l'image de profil en attente: %w", err)
+	}
+	return EnqueueMediaJobs(ctx, ownerID, 0, 0, []int64{mediaID})
+}
+
+// CancelPendingProfileImage oublie l'image en traitement d'un gabarit : elle sera retirée à la fin du traitement.
+func CancelPendingProfileImage(ctx context.Context, ownerID int64, layout string) {
+	_ = redis.ProfileImages.DeleteObject(ctx, profileImageKey(ownerID, layout))
+}
+
+// SwapProfileImage remplace l'image du gabarit sur le compte (0 : aucune image) et renvoie l'ID précédent.
+// Le compte est persisté sur L2/L3 via le Write-Behind, l'objet L1 invalidé et l'empreinte Lite réécrite
+// pour que l'image soit servie partout (fil, recherche, commentaires) sans attendre l'expiration du cache.
+func SwapProfileImage(ctx context.Context, userID int64, layout string, mediaID int64) (int64, error) {
+	user, err := loadProfileAccount(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	previous := setProfileImage(&user, layout, mediaID)
+	if previous == mediaID {
+		return previous, nil
+	}
+	user.UpdatedAt = time.Now().UTC()
+
+	if err := redis.EnqueueDB(ctx, user.ID, 0, redis.EntityUser, redis.ActionUpdate, user, redis.TargetAll); err != nil {
+		log.Printf("❌ CRITICAL: Rupture du Write-Behind (profil utilisateur %d) : %v", user.ID, err)
+		return 0, err
+	}
+	_ = redis.Users.DeleteObject(ctx, user.ID)
+
+	if err := cache_service.AddUserToSpeedCache(ctx, user); err != nil {
+		log.Printf("⚠️ Warning: Echec SPEED Cache Redis User %d : %v", user.ID, err)
+	}
+	return previous, nil
+}
+
+// applyProfileImage substitue une image de profil prête à l'image courante du compte, puis retire cette
+// dernière. Une image remplacée entre-temps par une demande plus récente (ou annulée) est retirée à la place.
+func applyProfileImage(ctx context.Context, job media_models.MediaJobPayload, media models.MediaRequest) {
+	layout := media.Metadata.Layout
+	pending, err := redis.ProfileImages.GetInt64(ctx, profileImageKey(job.OwnerID, layout))
+	if err != nil || pending != job.MediaID {
+		// Image posée directement sur le compte (inscription) : rien à substituer
+		user, errLoad := loadProfileAccount(job.OwnerID)
+		if errLoad != nil || profileImageOf(user, layout) == job.MediaID {
+			return
+		}
+		RetireMedia(ctx, job.MediaID)
+		return
+	}
+
+	previous, err := SwapProfileImage(ctx, job.OwnerID, layout, job.MediaID)
+	if err != nil {
+		log.Printf("❌ Image de profil %d non substituée (Owner: %d) : %v", job.MediaID, job.OwnerID, err)
+		return
+	}
+	_ = redis.ProfileImages.DeleteObject(ctx, profileImageKey(job.OwnerID, layout))
+	if previous != 0 && previous != job.MediaID {
+		RetireMedia(ctx, previous)
+	}
+}
+
+// --- HELPERS ---
+
+// profileImageKey est la clé ProfileImages d'un gabarit de profil.
+func profileImageKey(userID int64, layout string) string {
+	return fmt.Sprintf("%d:%s", userID, layout)
+}
+
+// loadProfileAccount lit le compte en L2, puis en L3.
+func loadProfileAccount(userID int64) (auth_models.UserPayload, error) {
+	user, err := mongo.MongoLoadUser(userID, "", "", "")
+	if err == nil && user.ID != 0 {
+		return user, nil
+	}
+	if user, err = postgres.FuncLoadUser(userID, "", "", ""); err != nil {
+		return auth_models.UserPayload{}, fmt.Errorf("erreur lors du chargement du compte %d: %w", userID, err)
+	}
+	if user.ID == 0 {
+		return auth_models.UserPayload{}, fmt.Errorf("compte %d introuvable", userID)
+	}
+	return user, nil
+}
+
+// profileImageOf renvoie l'image du gabarit portée par le compte.
+func profileImageOf(user auth_models.UserPayload, layout string) int64 {
+	if layout == models.MediaLayoutCover {
+		return user.CoverPictureID
+	}
+	return user.ProfilePictureID
+}
+
+// setProfileImage remplace l'image du gabarit sur le compte et renvoie l'ID précédent (0 : aucune image).
+func setProfileImage(user *auth_models.UserPayload, layout string, mediaID int64) int64 {
+	var previous int64
+	if layout == models.MediaLayoutCover {
+		previous, user.CoverPictureID = user.CoverPictureID, mediaID
+	} else {
+		previous, user.ProfilePictureID = user.ProfilePictureID, mediaID
+	}
+	return previous
+}
@@ -0,0 +1,354 @@
+package media_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/media_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/blobstore"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg/transcoder"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// 1. UPLOAD PAR MORCEAUX (API)
+// ============================================================================
+// L'original est assemblé directement dans la zone de transit par un upload multipart MinIO : chaque
+// morceau reçu est une partie stockée, le client peut reprendre après une coupure en ne renvoyant que
+// les morceaux absents de GetUploadSession. La vidéo n'est transcodée qu'une fois rattachée à un post.
+
+// CreateUploadSession ouvre un upload vidéo. La taille est plafonnée ici ; la durée, qui ne peut être
+// crue sur parole, est contrôlée par la sonde au moment du transcodage.
+func CreateUploadSession(ctx context.Context, userID int64, input media_models.CreateUploadInput) (media_models.UploadSessionOutput, error) {
+	if !variables.VideoContentTypes[input.ContentType] {
+		return media_models.UploadSessionOutput{}, nubo_error.ErrUnsupportedMedia
+	}
+	if input.SizeBytes > variables.MaxVideoBytes {
+		return media_models.UploadSessionOutput{}, nubo_error.ErrVideoTooLarge
+	}
+
+	now := time.Now().UTC()
+	mediaID := pkg.GenerateID()
+	session := media_models.UploadSessionPayload{
+		ID:          pkg.GenerateID(),
+		OwnerID:     userID,
+		MediaID:     mediaID,
+		ObjectPath:  StagingObjectPath(mediaID),
+		ContentType: input.ContentType,
+		SizeBytes:   input.SizeBytes,
+		ChunkBytes:  variables.VideoChunkBytes,
+		ChunkCount:  int((input.SizeBytes + variables.VideoChunkBytes - 1) / variables.VideoChunkBytes),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(variables.VideoUploadTTLHours * time.Hour),
+	}
+
+	uploads, err := multipartStore()
+	if err != nil {
+		return media_models.UploadSessionOutput{}, err
+	}
+	uploadID, err := uploads.CreateMultipart(ctx, os.Getenv("MINIO_BUCKET_NAME"), session.ObjectPath, input.ContentType)
+	if err != nil {
+		return media_models.UploadSessionOutput{}, fmt.Errorf("erreur lors de l'ouverture de l'upload multipart: %w", err)
+	}
+	session.UploadID = uploadID
+
+	if err := redis.UploadSessions.SetObject(ctx, session.ID, session); err != nil {
+		return media_models.UploadSessionOutput{}, fmt.Errorf("erreur lors de l'enregistrement de la session d'upload: %w", err)
+	}
+	return uploadStatus(session, nil), nil
+}
+
+// GetUploadSession renvoie les morceaux déjà reçus (reprise après coupure).
+func GetUploadSession(ctx context.Context, userID int64, sessionID int64) (media_models.UploadSessionOutput, error) {
+	session, err := loadUploadSession(ctx, userID, sessionID)
+	if err != nil {
+		return media_models.UploadSessionOutput{}, err
+	}
+	parts, err := redis.UploadParts.HGetAll(ctx, sessionID).Result()
+	if err != nil {
+		return media_models.UploadSessionOutput{}, fmt.Errorf("erreur lors de la lecture des morceaux reçus: %w", err)
+	}
+	return uploadStatus(session, parts), nil
+}
+
+// PutUploadChunk stocke le morceau index (à partir de 0). Tous les morceaux font ChunkBytes, sauf le
+// dernier qui porte le reste. Renvoyer un morceau déjà reçu le remplace.
+func PutUploadChunk(ctx context.Context, userID int64, sessionID int64, index int, body io.Reader, length int64) (media_models.UploadSessionOutput, error) {
+	session, err := loadUploadSession(ctx, userID, sessionID)
+	if err != nil {
+		return media_models.UploadSessionOutput{}, err
+	}
+	if index < 0 || index >= session.ChunkCount || length != chunkLength(session, index) {
+		return media_models.UploadSessionOutput{}, nubo_error.ErrInvalidChunk
+	}
+
+	uploads, err := multipartStore()
+	if err != nil {
+		return media_models.UploadSessionOutput{}, err
+	}
+	etag, err := uploads.PutPart(ctx, os.Getenv("MINIO_BUCKET_NAME"), session.ObjectPath, session.UploadID, index+1, body, length)
+	if err != nil {
+		return media_models.UploadSessionOutput{}, fmt.Errorf("erreur lors de l'envoi du morceau %d: %w", index, err)
+	}
+
+	key := redis.UploadParts.Key(sessionID)
+	if err := redis.UploadParts.HSet(ctx, sessionID, strconv.Itoa(index), etag); err != nil {
+		return media_models.UploadSessionOutput{}, fmt.Errorf("erreur lors de l'enregistrement du morceau %d: %w", index, err)
+	}
+	_ = redis.UploadParts.Client.ExpireAt(ctx, key, session.ExpiresAt).Err()
+
+	return GetUploadSession(ctx, userID, sessionID)
+}
+
+// CompleteUpload assemble l'original et crée le média vidéo en attente. Il est ensuite rattaché à un
+// post par son ID (CreatePostInput.MediaIDs), ce qui déclenche le transcodage.
+func CompleteUpload(ctx context.Context, userID int64, sessionID int64) (media_models.CompleteUploadOutput, error) {
+	session, err := loadUploadSession(ctx, userID, sessionID)
+	if err != nil {
+		return media_models.CompleteUploadOutput{}, err
+	}
+	etags, err := redis.UploadParts.HGetAll(ctx, sessionID).Result()
+	if err != nil {
+		return media_models.CompleteUploadOutput{}, fmt.Errorf("erreur lors de la lecture des morceaux reçus: %w", err)
+	}
+	if len(etags) != session.ChunkCount {
+		return media_models.CompleteUploadOutput{}, nubo_error.ErrUploadIncomplete
+	}
+
+	parts := make([]blobstore.Part, 0, session.ChunkCount)
+	for i := 0; i < session.ChunkCount; i++ {
+		etag, ok := etags[strconv.Itoa(i)]
+		if !ok {
+			return media_models.CompleteUploadOutput{}, nubo_error.ErrUploadIncomplete
+		}
+		parts = append(parts, blobstore.Part{Number: i + 1, ETag: etag})
+	}
+
+	uploads, err := multipartStore()
+	if err != nil {
+		return media_models.CompleteUploadOutput{}, err
+	}
+	bucketName := os.Getenv("MINIO_BUCKET_NAME")
+	if err := uploads.CompleteMultipart(ctx, bucketName, session.ObjectPath, session.UploadID, parts); err != nil {
+		return media_models.CompleteUploadOutput{}, fmt.Errorf("erreur lors de l'assemblage de l'upload: %w", err)
+	}
+
+	if err := registerStagedMedia(ctx, userID, session.MediaID, models.MediaTypeVideo, models.MediaMetadata{}); err != nil {
+		removeObjects(bucketName, []string{session.ObjectPath})
+		return media_models.CompleteUploadOutput{}, err
+	}
+
+	_ = redis.UploadSessions.DeleteObject(ctx, sessionID)
+	_ = redis.UploadParts.DeleteObject(ctx, sessionID)
+	return media_models.CompleteUploadOutput{MediaID: session.MediaID}, nil
+}
+
+// AbortUpload abandonne un upload inachevé et libère les morceaux déjà stockés.
+func AbortUpload(ctx context.Context, userID int64, sessionID int64) error {
+	session, err := loadUploadSession(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+	uploads, err := multipartStore()
+	if err != nil {
+		return err
+	}
+	if err := uploads.AbortMultipart(ctx, os.Getenv("MINIO_BUCKET_NAME"), session.ObjectPath, session.UploadID); err != nil {
+		return fmt.Errorf("erreur lors de l'abandon de l'upload multipart: %w", err)
+	}
+	_ = redis.UploadSessions.DeleteObject(ctx, sessionID)
+	_ = redis.UploadParts.DeleteObject(ctx, sessionID)
+	return nil
+}
+
+// AttachStagedMedia vérifie que des médias déposés à part (vidéos assemblées) appartiennent à l'auteur,
+// sont en attente et ne sont pas déjà rattachés à un autre post, puis les réserve.
+// La réservation (SETNX sur la clé du job) départage deux posts concurrents : EnqueueMediaJobs la remplace
+// par le job réel, ReleaseStagedMedia la libère si la création échoue, et elle expire d'elle-même sinon.
+func AttachStagedMedia(ctx context.Context, userID int64, mediaIDs []int64) error {
+	reservation := time.Duration(variables.MediaAttachReserveSeconds) * time.Second
+	for i, mediaID := range mediaIDs {
+		m, err := GetMediaCascade(ctx, mediaID)
+		if err != nil || m.OwnerID != userID || m.Status != variables.MediaStatusPending {
+			ReleaseStagedMedia(ctx, mediaIDs[:i])
+			return nubo_error.ErrMediaUnavailable
+		}
+		job := media_models.MediaJobPayload{MediaID: mediaID, OwnerID: userID, State: variables.MediaStatusPending}
+		claimed, err := redis.MediaJobs.SetObjectNX(ctx, mediaID, job, reservation)
+		if err != nil || !claimed {
+			ReleaseStagedMedia(ctx, mediaIDs[:i])
+			return nubo_error.ErrMediaUnavailable
+		}
+	}
+	return nil
+}
+
+// ReleaseStagedMedia libère les réservations posées par AttachStagedMedia (création du post abandonnée).
+func ReleaseStagedMedia(ctx context.Context, mediaIDs []int64) {
+	for _, mediaID := range mediaIDs {
+		_ = redis.MediaJobs.DeleteObject(ctx, mediaID)
+	}
+}
+
+// loadUploadSession lit une session ; celle d'un autre utilisateur est traitée comme absente.
+func loadUploadSession(ctx context.Context, userID int64, sessionID int64) (media_models.UploadSessionPayload, error) {
+	var session media_models.UploadSessionPayload
+	if err := redis.UploadSessions.GetObject(ctx, sessionID, &session); err != nil || session.OwnerID != userID {
+		return media_models.UploadSessionPayload{}, nubo_error.ErrNotFound
+	}
+	return session, nil
+}
+
+// chunkLength renvoie la taille attendue du morceau index.
+func chunkLength(session media_models.UploadSessionPayload, index int) int64 {
+	if index == session.ChunkCount-1 {
+		return session.SizeBytes - int64(session.ChunkCount-1)*session.ChunkBytes
+	}
+	return session.ChunkBytes
+}
+
+func uploadStatus(session media_models.UploadSessionPayload, parts map[string]string) media_models.UploadSessionOutput {
+	received := make([]int, 0, len(parts))
+	for field := range parts {
+		if i, err := strconv.Atoi(field); err == nil {
+			received = append(received, i)
+		}
+	}
+	sort.Ints(received)
+	return media_models.UploadSessionOutput{
+		UploadID:       session.ID,
+		MediaID:        session.MediaID,
+		ChunkBytes:     session.ChunkBytes,
+		ChunkCount:     session.ChunkCount,
+		ReceivedChunks: received,
+		ExpiresAt:      session.ExpiresAt,
+	}
+}
+
+// multipartStore renvoie le stockage s'il sait assembler un upload par parties.
+func multipartStore() (blobstore.MultipartStore, error) {
+	uploads, ok := blobstore.Store.(blobstore.MultipartStore)
+	if !ok {
+		return nil, fmt.Errorf("erreur lors de l'ouverture de l'upload multipart: %w", blobstore.ErrUnsupported)
+	}
+	return uploads, nil
+}
+
+// ============================================================================
+// 2. TRANSCODAGE (Worker)
+// ============================================================================
+
+// renderStagedVideo sonde la vidéo en transit, contrôle sa durée, produit le rendu HLS puis l'affiche
+// (mêmes déclinaisons AVIF/JPEG qu'une image). Une vidéo illisible ou trop longue échoue définitivement.
+func renderStagedVideo(ctx context.Context, stagingPath string) (renderedMedia, error) {
+	bucketName := os.Getenv("MINIO_BUCKET_NAME")
+	ctx, cancel := context.WithTimeout(ctx, variables.VideoTranscodeTimeoutMin*time.Minute)
+	defer cancel()
+
+	tmpDir, err := os.MkdirTemp("", "nubo-video-*")
+	if err != nil {
+		return renderedMedia{}, fmt.Errorf("erreur lors de la création du dossier de travail: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	// --- 1. RAPATRIEMENT DE L'ORIGINAL (fichier local : ffmpeg lit par accès aléatoire) ---
+	source := filepath.Join(tmpDir, "source")
+	if err := blobstore.DownloadFile(ctx, blobstore.Store, bucketName, stagingPath, source); err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return renderedMedia{}, fmt.Errorf("%w: original absent de la zone de transit", errUnprocessable)
+		}
+		return renderedMedia{}, fmt.Errorf("erreur lors de la lecture de la zone de transit: %v", err)
+	}
+
+	// --- 2. SONDE & LIMITES ---
+	tc := transcoder.Default()
+	probe, err := tc.Probe(ctx, source)
+	if err != nil {
+		return renderedMedia{}, fmt.Errorf("%w: %v", errUnprocessable, err)
+	}
+	if probe.Duration > variables.MaxVideoDurationSeconds*time.Second {
+		return renderedMedia{}, fmt.Errorf("%w: %w (%s)", errUnprocessable, nubo_error.ErrVideoTooLong, probe.Duration)
+	}
+
+	// --- 3. RENDU HLS & AFFICHE (CPU Heavy) ---
+	outDir := filepath.Join(tmpDir, "hls")
+	if err := os.Mkdir(outDir, 0o700); err != nil {
+		return renderedMedia{}, fmt.Errorf("erreur lors de la création du dossier de sortie: %v", err)
+	}
+	out, err := tc.Transcode(ctx, source, outDir)
+	if err != nil {
+		return renderedMedia{}, err
+	}
+
+	poster, err := tc.PosterFrame(ctx, source, posterOffset(probe.Duration))
+	if err != nil {
+		return renderedMedia{}, err
+	}
+	result, err := renderImage(ctx, bucketName, poster, RenditionWidths)
+	if err != nil {
+		return renderedMedia{}, err
+	}
+
+	// --- 4. UPLOAD DU RENDU (tout ou rien) ---
+	prefix := variables.VideoObjectPrefix + uuid.New().String() + "/"
+	var uploaded []string
+	for _, name := range append([]string{out.Playlist}, out.Segments...) {
+		err := blobstore.UploadFile(ctx, blobstore.Store, bucketName, prefix+name, filepath.Join(outDir, name),
+			blobstore.PutOptions{ContentType: hlsContentType(name)})
+		if err != nil {
+			removeObjects(bucketName, uploaded)
+			removeObjects(bucketName, renditionPaths(result.renditions))
+			return renderedMedia{}, fmt.Errorf("erreur lors de l'envoi du rendu vidéo: %v", err)
+		}
+		uploaded = append(uploaded, prefix+name)
+	}
+
+	result.video = models.MediaVideo{
+		DurationMs:   probe.Duration.Milliseconds(),
+		Width:        out.Width,
+		Height:       out.Height,
+		PlaylistPath: prefix + out.Playlist,
+		SegmentPaths: uploaded[1:],
+	}
+	if result.video.Width == 0 {
+		result.video.Width, result.video.Height = probe.Width, probe.Height
+	}
+	return result, nil
+}
+
+// posterOffset place l'affiche à VideoPosterOffsetMs, ou au milieu d'une vidéo plus courte.
+func posterOffset(duration time.Duration) time.Duration {
+	at := time.Duration(variables.VideoPosterOffsetMs) * time.Millisecond
+	if at >= duration {
+		return duration / 2
+	}
+	return at
+}
+
+func hlsContentType(name string) string {
+	if strings.HasSuffix(name, ".m3u8") {
+		return "application/vnd.apple.mpegurl"
+	}
+	return "video/mp2t"
+}
+
+func renditionPaths(renditions []models.MediaRendition) []string {
+	paths := make([]string, 0, len(renditions))
+	for _, r := range renditions {
+		paths = append(paths, r.StoragePath)
+	}
+	return paths
+}
@@ -1,39 +1,76 @@
 package media_service
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
-	"github.com/QuentinRegnier/nubo-backend/internal/pkg/security"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/media_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg/mediaurl"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
 )
 
-// GenerateWatermarkedURL crée une URL signée unique pour un seul média.
+// GenerateWatermarkedURL crée une URL tatouée signée pour un seul média, valable MediaURLTTLSeconds.
 func GenerateWatermarkedURL(mediaKey string, authorID, postID, readerID int64) string {
-	baseURL := os.Getenv("WATERMARK_API_URL")
-	secret := os.Getenv("WATERMARK_SECRET_KEY")
-	timestamp := time.Now().Unix()
+	now := time.Now()
+	query := mediaurl.Sign(mediaurl.Claims{
+		Key:       mediaKey,
+		AuthorID:  authorID,
+		PostID:    postID,
+		ReaderID:  readerID,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(variables.MediaURLTTLSeconds * time.Second),
+	}, os.Getenv("WATERMARK_SECRET_KEY"))
 
-	// Construction de la chaîne à signer
-	// L'ordre des paramètres doit être le même ici et dans le micro-service !
-	payload := fmt.Sprintf("key=%s&author=%d&post_service=%d&reader=%d&ts=%d",
-		mediaKey, authorID, postID, readerID, timestamp)
-
-	// Génération de la signature via ton package security
-	sig := security.GenerateHMAC(payload, secret)
-
-	return fmt.Sprintf("%s/process?%s&sig=%s", baseURL, payload, sig)
+	return fmt.Sprintf("%s/process?%s", os.Getenv("WATERMARK_API_URL"), query)
 }
 
-// FormatMediaURLs est la fonction outil qui transforme une liste de médias en liste d'URLs signées.
-func FormatMediaURLs(mediaList []models.MediaRequest, authorID, postID, readerID int64) []string {
-	urls := make([]string, len(mediaList))
+// FormatMediaURLs transforme une liste de médias en jeux de sources signées (une URL par déclinaison),
+// regroupées par format et triées par largeur croissante pour un usage de type srcset.
+// Un média antérieur aux déclinaisons n'expose que son AVIF d'origine (largeur inconnue : 0).
+// Les liens sont émis par MediaURL : l'appelant a déjà appliqué la politique d'accès (voir PostMediaSources).
+func FormatMediaURLs(ctx context.Context, mediaList []models.MediaRequest, authorID, postID, readerID int64) []media_models.MediaSourceSet {
+	sets := make([]media_models.MediaSourceSet, len(mediaList))
 
 	for i, m := range mediaList {
-		// On utilise la clé de stockage (ex: "uuid.avif") pour générer l'URL
-		urls[i] = GenerateWatermarkedURL(m.StoragePath, authorID, postID, readerID)
+		set := media_models.MediaSourceSet{
+			MediaID: m.ID,
+			Type:    models.MediaTypeImage,
+			Default: MediaURL(ctx, m.StoragePath, authorID, postID, readerID),
+			Sources: make(map[string][]media_models.MediaSource),
+			SrcSet:  make(map[string]string),
+		}
+		if m.IsVideo() {
+			set.Type, set.DurationMs = models.MediaTypeVideo, m.Video.DurationMs
+		}
+
+		if len(m.Renditions) == 0 {
+			set.Sources[models.MediaFormatAVIF] = []media_models.MediaSource{{URL: set.Default}}
+		}
+		for _, r := range m.Renditions {
+			url := set.Default
+			if r.StoragePath != m.StoragePath {
+				url = MediaURL(ctx, r.StoragePath, authorID, postID, readerID)
+			}
+			set.Sources[r.Format] = append(set.Sources[r.Format], media_models.MediaSource{Width: r.Width, Height: r.Height, URL: url})
+		}
+
+		for format, sources := range set.Sources {
+			sort.Slice(sources, func(a, b int) bool { return sources[a].Width < sources[b].Width })
+			var parts []string
+			for _, src := range sources {
+				if src.Width > 0 {
+					parts = append(parts, fmt.Sprintf("%s %dw", src.URL, src.Width))
+				}
+			}
+			set.SrcSet[format] = strings.Join(parts, ", ")
+		}
+		sets[i] = set
 	}
 
-	return urls
+	return sets
 }
@@ -2,6 +2,7 @@ package post_service
 
 import (
 	"context"
+	"log"
 	"mime/multipart"
 	"time"
 
@@ -12,25 +13,39 @@ import (
 	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service/object_cache_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/media_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/sanction_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
 )
 
 // CreatePost (Inchangé)
 func CreatePost(userID int64, input post_models.CreatePostInput, files []*multipart.FileHeader) (int64, error) {
+	// 0. Sanctions : un utilisateur restreint ne publie pas (avant tout upload)
+	if err := sanction_service.CheckRestriction(context.Background(), userID, variables.RestrictionScopePost); err != nil {
+		return 0, err
+	}
+
+	// 0.5 Médias déposés à part (vidéos) : ils doivent appartenir à l'auteur et n'être rattachés à aucun post
+	if err := media_service.AttachStagedMedia(context.Background(), userID, input.MediaIDs); err != nil {
+		return 0, err
+	}
+
 	now := time.Now().UTC()
 	postID := pkg.GenerateID()
 	var mediaIDs []int64
 
-	// 1. Upload Images
+	// 1. Dépôt des originaux en zone de transit (synchrone : un fichier illisible est refusé ici,
+	// l'encodage des déclinaisons est fait par les ouvriers de traitement des médias)
 	for _, fileHeader := range files {
 		mediaID := pkg.GenerateID()
-		file, err := fileHeader.Open()
-		if err == nil {
-			go func() {
-				_ = media_service.UploadMedia(file, userID, mediaID)
-			}()
-			mediaIDs = append(mediaIDs, mediaID)
+		if err := media_service.StageUpload(context.Background(), fileHeader, userID, mediaID); err != nil {
+			media_service.DiscardStagedMedia(context.Background(), userID, mediaIDs)
+			media_service.ReleaseStagedMedia(context.Background(), input.MediaIDs)
+			return 0, err
 		}
+		mediaIDs = append(mediaIDs, mediaID)
 	}
+	stagedIDs := mediaIDs
+	mediaIDs = append(mediaIDs, input.MediaIDs...)
 
 	// ✅ ÉVALUATION DYNAMIQUE DE LA PRIORITÉ VIA LA MAP DE GRADES
 	priorityLevel := 0
@@ -67,6 +82,11 @@ func CreatePost(userID int64, input post_models.CreatePostInput, files []*multip
 		VectorVersion: 1, // On initialise la version du vecteur
 	}
 
+	// Le post reste masqué tant que ses médias ne sont pas tous prêts (la barrière garde la visibilité demandée)
+	if len(mediaIDs) > 0 {
+		post.Visibility = variables.VisibilityProcessing
+	}
+
 	// ─────────────────────────────────────────────────────────────────────────
 	// 2.5 VECTORISATION SYNCHRONE DU CONTENU (O(1) - Très rapide)
 	// ─────────────────────────────────────────────────────────────────────────
@@ -77,6 +97,8 @@ func CreatePost(userID int64, input post_models.CreatePostInput, files []*multip
 
 	// 3. Cache Redis (LFU Init)
 	if err := object_cache_service.SetPostInObjectCache(context.Background(), post); err != nil {
+		media_service.DiscardStagedMedia(context.Background(), userID, stagedIDs)
+		media_service.ReleaseStagedMedia(context.Background(), input.MediaIDs)
 		return -1, err
 	}
 
@@ -86,6 +108,27 @@ func CreatePost(userID int64, input post_models.CreatePostInput, files []*multip
 	// 4. Persistance Async
 	// On passe 0 en partitionKey pour que le CRC32 se fasse sur postID.
 	// Les futurs Likes utiliseront ce postID pour tomber dans le même Shard !
-	err := redis.EnqueueDB(context.Background(), postID, 0, redis.EntityPost, redis.ActionCreate, post, redis.TargetAll)
-	return postID, err
+	if err := redis.EnqueueDB(context.Background(), postID, 0, redis.EntityPost, redis.ActionCreate, post, redis.TargetAll); err != nil {
+		// Le post ne sera jamais persisté : on le retire de L1 et on rend ses médias
+		_ = object_cache_service.DeletePostFromObjectCache(context.Background(), postID)
+		_ = cache_service.RemovePostFromUserProfile(context.Background(), userID, postID)
+		media_service.DiscardStagedMedia(context.Background(), userID, stagedIDs)
+		media_service.ReleaseStagedMedia(context.Background(), input.MediaIDs)
+		return 0, err
+	}
+
+	// 5. Mise en file du traitement des médias (le post est publié par le dernier job terminé)
+	if err := media_service.EnqueueMediaJobs(context.Background(), userID, postID, input.Visibility, mediaIDs); err != nil {
+		// Sans jobs, le post resterait en VisibilityProcessing : on annule la publication
+		media_service.CancelMediaJobs(context.Background(), postID, mediaIDs)
+		_ = object_cache_service.DeletePostFromObjectCache(context.Background(), postID)
+		_ = cache_service.RemovePostFromUserProfile(context.Background(), userID, postID)
+		if errDel := redis.EnqueueDB(context.Background(), postID, 0, redis.EntityPost, redis.ActionDelete, post, redis.TargetAll); errDel != nil {
+			log.Printf("❌ CRITICAL: post %d persisté mais sa suppression n'a pas pu être mise en file : %v", postID, errDel)
+		}
+		media_service.DiscardStagedMedia(context.Background(), userID, stagedIDs)
+		media_service.ReleaseStagedMedia(context.Background(), input.MediaIDs)
+		return 0, err
+	}
+	return postID, nil
 }
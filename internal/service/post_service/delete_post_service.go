@@ -6,6 +6,7 @@ import (
 	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/post_models"
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/algorithm_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service/object_cache_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/security_service"
 )
@@ -20,22 +21,29 @@ func DeletePost(ctx context.Context, input post_models.DeletePostInput) error {
 		return err
 	}
 
+	return RemovePost(ctx, post)
+}
+
+// RemovePost purge un post des caches puis délègue sa suppression aux workers.
+// Partagé par la rétractation de l'auteur et le retrait confirmé par la modération.
+func RemovePost(ctx context.Context, post post_models.PostPayload) error {
 	// ─────────────────────────────────────────────────────────────────────────
 	// 2. PURGE SYNCHRONE DES CACHES (Disparition instantanée)
 	// ─────────────────────────────────────────────────────────────────────────
 	// A. Suppression du Post en RAM
-	_ = object_cache_service.DeletePostFromObjectCache(ctx, input.PostID)
+	_ = object_cache_service.DeletePostFromObjectCache(ctx, post.ID)
 
 	// B. Purge des Commentaires en RAM (ZSET + JSON L1)
-	object_cache_service.PurgePostCommentsFromL1(ctx, input.PostID)
+	object_cache_service.PurgePostCommentsFromL1(ctx, post.ID)
 
 	// C. Suppression du seau LSH
-	_ = algorithm_service.PurgePostVectors(ctx, input.PostID)
+	_ = algorithm_service.PurgePostVectors(ctx, post.ID)
 
-	// D. Purge absolue des Médias associés en RAM
+	// D. Purge absolue des Médias associés en RAM et révocation des liens déjà émis
 	for _, mediaID := range post.MediaIDs {
 		_ = object_cache_service.DeleteMediaFromObjectCache(ctx, mediaID)
 	}
+	_ = cache_service.RevokePostMedia(ctx, post.ID)
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// 3. ENVOI AUX WORKERS POUR CASCADE BDD
@@ -2,12 +2,13 @@ package post_service
 
 import (
 	"context"
+	"errors"
 
 	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/comment_models"
 	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/post_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/mongo"
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
-	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service/object_cache_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/comment_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/media_service"
@@ -27,82 +28,19 @@ func GetPosts(ctx context.Context, input post_models.GetPostInput) []post_models
 			continue
 		}
 
-		// L'auteur a toujours accès à son propre post
-		isAuthor := post.UserID == input.UserID
-
-		// ─────────────────────────────────────────────────────────────────
-		// HYDRATATION DE L'ÉTAT DE RELATION EN O(1) CASCADE (L1->L2->L3)
-		// ─────────────────────────────────────────────────────────────────
-		// state: 0 = Rien, 1 = Follower, 2 = Ami, -1 = Banni
-		relationState := 0
-		if !isAuthor {
-			relationState = cache_service.RelationValue(ctx, post.UserID, input.UserID)
-		}
-
-		// Règle Z : Bannissement (Si l'auteur a bloqué le caller, ou inversement)
-		if relationState == -1 {
-			results = append(results, post_models.GetPostOutput{PostID: id, Error: "Post introuvable ou supprimé"})
-			continue // Mode furtif : on lui fait croire que le post n'existe pas.
-		}
-
 		// ─────────────────────────────────────────────────────────────────
-		// Règle A : Soft Delete / Supprimé (Visibility = -1)
+		// POLITIQUE D'ACCÈS CENTRALISÉE (visibilité × relation × blocages × comptes masqués)
 		// ─────────────────────────────────────────────────────────────────
-		if post.Visibility == -1 {
-			results = append(results, post_models.GetPostOutput{PostID: id, Error: "Post introuvable ou supprimé"})
+		// Évaluée à l'émission des liens : un refus ne produit aucune URL signée.
+		// ⚡ Une URL signée de courte durée par déclinaison ; Media garde l'AVIF le plus large pour les anciens clients
+		sources, err := media_service.PostMediaSources(ctx, post, input.UserID)
+		if err != nil {
+			results = append(results, post_models.GetPostOutput{PostID: id, Error: accessDeniedMessage(err)})
 			continue
 		}
-
-		// ─────────────────────────────────────────────────────────────────
-		// Règle B : Réservé aux abonnés (Visibility = 1)
-		// ─────────────────────────────────────────────────────────────────
-		if post.Visibility == 1 && !isAuthor {
-			// Doit être au moins Abonné (1) ou Ami (2)
-			if relationState < 1 {
-				results = append(results, post_models.GetPostOutput{
-					PostID: id,
-					Error:  "🔒 Ce post est privé et strictement réservé aux abonnés de l'auteur",
-				})
-				continue
-			}
-		}
-
-		// ─────────────────────────────────────────────────────────────────
-		// Règle C : Réservé aux AMIS (Visibility = 2)
-		// ─────────────────────────────────────────────────────────────────
-		if post.Visibility == 2 && !isAuthor {
-			// Doit être strictement Ami (2)
-			if relationState != 2 {
-				results = append(results, post_models.GetPostOutput{
-					PostID: id,
-					Error:  "🤝 Ce post est confidentiel et réservé au cercle d'amis de l'auteur",
-				})
-				continue
-			}
-		}
-
-		// ─────────────────────────────────────────────────────────────────
-		// Règle D : Public (Visibility = 0) ou accès validé
-		// ─────────────────────────────────────────────────────────────────
-
-		var mediaURLs []string
-
-		// ⚡ HYDRATATION DES MEDIAS ET SIGNATURE HMAC
-		for _, mediaID := range post.MediaIDs {
-			// On récupère le fameux storage_path
-			mediaPayload, err := media_service.GetMediaCascade(ctx, mediaID)
-
-			// Si le média existe et n'a pas été supprimé par l'auteur
-			if err == nil && mediaPayload.Visibility {
-				// On génère l'URL avec le vrai storage_path !
-				signedURL := media_service.GenerateWatermarkedURL(
-					mediaPayload.StoragePath, // "users/12/posts/45/uuid.avif"
-					post.UserID,              // L'auteur
-					post.ID,                  // Le Post
-					input.UserID,             // Le Lecteur
-				)
-				mediaURLs = append(mediaURLs, signedURL)
-			}
+		mediaURLs := make([]string, len(sources))
+		for i, set := range sources {
+			mediaURLs[i] = set.Default
 		}
 
 		// ⚡ HYDRATATION DES COMMENTAIRES (Via le service dédié optimisé)
@@ -119,13 +57,26 @@ func GetPosts(ctx context.Context, input post_models.GetPostInput) []post_models
 			PostID:   id,
 			Data:     &val,
 			Media:    mediaURLs, // Le client reçoit les URLs prêtes à l'emploi
-			Comments: comments,  // ✅ Injection instantanée de l'arbre des commentaires
+			Sources:  sources,
+			Comments: comments, // ✅ Injection instantanée de l'arbre des commentaires
 		})
 	}
 
 	return results
 }
 
+// accessDeniedMessage traduit un refus de la politique d'accès en message client.
+// Le mode furtif (blocage, compte masqué, contenu retiré) fait croire que le post n'existe pas.
+func accessDeniedMessage(err error) string {
+	switch {
+	case errors.Is(err, nubo_error.ErrPostFollowersOnly):
+		return "🔒 Ce post est privé et strictement réservé aux abonnés de l'auteur"
+	case errors.Is(err, nubo_error.ErrPostFriendsOnly):
+		return "🤝 Ce post est confidentiel et réservé au cercle d'amis de l'auteur"
+	}
+	return "Post introuvable ou supprimé"
+}
+
 // fetchPostsCascade gère la récupération L1 -> L2 -> L3 pour un batch d'IDs.
 func fetchPostsCascade(ctx context.Context, ids []int64) map[int64]post_models.PostPayload {
 	postsMap := make(map[int64]post_models.PostPayload)
@@ -5,10 +5,15 @@ import (
 	"time"
 
 	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/post_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/revision_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/algorithm_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service/object_cache_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/media_service"
 	"github.com/QuentinRegnier/nubo-backend/internal/service/security_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
 )
 
 // UpdatePost gère la modification en récupérant l'objet complet pour nourrir le Bulk Update des workers.
@@ -25,11 +30,40 @@ func UpdatePost(ctx context.Context, input post_models.UpdatePostInput) error {
 	// ─────────────────────────────────────────────────────────────────────────
 	// 2. APPLICATION DES MODIFICATIONS
 	// ─────────────────────────────────────────────────────────────────────────
+	// L'état d'avant est archivé pour l'historique des modifications (fiche d'administration)
+	revision := revision_models.RevisionPayload{
+		ID:         pkg.GenerateID(),
+		TargetType: variables.ReportTargetPost,
+		TargetID:   post.ID,
+		EditorID:   input.UserID,
+		Snapshot:   revision_models.PostSnapshot(post),
+	}
+
 	post.Content = input.Content
 	post.Hashtags = input.Hashtags
 	post.Identifiers = input.Identifiers
 	post.Location = input.Location
-	post.Visibility = input.Visibility
+	// Un post masqué par la modération le reste jusqu'à la clôture de son dossier ; un post dont les
+	// médias sont en traitement (ou ont échoué) reste masqué, la visibilité choisie sera appliquée à sa publication.
+	// Un post en échec redevient publiable une fois tous ses médias servables (quarantaine levée)
+	previousVisibility := post.Visibility
+	switch post.Visibility {
+	case variables.VisibilityUnderReview:
+	case variables.VisibilityMediaFailed:
+		if media_service.AllMediaReady(ctx, post.MediaIDs) {
+			post.Visibility = input.Visibility
+		}
+	case variables.VisibilityProcessing:
+		if !media_service.DeferPostVisibility(ctx, post.ID, input.Visibility) {
+			post.Visibility = input.Visibility
+		}
+	default:
+		post.Visibility = input.Visibility
+	}
+	// Audience restreinte (public -> abonnés -> amis) : les liens médias déjà émis sont révoqués
+	if previousVisibility >= 0 && post.Visibility > previousVisibility {
+		_ = cache_service.RevokePostMedia(ctx, post.ID)
+	}
 	post.UpdatedAt = time.Now().UTC()
 
 	// L'IA locale (Edge Computing) saura qu'il faut recalculer ses affinités
@@ -51,5 +85,16 @@ func UpdatePost(ctx context.Context, input post_models.UpdatePostInput) error {
 	}
 
 	// 2. Envoi de l'objet COMPLET dans la file asynchrone pour que tes bulkUpdate fonctionnent
-	return redis.EnqueueDB(ctx, post.ID, 0, redis.EntityPost, redis.ActionUpdate, post, redis.TargetAll)
+	if err := redis.EnqueueDB(ctx, post.ID, 0, redis.EntityPost, redis.ActionUpdate, post, redis.TargetAll); err != nil {
+		return err
+	}
+
+	// Publication d'un post resté masqué par ses médias (traitement ou échec levé) : il n'a jamais été distribué
+	if (previousVisibility == variables.VisibilityProcessing || previousVisibility == variables.VisibilityMediaFailed) && post.Visibility >= 0 {
+		cache_service.FanOutPostToMailboxes(ctx, post.UserID, post.ID, post.Visibility)
+	}
+
+	// 3. Historique (L3 uniquement, append-only)
+	revision.EditedAt = post.UpdatedAt
+	return redis.EnqueueDB(ctx, revision.ID, 0, redis.EntityRevision, redis.ActionCreate, revision, redis.TargetPostgres)
 }
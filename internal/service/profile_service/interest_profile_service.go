@@ -0,0 +1,160 @@
+package profile_service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/profile_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/algorithm_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// ============================================================================
+// 1. SYNCHRONISATION (API)
+// ============================================================================
+
+// SyncInterestProfile enregistre le vecteur d'intérêt calculé par le client (EMA, README §1.2).
+// L'intégrité du corps est déjà garantie par la signature HMAC de la route ; le service en vérifie la
+// cohérence : version, dimension, norme L2 et horodatage. Le profil est écrit en L1 puis persisté en L3
+// (Write-Behind). Le feed personnalisé en cache est invalidé quand le vecteur dérive au-delà de δ.
+func SyncInterestProfile(ctx context.Context, userID int64, input profile_models.SyncProfileInput) (profile_models.SyncProfileOutput, error) {
+	now := time.Now().UTC()
+
+	// 1. Validation (avant le verrou : une requête refusée ne consomme pas de créneau)
+	if input.Version != variables.VectorVersion {
+		return profile_models.SyncProfileOutput{}, nubo_error.ErrProfileVersionMismatch
+	}
+	if err := validateInterestVector(input.Vector); err != nil {
+		return profile_models.SyncProfileOutput{}, err
+	}
+	if input.ComputedAt.After(now.Add(variables.ProfileClockSkewSeconds * time.Second)) {
+		return profile_models.SyncProfileOutput{}, nubo_error.ErrInvalidProfileClock
+	}
+
+	previous, err := LoadInterestProfile(ctx, userID)
+	if err != nil {
+		return profile_models.SyncProfileOutput{}, err
+	}
+	if previous.Syncs > 0 && !input.ComputedAt.After(previous.ComputedAt) {
+		return profile_models.SyncProfileOutput{}, nubo_error.ErrProfileStale
+	}
+
+	// 2. Une synchronisation par utilisateur et par intervalle (le client pousse périodiquement, pas à chaque signal)
+	claimed, err := redis.ProfileSyncs.SetNX(ctx, userID, now.Unix(), 0)
+	if err != nil {
+		return profile_models.SyncProfileOutput{}, fmt.Errorf("erreur lors de la réservation de la synchronisation: %w", err)
+	}
+	if !claimed {
+		return profile_models.SyncProfileOutput{}, nubo_error.ErrProfileSyncTooFrequent
+	}
+
+	// 3. Nouveau profil (les interactions déclarées sont plafonnées : la confiance ne s'achète pas en une requête)
+	interactions := int64(min(input.Interactions, variables.ProfileMaxInteractionsPerSync))
+	profile := profile_models.InterestProfilePayload{
+		ID:           userID,
+		Version:      variables.VectorVersion,
+		Vector:       input.Vector,
+		Interactions: previous.Interactions + interactions,
+		Syncs:        previous.Syncs + 1,
+		ComputedAt:   input.ComputedAt.UTC(),
+		SyncedAt:     now,
+		CreatedAt:    previous.CreatedAt,
+	}
+	action := redis.ActionUpdate
+	if previous.Syncs == 0 {
+		action = redis.ActionCreate
+		profile.CreatedAt = now
+	}
+
+	// 4. L1 puis L3 (Write-Behind)
+	if err := redis.InterestProfiles.SetObject(ctx, userID, profile); err != nil {
+		_ = redis.ProfileSyncs.DeleteObject(ctx, userID)
+		return profile_models.SyncProfileOutput{}, fmt.Errorf("erreur lors de l'écriture du profil en cache: %w", err)
+	}
+	if err := redis.EnqueueDB(ctx, userID, userID, redis.EntityInterestProfile, action, profile, redis.TargetPostgres); err != nil {
+		// Le profil ne sera jamais persisté : L1 retombe sur L3 et le créneau est rendu au client
+		_ = redis.InterestProfiles.DeleteObject(ctx, userID)
+		_ = redis.ProfileSyncs.DeleteObject(ctx, userID)
+		return profile_models.SyncProfileOutput{}, fmt.Errorf("erreur lors de la mise en file du profil: %w", err)
+	}
+
+	// 5. Dérive : un premier profil (ou un changement de version) invalide toujours le feed calculé sans lui
+	var drifted bool
+	if previous.Syncs == 0 || previous.Version != profile.Version {
+		_ = redis.FeedsPersonalized.DeleteObject(ctx, userID)
+		drifted = true
+	} else {
+		drifted = algorithm_service.InvalidatePersonalizedFeedCache(ctx, userID, previous.Vector, profile.Vector)
+	}
+
+	return profile_models.SyncProfileOutput{
+		Version:    profile.Version,
+		Confidence: ProfileConfidence(profile, now),
+		Drifted:    drifted,
+		SyncedAt:   now,
+	}, nil
+}
+
+// validateInterestVector vérifie que u ∈ R^224, que toutes ses composantes sont finies et que ‖u‖₂ = 1.
+func validateInterestVector(vec []float32) error {
+	if len(vec) != variables.VectorDimTotal {
+		return nubo_error.ErrInvalidProfileVector
+	}
+	var sumSq float64
+	for _, v := range vec {
+		f := float64(v)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nubo_error.ErrInvalidProfileVector
+		}
+		sumSq += f * f
+	}
+	if math.Abs(math.Sqrt(sumSq)-1) > variables.VectorNormTolerance {
+		return nubo_error.ErrInvalidProfileVector
+	}
+	return nil
+}
+
+// ============================================================================
+// 2. LECTURE (Feed)
+// ============================================================================
+
+// LoadInterestProfile lit le profil en L1, puis en L3 (réhydratation). Un utilisateur sans profil renvoie un
+// payload vide (Syncs == 0), mémorisé en L1 pour épargner PostgreSQL à chaque chargement du feed.
+func LoadInterestProfile(ctx context.Context, userID int64) (profile_models.InterestProfilePayload, error) {
+	var profile profile_models.InterestProfilePayload
+	if err := redis.InterestProfiles.GetObject(ctx, userID, &profile); err == nil {
+		return profile, nil
+	}
+
+	profile, err := postgres.FuncLoadInterestProfile(ctx, userID)
+	if err != nil {
+		return profile_models.InterestProfilePayload{}, err
+	}
+	profile.ID = userID
+	_ = redis.InterestProfiles.SetObject(ctx, userID, profile)
+	return profile, nil
+}
+
+// LoadUserVector renvoie le vecteur û et la confiance à transmettre au feed personnalisé.
+// Sans profil exploitable (absent, d'une autre version ou illisible), renvoie (nil, 0) : le feed reste trié par tendance.
+func LoadUserVector(ctx context.Context, userID int64) ([]float32, float64) {
+	profile, err := LoadInterestProfile(ctx, userID)
+	if err != nil || profile.Syncs == 0 || profile.Version != variables.VectorVersion || len(profile.Vector) != variables.VectorDimTotal {
+		return nil, 0
+	}
+	return profile.Vector, ProfileConfidence(profile, time.Now())
+}
+
+// ProfileConfidence estime la fiabilité du profil dans [0, 1] :
+// c = (1 − e^(−n/ProfileConfidenceScale)) · 2^(−âge/ProfileHalfLifeDays)
+// n croît avec les signaux intégrés par l'EMA ; l'âge est compté depuis le calcul côté client.
+func ProfileConfidence(profile profile_models.InterestProfilePayload, now time.Time) float64 {
+	volume := 1 - math.Exp(-float64(profile.Interactions)/variables.ProfileConfidenceScale)
+	ageDays := math.Max(0, now.Sub(profile.ComputedAt).Hours()/24)
+	return volume * math.Exp2(-ageDays/variables.ProfileHalfLifeDays)
+}
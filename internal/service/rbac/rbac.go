@@ -0,0 +1,93 @@
+package rbac
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/auth_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/mongo"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// ============================================================================
+// 🔐 CONTRÔLE D'ACCÈS PAR RÔLE (RBAC)
+// ============================================================================
+// Les rôles sont les grades existants (auth.users.grade) ; chaque grade accorde un jeu de
+// permissions fines, cumulatif : un administrateur a tout ce qu'a un modérateur.
+
+// Permission est une capacité élémentaire vérifiée par le middleware RequirePermission.
+type Permission string
+
+const (
+	PermReportsView     Permission = "reports:view"      // Lister et lire les signalements
+	PermReportsResolve  Permission = "reports:resolve"   // Prendre en charge, escalader, clore un signalement
+	PermReportsOverride Permission = "reports:override"  // Traiter les dossiers escaladés ou assignés à un autre
+	PermUsersSanction   Permission = "users:sanction"    // Bannir, restreindre, avertir
+	PermAppealsView     Permission = "appeals:view"      // Lister et lire les appels
+	PermAppealsResolve  Permission = "appeals:resolve"   // Prendre en charge et trancher un appel
+	PermPrivateInfoView Permission = "private_info:view" // Fiches détaillées (email, téléphone, IP, sessions)
+	PermAuditView       Permission = "audit:view"        // Consulter le journal d'audit
+	PermForensicRun     Permission = "forensic:run"      // Analyser une image fuitée et ouvrir un dossier contre son responsable
+	PermGradesManage    Permission = "grades:manage"     // Promouvoir / rétrograder un utilisateur
+	PermStorageManage   Permission = "storage:manage"    // Lancer le ramasse-miettes du stockage et lire ses rapports
+)
+
+// gradePermissions liste les permissions propres à chaque grade (hors héritage).
+var gradePermissions = map[int][]Permission{
+	variables.GradeModerator: {PermReportsView, PermReportsResolve, PermUsersSanction, PermAppealsView, PermAppealsResolve},
+	variables.GradeAdmin:     {PermReportsOverride, PermPrivateInfoView, PermAuditView, PermForensicRun, PermGradesManage, PermStorageManage},
+}
+
+// Subject est l'utilisateur dont on évalue les droits.
+type Subject struct {
+	UserID int64
+	Grade  int
+}
+
+// Can indique si le grade accorde la permission (héritage des grades inférieurs compris).
+func Can(grade int, perm Permission) bool {
+	for g := variables.GradeNormal; g <= grade; g++ {
+		for _, p := range gradePermissions[g] {
+			if p == perm {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PermissionsFor renvoie l'ensemble des permissions effectives d'un grade.
+func PermissionsFor(grade int) []Permission {
+	var perms []Permission
+	for g := variables.GradeNormal; g <= grade; g++ {
+		perms = append(perms, gradePermissions[g]...)
+	}
+	return perms
+}
+
+// LoadSubject lit le grade depuis les bases de persistance (L2 puis L3), jamais depuis le Speed Cache :
+// une rétrogradation doit prendre effet dès la requête suivante. Un compte banni ou désactivé n'a aucun droit.
+func LoadSubject(ctx context.Context, userID int64) (Subject, error) {
+	user, err := mongo.MongoLoadUser(userID, "", "", "")
+	if err != nil || user.ID == 0 {
+		user, err = postgres.FuncLoadUser(userID, "", "", "")
+		if err != nil {
+			return Subject{}, fmt.Errorf("chargement du grade: %w", err)
+		}
+	}
+	return subjectOf(user)
+}
+
+func subjectOf(user auth_models.UserPayload) (Subject, error) {
+	switch {
+	case user.ID == 0:
+		return Subject{}, nubo_error.ErrNotFound
+	case user.Banned:
+		return Subject{}, nubo_error.ErrBanned
+	case user.Desactivated:
+		return Subject{}, nubo_error.ErrDesactivated
+	}
+	return Subject{UserID: user.ID, Grade: user.Grade}, nil
+}
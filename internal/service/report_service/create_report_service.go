@@ -7,11 +7,12 @@ import (
 	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/report_models"
 	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
 	"github.com/QuentinRegnier/nubo-backend/internal/variables"
 )
 
 // SubmitReport génère le signalement et l'envoie aux workers pour persistance.
-func SubmitReport(ctx context.Context, input report_models.CreateReportInput) error {
+func SubmitReport(ctx context.Context, input report_models.CreateReportInput, ipAddress string) error {
 
 	now := time.Now().UTC()
 
@@ -30,5 +31,14 @@ func SubmitReport(ctx context.Context, input report_models.CreateReportInput) er
 	// On envoie dans la file d'attente.
 	// L'EntityReport n'existe peut-être pas encore dans tes constantes Redis, il faudra l'ajouter !
 	// ActionCreate = On crée un nouveau signalement. TargetPostgres = Seulement besoin du L3 !
-	return redis.EnqueueDB(ctx, payload.ID, 0, redis.EntityReport, redis.ActionCreate, payload, redis.TargetPostgres)
+	if err := redis.EnqueueDB(ctx, payload.ID, 0, redis.EntityReport, redis.ActionCreate, payload, redis.TargetPostgres); err != nil {
+		return err
+	}
+
+	audit.Record(ctx, audit.User(input.UserID, ipAddress), audit.ActionReportCreate, audit.On(audit.TargetReport, payload.ID), map[string]any{
+		"target_type": input.TargetType,
+		"target_ids":  input.TargetIDs,
+		"category":    input.Category,
+	})
+	return nil
 }
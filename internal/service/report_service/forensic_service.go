@@ -0,0 +1,243 @@
+package report_service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	forensic "github.com/QuentinRegnier/nubo-backend/admin/service"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/post_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/report_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/blobstore"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg/watermark"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/mongo"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/auth_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service/object_cache_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// ============================================================================
+// INVESTIGATION FORENSIQUE : IMAGE FUITÉE -> DOSSIER CONTRE LE RESPONSABLE
+// ============================================================================
+// Chaque image servie est tatouée (DCT) avec l'auteur, le post, le lecteur et l'heure d'émission du lien.
+// Une image retrouvée hors de Nubo désigne donc le compte qui l'a fait sortir. Le tatouage est corrigé
+// (Reed-Solomon) après compression, recadrage ou redimensionnement : la confiance estimée et la cohérence
+// des identifiants résolus conditionnent l'ouverture du dossier. L'image est versée telle quelle au dossier (MinIO).
+
+// InvestigateLeak analyse une image fuitée, résout les identifiants tatoués en cascade et, si la lecture est
+// fiable et cohérente, ouvre (ou complète) le dossier contre le responsable avec l'image en preuve.
+// Une lecture non concluante n'est pas une erreur : Findings explique pourquoi aucun dossier n'a été ouvert.
+func InvestigateLeak(ctx context.Context, m Moderator, image []byte) (report_models.ForensicInvestigationOutput, error) {
+	// 1. Extraction du tatouage
+	wm, err := forensic.ExtractForensicData(image)
+	if err != nil {
+		return report_models.ForensicInvestigationOutput{}, fmt.Errorf("%w: %v", nubo_error.ErrWatermarkUnreadable, err)
+	}
+
+	out := report_models.ForensicInvestigationOutput{
+		Watermark: report_models.ForensicWatermark{
+			AuthorID:  wm.OriginalAuthorID,
+			PostID:    wm.PostID,
+			CulpritID: wm.CulpritID,
+			LeakedAt:  wm.LeakTimestamp.UTC(),
+		},
+		Stats:      forensicStats(wm.Stats),
+		Confidence: wm.Confidence,
+	}
+
+	// 2. Résolution des identifiants
+	authorID, postID, culpritID := wm.OriginalAuthorID, wm.PostID, wm.CulpritID
+
+	out.Author = loadUserLite(authorID)
+	out.Culprit = loadUserLite(culpritID)
+	if post, found := loadAnyPost(ctx, postID); found {
+		post.Vector = nil
+		out.Post = &post
+	}
+	out.Findings = forensicFindings(out)
+
+	target := audit.On(audit.TargetNone, 0)
+	if out.Culprit != nil {
+		target = audit.On(audit.TargetUser, out.Culprit.ID)
+	}
+	if len(out.Findings) > 0 {
+		audit.Record(ctx, m.actor(), audit.ActionForensic, target, map[string]any{
+			"confidence": out.Confidence,
+			"findings":   out.Findings,
+		})
+		return out, nil
+	}
+
+	// 3. Preuve : l'image est conservée telle quelle (adressée par son empreinte, jamais réencodée)
+	sum := sha256.Sum256(image)
+	evidence := report_models.ForensicEvidencePayload{
+		ID:             pkg.GenerateID(),
+		InvestigatorID: m.UserID,
+		AuthorID:       authorID,
+		PostID:         postID,
+		CulpritID:      culpritID,
+		LeakedAt:       out.Watermark.LeakedAt,
+		ContentType:    http.DetectContentType(image),
+		SizeBytes:      int64(len(image)),
+		SHA256:         hex.EncodeToString(sum[:]),
+		Confidence:     out.Confidence,
+		Stats:          out.Stats,
+	}
+	evidence.ObjectPath = fmt.Sprintf("forensic/%d/%s", culpritID, evidence.SHA256)
+
+	if err := storeEvidence(ctx, evidence, image); err != nil {
+		return report_models.ForensicInvestigationOutput{}, err
+	}
+
+	// 4. Dossier contre le responsable (la même image soumise deux fois n'est versée qu'une fois)
+	rc, evidence, inserted, err := postgres.FuncOpenForensicCase(ctx, evidence,
+		variables.ForensicCaseCategory, variables.ReportCategoryPriority[variables.ForensicCaseCategory])
+	if err != nil {
+		return report_models.ForensicInvestigationOutput{}, err
+	}
+	evidence.URL = evidenceURL(ctx, evidence)
+	out.Case, out.Evidence = &rc, &evidence
+
+	audit.Record(ctx, m.actor(), audit.ActionForensic, target, map[string]any{
+		"case_id":     rc.ID,
+		"evidence_id": evidence.ID,
+		"post_id":     postID,
+		"author_id":   authorID,
+		"confidence":  out.Confidence,
+		"duplicate":   !inserted,
+	})
+	return out, nil
+}
+
+// --- HELPERS ---
+
+// forensicFindings relève les incohérences qui interdisent d'accuser le responsable désigné.
+func forensicFindings(out report_models.ForensicInvestigationOutput) []string {
+	findings := []string{}
+	if out.Confidence < variables.ForensicMinConfidence {
+		findings = append(findings, "low_confidence")
+	}
+	if out.Author == nil {
+		findings = append(findings, "author_not_found")
+	}
+	if out.Post == nil {
+		findings = append(findings, "post_not_found")
+	}
+	if out.Culprit == nil {
+		findings = append(findings, "culprit_not_found")
+	}
+	if out.Author != nil && out.Post != nil && out.Post.UserID != out.Author.ID {
+		findings = append(findings, "author_mismatch")
+	}
+	if out.Author != nil && out.Culprit != nil && out.Author.ID == out.Culprit.ID {
+		findings = append(findings, "self_leak") // L'auteur diffuse sa propre image
+	}
+
+	// Le lien tatoué est émis à la lecture : jamais avant la publication, jamais dans le futur
+	skew := variables.ForensicClockSkewMinutes * time.Minute
+	if out.Post != nil && out.Watermark.LeakedAt.Before(out.Post.CreatedAt.Add(-skew)) {
+		findings = append(findings, "leak_before_post")
+	}
+	if out.Watermark.LeakedAt.After(time.Now().UTC().Add(skew)) {
+		findings = append(findings, "leak_in_future")
+	}
+	return findings
+}
+
+// forensicStats recopie les statistiques d'extraction dans le modèle exposé et persisté.
+func forensicStats(s watermark.Stats) report_models.ForensicStats {
+	return report_models.ForensicStats{
+		Scale:            s.Scale,
+		Tiles:            s.Tiles,
+		SyncScore:        s.SyncScore,
+		RawBitErrorRate:  s.RawBitErrorRate,
+		FoldedBitErrors:  s.FoldedBitErrors,
+		CorrectedSymbols: s.CorrectedSymbols,
+		Attempts:         s.Attempts,
+	}
+}
+
+// loadUserLite lit un utilisateur en cascade L2 -> L3 (bannis et désactivés compris). nil : introuvable.
+// Le Speed Cache n'est pas réhydraté : une investigation ne doit rien écrire dans le chemin de lecture.
+func loadUserLite(userID int64) *models.UserLiteRequest {
+	if userID == 0 {
+		return nil
+	}
+	u, err := auth_service.LoadUserCascade(userID, "")
+	if err != nil || u.ID == 0 {
+		return nil
+	}
+	return &models.UserLiteRequest{
+		ID:               u.ID,
+		Username:         u.Username,
+		FirstName:        u.FirstName,
+		LastName:         u.LastName,
+		ProfilePictureID: u.ProfilePictureID,
+		Bio:              u.Bio,
+		Grade:            u.Grade,
+		Badges:           u.Badges,
+	}
+}
+
+// loadAnyPost lit un post en cascade L1 -> L2 -> L3 quelle que soit sa visibilité :
+// une image peut fuiter d'un post supprimé ou masqué depuis.
+func loadAnyPost(ctx context.Context, postID int64) (post_models.PostPayload, bool) {
+	if postID == 0 {
+		return post_models.PostPayload{}, false
+	}
+	if p, err := object_cache_service.GetPostFromObjectCache(ctx, postID); err == nil {
+		return p, true
+	}
+	if posts, err := mongo.MongoLoadPosts([]int64{postID}); err == nil && len(posts) > 0 {
+		return posts[0], true
+	}
+	if p, err := postgres.FuncAdminLoadPost(ctx, postID); err == nil {
+		return p, true
+	}
+	return post_models.PostPayload{}, false
+}
+
+// storeEvidence dépose l'image dans MinIO. Le chemin dépend du contenu : une nouvelle soumission réécrit
+// les mêmes octets, et l'objet n'est jamais supprimé en cas d'échec (il peut appartenir à un dossier antérieur).
+func storeEvidence(ctx context.Context, e report_models.ForensicEvidencePayload, image []byte) error {
+	err := blobstore.Store.Put(ctx, forensicBucket(), e.ObjectPath, bytes.NewReader(image), e.SizeBytes,
+		blobstore.PutOptions{
+			ContentType: e.ContentType,
+			Metadata:    map[string]string{"sha256": e.SHA256, "culprit-id": strconv.FormatInt(e.CulpritID, 10)},
+		})
+	if err != nil {
+		return fmt.Errorf("dépôt de la preuve dans MinIO: %w", err)
+	}
+	return nil
+}
+
+// evidenceURL signe un lien de consultation temporaire vers une preuve (vide si la signature échoue).
+func evidenceURL(ctx context.Context, e report_models.ForensicEvidencePayload) string {
+	ttl := time.Duration(variables.ForensicEvidenceURLTTLSeconds) * time.Second
+	signed, err := blobstore.Store.Presign(ctx, forensicBucket(), e.ObjectPath, ttl, nil)
+	if err != nil {
+		log.Printf("⚠️ Signature du lien de la preuve %d : %v", e.ID, err)
+		return ""
+	}
+	return signed.String()
+}
+
+// forensicBucket renvoie le bucket des preuves (bucket principal par défaut).
+func forensicBucket() string {
+	if b := os.Getenv("MINIO_FORENSIC_BUCKET_NAME"); b != "" {
+		return b
+	}
+	return os.Getenv("MINIO_BUCKET_NAME")
+}
@@ -0,0 +1,92 @@
+package report_service
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"log"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/report_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/infrastructure/blobstore"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg/phash"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/media_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// ============================================================================
+// EMPREINTES DES CONTENUS JUGÉS : BLOCAGE OU LEVÉE DE QUARANTAINE
+// ============================================================================
+// La clôture d'un dossier visant un post tranche aussi le sort de ses médias. Une infraction confirmée dans
+// une catégorie de MediaHashBlockCategories bloque leurs empreintes (et celles des preuves forensiques du
+// dossier) : tout nouvel envoi proche sera mis en quarantaine. Un dossier clos sans infraction lève la
+// quarantaine des médias retenus à tort.
+
+// settleCaseMedia applique la décision d'un dossier clos aux empreintes de ses médias.
+// Appelé avant releaseTakedown, tant que le post retiré et ses médias sont lisibles.
+func settleCaseMedia(ctx context.Context, rc report_models.ReportCasePayload) {
+	restore := rc.Resolution == variables.ReportResolutionNoViolation || rc.Resolution == variables.ReportResolutionDuplicate
+	if !restore && !variables.MediaHashBlockCategories[rc.Category] {
+		return
+	}
+
+	var mediaIDs []int64
+	if rc.TargetType == variables.ReportTargetPost {
+		if post, found := loadAnyPost(ctx, rc.TargetID); found {
+			mediaIDs = post.MediaIDs
+		}
+	}
+
+	if restore {
+		if n := media_service.ReleaseQuarantinedMedia(ctx, rc.ID, mediaIDs); n > 0 {
+			log.Printf("✅ Dossier %d : quarantaine levée pour %d média(s)", rc.ID, n)
+		}
+		return
+	}
+
+	blocked := media_service.BlockCaseMedia(ctx, rc.ID, rc.Category, mediaIDs)
+	blocked += blockCaseEvidence(ctx, rc)
+	if blocked > 0 {
+		log.Printf("⛔ Dossier %d : %d empreinte(s) bloquée(s)", rc.ID, blocked)
+	}
+}
+
+// blockCaseEvidence bloque les empreintes des images fuitées versées au dossier. Retourne le nombre bloqué.
+func blockCaseEvidence(ctx context.Context, rc report_models.ReportCasePayload) int {
+	evidence, err := postgres.FuncLoadCaseEvidence(ctx, rc.ID)
+	if err != nil {
+		log.Printf("⚠️ Dossier %d : preuves non lues pour le blocage d'empreintes : %v", rc.ID, err)
+		return 0
+	}
+
+	blocked := 0
+	for _, e := range evidence {
+		h, err := evidenceHash(ctx, e)
+		if err != nil {
+			log.Printf("⚠️ Empreinte de la preuve %d non calculée : %v", e.ID, err)
+			continue
+		}
+		if err := media_service.BlockMediaHash(ctx, e.ID, rc.ID, rc.Category, h); err != nil {
+			log.Printf("⚠️ Empreinte de la preuve %d non bloquée : %v", e.ID, err)
+			continue
+		}
+		blocked++
+	}
+	return blocked
+}
+
+// evidenceHash relit une preuve dans le stockage et calcule son empreinte.
+func evidenceHash(ctx context.Context, e report_models.ForensicEvidencePayload) (phash.Hash, error) {
+	obj, err := blobstore.Store.Get(ctx, forensicBucket(), e.ObjectPath)
+	if err != nil {
+		return phash.Hash{}, fmt.Errorf("erreur lors de la lecture de la preuve: %w", err)
+	}
+	defer obj.Close()
+
+	img, _, err := image.Decode(io.LimitReader(obj, variables.ForensicMaxUploadBytes))
+	if err != nil {
+		return phash.Hash{}, fmt.Errorf("erreur lors du décodage de la preuve: %w", err)
+	}
+	return phash.Compute(img), nil
+}
@@ -0,0 +1,229 @@
+package report_service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/report_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/rbac"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// ============================================================================
+// FILE DE MODÉRATION : TRI, LISTE, PRISE EN CHARGE, ESCALADE, CLÔTURE
+// ============================================================================
+// Les signalements arrivent par le Write-Behind (SubmitReport) puis sont agrégés en dossiers
+// par TriageNewReports. Toutes les transitions sont des compare-and-set en L3 sur l'état observé :
+// deux modérateurs ne peuvent jamais prendre le même dossier.
+
+// Moderator est l'auteur d'une action de modération (grade injecté par RequirePermission).
+type Moderator struct {
+	UserID int64
+	Grade  int
+	IP     string
+}
+
+func (m Moderator) actor() audit.Actor { return audit.User(m.UserID, m.IP) }
+
+// TriageNewReports rattache les nouveaux signalements à leur dossier (cron). Retourne le nombre rattaché.
+func TriageNewReports(ctx context.Context) int {
+	n, err := postgres.ProcTriageReports(ctx, variables.ReportTriageBatchSize, variables.ReportCategoryPriority)
+	if err != nil {
+		log.Printf("⚠️ Tri des signalements : %v", err)
+	}
+	return n
+}
+
+// ListReportCases renvoie une page de la file, dossiers les plus urgents en tête.
+func ListReportCases(ctx context.Context, in report_models.ListReportCasesInput) (report_models.ListReportCasesOutput, error) {
+	if in.Limit <= 0 {
+		in.Limit = variables.ReportQueueDefaultLimit
+	}
+	if in.Limit > variables.ReportQueueMaxLimit {
+		in.Limit = variables.ReportQueueMaxLimit
+	}
+	if in.Offset < 0 {
+		in.Offset = 0
+	}
+
+	cases, err := postgres.FuncListReportCases(ctx, in)
+	if err != nil {
+		return report_models.ListReportCasesOutput{}, err
+	}
+	if cases == nil {
+		cases = []report_models.ReportCasePayload{}
+	}
+	return report_models.ListReportCasesOutput{Cases: cases}, nil
+}
+
+// GetReportCase renvoie un dossier et l'ensemble de ses signalements (et, pour un compte, les preuves d'investigation
+// avec un lien signé).
+func GetReportCase(ctx context.Context, caseID int64) (report_models.ReportCaseDetailOutput, error) {
+	rc, err := loadCase(ctx, caseID)
+	if err != nil {
+		return report_models.ReportCaseDetailOutput{}, err
+	}
+	reports, err := postgres.FuncLoadCaseReports(ctx, caseID)
+	if err != nil {
+		return report_models.ReportCaseDetailOutput{}, err
+	}
+	if reports == nil {
+		reports = []report_models.ReportPayload{}
+	}
+	out := report_models.ReportCaseDetailOutput{Case: rc, Reports: reports}
+	if t, err := postgres.FuncLoadCaseTakedown(ctx, caseID); err == nil {
+		out.Takedown = &t
+	}
+	if rc.TargetType == variables.ReportTargetUser {
+		evidence, err := postgres.FuncLoadCaseEvidence(ctx, caseID)
+		if err != nil {
+			return report_models.ReportCaseDetailOutput{}, err
+		}
+		for i := range evidence {
+			evidence[i].URL = evidenceURL(ctx, evidence[i])
+		}
+		out.Evidence = evidence
+	}
+	return out, nil
+}
+
+// AssignReportCase prend en charge un dossier pour soi (AssigneeID == 0) ou l'assigne à un autre modérateur.
+// Un modérateur peut prendre un dossier en attente ou céder le sien ; les dossiers escaladés ou
+// déjà pris par un autre exigent reports:override.
+func AssignReportCase(ctx context.Context, m Moderator, in report_models.AssignReportCaseInput) (report_models.ReportCasePayload, error) {
+	assigneeID := in.AssigneeID
+	if assigneeID == 0 {
+		assigneeID = m.UserID
+	}
+	override := rbac.Can(m.Grade, rbac.PermReportsOverride)
+
+	return transitionCase(ctx, m, in.CaseID, func(rc report_models.ReportCasePayload) (report_models.ReportCaseTransition, error) {
+		switch {
+		case rc.State == variables.ReportStateClosed:
+			return report_models.ReportCaseTransition{}, nubo_error.ErrCaseUnavailable
+		case rc.State == variables.ReportStateEscalated && !override:
+			return report_models.ReportCaseTransition{}, nubo_error.ErrCaseUnavailable
+		case rc.State == variables.ReportStateInProgress && rc.AssigneeID != m.UserID && !override:
+			return report_models.ReportCaseTransition{}, nubo_error.ErrNotAssignee
+		}
+
+		// L'assigné doit pouvoir traiter le dossier (et les escalades si c'en est une)
+		if assigneeID != m.UserID {
+			subject, err := rbac.LoadSubject(ctx, assigneeID)
+			if err != nil || !rbac.Can(subject.Grade, rbac.PermReportsResolve) ||
+				(rc.State == variables.ReportStateEscalated && !rbac.Can(subject.Grade, rbac.PermReportsOverride)) {
+				return report_models.ReportCaseTransition{}, nubo_error.ErrInvalidAssignee
+			}
+		}
+
+		return report_models.ReportCaseTransition{
+			NewState:   variables.ReportStateInProgress,
+			AssigneeID: assigneeID,
+		}, nil
+	})
+}
+
+// EscalateReportCase transmet un dossier pris en charge aux administrateurs (il est désassigné).
+func EscalateReportCase(ctx context.Context, m Moderator, in report_models.EscalateReportCaseInput) (report_models.ReportCasePayload, error) {
+	return transitionCase(ctx, m, in.CaseID, func(rc report_models.ReportCasePayload) (report_models.ReportCaseTransition, error) {
+		if rc.State != variables.ReportStateInProgress {
+			return report_models.ReportCaseTransition{}, nubo_error.ErrCaseUnavailable
+		}
+		if rc.AssigneeID != m.UserID {
+			return report_models.ReportCaseTransition{}, nubo_error.ErrNotAssignee
+		}
+		return report_models.ReportCaseTransition{
+			NewState:       variables.ReportStateEscalated,
+			EscalationNote: in.Note,
+		}, nil
+	})
+}
+
+// CloseReportCase clôt un dossier avec un code de résolution. Un modérateur ne clôt que ses dossiers
+// en cours ; reports:override permet de clore n'importe quel dossier ouvert.
+// Un contenu masqué automatiquement est rétabli (aucune infraction, doublon) ou supprimé (infraction confirmée).
+func CloseReportCase(ctx context.Context, m Moderator, in report_models.CloseReportCaseInput) (report_models.ReportCasePayload, error) {
+	override := rbac.Can(m.Grade, rbac.PermReportsOverride)
+
+	rc, err := transitionCase(ctx, m, in.CaseID, func(rc report_models.ReportCasePayload) (report_models.ReportCaseTransition, error) {
+		if rc.State == variables.ReportStateClosed {
+			return report_models.ReportCaseTransition{}, nubo_error.ErrCaseUnavailable
+		}
+		if !override {
+			if rc.State != variables.ReportStateInProgress {
+				return report_models.ReportCaseTransition{}, nubo_error.ErrCaseUnavailable
+			}
+			if rc.AssigneeID != m.UserID {
+				return report_models.ReportCaseTransition{}, nubo_error.ErrNotAssignee
+			}
+		}
+		return report_models.ReportCaseTransition{
+			NewState:       variables.ReportStateClosed,
+			AssigneeID:     rc.AssigneeID,
+			Resolution:     in.Resolution,
+			ResolutionNote: in.Note,
+			ResolvedBy:     m.UserID,
+		}, nil
+	})
+	if err != nil {
+		return rc, err
+	}
+
+	settleCaseMedia(ctx, rc)
+	releaseTakedown(ctx, m, rc)
+	return rc, nil
+}
+
+// --- HELPERS ---
+
+// transitionCase lit le dossier, laisse `rule` valider et construire la transition, puis l'applique
+// en compare-and-set sur l'état et l'assigné observés : une modification concurrente fait échouer l'appel.
+func transitionCase(
+	ctx context.Context,
+	m Moderator,
+	caseID int64,
+	rule func(rc report_models.ReportCasePayload) (report_models.ReportCaseTransition, error),
+) (report_models.ReportCasePayload, error) {
+	rc, err := loadCase(ctx, caseID)
+	if err != nil {
+		return report_models.ReportCasePayload{}, err
+	}
+
+	t, err := rule(rc)
+	if err != nil {
+		return report_models.ReportCasePayload{}, err
+	}
+	t.CaseID = rc.ID
+	t.AllowedStates = []int{rc.State}
+	t.ExpectAssignee = rc.AssigneeID
+
+	updated, ok, err := postgres.FuncTransitionReportCase(ctx, t)
+	if err != nil {
+		return report_models.ReportCasePayload{}, err
+	}
+	if !ok {
+		return report_models.ReportCasePayload{}, nubo_error.ErrCaseUnavailable
+	}
+
+	audit.Record(ctx, m.actor(), audit.ActionReportStateChange, audit.On(audit.TargetCase, rc.ID), map[string]any{
+		"from":        rc.State,
+		"to":          updated.State,
+		"assignee_id": updated.AssigneeID,
+		"resolution":  updated.Resolution,
+	})
+	return updated, nil
+}
+
+// loadCase charge un dossier (ErrNotFound s'il n'existe pas).
+func loadCase(ctx context.Context, caseID int64) (report_models.ReportCasePayload, error) {
+	rc, err := postgres.FuncLoadReportCase(ctx, caseID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return report_models.ReportCasePayload{}, nubo_error.ErrNotFound
+	}
+	return rc, err
+}
@@ -0,0 +1,372 @@
+package report_service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/comment_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/post_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/report_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/mongo"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/algorithm_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service/object_cache_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/comment_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/post_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/sanction_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// ============================================================================
+// RETRAIT AUTOMATIQUE : MARQUAGE, MASQUAGE, RÉTABLISSEMENT
+// ============================================================================
+// Chaque dossier ouvert visant un post ou un commentaire reçoit un score pondéré (catégorie × confiance
+// du signalant, voir FuncTakedownCandidates). Au-delà de TakedownFlagScore le post est pénalisé dans les
+// tendances ; au-delà de TakedownHideScore le contenu passe en VisibilityUnderReview par le Write-Behind
+// (les workers le retirent des classements, des seaux LSH et de la vitrine du profil). La clôture du
+// dossier rétablit le contenu (aucune infraction) ou confirme son retrait : l'auteur reçoit alors une
+// sanction de retrait, contestable, et le contenu reste masqué jusqu'à la fin du délai d'appel avant sa
+// suppression standard (PurgeConfirmedTakedowns). Un appel accueilli le rétablit (RestoreCaseContent).
+
+// EvaluateTakedowns applique marquages et retraits aux dossiers ouverts (cron, après le tri). Retourne le nombre de retraits.
+func EvaluateTakedowns(ctx context.Context) int {
+	candidates, err := postgres.FuncTakedownCandidates(ctx, variables.ReportCategoryWeight,
+		variables.ReporterTrustMin, variables.ReporterTrustMax, variables.TakedownFlagScore, variables.TakedownBatchSize)
+	if err != nil {
+		log.Printf("⚠️ Évaluation des retraits : %v", err)
+		return 0
+	}
+
+	hidden := 0
+	for _, c := range candidates {
+		if c.Score < variables.TakedownHideScore {
+			// Marquage seul (rafraîchi à chaque passe tant que le dossier reste ouvert)
+			if c.TargetType == variables.ReportTargetPost {
+				_ = redis.FlaggedPosts.SetPrimitive(ctx, c.TargetID, c.CaseID)
+			}
+			continue
+		}
+
+		ok, err := takeDown(ctx, c)
+		if err != nil {
+			log.Printf("❌ Retrait du dossier %d impossible : %v", c.CaseID, err)
+			continue
+		}
+		if ok {
+			hidden++
+		}
+	}
+	return hidden
+}
+
+// RestoreCaseContent rétablit le contenu masqué au titre d'un dossier (appel accueilli contre une sanction
+// issue de ce dossier), que le retrait soit encore en cours ou déjà confirmé. Retourne false si le dossier
+// n'a pas de retrait à rétablir (aucun, déjà rétabli, ou contenu déjà supprimé définitivement).
+func RestoreCaseContent(ctx context.Context, m Moderator, caseID int64, reason string) (bool, error) {
+	t, err := postgres.FuncLoadCaseTakedown(ctx, caseID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if t.Restored != nil && *t.Restored {
+		return false, nil
+	}
+
+	// Le contenu est rétabli AVANT le marquage : sur échec, le retrait reste rétablissable (appel retranché)
+	if t.TargetType == variables.ReportTargetPost {
+		_ = redis.FlaggedPosts.DeleteObject(ctx, t.TargetID)
+	}
+	if err := applyRelease(ctx, t, true); err != nil {
+		return false, err
+	}
+	if _, ok, err := postgres.FuncRestoreTakedown(ctx, caseID); err != nil || !ok {
+		return false, err
+	}
+
+	audit.Record(ctx, m.actor(), audit.ActionContentRestore, audit.On(takedownTarget(t.TargetType), t.TargetID), map[string]any{
+		"case_id": caseID,
+		"reason":  reason,
+	})
+	return true, nil
+}
+
+// ReleaseClosedCaseTakedowns rejoue la libération des retraits de dossiers clos restés en cours (rétablissement
+// interrompu par une panne du Write-Behind) (cron). Retourne le nombre de retraits libérés.
+func ReleaseClosedCaseTakedowns(ctx context.Context) int {
+	cases, err := postgres.FuncUnreleasedClosedCases(ctx, variables.ReportStateClosed,
+		variables.TakedownReleaseGraceSeconds, variables.TakedownBatchSize)
+	if err != nil {
+		log.Printf("⚠️ Libération des retraits des dossiers clos : %v", err)
+		return 0
+	}
+
+	released := 0
+	for _, rc := range cases {
+		// Attribué au modérateur qui a clos le dossier (sanction de retrait, journal d'audit)
+		if releaseTakedown(ctx, Moderator{UserID: rc.ResolvedBy}, rc) {
+			released++
+		}
+	}
+	return released
+}
+
+// PurgeConfirmedTakedowns supprime définitivement les contenus dont le retrait est confirmé depuis plus que
+// le délai d'appel, sans appel en attente (cron). Retourne le nombre de contenus supprimés.
+func PurgeConfirmedTakedowns(ctx context.Context) int {
+	due, err := postgres.FuncPurgeableTakedowns(ctx, variables.SanctionAppealWindowDays, variables.TakedownPurgeBatch)
+	if err != nil {
+		log.Printf("⚠️ Purge des retraits confirmés : %v", err)
+		return 0
+	}
+
+	purged := 0
+	for _, t := range due {
+		// Le marquage précède la suppression : un appel accueilli au même instant ne peut plus rétablir le contenu
+		ok, err := postgres.FuncMarkTakedownPurged(ctx, t.CaseID)
+		if err != nil || !ok {
+			continue
+		}
+		if err := applyRelease(ctx, t, false); err != nil {
+			log.Printf("❌ Dossier %d : contenu %d non supprimé : %v", t.CaseID, t.TargetID, err)
+			continue
+		}
+		audit.Record(ctx, audit.System, audit.ActionContentRemove, audit.On(takedownTarget(t.TargetType), t.TargetID), map[string]any{
+			"case_id": t.CaseID,
+			"reason":  "appeal_window_elapsed",
+		})
+		purged++
+	}
+	return purged
+}
+
+// --- HELPERS ---
+
+// takeDown masque la cible d'un dossier. false sans erreur : contenu introuvable, déjà supprimé ou déjà masqué.
+func takeDown(ctx context.Context, c report_models.TakedownCandidate) (bool, error) {
+	t := report_models.TakedownPayload{ID: pkg.GenerateID(), CaseID: c.CaseID, TargetType: c.TargetType, TargetID: c.TargetID, Score: c.Score}
+	now := time.Now().UTC()
+
+	switch c.TargetType {
+	case variables.ReportTargetPost:
+		post, found := loadPost(ctx, c.TargetID)
+		if !found || post.Visibility < 0 {
+			return false, nil
+		}
+		t.PreviousVisibility = post.Visibility
+		if inserted, err := postgres.FuncInsertTakedown(ctx, t); err != nil || !inserted {
+			return false, err
+		}
+
+		// Disparition immédiate (sans attendre les workers) : LFU à jour, classements, LSH et vitrine purgés
+		post.Visibility = variables.VisibilityUnderReview
+		post.UpdatedAt = now
+		_ = object_cache_service.SetPostInObjectCache(ctx, post)
+		_ = redis.FlaggedPosts.DeleteObject(ctx, post.ID)
+		_, _ = cache_service.RemovePostsFromMostCache(ctx, []int64{post.ID})
+		_ = algorithm_service.PurgePostVectors(ctx, post.ID)
+		_ = cache_service.RemovePostFromUserProfile(ctx, post.UserID, post.ID)
+		_ = cache_service.RevokePostMedia(ctx, post.ID)
+
+		if err := redis.EnqueueDB(ctx, post.ID, 0, redis.EntityPost, redis.ActionUpdate, post, redis.TargetAll); err != nil {
+			// L1 ne doit pas diverger de L2/L3 : le prochain passage retentera le retrait
+			_ = object_cache_service.DeletePostFromObjectCache(ctx, post.ID)
+			_ = postgres.FuncDeleteTakedown(ctx, c.CaseID)
+			return false, err
+		}
+
+	case variables.ReportTargetComment:
+		comment, found := loadComment(ctx, c.TargetID)
+		if !found || comment.Visibility < 0 {
+			return false, nil
+		}
+		t.PreviousVisibility = comment.Visibility
+		if inserted, err := postgres.FuncInsertTakedown(ctx, t); err != nil || !inserted {
+			return false, err
+		}
+
+		comment.Visibility = variables.VisibilityUnderReview
+		comment.UpdatedAt = now.Format(time.RFC3339)
+		_ = object_cache_service.SetCommentInObjectCache(ctx, comment)
+		_ = object_cache_service.RemoveCommentFromZSET(ctx, comment.PostID, comment.ID)
+
+		if err := redis.EnqueueDB(ctx, comment.ID, 0, redis.EntityComment, redis.ActionUpdate, comment, redis.TargetAll); err != nil {
+			_ = object_cache_service.DeleteCommentFromObjectCache(ctx, comment.ID)
+			_ = postgres.FuncDeleteTakedown(ctx, c.CaseID)
+			return false, err
+		}
+
+	default:
+		return false, nil
+	}
+
+	audit.Record(ctx, audit.System, audit.ActionContentTakedown, audit.On(takedownTarget(c.TargetType), c.TargetID), map[string]any{
+		"case_id":             c.CaseID,
+		"score":               c.Score,
+		"previous_visibility": t.PreviousVisibility,
+	})
+	return true, nil
+}
+
+// releaseTakedown tire les conséquences de la clôture d'un dossier sur son contenu : levée du marquage,
+// puis rétablissement (aucune infraction, doublon) ou confirmation du retrait (infraction). Un retrait
+// confirmé laisse le contenu masqué et notifie son auteur par une sanction de retrait, contestable en appel.
+// Retourne true si le retrait a été libéré par cet appel.
+func releaseTakedown(ctx context.Context, m Moderator, rc report_models.ReportCasePayload) bool {
+	if rc.TargetType == variables.ReportTargetPost {
+		_ = redis.FlaggedPosts.DeleteObject(ctx, rc.TargetID)
+	}
+
+	restore := rc.Resolution == variables.ReportResolutionNoViolation || rc.Resolution == variables.ReportResolutionDuplicate
+	if restore {
+		// Le contenu est rétabli AVANT le marquage : sur échec, le retrait reste en cours et
+		// ReleaseClosedCaseTakedowns rejoue la libération
+		t, err := postgres.FuncLoadCaseTakedown(ctx, rc.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return false
+		}
+		if err != nil {
+			log.Printf("❌ Dossier %d clos mais retrait non libéré : %v", rc.ID, err)
+			return false
+		}
+		if t.ReleasedAt != nil {
+			return false
+		}
+		if err := applyRelease(ctx, t, true); err != nil {
+			log.Printf("❌ Dossier %d : contenu %d non rétabli : %v", rc.ID, t.TargetID, err)
+			return false
+		}
+		if _, ok, err := postgres.FuncReleaseTakedown(ctx, rc.ID, true); err != nil || !ok {
+			if err != nil {
+				log.Printf("❌ Dossier %d : contenu %d rétabli mais retrait non libéré : %v", rc.ID, t.TargetID, err)
+			}
+			return false
+		}
+		audit.Record(ctx, m.actor(), audit.ActionContentRestore, audit.On(takedownTarget(t.TargetType), t.TargetID), map[string]any{
+			"case_id":    rc.ID,
+			"resolution": rc.Resolution,
+		})
+		return true
+	}
+
+	t, ok, err := postgres.FuncReleaseTakedown(ctx, rc.ID, false)
+	if err != nil {
+		log.Printf("❌ Dossier %d clos mais retrait non libéré : %v", rc.ID, err)
+		return false
+	}
+	if !ok {
+		return false
+	}
+
+	// Retrait confirmé : le contenu reste masqué pendant le délai d'appel (PurgeConfirmedTakedowns le supprimera)
+	details := map[string]any{
+		"case_id":    rc.ID,
+		"resolution": rc.Resolution,
+	}
+	if authorID := takedownAuthor(ctx, t); authorID != 0 {
+		reason := rc.ResolutionNote
+		if reason == "" {
+			reason = "Contenu retiré à la suite de signalements"
+		}
+		s, err := sanction_service.IssueContentRemoval(ctx, sanction_service.Moderator(m), authorID, rc.ID, reason)
+		if err != nil {
+			log.Printf("❌ Dossier %d : retrait du contenu %d non notifié à son auteur : %v", rc.ID, t.TargetID, err)
+		} else {
+			details["sanction_id"] = s.ID
+		}
+	}
+	audit.Record(ctx, m.actor(), audit.ActionContentRemove, audit.On(takedownTarget(t.TargetType), t.TargetID), details)
+	return true
+}
+
+// applyRelease rétablit la visibilité d'origine du contenu masqué ou le supprime par le chemin standard.
+// Un contenu qui n'est plus masqué (supprimé par son auteur entre-temps) est laissé tel quel.
+func applyRelease(ctx context.Context, t report_models.TakedownPayload, restore bool) error {
+	switch t.TargetType {
+	case variables.ReportTargetPost:
+		post, found := loadPost(ctx, t.TargetID)
+		if !found || post.Visibility != variables.VisibilityUnderReview {
+			return nil
+		}
+		if !restore {
+			return post_service.RemovePost(ctx, post)
+		}
+		// Les workers réinscrivent le post dans les classements, les seaux LSH et la vitrine du profil
+		post.Visibility = t.PreviousVisibility
+		post.UpdatedAt = time.Now().UTC()
+		_ = object_cache_service.SetPostInObjectCache(ctx, post)
+		return redis.EnqueueDB(ctx, post.ID, 0, redis.EntityPost, redis.ActionUpdate, post, redis.TargetAll)
+
+	case variables.ReportTargetComment:
+		comment, found := loadComment(ctx, t.TargetID)
+		if !found || comment.Visibility != variables.VisibilityUnderReview {
+			return nil
+		}
+		if !restore {
+			return comment_service.RemoveComment(ctx, comment)
+		}
+		comment.Visibility = t.PreviousVisibility
+		comment.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+		_ = object_cache_service.SetCommentInObjectCache(ctx, comment)
+		_ = object_cache_service.AddCommentToZSET(ctx, comment.PostID, comment.ID, float64(comment.Score))
+		return redis.EnqueueDB(ctx, comment.ID, 0, redis.EntityComment, redis.ActionUpdate, comment, redis.TargetAll)
+	}
+	return nil
+}
+
+// takedownAuthor renvoie l'auteur du contenu d'un retrait (0 : contenu introuvable).
+func takedownAuthor(ctx context.Context, t report_models.TakedownPayload) int64 {
+	if t.TargetType == variables.ReportTargetComment {
+		comment, _ := loadComment(ctx, t.TargetID)
+		return comment.UserID
+	}
+	post, _ := loadPost(ctx, t.TargetID)
+	return post.UserID
+}
+
+// takedownTarget traduit un type de cible de signalement en type de cible d'audit.
+func takedownTarget(targetType int) audit.TargetType {
+	if targetType == variables.ReportTargetComment {
+		return audit.TargetComment
+	}
+	return audit.TargetPost
+}
+
+// loadPost lit un post en cascade L1 -> L2 -> L3, quelle que soit sa visibilité : un post en examen (-2)
+// évincé des caches doit rester restaurable à la clôture du dossier.
+func loadPost(ctx context.Context, postID int64) (post_models.PostPayload, bool) {
+	if p, err := object_cache_service.GetPostFromObjectCache(ctx, postID); err == nil {
+		return p, true
+	}
+	if posts, err := mongo.MongoLoadPosts([]int64{postID}); err == nil && len(posts) > 0 {
+		return posts[0], true
+	}
+	if p, err := postgres.FuncAdminLoadPost(ctx, postID); err == nil {
+		// La lecture brute n'inclut pas le vecteur : il est recalculé pour ne pas l'effacer à la réécriture
+		p.Vector = algorithm_service.ComputeContentVectorFull(p, nil)
+		return p, true
+	}
+	return post_models.PostPayload{}, false
+}
+
+// loadComment lit un commentaire en cascade L1 -> L2 -> L3, quelle que soit sa visibilité.
+func loadComment(ctx context.Context, commentID int64) (comment_models.CommentPayload, bool) {
+	if c, err := object_cache_service.GetCommentFromObjectCache(ctx, commentID); err == nil {
+		return c, true
+	}
+	if comments, err := mongo.MongoLoadComments([]int64{commentID}); err == nil && len(comments) > 0 {
+		return comments[0], true
+	}
+	if c, err := postgres.FuncAdminLoadComment(ctx, commentID); err == nil {
+		return c, true
+	}
+	return comment_models.CommentPayload{}, false
+}
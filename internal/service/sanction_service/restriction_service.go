@@ -0,0 +1,185 @@
+package sanction_service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/sanction_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/auth_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+	redisgo "github.com/go-redis/redis/v8"
+)
+
+// ============================================================================
+// APPLICATION EN L1 : RESTRICTIONS, STRIKES, ÉCHÉANCES
+// ============================================================================
+
+// CheckRestriction vérifie en O(1) qu'un utilisateur peut utiliser une fonctionnalité (scope = RestrictionScope*).
+// Renvoie une *nubo_error.SanctionError (errors.Is ErrRestricted) portant la notice à afficher.
+// Fail-Open : une panne Redis ne bloque pas l'usage normal de l'application.
+func CheckRestriction(ctx context.Context, userID int64, scope int) error {
+	var s sanction_models.SanctionPayload
+	if err := redis.UserRestrictions.GetObject(ctx, restrictionKey(userID, scope), &s); err != nil {
+		return nil
+	}
+	if !IsActive(s, time.Now().UTC()) {
+		return nil
+	}
+	return &nubo_error.SanctionError{Cause: nubo_error.ErrRestricted, Notice: s.Notice()}
+}
+
+// ListMySanctions renvoie à l'utilisateur ses sanctions en vigueur (motif, échéance, délai de contestation).
+func ListMySanctions(ctx context.Context, userID int64) (sanction_models.MySanctionsOutput, error) {
+	active, err := postgres.FuncLoadActiveSanctions(ctx, userID, variables.StrikeWindowDays)
+	if err != nil {
+		return sanction_models.MySanctionsOutput{}, err
+	}
+
+	out := sanction_models.MySanctionsOutput{Sanctions: []nubo_error.SanctionNotice{}}
+	for _, s := range active {
+		out.Sanctions = append(out.Sanctions, s.Notice())
+		if s.Kind == variables.SanctionKindWarning {
+			out.Strikes++
+		}
+	}
+	return out, nil
+}
+
+// LiftExpiredBans lève les bans temporaires arrivés à échéance (cron). Chaque compte est réclamé
+// par un ZREM atomique : une autre instance ne peut pas le traiter en double. Retourne le nombre de bans levés.
+func LiftExpiredBans(ctx context.Context) int {
+	now := time.Now().UTC()
+	due, err := redis.BanExpiries.ZRangeByScoreWithLimit(ctx, banScheduleID, now.Unix(), variables.BanExpiryBatchSize)
+	if err != nil || len(due) == 0 {
+		return 0
+	}
+
+	lifted := 0
+	for _, member := range due {
+		claimed, errZ := redis.BanExpiries.Client.ZRem(ctx, redis.BanExpiries.Key(banScheduleID), member).Result()
+		if errZ != nil || claimed == 0 {
+			continue
+		}
+		userID, errParse := strconv.ParseInt(member, 10, 64)
+		if errParse != nil {
+			continue
+		}
+
+		user, errUser := auth_service.LoadUserCascade(userID, "")
+		if errUser != nil || user.ID == 0 || !user.Banned {
+			continue
+		}
+		// Un autre ban (définitif, ou plus long) reste en vigueur : reconcileBan replanifie son échéance
+		stillBanned, err := reconcileBan(ctx, user)
+		if err != nil {
+			log.Printf("❌ Levée du ban de %d impossible : %v", user.ID, err)
+			_ = redis.BanExpiries.ZAdd(ctx, banScheduleID, float64(now.Add(time.Minute).Unix()), user.ID)
+			continue
+		}
+		if stillBanned {
+			continue
+		}
+		audit.Record(ctx, audit.System, audit.ActionUserUnban, audit.On(audit.TargetUser, user.ID), map[string]any{
+			"reason": "expired",
+		})
+		lifted++
+	}
+	return lifted
+}
+
+// SeedSanctions reconstruit restrictions, strikes et échéances de ban depuis L3 (redémarrage à froid de Redis).
+func SeedSanctions(ctx context.Context) error {
+	active, err := postgres.FuncLoadAllActiveSanctions(ctx, variables.StrikeWindowDays)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range active {
+		switch s.Kind {
+		case variables.SanctionKindRestriction:
+			_ = cacheRestriction(ctx, s)
+		case variables.SanctionKindWarning:
+			_, _ = addStrike(ctx, s)
+		case variables.SanctionKindBan:
+			_ = redis.UserBans.SAdd(ctx, s.UserID, s.ID)
+			if !s.ExpiresAt.IsZero() {
+				_ = redis.BanExpiries.ZAdd(ctx, banScheduleID, float64(s.ExpiresAt.Unix()), s.UserID)
+			}
+		}
+	}
+	log.Printf("✅ Sanctions: %d sanctions actives rechargées.", len(active))
+	return nil
+}
+
+// --- HELPERS ---
+
+// restrictionKey identifie la restriction d'un utilisateur sur un périmètre : "<user_id>:<scope>".
+func restrictionKey(userID int64, scope int) string {
+	return fmt.Sprintf("%d:%d", userID, scope)
+}
+
+// cacheRestriction indexe la restriction sur chacun de ses périmètres. Le TTL suit l'échéance ;
+// une restriction plus longue déjà en place sur un périmètre est conservée.
+func cacheRestriction(ctx context.Context, s sanction_models.SanctionPayload) error {
+	ttl := time.Until(s.ExpiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	for _, scope := range []int{variables.RestrictionScopePost, variables.RestrictionScopeComment, variables.RestrictionScopeMessage} {
+		if s.Scopes&scope == 0 {
+			continue
+		}
+		var current sanction_models.SanctionPayload
+		if err := redis.UserRestrictions.GetObject(ctx, restrictionKey(s.UserID, scope), &current); err == nil && current.ExpiresAt.After(s.ExpiresAt) {
+			continue
+		}
+		if err := redis.UserRestrictions.SetObjectTTL(ctx, restrictionKey(s.UserID, scope), s, ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clearRestriction retire une restriction levée puis réindexe les autres restrictions en vigueur de l'utilisateur.
+func clearRestriction(ctx context.Context, lifted sanction_models.SanctionPayload) {
+	for _, scope := range []int{variables.RestrictionScopePost, variables.RestrictionScopeComment, variables.RestrictionScopeMessage} {
+		var current sanction_models.SanctionPayload
+		if err := redis.UserRestrictions.GetObject(ctx, restrictionKey(lifted.UserID, scope), &current); err == nil && current.ID == lifted.ID {
+			_ = redis.UserRestrictions.DeleteObject(ctx, restrictionKey(lifted.UserID, scope))
+		}
+	}
+
+	active, err := postgres.FuncLoadActiveSanctions(ctx, lifted.UserID, variables.StrikeWindowDays)
+	if err != nil {
+		return
+	}
+	for _, s := range active {
+		// L3 peut ne pas encore refléter la levée (Write-Behind)
+		if s.Kind == variables.SanctionKindRestriction && s.ID != lifted.ID {
+			_ = cacheRestriction(ctx, s)
+		}
+	}
+}
+
+// addStrike ajoute un avertissement à la fenêtre glissante et renvoie le nombre d'avertissements actifs.
+func addStrike(ctx context.Context, s sanction_models.SanctionPayload) (int64, error) {
+	key := redis.SanctionStrikes.Key(s.UserID)
+	cutoff := time.Now().UTC().AddDate(0, 0, -variables.StrikeWindowDays).Unix()
+
+	pipe := redis.SanctionStrikes.Pipeline()
+	pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(cutoff, 10))
+	pipe.ZAdd(ctx, key, &redisgo.Z{Score: float64(s.IssuedAt.Unix()), Member: s.ID})
+	card := pipe.ZCard(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return card.Val(), nil
+}
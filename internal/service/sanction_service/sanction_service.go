@@ -0,0 +1,499 @@
+package sanction_service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/auth_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/sanction_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/auth_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/cache_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// banScheduleID est l'identifiant du ZSET des bans temporaires (score = échéance Unix).
+const banScheduleID = "schedule"
+
+// ============================================================================
+// SANCTIONS : BAN, RESTRICTION, AVERTISSEMENT, LEVÉE
+// ============================================================================
+// Chaque sanction est mémorisée en L1 (objet + index de restriction/strikes) puis persistée
+// en L3 par le Write-Behind. Le profil (auth.users.banned) suit les bans sur L2/L3.
+
+// Moderator est l'auteur d'une sanction (grade injecté par RequirePermission).
+type Moderator struct {
+	UserID int64
+	Grade  int
+	IP     string
+}
+
+func (m Moderator) actor() audit.Actor { return audit.User(m.UserID, m.IP) }
+
+// BanUser bannit un utilisateur (définitivement si DurationDays == 0) et révoque immédiatement ses sessions.
+func BanUser(ctx context.Context, moderator Moderator, in sanction_models.BanUserInput) (sanction_models.SanctionOutput, error) {
+	target, err := loadTarget(ctx, moderator, in.UserID)
+	if err != nil {
+		return sanction_models.SanctionOutput{}, err
+	}
+
+	s := newSanction(moderator, target.ID, variables.SanctionKindBan, 0, in.Reason, in.CaseID, in.DurationDays)
+	if err := applyBan(ctx, moderator.actor(), target, s); err != nil {
+		return sanction_models.SanctionOutput{}, err
+	}
+	return sanction_models.SanctionOutput{Sanction: s}, nil
+}
+
+// RestrictUser interdit une ou plusieurs fonctionnalités (post, commentaire, message) pendant DurationDays.
+func RestrictUser(ctx context.Context, moderator Moderator, in sanction_models.RestrictUserInput) (sanction_models.SanctionOutput, error) {
+	target, err := loadTarget(ctx, moderator, in.UserID)
+	if err != nil {
+		return sanction_models.SanctionOutput{}, err
+	}
+
+	scopes := 0
+	for _, name := range in.Scopes {
+		scopes |= variables.RestrictionScopeByName[name]
+	}
+
+	s := newSanction(moderator, target.ID, variables.SanctionKindRestriction, scopes, in.Reason, in.CaseID, in.DurationDays)
+	if err := applyRestriction(ctx, moderator.actor(), s); err != nil {
+		return sanction_models.SanctionOutput{}, err
+	}
+	return sanction_models.SanctionOutput{Sanction: s}, nil
+}
+
+// WarnUser émet un avertissement. Les avertissements actifs sur StrikeWindowDays forment une échelle :
+// le franchissement d'un palier déclenche automatiquement une restriction puis un ban.
+func WarnUser(ctx context.Context, moderator Moderator, in sanction_models.WarnUserInput) (sanction_models.SanctionOutput, error) {
+	target, err := loadTarget(ctx, moderator, in.UserID)
+	if err != nil {
+		return sanction_models.SanctionOutput{}, err
+	}
+
+	s := newSanction(moderator, target.ID, variables.SanctionKindWarning, 0, in.Reason, in.CaseID, 0)
+	if err := persistSanction(ctx, s, redis.ActionCreate); err != nil {
+		return sanction_models.SanctionOutput{}, err
+	}
+
+	strikes, err := addStrike(ctx, s)
+	if err != nil {
+		log.Printf("⚠️ Strikes: comptage impossible pour l'utilisateur %d : %v", target.ID, err)
+	}
+	audit.Record(ctx, moderator.actor(), audit.ActionUserWarn, audit.On(audit.TargetUser, target.ID), map[string]any{
+		"sanction_id": s.ID,
+		"case_id":     s.CaseID,
+		"strikes":     strikes,
+	})
+
+	out := sanction_models.SanctionOutput{Sanction: s, Strikes: strikes}
+	escalation, err := escalate(ctx, moderator, target, s, strikes)
+	if err != nil {
+		return out, err
+	}
+	out.Escalation = escalation
+	return out, nil
+}
+
+// IssueContentRemoval notifie à l'auteur le retrait confirmé d'un contenu (dossier caseID). La sanction court
+// jusqu'à la fin du délai de contestation : le contenu reste masqué jusque-là et un appel accueilli le rétablit.
+// Ce n'est pas un strike : l'échelle des avertissements n'est pas alimentée.
+func IssueContentRemoval(ctx context.Context, moderator Moderator, userID int64, caseID int64, reason string) (sanction_models.SanctionPayload, error) {
+	s := newSanction(moderator, userID, variables.SanctionKindRemoval, 0, reason, caseID, variables.SanctionAppealWindowDays)
+	if err := persistSanction(ctx, s, redis.ActionCreate); err != nil {
+		return sanction_models.SanctionPayload{}, err
+	}
+	return s, nil
+}
+
+// LiftSanction lève une sanction avant son terme (ban, restriction, avertissement ou retrait de contenu).
+// Le rétablissement d'un contenu retiré passe par l'appel (appeal_service.DecideAppeal).
+func LiftSanction(ctx context.Context, moderator Moderator, in sanction_models.LiftSanctionInput) (sanction_models.SanctionPayload, error) {
+	s, err := LoadSanction(ctx, in.SanctionID)
+	if err != nil {
+		return sanction_models.SanctionPayload{}, err
+	}
+	if !IsActive(s, time.Now().UTC()) {
+		return sanction_models.SanctionPayload{}, nubo_error.ErrSanctionInactive
+	}
+
+	s.LiftedAt = time.Now().UTC()
+	s.LiftedBy = moderator.UserID
+	s.LiftReason = in.Reason
+	if err := persistSanction(ctx, s, redis.ActionUpdate); err != nil {
+		return sanction_models.SanctionPayload{}, err
+	}
+
+	switch s.Kind {
+	case variables.SanctionKindBan:
+		user, errUser := auth_service.LoadUserCascade(s.UserID, "")
+		if errUser != nil {
+			return s, errUser
+		}
+		// Un autre ban en vigueur (définitif, ou plus long) maintient le compte banni
+		stillBanned, err := reconcileBan(ctx, user)
+		if err != nil {
+			return s, err
+		}
+		if !stillBanned {
+			audit.Record(ctx, moderator.actor(), audit.ActionUserUnban, audit.On(audit.TargetUser, s.UserID), map[string]any{
+				"sanction_id": s.ID,
+				"reason":      in.Reason,
+			})
+			return s, nil
+		}
+	case variables.SanctionKindRestriction:
+		clearRestriction(ctx, s)
+	case variables.SanctionKindWarning:
+		_ = redis.SanctionStrikes.ZRem(ctx, s.UserID, s.ID)
+		// Les paliers franchis grâce à cet avertissement n'ont plus lieu d'être
+		if err := LiftEscalations(ctx, moderator, s, in.Reason); err != nil {
+			return s, err
+		}
+	}
+
+	audit.Record(ctx, moderator.actor(), audit.ActionSanctionLift, audit.On(audit.TargetSanction, s.ID), map[string]any{
+		"user_id": s.UserID,
+		"kind":    s.Kind,
+		"reason":  in.Reason,
+	})
+	return s, nil
+}
+
+// --- APPLICATION DES SANCTIONS ---
+
+// applyBan persiste le ban, bascule le profil (banned, motif, échéance), masque le compte et révoque ses sessions.
+// Les bans se cumulent : le profil reflète le plus sévère des bans en vigueur (voir reconcileBan).
+func applyBan(ctx context.Context, actor audit.Actor, user auth_models.UserPayload, s sanction_models.SanctionPayload) error {
+	if err := persistSanction(ctx, s, redis.ActionCreate); err != nil {
+		return err
+	}
+	if err := redis.UserBans.SAdd(ctx, user.ID, s.ID); err != nil {
+		log.Printf("⚠️ Ban: indexation L1 impossible pour %d : %v", user.ID, err)
+	}
+
+	if _, err := reconcileBan(ctx, user); err != nil {
+		return err
+	}
+
+	if err := cache_service.HideAccount(ctx, user); err != nil {
+		log.Printf("⚠️ Ban: masquage L1 impossible pour %d : %v", user.ID, err)
+	}
+
+	revoked := auth_service.RevokeUserSessions(ctx, actor, user.ID, 0)
+	audit.Record(ctx, actor, audit.ActionUserBan, audit.On(audit.TargetUser, user.ID), map[string]any{
+		"sanction_id":      s.ID,
+		"case_id":          s.CaseID,
+		"automatic":        s.Automatic,
+		"expires_at":       formatExpiry(s.ExpiresAt),
+		"sessions_revoked": revoked,
+	})
+	return nil
+}
+
+// applyRestriction persiste la restriction et l'indexe en L1 pour chaque périmètre visé.
+func applyRestriction(ctx context.Context, actor audit.Actor, s sanction_models.SanctionPayload) error {
+	if err := persistSanction(ctx, s, redis.ActionCreate); err != nil {
+		return err
+	}
+	if err := cacheRestriction(ctx, s); err != nil {
+		log.Printf("⚠️ Restriction: indexation L1 impossible pour %d : %v", s.UserID, err)
+	}
+
+	audit.Record(ctx, actor, audit.ActionUserRestrict, audit.On(audit.TargetUser, s.UserID), map[string]any{
+		"sanction_id": s.ID,
+		"case_id":     s.CaseID,
+		"automatic":   s.Automatic,
+		"scopes":      s.Scopes,
+		"expires_at":  formatExpiry(s.ExpiresAt),
+	})
+	return nil
+}
+
+// escalate applique le palier atteint par l'échelle des strikes (nil si aucun palier n'est franchi).
+func escalate(ctx context.Context, moderator Moderator, user auth_models.UserPayload, warning sanction_models.SanctionPayload, strikes int64) (*sanction_models.SanctionPayload, error) {
+	reason := fmt.Sprintf("%d avertissements en %d jours", strikes, variables.StrikeWindowDays)
+
+	var s sanction_models.SanctionPayload
+	switch {
+	case strikes >= variables.StrikesForPermanentBan:
+		s = newSanction(moderator, user.ID, variables.SanctionKindBan, 0, reason, warning.CaseID, 0)
+	case strikes == variables.StrikesForTempBan:
+		s = newSanction(moderator, user.ID, variables.SanctionKindBan, 0, reason, warning.CaseID, variables.StrikeTempBanDays)
+	case strikes == variables.StrikesForRestriction:
+		s = newSanction(moderator, user.ID, variables.SanctionKindRestriction, variables.RestrictionScopeAll, reason, warning.CaseID, variables.StrikeRestrictionDays)
+	default:
+		return nil, nil
+	}
+	s.Automatic = true
+
+	var err error
+	if s.Kind == variables.SanctionKindBan {
+		err = applyBan(ctx, moderator.actor(), user, s)
+	} else {
+		err = applyRestriction(ctx, moderator.actor(), s)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// LiftEscalations lève les sanctions automatiques que l'échelle n'aurait pas déclenchées sans l'avertissement
+// `warning` : chaque palier émis depuis est réévalué sur les autres avertissements non levés de sa fenêtre.
+// Idempotent : une sanction déjà levée ou expirée est ignorée.
+func LiftEscalations(ctx context.Context, moderator Moderator, warning sanction_models.SanctionPayload, reason string) error {
+	history, err := postgres.FuncLoadUserSanctions(ctx, warning.UserID)
+	if err != nil {
+		return fmt.Errorf("erreur lors du chargement des sanctions de %d: %w", warning.UserID, err)
+	}
+
+	now := time.Now().UTC()
+	var warnings, escalations []sanction_models.SanctionPayload
+	for _, stored := range history {
+		// L1 d'abord : une levée récente n'est peut-être pas encore persistée
+		s, errLoad := LoadSanction(ctx, stored.ID)
+		if errLoad != nil {
+			s = stored
+		}
+		switch {
+		case s.ID == warning.ID:
+		case s.Kind == variables.SanctionKindWarning:
+			warnings = append(warnings, s)
+		case s.Automatic && IsActive(s, now) && !s.IssuedAt.Before(warning.IssuedAt):
+			escalations = append(escalations, s)
+		}
+	}
+
+	for _, e := range escalations {
+		if strikesAt(warnings, e.IssuedAt) >= escalationThreshold(e) {
+			continue
+		}
+		_, err := LiftSanction(ctx, moderator, sanction_models.LiftSanctionInput{SanctionID: e.ID, Reason: reason})
+		if err != nil && !errors.Is(err, nubo_error.ErrSanctionInactive) {
+			return err
+		}
+	}
+	return nil
+}
+
+// strikesAt compte les avertissements non levés de la fenêtre glissante se terminant à `at`.
+func strikesAt(warnings []sanction_models.SanctionPayload, at time.Time) int {
+	cutoff := at.AddDate(0, 0, -variables.StrikeWindowDays)
+	n := 0
+	for _, w := range warnings {
+		if w.LiftedAt.IsZero() && w.IssuedAt.After(cutoff) && !w.IssuedAt.After(at) {
+			n++
+		}
+	}
+	return n
+}
+
+// escalationThreshold renvoie le nombre de strikes ayant déclenché une sanction automatique (voir escalate).
+func escalationThreshold(s sanction_models.SanctionPayload) int {
+	switch {
+	case s.Kind == variables.SanctionKindRestriction:
+		return variables.StrikesForRestriction
+	case s.ExpiresAt.IsZero():
+		return variables.StrikesForPermanentBan
+	default:
+		return variables.StrikesForTempBan
+	}
+}
+
+// reconcileBan aligne le profil sur l'ensemble des bans en vigueur de l'utilisateur : un ban définitif l'emporte,
+// sinon l'échéance la plus lointaine. Sans ban restant, le compte est rétabli (unban).
+// Retourne true si le compte reste banni.
+func reconcileBan(ctx context.Context, user auth_models.UserPayload) (bool, error) {
+	bans := activeBans(ctx, user.ID)
+	if len(bans) == 0 {
+		return false, unban(ctx, user)
+	}
+
+	governing := bans[0]
+	for _, b := range bans[1:] {
+		if governing.ExpiresAt.IsZero() {
+			break
+		}
+		if b.ExpiresAt.IsZero() || b.ExpiresAt.After(governing.ExpiresAt) {
+			governing = b
+		}
+	}
+
+	if !user.Banned || user.BanReason != governing.Reason || !user.BanExpiresAt.Equal(governing.ExpiresAt) {
+		user.Banned = true
+		user.BanReason = governing.Reason
+		user.BanExpiresAt = governing.ExpiresAt
+		user.UpdatedAt = time.Now().UTC()
+		if err := redis.EnqueueDB(ctx, user.ID, 0, redis.EntityUser, redis.ActionUpdate, user, redis.TargetAll); err != nil {
+			log.Printf("❌ CRITICAL: Rupture du Write-Behind (ban utilisateur %d) : %v", user.ID, err)
+			return true, err
+		}
+		_ = redis.Users.DeleteObject(ctx, user.ID)
+	}
+
+	// Seul un ban entièrement temporaire est planifié pour une levée automatique
+	if governing.ExpiresAt.IsZero() {
+		_ = redis.BanExpiries.ZRem(ctx, banScheduleID, user.ID)
+	} else if err := redis.BanExpiries.ZAdd(ctx, banScheduleID, float64(governing.ExpiresAt.Unix()), user.ID); err != nil {
+		log.Printf("⚠️ Ban: planification de la levée impossible pour %d : %v", user.ID, err)
+	}
+	return true, nil
+}
+
+// activeBans renvoie les bans en vigueur d'un utilisateur : index L1 (bans récents, pas encore persistés)
+// complété par L3. Chaque ban est relu en L1 d'abord, qui reflète les levées avant le Write-Behind.
+func activeBans(ctx context.Context, userID int64) []sanction_models.SanctionPayload {
+	now := time.Now().UTC()
+	ids := make(map[int64]bool)
+
+	if members, err := redis.UserBans.SMembers(ctx, userID); err == nil {
+		for _, m := range members {
+			if id, errParse := strconv.ParseInt(m, 10, 64); errParse == nil {
+				ids[id] = true
+			}
+		}
+	}
+	if stored, err := postgres.FuncLoadActiveSanctions(ctx, userID, variables.StrikeWindowDays); err == nil {
+		for _, s := range stored {
+			if s.Kind == variables.SanctionKindBan {
+				ids[s.ID] = true
+			}
+		}
+	} else {
+		log.Printf("⚠️ Ban: lecture L3 des sanctions de %d impossible : %v", userID, err)
+	}
+
+	var bans []sanction_models.SanctionPayload
+	for id := range ids {
+		s, err := LoadSanction(ctx, id)
+		if err != nil || s.Kind != variables.SanctionKindBan || !IsActive(s, now) {
+			_ = redis.UserBans.SRem(ctx, userID, id) // Élagage des bans levés ou échus
+			continue
+		}
+		bans = append(bans, s)
+	}
+	return bans
+}
+
+// unban rétablit le profil et la visibilité du compte (sauf s'il est par ailleurs désactivé).
+func unban(ctx context.Context, user auth_models.UserPayload) error {
+	_ = redis.BanExpiries.ZRem(ctx, banScheduleID, user.ID)
+	if !user.Banned {
+		return nil
+	}
+
+	user.Banned = false
+	user.BanReason = ""
+	user.BanExpiresAt = time.Time{}
+	user.UpdatedAt = time.Now().UTC()
+	if err := redis.EnqueueDB(ctx, user.ID, 0, redis.EntityUser, redis.ActionUpdate, user, redis.TargetAll); err != nil {
+		log.Printf("❌ CRITICAL: Rupture du Write-Behind (levée du ban utilisateur %d) : %v", user.ID, err)
+		return err
+	}
+	_ = redis.Users.DeleteObject(ctx, user.ID)
+
+	if !user.Desactivated {
+		if err := cache_service.UnhideAccount(ctx, user); err != nil {
+			log.Printf("⚠️ Ban: réindexation L1 impossible pour %d : %v", user.ID, err)
+		}
+	}
+	return nil
+}
+
+// --- HELPERS ---
+
+// loadTarget charge l'utilisateur visé et vérifie que son grade est strictement inférieur à celui du modérateur.
+func loadTarget(ctx context.Context, moderator Moderator, userID int64) (auth_models.UserPayload, error) {
+	user, err := auth_service.LoadUserCascade(userID, "")
+	if err != nil {
+		return auth_models.UserPayload{}, err
+	}
+	if user.ID == 0 {
+		return auth_models.UserPayload{}, nubo_error.ErrNotFound
+	}
+	if user.Grade >= moderator.Grade {
+		audit.Record(ctx, moderator.actor(), audit.ActionAccessDenied, audit.On(audit.TargetUser, user.ID), map[string]any{
+			"reason":       "sanction_target_grade",
+			"target_grade": user.Grade,
+		})
+		return auth_models.UserPayload{}, nubo_error.ErrSanctionTarget
+	}
+	return user, nil
+}
+
+// newSanction construit une sanction (durationDays == 0 : sans échéance).
+func newSanction(moderator Moderator, userID int64, kind int, scopes int, reason string, caseID int64, durationDays int) sanction_models.SanctionPayload {
+	now := time.Now().UTC().Truncate(time.Microsecond) // Précision TIMESTAMPTZ : L1 et L3 restent identiques
+	s := sanction_models.SanctionPayload{
+		ID:              pkg.GenerateID(),
+		UserID:          userID,
+		Kind:            kind,
+		Scopes:          scopes,
+		Reason:          reason,
+		CaseID:          caseID,
+		IssuedBy:        moderator.UserID,
+		IssuedAt:        now,
+		AppealableUntil: now.AddDate(0, 0, variables.SanctionAppealWindowDays),
+	}
+	if durationDays > 0 {
+		s.ExpiresAt = now.AddDate(0, 0, durationDays)
+	}
+	return s
+}
+
+// persistSanction mémorise la sanction en L1 et la transmet au Write-Behind (L3).
+func persistSanction(ctx context.Context, s sanction_models.SanctionPayload, action redis.ActionType) error {
+	_ = redis.Sanctions.SetObject(ctx, s.ID, s)
+	if err := redis.EnqueueDB(ctx, s.ID, s.UserID, redis.EntitySanction, action, s, redis.TargetPostgres); err != nil {
+		log.Printf("❌ CRITICAL: Rupture du Write-Behind (sanction %d) : %v", s.ID, err)
+		return err
+	}
+	return nil
+}
+
+// LoadSanction lit une sanction en L1 puis en L3 (ErrNotFound si elle n'existe pas).
+func LoadSanction(ctx context.Context, sanctionID int64) (sanction_models.SanctionPayload, error) {
+	var s sanction_models.SanctionPayload
+	if err := redis.Sanctions.GetObject(ctx, sanctionID, &s); err == nil && s.ID != 0 {
+		return s, nil
+	}
+
+	s, err := postgres.FuncLoadSanction(ctx, sanctionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return sanction_models.SanctionPayload{}, nubo_error.ErrNotFound
+	}
+	if err != nil {
+		return sanction_models.SanctionPayload{}, err
+	}
+	_ = redis.Sanctions.SetObject(ctx, s.ID, s)
+	return s, nil
+}
+
+// IsActive indique si une sanction est encore en vigueur à l'instant donné.
+func IsActive(s sanction_models.SanctionPayload, now time.Time) bool {
+	if !s.LiftedAt.IsZero() {
+		return false
+	}
+	if s.Kind == variables.SanctionKindWarning {
+		return now.Before(s.IssuedAt.AddDate(0, 0, variables.StrikeWindowDays))
+	}
+	return s.ExpiresAt.IsZero() || now.Before(s.ExpiresAt)
+}
+
+// formatExpiry rend une échéance lisible dans le journal d'audit ("" : définitif).
+func formatExpiry(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,66 @@
+package verification_service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	redisgo "github.com/QuentinRegnier/nubo-backend/internal/infrastructure/redis"
+	"github.com/QuentinRegnier/nubo-backend/internal/pkg"
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
+)
+
+// ============================================================================
+// CANAL DE VÉRIFICATION (Email / SMS)
+// ============================================================================
+
+// Objets (purpose) transportés par le canal de vérification
+const (
+	PurposePasswordReset = "password_reset"
+)
+
+// VerificationChannelName est le nom du flux Redis consommé par le service d'envoi (mailer / SMS gateway).
+const VerificationChannelName = "nubo-verification"
+
+// VerificationMessage est le message publié sur le canal. Le jeton n'est jamais journalisé côté API.
+type VerificationMessage struct {
+	UserID    int64     `json:"user_id"`
+	Email     string    `json:"email"`
+	Phone     string    `json:"phone"`
+	Purpose   string    `json:"purpose"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Channel abstrait le transport vers le fournisseur d'envoi (interchangeable pour les environnements locaux).
+type Channel interface {
+	Send(ctx context.Context, msg VerificationMessage) error
+}
+
+// fluxChannel publie les messages via le pattern Claim Check (PushFluxWithTTL), comme le hub WebSocket.
+type fluxChannel struct{}
+
+func (fluxChannel) Send(ctx context.Context, msg VerificationMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	// Le TTL du message de flux est aligné sur la validité du jeton : au-delà il est inutile de le délivrer
+	ttl := time.Until(msg.ExpiresAt)
+	if ttl <= 0 {
+		return fmt.Errorf("verification: jeton déjà expiré")
+	}
+
+	messageID := fmt.Sprintf("verif:%d", pkg.GenerateID())
+	return redis.PushFluxWithTTL(redisgo.Rdb, VerificationChannelName, messageID, data, ttl)
+}
+
+// DefaultChannel est le canal utilisé par les services métier.
+var DefaultChannel Channel = fluxChannel{}
+
+// Send délègue l'envoi au canal par défaut.
+func Send(ctx context.Context, msg VerificationMessage) error {
+	return DefaultChannel.Send(ctx, msg)
+}
@@ -0,0 +1,38 @@
+package variables
+
+// ─────────────────────────────────────────────────────────────────────────────
+// CYCLE DE VIE DU COMPTE (State)
+// ─────────────────────────────────────────────────────────────────────────────
+const (
+	AccountStateActive          = 0 // Compte visible et utilisable
+	AccountStateDeactivated     = 1 // Masqué partout, données conservées (réactivable au login)
+	AccountStatePendingDeletion = 2 // Suppression définitive planifiée (annulable au login)
+	AccountStateDeleted         = 3 // Cascade de suppression exécutée (seul le reçu subsiste)
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// DÉLAIS DE GRÂCE
+// ─────────────────────────────────────────────────────────────────────────────
+const (
+	AccountReactivationGraceDays = 30 // Fenêtre pendant laquelle un login réactive un compte désactivé
+	AccountDeletionGraceDays     = 14 // Délai entre DELETE /account et l'exécution de la cascade
+	AccountDeletionBatchSize     = 20 // Nombre de comptes purgés par passage du cron
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// EXPORT DES DONNÉES (RGPD - Article 20)
+// ─────────────────────────────────────────────────────────────────────────────
+const (
+	ExportStatePending = 0  // En file d'attente
+	ExportStateRunning = 1  // Archive en cours de construction
+	ExportStateReady   = 2  // Archive disponible au téléchargement
+	ExportStateFailed  = -1 // Échec (une nouvelle demande est possible immédiatement)
+
+	ExportRetentionHours = 168               // 7 jours : durée de conservation de l'archive
+	ExportCooldownHours  = 24                // Une archive prête par jour au maximum
+	ExportURLTTLSeconds  = 900               // 15 minutes : validité du lien de téléchargement signé
+	ExportBatchSize      = 5                 // Archives construites par passage du cron
+	ExportLeaseSeconds   = 1800              // 30 minutes : bail d'une archive en construction (instance arrêtée au-delà)
+	ExportMaxAttempts    = 3                 // Constructions tentées avant de déclarer l'export en échec
+	ExportObjectPrefix   = "private/exports" // Préfixe MinIO (jamais exposé publiquement)
+)
@@ -4,6 +4,16 @@ const (
 	ToleranceTimeSeconds         = 300     // 5 minutes
 	JWTExpirationSeconds         = 900     // 15 minutes
 	MasterTokenExpirationSeconds = 2592000 // 1 mois en secondes
+	PasswordResetTokenTTLSeconds = 900     // 15 minutes (jeton de récupération à usage unique)
+)
+
+// ---------------------------------------------------------
+// IDEMPOTENCE (Idempotency-Key / X-Request-Id)
+// ---------------------------------------------------------
+const (
+	IdempotencyTTLSeconds     = 86400      // 24h : durée de conservation de la première réponse
+	IdempotencyLockSeconds    = 60         // Verrou "en cours" : libéré si le handler plante sans répondre
+	IdempotencyMaxStoredBytes = 256 * 1024 // Au-delà, la réponse n'est pas mémorisée (le verrou est libéré)
 )
 
 // ---------------------------------------------------------
@@ -14,3 +24,18 @@ const (
 	BoostLikes    = 1.5 // Augmente le poids des likes de 50%
 	BoostComments = 1.5 // Augmente le poids des commentaires de 50%
 )
+
+// ---------------------------------------------------------
+// TATOUAGE (URLs signées du micro-service Watermark)
+// ---------------------------------------------------------
+// Chaque lien porte sa propre échéance (MediaURLTTLSeconds), plafonnée par WatermarkURLMaxAgeSeconds.
+// Une révocation (post passé en privé ou supprimé, blocage, compte masqué) refuse les liens émis avant
+// elle ; elle n'a plus d'effet au-delà de la durée de vie maximale d'un lien et expire alors.
+const (
+	WatermarkURLMaxAgeSeconds    = 3600 // Au-delà, le lien signé est refusé (410) : le client redemande le post
+	WatermarkURLClockSkewSeconds = 60   // Tolérance d'horloge entre l'API et le micro-service
+	WatermarkCachePrefix         = "watermark-cache/"
+	WatermarkCacheExpirationDays = 1               // Règle de cycle de vie MinIO : les variantes expirées sont purgées
+	MediaURLTTLSeconds           = 600             // Durée de vie d'un lien émis (tatoué ou présigné pour l'auteur)
+	MediaRevocationPrefix        = "media:revoked" // Clés Redis "<préfixe>:<périmètre>" lues par le micro-service
+)
@@ -0,0 +1,110 @@
+package variables
+
+// ─────────────────────────────────────────────────────────────────────────────
+// STATUT DES MÉDIAS (content.media.status)
+// ─────────────────────────────────────────────────────────────────────────────
+// Les médias antérieurs à la file de traitement n'ont pas de statut : la valeur par défaut (0) vaut "prêt".
+const (
+	MediaStatusReady   = 0  // Déclinaisons stockées, média servable
+	MediaStatusPending = 1  // Original en zone de transit, traitement en attente ou en cours
+	MediaStatusFailed  = -1 // Traitement abandonné (fichier illisible ou tentatives épuisées)
+	// Proche d'une empreinte bloquée : déclinaisons produites mais jamais servies, dossier ouvert
+	MediaStatusQuarantined = -2
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// FILE DE TRAITEMENT DES MÉDIAS
+// ─────────────────────────────────────────────────────────────────────────────
+// L'original est déposé sous MediaStagingPrefix pendant la requête ; le décodage et l'encodage des
+// déclinaisons sont faits par un pool borné d'ouvriers. Un job réclamé porte un bail : s'il n'est pas
+// terminé à échéance (instance arrêtée en plein encodage), il est remis en file.
+const (
+	MediaStagingPrefix        = "staging/" // Préfixe MinIO des originaux en attente de traitement
+	MediaWorkerConcurrency    = 4          // Encodages simultanés par instance (CPU bound)
+	MediaJobPollSeconds       = 1          // Intervalle de scrutation de la file
+	MediaJobLeaseSeconds      = 900        // Durée du bail d'un job réclamé (au-delà du délai de transcodage vidéo)
+	MediaJobMaxAttempts       = 3          // Tentatives avant abandon (erreurs transitoires uniquement)
+	MediaJobRetryDelaySeconds = 30         // Délai avant nouvelle tentative (multiplié par le rang de l'essai)
+	MediaJobRetentionHours    = 24         // Conservation de l'état d'un job terminé
+	MediaAttachReserveSeconds = 300        // Réservation d'un média déposé à part, le temps de créer son post
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// VIDÉOS COURTES
+// ─────────────────────────────────────────────────────────────────────────────
+// Une vidéo est envoyée par morceaux (reprise possible après coupure réseau) dans un upload multipart
+// MinIO, puis transcodée en HLS par les ouvriers de traitement. Chaque morceau, sauf le dernier, fait
+// exactement VideoChunkBytes (minimum S3 d'une partie : 5 Mio).
+const (
+	MaxVideoBytes            = 200 << 20 // Taille maximale de l'original
+	MaxVideoDurationSeconds  = 60        // Durée maximale (contrôlée par sonde, la durée annoncée ne suffit pas)
+	VideoChunkBytes          = 5 << 20   // Taille d'un morceau d'upload
+	VideoUploadTTLHours      = 24        // Durée de vie d'une session d'upload inachevée
+	VideoMaxHeight           = 720       // Hauteur maximale du rendu HLS (pas d'agrandissement)
+	VideoSegmentSeconds      = 4         // Durée cible d'un segment HLS
+	VideoPosterOffsetMs      = 1000      // Image d'affiche prise à 1 s (ou au milieu d'une vidéo plus courte)
+	VideoObjectPrefix        = "video/"  // Préfixe MinIO des rendus HLS ("video/<base>/index.m3u8")
+	VideoTranscodeTimeoutMin = 10        // Délai maximal d'un transcodage
+)
+
+// VideoContentTypes liste les conteneurs acceptés à l'upload.
+var VideoContentTypes = map[string]bool{
+	"video/mp4":       true,
+	"video/quicktime": true,
+	"video/webm":      true,
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// EMPREINTES PERCEPTUELLES (doublons et contenus bloqués)
+// ─────────────────────────────────────────────────────────────────────────────
+// Chaque image (ou affiche de vidéo) reçoit un pHash et un dHash à l'encodage. Une correspondance exige
+// les deux seuils ; les empreintes des médias d'un dossier clos pour infraction dans une catégorie de
+// MediaHashBlockCategories sont bloquées, et tout nouvel envoi proche est mis en quarantaine.
+const (
+	MediaHashMaxDistance     = 10  // pHash : distance de Hamming maximale (<= phash.MaxIndexedDistance)
+	MediaDHashMaxDistance    = 14  // dHash : confirmation de la correspondance
+	MediaSimilarDefaultLimit = 50  // Taille de page par défaut de la recherche admin
+	MediaSimilarMaxLimit     = 200 // Plafond d'une page
+)
+
+// MediaHashBlockCategories liste les catégories dont la clôture pour infraction bloque les empreintes.
+var MediaHashBlockCategories = map[int]bool{
+	ReportCatUnderage:      true,
+	ReportCatNonConsensual: true,
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// RAMASSE-MIETTES DES OBJETS MINIO
+// ─────────────────────────────────────────────────────────────────────────────
+// Un objet média (image, déclinaison, rendu HLS, original en transit) qu'aucun média vivant ne réclame
+// est d'abord déplacé sous MediaTrashPrefix, puis supprimé après MediaTrashRetentionDays : une erreur de
+// rattachement reste réparable. Le délai de grâce couvre le Write-Behind (ligne pas encore en L3) et les
+// envois en cours. MEDIA_GC_DRY_RUN=true fait tourner le cron en simulation (rapport seul).
+const (
+	MediaGCIntervalHours    = 6        // Fréquence du cron
+	MediaGCGraceHours       = 24       // Âge minimal d'un objet (et d'un média non référencé) avant collecte
+	MediaGCMaxScanned       = 20000    // Objets listés par passage
+	MediaGCMaxTrashed       = 2000     // Objets déplacés dans la corbeille par passage
+	MediaGCBatchSize        = 500      // Objets rattachés par requête PostgreSQL
+	MediaGCLockSeconds      = 3600     // Verrou d'exécution (une seule instance à la fois)
+	MediaTrashPrefix        = "trash/" // Corbeille ("trash/<chemin d'origine>")
+	MediaTrashRetentionDays = 7        // Conservation dans la corbeille avant suppression définitive
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// PHOTO DE PROFIL & COUVERTURE
+// ─────────────────────────────────────────────────────────────────────────────
+// Le client propose un rectangle de l'original redressé ; le serveur le ramène au ratio du gabarit (recadrage
+// centré dans le rectangle) puis produit ses propres déclinaisons. Une photo de profil est toujours carrée :
+// le cercle inscrit est exactement le disque affiché sous un masque circulaire.
+const (
+	ProfileImageMinSide = 128 // Plus petit côté du recadrage, en pixels de l'original
+	CoverAspectWidth    = 3   // Ratio largeur:hauteur d'une couverture
+	CoverAspectHeight   = 1
+)
+
+// AvatarRenditionSizes et CoverRenditionWidths listent les déclinaisons des gabarits de profil.
+var (
+	AvatarRenditionSizes = []int{64, 160, 320, 640}
+	CoverRenditionWidths = []int{640, 1080, 1500}
+)
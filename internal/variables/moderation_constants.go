@@ -0,0 +1,137 @@
+package variables
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GRADES UTILISATEUR (auth.users.grade)
+// ─────────────────────────────────────────────────────────────────────────────
+const (
+	GradeNormal    = 0
+	GradeCertified = 1
+	GradePartner   = 2
+	GradeModerator = 3
+	GradeAdmin     = 4
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// JOURNAL D'AUDIT (moderation.audit_log)
+// ─────────────────────────────────────────────────────────────────────────────
+const (
+	AuditQueryDefaultLimit = 50  // Taille de page par défaut de la consultation admin
+	AuditQueryMaxLimit     = 200 // Plafond d'une page (la requête reste bornée même sans filtre)
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// FICHES D'ADMINISTRATION (information-user, -post, -comment, -message)
+// ─────────────────────────────────────────────────────────────────────────────
+const (
+	AdminRecentContentLimit = 20  // Posts et commentaires récents affichés sur une fiche utilisateur
+	AdminRevisionLimit      = 200 // Plafond de l'historique des modifications d'un contenu
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// INVESTIGATION FORENSIQUE (moderation.forensic_evidence)
+// ─────────────────────────────────────────────────────────────────────────────
+// Une image fuitée ne désigne son responsable qu'à travers un tatouage lu avec des erreurs : le dossier
+// n'est ouvert que si la probabilité d'une lecture exacte des identifiants atteint ForensicMinConfidence.
+const (
+	ForensicMaxUploadBytes        = 20 << 20               // Taille maximale d'une image soumise
+	ForensicMinConfidence         = 0.8                    // Seuil d'ouverture du dossier contre le responsable
+	ForensicCaseCategory          = ReportCatNonConsensual // Catégorie (et donc priorité) du dossier ouvert
+	ForensicClockSkewMinutes      = 5                      // Tolérance d'horloge sur l'horodatage tatoué
+	ForensicEvidenceURLTTLSeconds = 900                    // Validité du lien signé vers l'image versée au dossier
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// SANCTIONS (moderation.sanctions)
+// ─────────────────────────────────────────────────────────────────────────────
+const (
+	SanctionKindWarning     = 1 // Avertissement : compte comme un "strike"
+	SanctionKindRestriction = 2 // Restriction temporaire d'une ou plusieurs fonctionnalités
+	SanctionKindBan         = 3 // Bannissement (ExpiresAt nul = définitif)
+	SanctionKindRemoval     = 4 // Retrait de contenu confirmé : le contenu reste masqué, donc rétablissable, jusqu'à l'échéance
+)
+
+// Périmètres de restriction (bitmask cumulable)
+const (
+	RestrictionScopePost    = 1 << 0
+	RestrictionScopeComment = 1 << 1
+	RestrictionScopeMessage = 1 << 2
+	RestrictionScopeAll     = RestrictionScopePost | RestrictionScopeComment | RestrictionScopeMessage
+)
+
+// RestrictionScopeByName traduit les périmètres exposés par l'API en bits.
+var RestrictionScopeByName = map[string]int{
+	"post":    RestrictionScopePost,
+	"comment": RestrictionScopeComment,
+	"message": RestrictionScopeMessage,
+}
+
+const (
+	SanctionAppealWindowDays = 30  // Délai de contestation après l'émission d'une sanction
+	SanctionMaxDurationDays  = 365 // Durée maximale d'une restriction ou d'un ban temporaire
+	BanExpiryBatchSize       = 100 // Bans levés par passe du cron
+
+	// Échelle automatique des avertissements (strikes comptés sur une fenêtre glissante)
+	StrikeWindowDays       = 90
+	StrikesForRestriction  = 3 // → restriction totale de StrikeRestrictionDays
+	StrikeRestrictionDays  = 7
+	StrikesForTempBan      = 5 // → ban de StrikeTempBanDays
+	StrikeTempBanDays      = 30
+	StrikesForPermanentBan = 7 // → ban définitif
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// APPELS (moderation.appeals)
+// ─────────────────────────────────────────────────────────────────────────────
+const (
+	AppealStatePending  = 0  // En attente d'un modérateur
+	AppealStateInReview = 1  // Pris en charge
+	AppealStateDecided  = -1 // Décision rendue
+)
+
+const (
+	AppealOutcomeUpheld     = 1 // Sanction maintenue
+	AppealOutcomeOverturned = 2 // Sanction annulée (levée automatique)
+)
+
+// AppealSLAHours fixe le délai de traitement d'un appel selon la sanction contestée.
+var AppealSLAHours = map[int]int{
+	SanctionKindBan:         24,
+	SanctionKindRestriction: 48,
+	SanctionKindWarning:     72,
+	SanctionKindRemoval:     48,
+}
+
+const (
+	AppealQueueDefaultLimit = 50  // Taille de page par défaut de la file des appels
+	AppealQueueMaxLimit     = 200 // Plafond d'une page
+	AppealTokenTTLMinutes   = 30  // Validité du jeton d'appel remis à un compte banni
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// VISIBILITÉ DES CONTENUS (content.posts.visibility / content.comments.visibility)
+// ─────────────────────────────────────────────────────────────────────────────
+// 0 = public, 1 = abonnés, 2 = amis (posts). Les valeurs négatives ne sont jamais diffusées.
+const (
+	VisibilityDeleted     = -1 // Supprimé (soft delete)
+	VisibilityUnderReview = -2 // Masqué automatiquement en attendant la décision d'un modérateur
+	VisibilityProcessing  = -3 // Médias en cours de traitement, seul l'auteur le voit
+	VisibilityMediaFailed = -4 // Un média n'a pas pu être traité, seul l'auteur le voit
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// RETRAIT AUTOMATIQUE (moderation.takedowns)
+// ─────────────────────────────────────────────────────────────────────────────
+// Score d'un dossier = Σ sur les signalants distincts de poids(catégorie) × confiance(signalant).
+// La confiance vaut 2 × la part lissée des dossiers clos du signalant ayant confirmé une infraction,
+// 2 × (confirmés + 1) / (clos + 2) : 1 pour un inconnu, bornée à [ReporterTrustMin, ReporterTrustMax].
+const (
+	TakedownFlagScore    = 2.0 // Seuil de signalement : pénalité φ_mod sur le score de tendance
+	TakedownHideScore    = 6.0 // Seuil de retrait : le contenu passe en VisibilityUnderReview
+	ReporterTrustMin     = 0.25
+	ReporterTrustMax     = 2.0
+	TakedownBatchSize    = 200 // Dossiers évalués par passe du cron de tri
+	TakedownFlagTTLHours = 24  // Durée d'un marquage non rafraîchi (dossier oublié)
+	TakedownPurgeBatch   = 100 // Retraits confirmés supprimés définitivement par passe du cron
+
+	TakedownReleaseGraceSeconds = 60 // Délai avant de rejouer la libération d'un retrait d'un dossier clos
+)
@@ -36,3 +36,57 @@ const (
 	ReportStateEscalated  = 2  // Escaladé à un supérieur
 	ReportStateClosed     = -1 // Fermé / Traité
 )
+
+// ─────────────────────────────────────────────────────────────────────────────
+// PRIORITÉ DE TRAITEMENT (file de modération, plus haut = traité en premier)
+// ─────────────────────────────────────────────────────────────────────────────
+var ReportCategoryPriority = map[int]int{
+	ReportCatUnderage:         100, // Mineur : traitement immédiat, obligation légale
+	ReportCatNonConsensual:    90,  // Contenu non consenti : retrait en urgence
+	ReportCatSelfHarm:         80,
+	ReportCatIllegalContent:   70,
+	ReportCatHarassmentSexual: 60,
+	ReportCatHateSpeech:       50,
+	ReportCatHarassmentMoral:  40,
+	ReportCatIdentityTheft:    30,
+	ReportCatOther:            20,
+	ReportCatSpam:             10,
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// POIDS D'UN SIGNALEMENT (retrait automatique, voir TakedownHideScore)
+// ─────────────────────────────────────────────────────────────────────────────
+// Un seul signalement crédible suffit pour les catégories critiques ; le spam exige un volume réel.
+var ReportCategoryWeight = map[int]float64{
+	ReportCatUnderage:         6.0,
+	ReportCatNonConsensual:    6.0,
+	ReportCatSelfHarm:         3.0,
+	ReportCatIllegalContent:   3.0,
+	ReportCatHarassmentSexual: 2.0,
+	ReportCatHateSpeech:       2.0,
+	ReportCatHarassmentMoral:  1.5,
+	ReportCatIdentityTheft:    1.0,
+	ReportCatOther:            0.5,
+	ReportCatSpam:             0.5,
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// CODES DE RÉSOLUTION (clôture d'un dossier)
+// ─────────────────────────────────────────────────────────────────────────────
+const (
+	ReportResolutionNoViolation    = 1 // Aucune infraction constatée
+	ReportResolutionContentRemoved = 2 // Contenu retiré
+	ReportResolutionUserWarned     = 3 // Auteur averti
+	ReportResolutionUserRestricted = 4 // Auteur restreint
+	ReportResolutionUserBanned     = 5 // Auteur banni
+	ReportResolutionDuplicate      = 6 // Doublon d'un dossier déjà traité
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// FILE DE MODÉRATION
+// ─────────────────────────────────────────────────────────────────────────────
+const (
+	ReportQueueDefaultLimit = 50  // Taille de page par défaut de la file
+	ReportQueueMaxLimit     = 200 // Plafond d'une page
+	ReportTriageBatchSize   = 500 // Signalements rattachés à un dossier par passe du cron
+)
@@ -17,3 +17,19 @@ const (
 	VectorOffEng  = 152 // début bloc engagement
 	VectorOffSoc  = 160 // début bloc social
 )
+
+// ================================================================
+// PROFIL D'INTÉRÊT — vecteur u calculé côté client (EMA, README §1.2)
+// ================================================================
+const (
+	VectorVersion       = 1    // Version du schéma de u : un changement de blocs ou d'encodage l'incrémente
+	VectorNormTolerance = 1e-3 // Écart toléré |‖u‖₂ − 1| (u est normalisé L2 côté client)
+
+	ProfileSyncMinIntervalSeconds = 60  // Délai minimal entre deux synchronisations d'un même utilisateur
+	ProfileClockSkewSeconds       = 300 // Avance tolérée de computed_at sur l'horloge serveur
+	ProfileMaxInteractionsPerSync = 500 // Plafond des interactions déclarées par synchronisation
+
+	// Confiance c = (1 − e^(−n/ProfileConfidenceScale)) · 2^(−âge/ProfileHalfLifeDays)
+	ProfileConfidenceScale = 200.0 // n interactions cumulées : c ≈ 0.63 à 200, ≈ 0.95 à 600
+	ProfileHalfLifeDays    = 21.0  // Un profil non resynchronisé perd la moitié de sa confiance en 3 semaines
+)
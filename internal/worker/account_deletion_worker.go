@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/service/auth_service"
+)
+
+// StartAccountDeletionCron lance l'exécuteur des suppressions de comptes planifiées.
+// Il tourne toutes les 10 minutes : le délai de grâce se compte en jours, la précision suffit largement.
+func StartAccountDeletionCron(ctx context.Context) {
+	log.Println("🗑️ Démarrage de l'exécuteur de suppressions de comptes (10m)...")
+	go func() {
+		ticker := time.NewTicker(10 * time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if n := auth_service.ExecuteDueAccountDeletions(ctx); n > 0 {
+					log.Printf("🗑️ %d compte(s) supprimé(s) définitivement.", n)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,33 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/service/export_service"
+)
+
+// StartAccountExportCron lance le constructeur d'archives RGPD ("Télécharger mes données").
+// Il tourne toutes les minutes, remet en file les constructions dont le bail a expiré, puis traite au plus
+// ExportBatchSize demandes par passage.
+func StartAccountExportCron(ctx context.Context) {
+	log.Println("📦 Démarrage du constructeur d'exports de données (1m)...")
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if n := export_service.RequeueExpiredExports(ctx); n > 0 {
+					log.Printf("🔁 %d export(s) de données remis en file (bail expiré).", n)
+				}
+				if n := export_service.ProcessPendingExports(ctx); n > 0 {
+					log.Printf("📦 %d archive(s) de données prête(s).", n)
+				}
+			}
+		}
+	}()
+}
@@ -13,14 +13,15 @@ import (
 
 // ScoreJob contient les métriques pré-calculées par SQL pour éviter l'hydratation N+1
 type ScoreJob struct {
-	PostID       int64
-	LikeCount    int
-	CommentCount int
-	ViewCount    int // NOUVEAU : Indispensable pour ne pas perdre les vues au recalcul
-	HasMedia     bool
-	CreatedAt    time.Time
-	Hashtags     []string
-	Visibility   int
+	PostID        int64
+	LikeCount     int
+	CommentCount  int
+	ViewCount     int // NOUVEAU : Indispensable pour ne pas perdre les vues au recalcul
+	HasMedia      bool
+	CreatedAt     time.Time
+	Hashtags      []string
+	Visibility    int
+	PriorityLevel int
 }
 
 // StartScoreUpdaterCron initialise le Worker Pool basé sur le nombre de threads CPU
@@ -45,7 +46,6 @@ func StartScoreUpdaterCron(ctx context.Context) {
 						mediaCount = 1
 					}
 					// Appel du moteur mathématique pur. BDD = 0, Redis = Max
-					// TODO rendre dynamique isFlagged
 					cache_service.UpdateScoreWithMetrics(
 						ctx,
 						job.PostID,
@@ -56,7 +56,8 @@ func StartScoreUpdaterCron(ctx context.Context) {
 						job.CreatedAt,
 						job.Hashtags,
 						job.Visibility,
-						0,
+						cache_service.IsPostFlagged(ctx, job.PostID),
+						job.PriorityLevel,
 					)
 				}
 			}
@@ -80,11 +81,12 @@ func runTierCron(ctx context.Context, jobs chan<- ScoreJob, interval time.Durati
 
 	// CORRECTION SQL : Ajout de view_count et visibility dans le SELECT
 	query := `
-		SELECT id, like_count, comment_count, view_count, has_media, created_at, hashtags, visibility
+		SELECT id, like_count, comment_count, view_count, has_media, created_at, hashtags, visibility, priority_level
 		FROM content.posts 
 		WHERE created_at <= NOW() - $1::interval 
 		AND created_at > NOW() - $2::interval 
 		AND visibility != 2
+		AND visibility >= 0
 	`
 
 	for {
@@ -110,6 +112,7 @@ func runTierCron(ctx context.Context, jobs chan<- ScoreJob, interval time.Durati
 					&job.CreatedAt,
 					pq.Array(&job.Hashtags),
 					&job.Visibility,
+					&job.PriorityLevel,
 				)
 				if err != nil {
 					log.Printf("⚠️ Erreur de scan dans runTierCron: %v", err)
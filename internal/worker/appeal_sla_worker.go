@@ -0,0 +1,34 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/service/appeal_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/report_service"
+)
+
+// StartAppealSLACron signale les appels non tranchés dont l'échéance de traitement est dépassée.
+// Toutes les 5 minutes : les délais se comptent en heures, chaque dépassement n'est journalisé qu'une fois.
+// Le même passage supprime les contenus retirés dont le délai d'appel est écoulé sans appel en attente.
+func StartAppealSLACron(ctx context.Context) {
+	log.Println("⏱️ Démarrage du suivi des délais d'appel (5m)...")
+	go func() {
+		ticker := time.NewTicker(5 * time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if n := appeal_service.FlagOverdueAppeals(ctx); n > 0 {
+					log.Printf("⏱️ %d appel(s) hors délai.", n)
+				}
+				if n := report_service.PurgeConfirmedTakedowns(ctx); n > 0 {
+					log.Printf("🗑️ %d contenu(s) retiré(s) supprimé(s) à l'issue du délai d'appel.", n)
+				}
+			}
+		}
+	}()
+}
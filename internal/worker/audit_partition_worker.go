@@ -0,0 +1,34 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/repository/postgres"
+)
+
+// StartAuditPartitionCron garantit l'existence des partitions mensuelles du journal d'audit.
+// Exécuté au démarrage puis toutes les 24h : le mois suivant est toujours prêt avant son premier jour.
+func StartAuditPartitionCron(ctx context.Context) {
+	log.Println("🛡️ Démarrage du gestionnaire de partitions du journal d'audit (24h)...")
+	go func() {
+		ensure := func() {
+			if err := postgres.FuncEnsureAuditLogPartitions(ctx, time.Now().UTC()); err != nil {
+				log.Printf("⚠️ Partitions du journal d'audit : %v", err)
+			}
+		}
+		ensure()
+
+		ticker := time.NewTicker(24 * time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				ensure()
+			}
+		}
+	}()
+}
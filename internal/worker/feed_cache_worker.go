@@ -115,73 +115,19 @@ func mockGetTelemetryData(userID int64) (time.Time, bool) {
 
 // handleSocialFanOut intercepte les créations de posts pour distribuer l'ID
 // dans les boîtes aux lettres Redis ciblées (Amis ou Abonnés).
+// Un post avec médias est créé masqué : sa distribution a lieu à la publication (media_service.finalizePost).
 func handleSocialFanOut(ctx context.Context, events []redis.AsyncEvent) {
 	for _, evt := range events {
 		// On ne cible que les créations de posts réussies
 		if evt.Type == redis.EntityPost && evt.Action == redis.ActionCreate {
-			postID := evt.ID
-
-			// 1. Vérification absolue via le fallback BDD/Cache (Sécurité & Visibilité)
+			// Vérification absolue via le fallback BDD/Cache (Sécurité & Visibilité)
 			// getPostWithFallback est disponible dans le package worker (défini dans most_cache_worker.go)
-			p, err := getPostWithFallback(ctx, postID)
-			if err != nil || p.Visibility == -1 {
-				continue // Le post a été supprimé ou est introuvable entre temps
-			}
-
-			authorID := p.UserID
-			var targetIDs []int64
-
-			// 2. LE FILTRE DE VISIBILITÉ ET LE FAN-OUT HYBRIDE
-			if p.Visibility == 2 {
-				// ✅ CAS 1 : Post Privé (Amis Uniquement)
-				// On ne l'envoie qu'aux amis (graphe bidirectionnel) pour ne pas polluer les simples abonnés.
-				// (Assure-toi d'avoir implémenté GetSpeedFriends dans cache_service)
-				targetIDs, err = cache_service.GetSpeedFriends(ctx, authorID)
-			} else {
-				// ✅ CAS 2 : Post Public ou Abonnés (Visibility 0 ou 1)
-				// Protection Anti-Crash "Justin Bieber" : On compte avant de charger en RAM
-				// (Assure-toi d'avoir implémenté GetFollowerCount dans cache_service, via un ZCARD par exemple)
-				followerCount := cache_service.GetFollowerCount(ctx, authorID)
-				if followerCount > 50000 {
-					log.Printf("🛡️ [FanOut] Annulé pour le VIP %d (%d abonnés). Délégation au Most Cache Global.", authorID, followerCount)
-					continue
-				}
-
-				targetIDs, err = cache_service.GetSpeedFollowers(ctx, authorID)
-			}
-
-			if err != nil {
-				log.Printf("⚠️ [FanOut] Impossible de lire le graphe de l'user %d: %v", authorID, err)
-				continue
-			}
-
-			if len(targetIDs) == 0 {
-				continue // L'utilisateur n'a pas d'audience (Ville fantôme locale), rien à distribuer
+			p, err := getPostWithFallback(ctx, evt.ID)
+			if err != nil || p.Visibility < 0 {
+				continue // Le post a été supprimé, masqué ou est introuvable entre temps
 			}
 
-			// 3. Distribution de masse via Redis Pipeline encapsulé (DDD)
-			pipe := redis.FeedsMailbox.Pipeline()
-			score := float64(time.Now().UnixMilli()) // Le score chronologique absolu
-
-			// ✅ CORRECTION : On itère sur targetIDs (qui contient les amis ou les abonnés filtrés)
-			for _, followerID := range targetIDs {
-				// ✅ Génération propre de la clé via le wrapper de manager.go
-				mailboxKey := redis.FeedsMailbox.Key(followerID)
-
-				// ✅ On utilise pipe.Do() pour éviter les erreurs de structure avec redisgo.Z{}
-				pipe.Do(ctx, "ZADD", mailboxKey, score, postID)
-
-				// ✅ ZREMRANGEBYRANK remplace LTRIM.
-				// En supprimant du rang 0 au rang -501, on demande à Redis de ne conserver que
-				// les 500 posts avec le score le plus élevé (les plus récents).
-				pipe.Do(ctx, "ZREMRANGEBYRANK", mailboxKey, 0, -501)
-			}
-
-			// Exécution atomique du lot de distribution
-			_, err = pipe.Exec(ctx)
-			if err != nil {
-				log.Printf("❌ [FanOut] Échec de l'exécution du pipeline de distribution pour le post_service %d: %v", postID, err)
-			}
+			cache_service.FanOutPostToMailboxes(ctx, p.UserID, evt.ID, p.Visibility)
 		}
 	}
 }
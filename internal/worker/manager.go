@@ -27,6 +27,30 @@ func StartBackgroundWorkers(ctx context.Context) {
 	// Lancement du Moteur de Warm-up Algorithmique (Génération asynchrone des flux)
 	StartFeedWarmupCron(ctx)
 
+	// Lancement de l'exécuteur des suppressions de comptes (délai de grâce écoulé)
+	StartAccountDeletionCron(ctx)
+
+	// Lancement du constructeur d'archives RGPD (export des données utilisateur)
+	StartAccountExportCron(ctx)
+
+	// Lancement du pool de traitement des médias (déclinaisons AVIF/JPEG)
+	StartMediaProcessingWorkers(ctx)
+
+	// Lancement du gestionnaire de partitions mensuelles du journal d'audit
+	StartAuditPartitionCron(ctx)
+
+	// Lancement du tri des signalements (agrégation en dossiers de modération)
+	StartReportTriageCron(ctx)
+
+	// Lancement de la levée des bans temporaires échus
+	StartSanctionExpiryCron(ctx)
+
+	// Lancement du suivi des délais de traitement des appels
+	StartAppealSLACron(ctx)
+
+	// Lancement du ramasse-miettes des objets médias orphelins
+	StartMediaGCCron(ctx)
+
 	// On lance 64 goroutines (une par shard Redis)
 	for i := 0; i < redis.QueueShards; i++ {
 		wg.Add(1)
@@ -4,12 +4,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/QuentinRegnier/nubo-backend/internal/domain/models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/account_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/audit_models"
 	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/auth_models"
 	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/comment_models"
 	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/post_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/profile_models"
 	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/report_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/revision_models"
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/models/sanction_models"
 	"github.com/QuentinRegnier/nubo-backend/internal/repository/redis"
 	"github.com/lib/pq"
 )
@@ -30,6 +36,10 @@ func GetMapper(entity redis.EntityType) EntityMapper {
 		return &UserMapper{}
 	case redis.EntitySession:
 		return &SessionMapper{}
+	case redis.EntityAccountLifecycle:
+		return &AccountLifecycleMapper{}
+	case redis.EntityInterestProfile:
+		return &InterestProfileMapper{}
 	// case redis.EntityRelation:
 	// 	return &RelationMapper{}
 
@@ -42,6 +52,8 @@ func GetMapper(entity redis.EntityType) EntityMapper {
 		return &MediaMapper{}
 	case redis.EntityLike:
 		return &LikeMapper{}
+	case redis.EntityRevision:
+		return &RevisionMapper{}
 
 	// // --- MESSAGING ---
 	// case redis.EntityMessage:
@@ -54,6 +66,10 @@ func GetMapper(entity redis.EntityType) EntityMapper {
 	// --- MODERATION ---
 	case redis.EntityReport:
 		return &ReportMapper{}
+	case redis.EntityAuditLog:
+		return &AuditLogMapper{}
+	case redis.EntitySanction:
+		return &SanctionMapper{}
 
 	default:
 		return nil
@@ -75,7 +91,7 @@ func (m *UserMapper) Columns() []string {
 		"password_hash", "first_name", "last_name", "birthdate", "sex", "bio",
 		"profile_picture_id", "grade", "location", "school", "work", "badges",
 		"desactivated", "banned", "ban_reason", "ban_expires_at",
-		"created_at", "updated_at",
+		"created_at", "updated_at", "cover_picture_id",
 	}
 }
 
@@ -97,7 +113,7 @@ func (m *UserMapper) ToRow(data any) ([]any, error) {
 		u.PasswordHash, u.FirstName, u.LastName, u.Birthdate, u.Sex, u.Bio,
 		u.ProfilePictureID, u.Grade, u.Location, u.School, u.Work, pq.Array(u.Badges),
 		u.Desactivated, u.Banned, u.BanReason, u.BanExpiresAt,
-		u.CreatedAt, u.UpdatedAt,
+		u.CreatedAt, u.UpdatedAt, u.CoverPictureID,
 	}, nil
 }
 
@@ -144,6 +160,77 @@ func (m *SessionMapper) BuildUpdateQuery(tempTable string) string {
 	return buildGenericUpdateQuery(m.TableName(), tempTable, m.Columns())
 }
 
+// --- ACCOUNT LIFECYCLE MAPPER (auth.account_lifecycle, append-only) ---
+type AccountLifecycleMapper struct{}
+
+func (m *AccountLifecycleMapper) TableName() string { return "auth.account_lifecycle" }
+
+func (m *AccountLifecycleMapper) Columns() []string {
+	return []string{
+		"id", "user_id", "state", "requested_at", "scheduled_for", "completed_at",
+		"report", "digest", "created_at",
+	}
+}
+
+func (m *AccountLifecycleMapper) ToRow(data any) ([]any, error) {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var lc account_models.AccountLifecyclePayload
+	if err := json.Unmarshal(jsonBytes, &lc); err != nil {
+		return nil, err
+	}
+
+	// Le reçu de suppression est stocké en JSONB (NULL tant que la cascade n'a pas eu lieu)
+	var report any
+	if len(lc.Report) > 0 {
+		reportBytes, err := json.Marshal(lc.Report)
+		if err != nil {
+			return nil, err
+		}
+		report = string(reportBytes)
+	}
+
+	return []any{
+		lc.ID, lc.UserID, lc.State, nullableTime(lc.RequestedAt), nullableTime(lc.ScheduledFor), nullableTime(lc.CompletedAt),
+		report, lc.Digest, lc.CreatedAt,
+	}, nil
+}
+
+// Les événements de cycle de vie sont immuables : aucune mise à jour n'est jamais émise.
+func (m *AccountLifecycleMapper) BuildUpdateQuery(_ string) string { return "" }
+
+// --- INTEREST PROFILE MAPPER (auth.interest_profiles, id = user_id) ---
+type InterestProfileMapper struct{}
+
+func (m *InterestProfileMapper) TableName() string { return "auth.interest_profiles" }
+
+func (m *InterestProfileMapper) Columns() []string {
+	return []string{
+		"id", "version", "vector", "interactions", "syncs", "computed_at", "synced_at", "created_at",
+	}
+}
+
+func (m *InterestProfileMapper) ToRow(data any) ([]any, error) {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var p profile_models.InterestProfilePayload
+	if err := json.Unmarshal(jsonBytes, &p); err != nil {
+		return nil, err
+	}
+
+	return []any{
+		p.ID, p.Version, pq.Array(p.Vector), p.Interactions, p.Syncs, p.ComputedAt, p.SyncedAt, p.CreatedAt,
+	}, nil
+}
+
+func (m *InterestProfileMapper) BuildUpdateQuery(tempTable string) string {
+	return buildGenericUpdateQuery(m.TableName(), tempTable, m.Columns())
+}
+
 // // --- RELATION MAPPER (auth.relations) ---
 // type RelationMapper struct{}
 
@@ -219,7 +306,7 @@ type MediaMapper struct{}
 func (m *MediaMapper) TableName() string { return "content.media" }
 
 func (m *MediaMapper) Columns() []string {
-	return []string{"id", "owner_id", "storage_path", "visibility", "created_at", "updated_at"}
+	return []string{"id", "owner_id", "type", "storage_path", "renditions", "video", "metadata", "hash", "visibility", "status", "created_at", "updated_at"}
 }
 
 func (m *MediaMapper) ToRow(data any) ([]any, error) {
@@ -231,9 +318,31 @@ func (m *MediaMapper) ToRow(data any) ([]any, error) {
 	if err := json.Unmarshal(jsonBytes, &med); err != nil {
 		return nil, err
 	}
+	if med.Renditions == nil {
+		med.Renditions = []models.MediaRendition{}
+	}
+	renditions, err := json.Marshal(med.Renditions)
+	if err != nil {
+		return nil, err
+	}
+	if med.Type == "" {
+		med.Type = models.MediaTypeImage
+	}
+	video, err := json.Marshal(med.Video)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := json.Marshal(med.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := json.Marshal(med.Hash)
+	if err != nil {
+		return nil, err
+	}
 
 	return []any{
-		med.ID, med.OwnerID, med.StoragePath, med.Visibility, med.CreatedAt, med.UpdatedAt,
+		med.ID, med.OwnerID, med.Type, med.StoragePath, string(renditions), string(video), string(metadata), string(hash), med.Visibility, med.Status, med.CreatedAt, med.UpdatedAt,
 	}, nil
 }
 
@@ -299,6 +408,37 @@ func (m *LikeMapper) ToRow(data any) ([]any, error) {
 // Pas d'update sur les likes (le paramètre requis par l'interface est ignoré via '_')
 func (m *LikeMapper) BuildUpdateQuery(_ string) string { return "" }
 
+// --- REVISION MAPPER (content.revisions, append-only) ---
+type RevisionMapper struct{}
+
+func (m *RevisionMapper) TableName() string { return "content.revisions" }
+
+func (m *RevisionMapper) Columns() []string {
+	return []string{"id", "target_type", "target_id", "editor_id", "snapshot", "edited_at"}
+}
+
+func (m *RevisionMapper) ToRow(data any) ([]any, error) {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var r revision_models.RevisionPayload
+	if err := json.Unmarshal(jsonBytes, &r); err != nil {
+		return nil, err
+	}
+
+	// L'état archivé est stocké en JSONB
+	snapshot, err := json.Marshal(r.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return []any{r.ID, r.TargetType, r.TargetID, r.EditorID, string(snapshot), r.EditedAt}, nil
+}
+
+// L'historique est immuable : aucune mise à jour n'est jamais émise.
+func (m *RevisionMapper) BuildUpdateQuery(_ string) string { return "" }
+
 // // ============================================================================
 // //                                MESSAGING SCHEMA
 // // ============================================================================
@@ -413,10 +553,97 @@ func (m *ReportMapper) BuildUpdateQuery(tempTable string) string {
 	return buildGenericUpdateQuery(m.TableName(), tempTable, m.Columns())
 }
 
+// --- AUDIT LOG MAPPER (moderation.audit_log, append-only) ---
+type AuditLogMapper struct{}
+
+func (m *AuditLogMapper) TableName() string { return "moderation.audit_log" }
+
+func (m *AuditLogMapper) Columns() []string {
+	return []string{
+		"id", "actor_id", "actor_ip", "action", "target_type", "target_id", "metadata", "created_at",
+	}
+}
+
+func (m *AuditLogMapper) ToRow(data any) ([]any, error) {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var e audit_models.AuditEntryPayload
+	if err := json.Unmarshal(jsonBytes, &e); err != nil {
+		return nil, err
+	}
+
+	// Métadonnées libres stockées en JSONB (NULL si absentes)
+	var metadata any
+	if len(e.Metadata) > 0 {
+		metaBytes, err := json.Marshal(e.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		metadata = string(metaBytes)
+	}
+
+	return []any{
+		e.ID, e.ActorID, e.ActorIP, e.Action, e.TargetType, e.TargetID, metadata, e.CreatedAt,
+	}, nil
+}
+
+// Le journal d'audit est immuable : aucune mise à jour n'est jamais émise.
+func (m *AuditLogMapper) BuildUpdateQuery(_ string) string { return "" }
+
+// --- SANCTION MAPPER (moderation.sanctions) ---
+type SanctionMapper struct{}
+
+func (m *SanctionMapper) TableName() string { return "moderation.sanctions" }
+
+func (m *SanctionMapper) Columns() []string {
+	return []string{
+		"id", "user_id", "kind", "scopes", "reason", "case_id", "issued_by", "automatic",
+		"issued_at", "expires_at", "appealable_until", "lifted_at", "lifted_by", "lift_reason",
+	}
+}
+
+func (m *SanctionMapper) ToRow(data any) ([]any, error) {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var s sanction_models.SanctionPayload
+	if err := json.Unmarshal(jsonBytes, &s); err != nil {
+		return nil, err
+	}
+
+	return []any{
+		s.ID, s.UserID, s.Kind, s.Scopes, s.Reason, nullableID(s.CaseID), s.IssuedBy, s.Automatic,
+		s.IssuedAt, nullableTime(s.ExpiresAt), s.AppealableUntil, nullableTime(s.LiftedAt), nullableID(s.LiftedBy), s.LiftReason,
+	}, nil
+}
+
+func (m *SanctionMapper) BuildUpdateQuery(tempTable string) string {
+	return buildGenericUpdateQuery(m.TableName(), tempTable, m.Columns())
+}
+
 // ============================================================================
 //                                UTILITAIRES
 // ============================================================================
 
+// nullableTime convertit une date zéro en NULL SQL.
+func nullableTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+// nullableID convertit un identifiant nul (0) en NULL SQL.
+func nullableID(id int64) any {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
 // buildGenericUpdateQuery génère la requête SQL "UPDATE ... FROM temp_table" automatiquement
 func buildGenericUpdateQuery(tableName, tempTable string, columns []string) string {
 	var sets []string
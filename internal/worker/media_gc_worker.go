@@ -0,0 +1,43 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/domain/nubo_error"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/audit"
+	"github.com/QuentinRegnier/nubo-backend/internal/service/media_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// StartMediaGCCron met à la corbeille les objets MinIO qu'aucun média vivant ne référence,
+// et purge la corbeille échue. Un verrou Redis garantit un seul passage à la fois entre les instances.
+// MEDIA_GC_DRY_RUN=true : les passages planifiés se contentent du rapport, sans rien déplacer.
+func StartMediaGCCron(ctx context.Context) {
+	dryRun := os.Getenv("MEDIA_GC_DRY_RUN") == "true"
+	log.Printf("🧹 Démarrage du ramasse-miettes des médias (%dh, simulation : %t)...", variables.MediaGCIntervalHours, dryRun)
+	go func() {
+		ticker := time.NewTicker(variables.MediaGCIntervalHours * time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				report, err := media_service.CollectOrphanMedia(ctx, audit.System, dryRun)
+				if errors.Is(err, nubo_error.ErrMediaGCRunning) {
+					continue
+				}
+				if err != nil {
+					log.Printf("⚠️ Ramasse-miettes des médias : %v", err)
+					continue
+				}
+				log.Printf("🧹 Ramasse-miettes : %d objet(s) examiné(s), %d orphelin(s) (%d octets à la corbeille), %d octets libérés (simulation : %t)",
+					report.Scanned, report.Orphans, report.TrashedBytes, report.ReclaimedBytes, report.DryRun)
+			}
+		}
+	}()
+}
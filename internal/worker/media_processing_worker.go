@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/service/media_service"
+	"github.com/QuentinRegnier/nubo-backend/internal/variables"
+)
+
+// StartMediaProcessingWorkers lance le pool borné d'encodage des médias (MediaWorkerConcurrency jobs
+// simultanés par instance) et la récupération des baux expirés (instance arrêtée en plein encodage).
+func StartMediaProcessingWorkers(ctx context.Context) {
+	log.Printf("🖼️ Démarrage du traitement des médias (%d ouvriers)...", variables.MediaWorkerConcurrency)
+	slots := make(chan struct{}, variables.MediaWorkerConcurrency)
+
+	go func() {
+		poll := time.NewTicker(time.Duration(variables.MediaJobPollSeconds) * time.Second)
+		reap := time.NewTicker(time.Minute)
+		defer poll.Stop()
+		defer reap.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-reap.C:
+				if n := media_service.RequeueExpiredMediaJobs(ctx); n > 0 {
+					log.Printf("🖼️ %d job(s) média remis en file (bail expiré).", n)
+				}
+			case <-poll.C:
+				// Seule cette boucle occupe les places : le nombre réclamé ne dépasse jamais les places libres
+				free := cap(slots) - len(slots)
+				if free == 0 {
+					continue
+				}
+				for _, mediaID := range media_service.ClaimMediaJobs(ctx, free) {
+					slots <- struct{}{}
+					go func(mediaID int64) {
+						defer func() { <-slots }()
+						if err := media_service.ProcessMediaJob(ctx, mediaID); err != nil {
+							log.Printf("⚠️ Traitement média : %v", err)
+						}
+					}(mediaID)
+				}
+			}
+		}
+	}()
+}
@@ -77,7 +77,7 @@ func flushMongo(ctx context.Context, events []redis.AsyncEvent) {
 			case redis.ActionUpdate:
 				// UpdateOneModel ($set)
 				models = append(models, libMongo.NewUpdateOneModel().
-					SetFilter(bson.M{"_id": e.ID}).
+					SetFilter(bson.M{"id": e.ID}).
 					SetUpdate(bson.M{"$set": e.Payload}))
 
 			// ... dans la boucle switch e.Action de flushMongo ...
@@ -25,9 +25,15 @@ func updateMostCache(ctx context.Context, events []redis.AsyncEvent) {
 			if err == nil {
 				var post post_models.PostPayload
 				if err := json.Unmarshal(jsonBytes, &post); err == nil {
-					// Uniquement de l'algorithmique (Global & Personnalisé)
-					cache_service.UpdatePostRecommendationScore(ctx, post)
-					algorithm_service.StoreContentVector(ctx, post)
+					if post.Visibility < 0 {
+						// Post masqué par la modération : retrait des classements et des seaux LSH
+						_, _ = cache_service.RemovePostsFromMostCache(ctx, []int64{post.ID})
+						_ = algorithm_service.PurgePostVectors(ctx, post.ID)
+					} else {
+						// Uniquement de l'algorithmique (Global & Personnalisé)
+						cache_service.UpdatePostRecommendationScore(ctx, post)
+						algorithm_service.StoreContentVector(ctx, post)
+					}
 				}
 			}
 		}
@@ -76,7 +82,7 @@ func updateMostCache(ctx context.Context, events []redis.AsyncEvent) {
 
 					if targetID != 0 {
 						p, err := getPostWithFallback(ctx, targetID)
-						if err == nil && p.Visibility != -1 {
+						if err == nil && p.Visibility >= 0 {
 
 							// VÉRIFICATION DES DROITS ASYNCHRONE
 							if interactionEvent.UserID != 0 && p.UserID != interactionEvent.UserID {
@@ -114,7 +120,7 @@ func updateMostCache(ctx context.Context, events []redis.AsyncEvent) {
 
 				if err := json.Unmarshal(jsonBytes, &commentEvent); err == nil && commentEvent.PostID != 0 {
 					p, err := getPostWithFallback(ctx, commentEvent.PostID)
-					if err == nil && p.Visibility != -1 {
+					if err == nil && p.Visibility >= 0 {
 						// ÉVALUATION ALGORITHMIQUE UNIQUEMENT
 						cache_service.UpdatePostRecommendationScore(ctx, p)
 					}
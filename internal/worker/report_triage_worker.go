@@ -0,0 +1,36 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/service/report_service"
+)
+
+// StartReportTriageCron rattache les nouveaux signalements à leur dossier de modération, puis évalue
+// les seuils de retrait automatique des dossiers ouverts et rejoue la libération des retraits des dossiers clos.
+// Toutes les 30 secondes : un signalement apparaît dans la file moins d'une minute après son envoi.
+func StartReportTriageCron(ctx context.Context) {
+	log.Println("🚩 Démarrage du tri des signalements (30s)...")
+	go func() {
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if n := report_service.TriageNewReports(ctx); n > 0 {
+					log.Printf("🚩 %d signalement(s) rattaché(s) à un dossier.", n)
+				}
+				if n := report_service.EvaluateTakedowns(ctx); n > 0 {
+					log.Printf("🚩 %d contenu(s) masqué(s) en attente de modération.", n)
+				}
+				if n := report_service.ReleaseClosedCaseTakedowns(ctx); n > 0 {
+					log.Printf("🚩 %d retrait(s) de dossiers clos libéré(s) après coup.", n)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/QuentinRegnier/nubo-backend/internal/service/sanction_service"
+)
+
+// StartSanctionExpiryCron lève les bans temporaires arrivés à échéance.
+// Toutes les minutes : un utilisateur retrouve son compte au plus une minute après la fin de son ban.
+// Les restrictions n'ont pas besoin de cron : leur index L1 expire avec son TTL.
+func StartSanctionExpiryCron(ctx context.Context) {
+	log.Println("⚖️ Démarrage de la levée des bans temporaires (1m)...")
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if n := sanction_service.LiftExpiredBans(ctx); n > 0 {
+					log.Printf("⚖️ %d ban(s) temporaire(s) levé(s).", n)
+				}
+			}
+		}
+	}()
+}
@@ -19,8 +19,13 @@ func updateUserCache(ctx context.Context, events []redis.AsyncEvent) {
 			if err == nil {
 				var post post_models.PostPayload
 				if err := json.Unmarshal(jsonBytes, &post); err == nil {
-					// ✅ Ajout du post dans la vitrine du profil de l'utilisateur
-					_ = cache_service.AddPostToUserProfile(ctx, post.UserID, post.ID, float64(post.CreatedAt.UnixMilli()))
+					if post.Visibility < 0 {
+						// Post masqué par la modération : il quitte la vitrine jusqu'à la décision
+						_ = cache_service.RemovePostFromUserProfile(ctx, post.UserID, post.ID)
+					} else {
+						// ✅ Ajout du post dans la vitrine du profil de l'utilisateur
+						_ = cache_service.AddPostToUserProfile(ctx, post.UserID, post.ID, float64(post.CreatedAt.UnixMilli()))
+					}
 				}
 			}
 		}
@@ -170,8 +170,8 @@ func purifyBatch(ctx context.Context, events []redis.AsyncEvent) []redis.AsyncEv
 					// (getPostWithFallback est déjà défini dans most_cache_worker.go et accessible ici)
 					p, err := getPostWithFallback(ctx, targetID)
 
-					// Règle 1 : Post supprimé ou introuvable
-					if err != nil || p.Visibility == -1 {
+					// Règle 1 : Post supprimé, masqué par la modération ou introuvable
+					if err != nil || p.Visibility < 0 {
 						continue // Événement détruit
 					}
 
@@ -0,0 +1,22 @@
+-- ============================================================================
+-- auth.account_lifecycle : journal append-only du cycle de vie des comptes
+-- ============================================================================
+-- state : 0 = actif, 1 = désactivé, 2 = suppression planifiée, 3 = supprimé
+-- Après la cascade de suppression, la ligne state = 3 est le reçu vérifiable :
+--   digest = sha256(user_id|completed_at(RFC3339Nano)|report JSON canonique)
+-- Aucune clé étrangère vers auth.users : le reçu doit survivre à l'utilisateur.
+
+CREATE TABLE IF NOT EXISTS auth.account_lifecycle (
+    id             BIGINT PRIMARY KEY,
+    user_id        BIGINT      NOT NULL,
+    state          SMALLINT    NOT NULL,
+    requested_at   TIMESTAMPTZ,
+    scheduled_for  TIMESTAMPTZ,
+    completed_at   TIMESTAMPTZ,
+    report         JSONB,
+    digest         TEXT        NOT NULL DEFAULT '',
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_lifecycle_user
+    ON auth.account_lifecycle (user_id, created_at DESC);
@@ -0,0 +1,18 @@
+-- ============================================================================
+-- auth.interest_profiles : profil d'intérêt u ∈ R^224 synchronisé par le client
+-- ============================================================================
+-- Une ligne par utilisateur (id = auth.users.id), écrasée à chaque synchronisation (Write-Behind).
+-- version : VectorVersion du client au moment du calcul ; un vecteur d'une autre version est ignoré par le feed.
+-- interactions : signaux cumulés ayant nourri l'EMA, base de la confiance (avec computed_at).
+-- La suppression du compte (auth.proc_delete_user) emporte le profil par cascade.
+
+CREATE TABLE IF NOT EXISTS auth.interest_profiles (
+    id            BIGINT PRIMARY KEY REFERENCES auth.users (id) ON DELETE CASCADE,
+    version       INTEGER     NOT NULL,
+    vector        REAL[]      NOT NULL CHECK (cardinality(vector) = 224),
+    interactions  BIGINT      NOT NULL DEFAULT 0,
+    syncs         BIGINT      NOT NULL DEFAULT 1,
+    computed_at   TIMESTAMPTZ NOT NULL,
+    synced_at     TIMESTAMPTZ NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
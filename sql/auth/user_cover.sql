@@ -0,0 +1,9 @@
+-- ============================================================================
+-- auth.users.cover_picture_id : couverture de profil
+-- ============================================================================
+-- Média au gabarit "cover" (bandeau recadré côté serveur). 0 : aucune couverture.
+-- auth.func_load_user ne la renvoie pas : FuncLoadUser la relit à part.
+
+ALTER TABLE auth.users ADD COLUMN IF NOT EXISTS cover_picture_id BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS users_cover_picture_idx ON auth.users (cover_picture_id) WHERE cover_picture_id <> 0;
@@ -0,0 +1,12 @@
+-- ============================================================================
+-- Ramasse-miettes des objets MinIO : index de rattachement objet -> média
+-- ============================================================================
+-- Le ramasse-miettes retrouve le média d'un objet par son chemin AVIF historique (images et affiches),
+-- par le dossier de son rendu HLS ("video/<base>/") ou par son ID (zone de transit "staging/<id>"),
+-- puis vérifie qu'un post non supprimé, une photo de profil ou une couverture le référence encore
+-- (index de cover_picture_id : voir auth/user_cover.sql).
+
+CREATE INDEX IF NOT EXISTS media_storage_path_idx ON content.media (storage_path);
+CREATE INDEX IF NOT EXISTS media_video_dir_idx ON content.media ((regexp_replace(video->>'playlist_path', '[^/]*$', '')));
+CREATE INDEX IF NOT EXISTS posts_media_ids_idx ON content.posts USING GIN (media_ids);
+CREATE INDEX IF NOT EXISTS users_profile_picture_idx ON auth.users (profile_picture_id) WHERE profile_picture_id <> 0;
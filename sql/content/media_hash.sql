@@ -0,0 +1,7 @@
+-- ============================================================================
+-- content.media.hash : empreintes perceptuelles
+-- ============================================================================
+-- {phash, dhash} : empreintes 64 bits (bits stockés en BIGINT signé). {0, 0} : non calculées (média
+-- antérieur). L'index de recherche vit dans Redis (media:phash*) et se reconstruit à partir de cette colonne.
+
+ALTER TABLE content.media ADD COLUMN IF NOT EXISTS hash JSONB NOT NULL DEFAULT '{}'::jsonb;
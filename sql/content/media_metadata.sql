@@ -0,0 +1,8 @@
+-- ============================================================================
+-- content.media.metadata : métadonnées retenues de l'original
+-- ============================================================================
+-- {orientation, captured_at, width, height}, plus {layout, crop} pour une photo de profil ou une couverture
+-- (gabarit des déclinaisons et rectangle recadré, en pixels de l'original redressé). Liste blanche : les autres champs EXIF (GPS, appareil,
+-- numéro de série...) ne sont jamais lus, et sont retirés des octets de l'original dès le dépôt.
+
+ALTER TABLE content.media ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}'::jsonb;
@@ -0,0 +1,8 @@
+-- ============================================================================
+-- content.media.renditions : déclinaisons d'un média (largeur x format)
+-- ============================================================================
+-- Alimenté par le Write-Behind à l'ingestion. Chaque élément : {width, height, format, storage_path,
+-- size_bytes}. storage_path (colonne) reste la déclinaison AVIF la plus large : les médias antérieurs
+-- n'ont qu'elle et gardent un tableau vide.
+
+ALTER TABLE content.media ADD COLUMN IF NOT EXISTS renditions JSONB NOT NULL DEFAULT '[]'::jsonb;
@@ -0,0 +1,8 @@
+-- ============================================================================
+-- content.media.status : état du traitement d'un média
+-- ============================================================================
+-- 0 = prêt, 1 = en attente (original en zone de transit MinIO "staging/"), -1 = échec.
+-- Les médias antérieurs à la file de traitement sont tous prêts : la valeur par défaut les couvre.
+-- Tant que l'un de ses médias n'est pas prêt, le post reste en visibilité -3 (traitement) ou -4 (échec).
+
+ALTER TABLE content.media ADD COLUMN IF NOT EXISTS status SMALLINT NOT NULL DEFAULT 0;
@@ -0,0 +1,9 @@
+-- ============================================================================
+-- content.media.type / content.media.video : vidéos courtes
+-- ============================================================================
+-- type : 'image' ou 'video'. Les médias antérieurs sont des images.
+-- video : rendu HLS d'une vidéo, {duration_ms, width, height, playlist_path, segment_paths}.
+-- Pour une vidéo, storage_path et renditions désignent l'image d'affiche (même chaîne AVIF/JPEG qu'une image).
+
+ALTER TABLE content.media ADD COLUMN IF NOT EXISTS type TEXT NOT NULL DEFAULT 'image';
+ALTER TABLE content.media ADD COLUMN IF NOT EXISTS video JSONB NOT NULL DEFAULT '{}'::jsonb;
@@ -0,0 +1,31 @@
+-- ============================================================================
+-- content.revisions : historique des modifications de posts et commentaires
+-- ============================================================================
+-- Append-only, alimenté par le Write-Behind (TargetPostgres) à chaque modification par l'auteur :
+-- chaque ligne archive l'état AVANT la modification (la version courante reste dans content.posts
+-- ou content.comments). Lu uniquement par les fiches d'administration.
+-- target_type : 0 = post, 1 = commentaire
+
+CREATE TABLE IF NOT EXISTS content.revisions (
+    id           BIGINT PRIMARY KEY,
+    target_type  SMALLINT    NOT NULL,
+    target_id    BIGINT      NOT NULL,
+    editor_id    BIGINT      NOT NULL,
+    snapshot     JSONB       NOT NULL,
+    edited_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_revisions_target
+    ON content.revisions (target_type, target_id, edited_at DESC);
+
+-- Append-only : un historique modifiable n'a aucune valeur probante
+CREATE OR REPLACE FUNCTION content.revisions_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'content.revisions est append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_revisions_immutable ON content.revisions;
+CREATE TRIGGER trg_revisions_immutable
+    BEFORE UPDATE ON content.revisions
+    FOR EACH ROW EXECUTE FUNCTION content.revisions_immutable();
@@ -0,0 +1,34 @@
+-- ============================================================================
+-- moderation.appeals : contestation des sanctions par les utilisateurs
+-- ============================================================================
+-- Écrit directement par appeal_service (transitions en compare-and-set, comme report_cases).
+-- Un seul appel par sanction : la contrainte d'unicité arbitre les soumissions concurrentes.
+-- state   : 0 = en attente, 1 = en cours, -1 = tranché
+-- outcome : 1 = sanction maintenue, 2 = sanction annulée
+-- due_at  : échéance de traitement (SLA) ; sla_breached_at est posé au premier dépassement
+
+CREATE TABLE IF NOT EXISTS moderation.appeals (
+    id               BIGINT PRIMARY KEY,
+    sanction_id      BIGINT      NOT NULL UNIQUE,
+    user_id          BIGINT      NOT NULL,
+    sanction_kind    SMALLINT    NOT NULL,
+    message          TEXT        NOT NULL,
+    state            SMALLINT    NOT NULL DEFAULT 0,
+    assignee_id      BIGINT,
+    outcome          SMALLINT,
+    decision_note    TEXT        NOT NULL DEFAULT '',
+    decided_by       BIGINT,
+    decided_at       TIMESTAMPTZ,
+    due_at           TIMESTAMPTZ NOT NULL,
+    sla_breached_at  TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Ordre de la file : échéance la plus proche en tête
+CREATE INDEX IF NOT EXISTS idx_appeals_queue
+    ON moderation.appeals (state, due_at);
+CREATE INDEX IF NOT EXISTS idx_appeals_user
+    ON moderation.appeals (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_appeals_assignee
+    ON moderation.appeals (assignee_id) WHERE assignee_id IS NOT NULL;
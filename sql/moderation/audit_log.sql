@@ -0,0 +1,83 @@
+-- ============================================================================
+-- moderation.audit_log : journal d'audit append-only (qui a fait quoi, sur quoi)
+-- ============================================================================
+-- Partitionné par mois sur created_at : la rétention se gère par DETACH/DROP de partition,
+-- jamais par DELETE. Les partitions du mois courant et du suivant sont créées d'avance
+-- par moderation.ensure_audit_log_partition (appelée au démarrage puis chaque jour).
+-- actor_id = 0 : action système (cron, worker). target_id = 0 : pas de cible.
+
+CREATE TABLE IF NOT EXISTS moderation.audit_log (
+    id           BIGINT      NOT NULL,
+    actor_id     BIGINT      NOT NULL DEFAULT 0,
+    actor_ip     TEXT        NOT NULL DEFAULT '',
+    action       TEXT        NOT NULL,
+    target_type  TEXT        NOT NULL DEFAULT '',
+    target_id    BIGINT      NOT NULL DEFAULT 0,
+    metadata     JSONB,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+
+-- Filet de sécurité : une entrée hors de toute partition mensuelle n'est jamais perdue
+-- (ses lignes rejoignent la partition du mois dès sa création, voir ensure_audit_log_partition)
+CREATE TABLE IF NOT EXISTS moderation.audit_log_default
+    PARTITION OF moderation.audit_log DEFAULT;
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor
+    ON moderation.audit_log (actor_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_target
+    ON moderation.audit_log (target_type, target_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_action
+    ON moderation.audit_log (action text_pattern_ops, created_at DESC);
+
+-- Append-only : toute modification ou suppression ligne à ligne est refusée
+CREATE OR REPLACE FUNCTION moderation.audit_log_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'moderation.audit_log est append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_audit_log_immutable ON moderation.audit_log;
+CREATE TRIGGER trg_audit_log_immutable
+    BEFORE UPDATE OR DELETE ON moderation.audit_log
+    FOR EACH ROW EXECUTE FUNCTION moderation.audit_log_immutable();
+
+-- Création idempotente de la partition du mois contenant p_month.
+-- Une partition mensuelle ne peut pas être attachée tant que la partition par défaut contient des lignes
+-- de ce mois (cron manqué à un changement de mois) : la partition par défaut est alors remplacée et ses
+-- lignes réinsérées par le parent, qui les range dans la nouvelle partition. Row-level, le trigger
+-- append-only ne s'applique ni au DETACH ni au DROP : aucune ligne n'est modifiée ni perdue.
+CREATE OR REPLACE FUNCTION moderation.ensure_audit_log_partition(p_month DATE) RETURNS VOID AS $$
+DECLARE
+    v_start DATE := date_trunc('month', p_month)::DATE;
+    v_end   DATE := (date_trunc('month', p_month) + INTERVAL '1 month')::DATE;
+    v_name  TEXT := 'audit_log_' || to_char(v_start, 'YYYYMM');
+BEGIN
+    IF to_regclass(format('moderation.%I', v_name)) IS NOT NULL THEN
+        RETURN;
+    END IF;
+
+    IF NOT EXISTS (
+        SELECT 1 FROM moderation.audit_log_default WHERE created_at >= v_start AND created_at < v_end
+    ) THEN
+        EXECUTE format(
+            'CREATE TABLE IF NOT EXISTS moderation.%I PARTITION OF moderation.audit_log FOR VALUES FROM (%L) TO (%L)',
+            v_name, v_start, v_end
+        );
+        RETURN;
+    END IF;
+
+    ALTER TABLE moderation.audit_log DETACH PARTITION moderation.audit_log_default;
+    ALTER TABLE moderation.audit_log_default RENAME TO audit_log_default_old;
+    EXECUTE format(
+        'CREATE TABLE moderation.%I PARTITION OF moderation.audit_log FOR VALUES FROM (%L) TO (%L)',
+        v_name, v_start, v_end
+    );
+    CREATE TABLE moderation.audit_log_default PARTITION OF moderation.audit_log DEFAULT;
+    INSERT INTO moderation.audit_log SELECT * FROM moderation.audit_log_default_old;
+    DROP TABLE moderation.audit_log_default_old;
+END;
+$$ LANGUAGE plpgsql;
+
+SELECT moderation.ensure_audit_log_partition(CURRENT_DATE);
+SELECT moderation.ensure_audit_log_partition((CURRENT_DATE + INTERVAL '1 month')::DATE);
@@ -0,0 +1,43 @@
+-- ============================================================================
+-- moderation.forensic_evidence : preuves d'investigation sur les fuites d'images
+-- ============================================================================
+-- Écrit directement par report_service (InvestigateLeak) quand le tatouage relu sur une image
+-- fuitée désigne un responsable avec une confiance suffisante. L'image est conservée telle quelle
+-- dans MinIO (object_path, empreinte sha256) et rattachée au dossier ouvert contre le responsable
+-- (target_type = 5).
+-- stats : statistiques de lecture du tatouage (voir internal/pkg/watermark.Stats)
+
+CREATE TABLE IF NOT EXISTS moderation.forensic_evidence (
+    id              BIGINT PRIMARY KEY,
+    case_id         BIGINT           NOT NULL,
+    investigator_id BIGINT           NOT NULL,
+    author_id       BIGINT           NOT NULL,
+    post_id         BIGINT           NOT NULL,
+    culprit_id      BIGINT           NOT NULL,
+    leaked_at       TIMESTAMPTZ      NOT NULL,
+    object_path     TEXT             NOT NULL,
+    content_type    TEXT             NOT NULL,
+    size_bytes      BIGINT           NOT NULL,
+    sha256          TEXT             NOT NULL,
+    confidence      DOUBLE PRECISION NOT NULL,
+    stats           JSONB            NOT NULL DEFAULT '{}',
+    created_at      TIMESTAMPTZ      NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_forensic_evidence_case ON moderation.forensic_evidence (case_id);
+CREATE INDEX IF NOT EXISTS idx_forensic_evidence_culprit ON moderation.forensic_evidence (culprit_id, created_at DESC);
+
+-- La même fuite soumise deux fois ne produit qu'une preuve par dossier
+CREATE UNIQUE INDEX IF NOT EXISTS uq_forensic_evidence_case_sha ON moderation.forensic_evidence (case_id, sha256);
+
+-- Append-only : une preuve modifiable n'a aucune valeur probante
+CREATE OR REPLACE FUNCTION moderation.forensic_evidence_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'moderation.forensic_evidence est append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_forensic_evidence_immutable ON moderation.forensic_evidence;
+CREATE TRIGGER trg_forensic_evidence_immutable
+    BEFORE UPDATE ON moderation.forensic_evidence
+    FOR EACH ROW EXECUTE FUNCTION moderation.forensic_evidence_immutable();
@@ -0,0 +1,41 @@
+-- ============================================================================
+-- moderation.report_cases : dossiers de modération (agrégation des signalements)
+-- ============================================================================
+-- Un dossier regroupe tous les signalements visant la même cible (target_type + première
+-- cible signalée) tant qu'il n'est pas clos. Les signalements sont rattachés par le cron
+-- de tri (report_service.TriageNewReports) via moderation.reports.case_id.
+-- state : 0 = en attente, 1 = en cours, 2 = escaladé, -1 = clos
+-- resolution : 1 = aucune infraction, 2 = contenu retiré, 3 = averti, 4 = restreint, 5 = banni, 6 = doublon
+
+CREATE TABLE IF NOT EXISTS moderation.report_cases (
+    id               BIGINT PRIMARY KEY,
+    target_type      SMALLINT    NOT NULL,
+    target_id        BIGINT      NOT NULL,
+    target_ids       BIGINT[]    NOT NULL DEFAULT '{}',
+    category         SMALLINT    NOT NULL,
+    priority         SMALLINT    NOT NULL DEFAULT 0,
+    state            SMALLINT    NOT NULL DEFAULT 0,
+    assignee_id      BIGINT,
+    report_count     INTEGER     NOT NULL DEFAULT 1,
+    escalation_note  TEXT        NOT NULL DEFAULT '',
+    resolution       SMALLINT,
+    resolution_note  TEXT        NOT NULL DEFAULT '',
+    resolved_by      BIGINT,
+    resolved_at      TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Un seul dossier ouvert par cible : c'est la clé de l'agrégation (ON CONFLICT)
+CREATE UNIQUE INDEX IF NOT EXISTS uq_report_cases_open_target
+    ON moderation.report_cases (target_type, target_id) WHERE state <> -1;
+
+-- Ordre de la file : priorité décroissante puis ancienneté
+CREATE INDEX IF NOT EXISTS idx_report_cases_queue
+    ON moderation.report_cases (state, priority DESC, created_at);
+CREATE INDEX IF NOT EXISTS idx_report_cases_assignee
+    ON moderation.report_cases (assignee_id) WHERE assignee_id IS NOT NULL;
+
+ALTER TABLE moderation.reports ADD COLUMN IF NOT EXISTS case_id BIGINT;
+CREATE INDEX IF NOT EXISTS idx_reports_case ON moderation.reports (case_id);
+CREATE INDEX IF NOT EXISTS idx_reports_untriaged ON moderation.reports (id) WHERE case_id IS NULL;
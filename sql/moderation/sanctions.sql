@@ -0,0 +1,32 @@
+-- ============================================================================
+-- moderation.sanctions : avertissements, restrictions et bannissements
+-- ============================================================================
+-- Écrit par le Write-Behind (sanction_service). Une sanction n'est jamais supprimée :
+-- sa levée anticipée renseigne lifted_at / lifted_by / lift_reason.
+-- kind   : 1 = avertissement (strike), 2 = restriction, 3 = ban, 4 = retrait de contenu (dossier case_id)
+-- scopes : bitmask des fonctionnalités restreintes (1 = post, 2 = commentaire, 4 = message)
+-- expires_at NULL : sans échéance (ban définitif, avertissement) ; retrait : fin du délai de contestation
+
+CREATE TABLE IF NOT EXISTS moderation.sanctions (
+    id                BIGINT PRIMARY KEY,
+    user_id           BIGINT      NOT NULL,
+    kind              SMALLINT    NOT NULL,
+    scopes            SMALLINT    NOT NULL DEFAULT 0,
+    reason            TEXT        NOT NULL DEFAULT '',
+    case_id           BIGINT,
+    issued_by         BIGINT      NOT NULL,
+    automatic         BOOLEAN     NOT NULL DEFAULT false,
+    issued_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at        TIMESTAMPTZ,
+    appealable_until  TIMESTAMPTZ NOT NULL,
+    lifted_at         TIMESTAMPTZ,
+    lifted_by         BIGINT,
+    lift_reason       TEXT        NOT NULL DEFAULT ''
+);
+
+-- Sanctions d'un utilisateur (notices, échelle des strikes)
+CREATE INDEX IF NOT EXISTS idx_sanctions_user
+    ON moderation.sanctions (user_id, issued_at DESC);
+-- Sanctions encore en vigueur (amorçage du cache au démarrage)
+CREATE INDEX IF NOT EXISTS idx_sanctions_active
+    ON moderation.sanctions (kind, expires_at) WHERE lifted_at IS NULL;
@@ -0,0 +1,31 @@
+-- ============================================================================
+-- moderation.takedowns : retraits automatiques de contenus signalés
+-- ============================================================================
+-- Écrit directement par report_service (EvaluateTakedowns) quand le score pondéré d'un dossier
+-- franchit le seuil de retrait. Conserve la visibilité d'origine pour pouvoir rétablir le contenu
+-- si le modérateur clôt le dossier sans infraction, ou si l'auteur obtient gain de cause en appel.
+-- Un seul retrait par dossier.
+-- target_type : 0 = post, 1 = commentaire
+-- restored    : NULL tant que le dossier est ouvert ; TRUE = contenu rétabli, FALSE = retrait confirmé
+-- purged_at   : suppression définitive d'un retrait confirmé, une fois le délai d'appel écoulé
+
+CREATE TABLE IF NOT EXISTS moderation.takedowns (
+    id                  BIGINT PRIMARY KEY,
+    case_id             BIGINT           NOT NULL UNIQUE,
+    target_type         SMALLINT         NOT NULL,
+    target_id           BIGINT           NOT NULL,
+    previous_visibility SMALLINT         NOT NULL,
+    score               DOUBLE PRECISION NOT NULL,
+    hidden_at           TIMESTAMPTZ      NOT NULL DEFAULT NOW(),
+    released_at         TIMESTAMPTZ,
+    restored            BOOLEAN
+);
+ALTER TABLE moderation.takedowns ADD COLUMN IF NOT EXISTS purged_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_takedowns_target ON moderation.takedowns (target_type, target_id);
+-- Retraits confirmés en attente de suppression définitive
+CREATE INDEX IF NOT EXISTS idx_takedowns_purge
+    ON moderation.takedowns (released_at) WHERE restored = FALSE AND purged_at IS NULL;
+
+-- Confiance des signalants : historique des signalements clos par auteur
+CREATE INDEX IF NOT EXISTS idx_reports_reporter ON moderation.reports (reporter_id, case_id);